@@ -0,0 +1,217 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	chatpb "github.com/Arsencchikkk/final/casino/proto/chat"
+	userpb "github.com/Arsencchikkk/final/casino/proto/user"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+// wsConn сериализует запись: в одно соединение пишут и игра, и чат.
+type wsConn struct {
+	*websocket.Conn
+	mu sync.Mutex
+}
+
+func (w *wsConn) send(v interface{}) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.SetWriteDeadline(time.Now().Add(5 * time.Second))
+	return w.WriteJSON(v)
+}
+
+// chatFrame — что клиент присылает в WebSocket: {"chat": "текст"}.
+type chatFrame struct {
+	Chat string `json:"chat"`
+}
+
+// chatSession — чат одного WebSocket-соединения в одной комнате.
+// Сообщения комнаты уходят клиенту как {"chat": {...}}, ошибки отправки — {"chat_error": "..."}.
+type chatSession struct {
+	chat     chatpb.ChatServiceClient
+	conn     *wsConn
+	room     string
+	userId   string
+	username string
+}
+
+func newChatSession(ctx context.Context, chat chatpb.ChatServiceClient, users userpb.UserServiceClient, conn *wsConn, room, userId string) *chatSession {
+	s := &chatSession{chat: chat, conn: conn, room: room, userId: userId, username: userId}
+	if p, err := users.GetProfile(ctx, &userpb.GetProfileRequest{UserId: userId}); err == nil && p.Username != "" {
+		s.username = p.Username
+	}
+	return s
+}
+
+// relay пересылает новые сообщения комнаты, пока жив ctx.
+func (s *chatSession) relay(ctx context.Context) {
+	stream, err := s.chat.Watch(ctx, &chatpb.WatchRequest{Room: s.room})
+	if err != nil {
+		s.conn.send(gin.H{"chat_error": err.Error()})
+		return
+	}
+	for {
+		msg, err := stream.Recv()
+		if err != nil {
+			return
+		}
+		if err := s.conn.send(gin.H{"chat": msg}); err != nil {
+			return
+		}
+	}
+}
+
+// handle разбирает кадр от клиента; всё, что не чат, игнорируется.
+func (s *chatSession) handle(ctx context.Context, data []byte) {
+	var f chatFrame
+	if json.Unmarshal(data, &f) != nil || f.Chat == "" {
+		return
+	}
+	if _, err := s.chat.Send(ctx, &chatpb.SendRequest{
+		Room:     s.room,
+		UserId:   s.userId,
+		Username: s.username,
+		Text:     f.Chat,
+	}); err != nil {
+		s.conn.send(gin.H{"chat_error": err.Error()})
+	}
+}
+
+// chatBanned — проверка для JWT-middleware на маршрутах чата.
+func chatBanned(ctx context.Context, chat chatpb.ChatServiceClient, userId string) (bool, error) {
+	resp, err := chat.Sanctions(ctx, &chatpb.SanctionsRequest{UserId: userId})
+	if err != nil {
+		return false, err
+	}
+	return resp.Banned, nil
+}
+
+func registerChatRoutes(protected *gin.RouterGroup, chat chatpb.ChatServiceClient, users userpb.UserServiceClient, upgrader websocket.Upgrader, moderators map[string]bool) {
+	protected.GET("/chat/rooms/:room/history", func(c *gin.Context) {
+		limit, _ := strconv.Atoi(c.Query("limit"))
+		before, _ := strconv.ParseInt(c.Query("before"), 10, 64)
+		resp, err := chat.History(context.Background(), &chatpb.HistoryRequest{
+			Room:   c.Param("room"),
+			Limit:  int32(limit),
+			Before: before,
+		})
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, resp)
+	})
+	protected.POST("/chat/rooms/:room/messages", func(c *gin.Context) {
+		var body struct {
+			Text string `json:"text"`
+		}
+		if err := c.ShouldBindJSON(&body); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		uid := c.GetString("user_id")
+		username := uid
+		if p, err := users.GetProfile(context.Background(), &userpb.GetProfileRequest{UserId: uid}); err == nil && p.Username != "" {
+			username = p.Username
+		}
+		msg, err := chat.Send(context.Background(), &chatpb.SendRequest{
+			Room:     c.Param("room"),
+			UserId:   uid,
+			Username: username,
+			Text:     body.Text,
+		})
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, msg)
+	})
+	// чат лобби и любой другой комнаты без игровых событий: ?room=lobby
+	protected.GET("/chat/ws", func(c *gin.Context) {
+		uid := c.GetString("user_id")
+		room := c.DefaultQuery("room", "lobby")
+		ws, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+		if err != nil {
+			return
+		}
+		conn := &wsConn{Conn: ws}
+		defer conn.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		cs := newChatSession(ctx, chat, users, conn, room, uid)
+		go func() {
+			defer cancel()
+			cs.relay(ctx)
+		}()
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			cs.handle(ctx, data)
+		}
+	})
+
+	// === Модерация: MODERATOR_USER_IDS и администраторы ===
+	mod := protected.Group("/chat/moderation")
+	mod.Use(func(c *gin.Context) {
+		if !moderators[c.GetString("user_id")] {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "moderators only"})
+			return
+		}
+		c.Next()
+	})
+	mod.POST("/sanctions", func(c *gin.Context) {
+		var body struct {
+			UserId  string `json:"user_id"`
+			Kind    string `json:"kind"`
+			Reason  string `json:"reason"`
+			Minutes int32  `json:"minutes"`
+		}
+		if err := c.ShouldBindJSON(&body); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		resp, err := chat.Moderate(context.Background(), &chatpb.SanctionRequest{
+			UserId:      body.UserId,
+			Kind:        body.Kind,
+			Reason:      body.Reason,
+			ModeratorId: c.GetString("user_id"),
+			Minutes:     body.Minutes,
+		})
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, resp)
+	})
+	mod.DELETE("/sanctions/:user_id/:kind", func(c *gin.Context) {
+		resp, err := chat.Lift(context.Background(), &chatpb.LiftRequest{
+			UserId:      c.Param("user_id"),
+			Kind:        c.Param("kind"),
+			ModeratorId: c.GetString("user_id"),
+		})
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, resp)
+	})
+	mod.GET("/sanctions/:user_id", func(c *gin.Context) {
+		resp, err := chat.Sanctions(context.Background(), &chatpb.SanctionsRequest{UserId: c.Param("user_id")})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, resp)
+	})
+}
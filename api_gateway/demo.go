@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	userpb "github.com/Arsencchikkk/final/casino/proto/user"
+	walletpb "github.com/Arsencchikkk/final/casino/proto/wallet"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+)
+
+// Демо-режим: анонимный короткий JWT с sub "demo:<uuid>" и claim demo=true.
+// Баланс такого игрока живёт в отдельном демо-кошельке WalletService.
+const demoPrefix = "demo:"
+
+// куда демо-токен не пускают: нет профиля, чата и игры против настоящих денег
+var demoBlocked = []string{
+	"/api/profile",
+	"/api/chat/",
+	"/api/admin/",
+	"/api/holdem/",
+	"/api/tournaments/",
+	"/api/wallet/transfers",
+}
+
+func demoAllowed(path string) bool {
+	for _, p := range demoBlocked {
+		if strings.HasPrefix(path, p) {
+			return false
+		}
+	}
+	return true
+}
+
+func registerDemoRoutes(api, protected *gin.RouterGroup, secret []byte, users userpb.UserServiceClient, wallet walletpb.WalletServiceClient) {
+	ttl := 2 * time.Hour
+	if v := envOr("DEMO_TOKEN_TTL_MIN", ""); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			ttl = time.Duration(n) * time.Minute
+		}
+	}
+
+	// новый демо-игрок: токен + стартовые виртуальные кредиты
+	api.POST("/demo", func(c *gin.Context) {
+		uid := demoPrefix + uuid.New().String()
+		dr, err := wallet.StartDemo(context.Background(), &walletpb.DemoRequest{UserId: uid})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		exp := time.Now().Add(ttl)
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			"sub":  uid,
+			"demo": true,
+			"exp":  exp.Unix(),
+		}).SignedString(secret)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"token":      token,
+			"user_id":    uid,
+			"balance":    formatMoney(dr.Balance),
+			"currency":   dr.Balance.GetCurrency(),
+			"demo":       true,
+			"expires_at": exp.Unix(),
+		})
+	})
+
+	// переход на настоящий аккаунт: обычная регистрация, демо-баланс сгорает
+	protected.POST("/demo/upgrade", func(c *gin.Context) {
+		if !c.GetBool("demo") {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "not a demo session"})
+			return
+		}
+		var body struct {
+			Username string `json:"username"`
+			Password string `json:"password"`
+			Email    string `json:"email"`
+			Name     string `json:"name"`
+			Surname  string `json:"surname"`
+		}
+		if err := c.ShouldBindJSON(&body); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		regResp, err := users.Register(context.Background(), &userpb.RegisterRequest{
+			Username: body.Username,
+			Password: body.Password,
+			Email:    body.Email,
+			Name:     body.Name,
+			Surname:  body.Surname,
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		// приветственный бонус как при обычной регистрации, демо-кредиты не переносятся
+		if _, err := grantWelcomeBonus(wallet, regResp.UserId); err != nil {
+			log.Printf("warning: cannot grant welcome bonus: %v", err)
+		}
+		if _, err := wallet.EndDemo(context.Background(), &walletpb.DemoRequest{UserId: c.GetString("user_id")}); err != nil {
+			log.Printf("warning: cannot close demo wallet %s: %v", c.GetString("user_id"), err)
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"user_id": regResp.UserId,
+			"message": "account created, confirm your email and log in; demo credits are not carried over",
+		})
+	})
+}
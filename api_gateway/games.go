@@ -0,0 +1,392 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	catalogpb "github.com/Arsencchikkk/final/casino/proto/catalog"
+	gamepb "github.com/Arsencchikkk/final/casino/proto/game"
+	jackpotpb "github.com/Arsencchikkk/final/casino/proto/jackpot"
+	kenopb "github.com/Arsencchikkk/final/casino/proto/keno"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+)
+
+// gameRegistry — каталог игр, собранный со всех провайдеров (GAME_PROVIDERS).
+// Новая игра появляется в /api/games сама, как только её провайдер начинает
+// отвечать на Catalog — правки шлюза не нужны.
+type gameRegistry struct {
+	providers []catalogpb.GameProviderClient
+
+	mu     sync.RWMutex
+	games  map[string]registeredGame
+	byProv [][]string // id игр каждого провайдера, в порядке каталога
+}
+
+type registeredGame struct {
+	info   *catalogpb.GameInfo
+	client catalogpb.GameProviderClient
+}
+
+func newGameRegistry(addrs []string) (*gameRegistry, error) {
+	r := &gameRegistry{
+		games:  make(map[string]registeredGame),
+		byProv: make([][]string, len(addrs)),
+	}
+	for _, addr := range addrs {
+		conn, err := grpc.Dial(addr, grpc.WithInsecure())
+		if err != nil {
+			return nil, fmt.Errorf("dial game provider %s: %w", addr, err)
+		}
+		r.providers = append(r.providers, catalogpb.NewGameProviderClient(conn))
+	}
+	return r, nil
+}
+
+// refresh перечитывает каталоги. Если провайдер не ответил, его игры
+// остаются из прошлого снимка.
+func (r *gameRegistry) refresh(ctx context.Context) {
+	for i, p := range r.providers {
+		cctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+		resp, err := p.Catalog(cctx, &catalogpb.CatalogRequest{})
+		cancel()
+		if err != nil {
+			log.Printf("[games] provider %d catalog error: %v", i, err)
+			continue
+		}
+		r.mu.Lock()
+		for _, id := range r.byProv[i] {
+			delete(r.games, id)
+		}
+		r.byProv[i] = r.byProv[i][:0]
+		for _, g := range resp.Games {
+			if _, dup := r.games[g.GameId]; dup {
+				log.Printf("[games] duplicate game_id %q, keeping the first", g.GameId)
+				continue
+			}
+			r.games[g.GameId] = registeredGame{info: g, client: p}
+			r.byProv[i] = append(r.byProv[i], g.GameId)
+		}
+		r.mu.Unlock()
+	}
+}
+
+func (r *gameRegistry) run(ctx context.Context, every time.Duration) {
+	r.refresh(ctx)
+	t := time.NewTicker(every)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			r.refresh(ctx)
+		}
+	}
+}
+
+func (r *gameRegistry) lookup(id string) (registeredGame, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	g, ok := r.games[id]
+	return g, ok
+}
+
+func (r *gameRegistry) list() []*catalogpb.GameInfo {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var out []*catalogpb.GameInfo
+	for _, ids := range r.byProv {
+		for _, id := range ids {
+			out = append(out, r.games[id].info)
+		}
+	}
+	return out
+}
+
+// stringParams приводит JSON-параметры к строкам, как их ждёт провайдер:
+// числа — без экспоненты, массивы — через запятую.
+func stringParams(in map[string]interface{}) map[string]string {
+	out := make(map[string]string, len(in))
+	for k, v := range in {
+		out[k] = paramString(v)
+	}
+	return out
+}
+
+func paramString(v interface{}) string {
+	switch x := v.(type) {
+	case string:
+		return x
+	case float64:
+		return strconv.FormatFloat(x, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(x)
+	case []interface{}:
+		parts := make([]string, len(x))
+		for i, e := range x {
+			parts[i] = paramString(e)
+		}
+		return strings.Join(parts, ",")
+	case nil:
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+func roundJSON(rs *catalogpb.RoundState) gin.H {
+	cur := gameMoney(0, rs.Currency).Currency
+	h := gin.H{
+		"game_id":  rs.GameId,
+		"round_id": rs.RoundId,
+		"status":   rs.Status,
+		"finished": rs.Finished,
+		"stake":    gameAmount(int64(rs.Stake), cur),
+		"payout":   gameAmount(int64(rs.Payout), cur),
+		"balance":  gameAmount(int64(rs.Balance), cur),
+		"currency": cur,
+	}
+	if rs.Jackpot > 0 {
+		h["jackpot"] = gameAmount(int64(rs.Jackpot), cur)
+	}
+	if rs.StateJson != "" {
+		h["state"] = json.RawMessage(rs.StateJson)
+	}
+	return h
+}
+
+// registerGameRoutes — общие маршруты для любой игры из каталога.
+func registerGameRoutes(api, protected *gin.RouterGroup, games *gameRegistry) {
+	api.GET("/games", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"games": games.list()})
+	})
+	api.GET("/games/:game_id", func(c *gin.Context) {
+		g, ok := games.lookup(c.Param("game_id"))
+		if !ok {
+			c.JSON(http.StatusNotFound, gin.H{"error": "game not found"})
+			return
+		}
+		c.JSON(http.StatusOK, g.info)
+	})
+
+	protected.POST("/games/:game_id/start", func(c *gin.Context) {
+		g, ok := games.lookup(c.Param("game_id"))
+		if !ok {
+			c.JSON(http.StatusNotFound, gin.H{"error": "game not found"})
+			return
+		}
+		var body struct {
+			Stake    int32                  `json:"stake"`
+			Currency string                 `json:"currency"` // подкошелёк ставки, пусто — основной
+			Params   map[string]interface{} `json:"params"`
+		}
+		if err := c.ShouldBindJSON(&body); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if l := g.info.Limits; l != nil && (body.Stake < l.MinStake || body.Stake > l.MaxStake) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("stake must be between %d and %d", l.MinStake, l.MaxStake)})
+			return
+		}
+		rs, err := g.client.Start(context.Background(), &catalogpb.StartRequest{
+			GameId:   g.info.GameId,
+			UserId:   c.GetString("user_id"),
+			Stake:    body.Stake,
+			Currency: body.Currency,
+			Params:   stringParams(body.Params),
+		})
+		if err != nil {
+			c.JSON(errStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, roundJSON(rs))
+	})
+	protected.POST("/games/:game_id/act", func(c *gin.Context) {
+		g, ok := games.lookup(c.Param("game_id"))
+		if !ok {
+			c.JSON(http.StatusNotFound, gin.H{"error": "game not found"})
+			return
+		}
+		var body struct {
+			RoundId string                 `json:"round_id"`
+			Action  string                 `json:"action"`
+			Params  map[string]interface{} `json:"params"`
+		}
+		if err := c.ShouldBindJSON(&body); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		rs, err := g.client.Act(context.Background(), &catalogpb.ActRequest{
+			GameId:  g.info.GameId,
+			UserId:  c.GetString("user_id"),
+			RoundId: body.RoundId,
+			Action:  body.Action,
+			Params:  stringParams(body.Params),
+		})
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, roundJSON(rs))
+	})
+	protected.POST("/games/:game_id/settle", func(c *gin.Context) {
+		g, ok := games.lookup(c.Param("game_id"))
+		if !ok {
+			c.JSON(http.StatusNotFound, gin.H{"error": "game not found"})
+			return
+		}
+		var body struct {
+			RoundId string `json:"round_id"`
+		}
+		if err := c.ShouldBindJSON(&body); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		rs, err := g.client.Settle(context.Background(), &catalogpb.SettleRequest{
+			GameId:  g.info.GameId,
+			UserId:  c.GetString("user_id"),
+			RoundId: body.RoundId,
+		})
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, roundJSON(rs))
+	})
+}
+
+// Игры считают целыми кредитами; наружу суммы уходят так же, как из
+// кошелька: десятичной строкой рядом с кодом валюты. Турнирные фишки и
+// фишки за столом холдема — не деньги, они остаются числами.
+
+func minesJSON(st *gamepb.MinesState) gin.H {
+	cur := gameMoney(0, st.Currency).Currency
+	return gin.H{
+		"session_id":      st.SessionId,
+		"grid_size":       st.GridSize,
+		"mines":           st.Mines,
+		"stake":           gameAmount(int64(st.Stake), cur),
+		"revealed":        st.Revealed,
+		"mine_tiles":      st.MineTiles,
+		"status":          st.Status,
+		"multiplier":      st.Multiplier,
+		"next_multiplier": st.NextMultiplier,
+		"payout":          gameAmount(int64(st.Payout), cur),
+		"balance":         gameAmount(int64(st.Balance), cur),
+		"currency":        cur,
+	}
+}
+
+func ticketJSON(t *kenopb.Ticket) gin.H {
+	cur := gameMoney(0, t.Currency).Currency
+	return gin.H{
+		"ticket_id":  t.TicketId,
+		"user_id":    t.UserId,
+		"draw_no":    t.DrawNo,
+		"picks":      t.Picks,
+		"stake":      gameAmount(int64(t.Stake), cur),
+		"status":     t.Status,
+		"hits":       t.Hits,
+		"payout":     gameAmount(int64(t.Payout), cur),
+		"created_at": t.CreatedAt,
+		"currency":   cur,
+	}
+}
+
+func ticketsJSON(ts []*kenopb.Ticket) []gin.H {
+	out := make([]gin.H, 0, len(ts))
+	for _, t := range ts {
+		out = append(out, ticketJSON(t))
+	}
+	return out
+}
+
+// турниры играются только в основной валюте
+func tournamentJSON(t *gamepb.Tournament) gin.H {
+	return gin.H{
+		"tournament_id":  t.TournamentId,
+		"name":           t.Name,
+		"game":           t.Game,
+		"buy_in":         gameAmount(int64(t.BuyIn), ""),
+		"starting_chips": t.StartingChips,
+		"max_hands":      t.MaxHands,
+		"starts_at":      t.StartsAt,
+		"ends_at":        t.EndsAt,
+		"payouts":        t.Payouts,
+		"guarantee":      gameAmount(int64(t.Guarantee), ""),
+		"status":         t.Status,
+		"prize_pool":     gameAmount(int64(t.PrizePool), ""),
+		"entries":        t.Entries,
+		"currency":       mainCurrency,
+	}
+}
+
+func entryJSON(e *gamepb.TournamentEntry) gin.H {
+	if e == nil {
+		return nil
+	}
+	return gin.H{
+		"tournament_id": e.TournamentId,
+		"user_id":       e.UserId,
+		"chips":         e.Chips,
+		"hands_played":  e.HandsPlayed,
+		"hands_left":    e.HandsLeft,
+		"rank":          e.Rank,
+		"prize":         gameAmount(int64(e.Prize), ""),
+		"in_hand":       e.InHand,
+		"currency":      mainCurrency,
+	}
+}
+
+func entriesJSON(es []*gamepb.TournamentEntry) []gin.H {
+	out := make([]gin.H, 0, len(es))
+	for _, e := range es {
+		out = append(out, entryJSON(e))
+	}
+	return out
+}
+
+func jackpotsJSON(r *jackpotpb.GetJackpotsResponse) gin.H {
+	out := make([]gin.H, 0, len(r.Jackpots))
+	for _, j := range r.Jackpots {
+		out = append(out, jackpotJSON(j))
+	}
+	return gin.H{"jackpots": out}
+}
+
+// джекпот копится в основной валюте
+func jackpotJSON(j *jackpotpb.Jackpot) gin.H {
+	return gin.H{
+		"pool_id":     j.PoolId,
+		"amount":      gameAmount(j.Amount, ""),
+		"seed":        gameAmount(j.Seed, ""),
+		"rate_bp":     j.RateBp,
+		"last_winner": j.LastWinner,
+		"last_amount": gameAmount(j.LastAmount, ""),
+		"updated_at":  j.UpdatedAt,
+		"currency":    mainCurrency,
+	}
+}
+
+func jackpotWinJSON(w *jackpotpb.JackpotWin) gin.H {
+	return gin.H{
+		"win_id":     w.WinId,
+		"pool_id":    w.PoolId,
+		"game":       w.Game,
+		"user_id":    w.UserId,
+		"round_id":   w.RoundId,
+		"trigger":    w.Trigger,
+		"amount":     gameAmount(int64(w.Amount), ""),
+		"status":     w.Status,
+		"created_at": w.CreatedAt,
+		"currency":   mainCurrency,
+	}
+}
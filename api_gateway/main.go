@@ -3,43 +3,83 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"io"
 	"log"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
+	chatpb "github.com/Arsencchikkk/final/casino/proto/chat"
 	gamepb "github.com/Arsencchikkk/final/casino/proto/game"
+	jackpotpb "github.com/Arsencchikkk/final/casino/proto/jackpot"
+	kenopb "github.com/Arsencchikkk/final/casino/proto/keno"
 	userpb "github.com/Arsencchikkk/final/casino/proto/user"
 	walletpb "github.com/Arsencchikkk/final/casino/proto/wallet"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/joho/godotenv"
 	"google.golang.org/grpc"
 )
 
 func main() {
+	// адреса сервисов берём из .env, по умолчанию — локальные порты
+	_ = godotenv.Load()
+	gameAddr := envOr("GAME_SERVICE_ADDR", "localhost:50051")
+	kenoAddr := envOr("KENO_SERVICE_ADDR", "localhost:50054")
+	mainCurrency = envOr("WALLET_CURRENCY", mainCurrency)
+
 	// Подключаемся к gRPC-сервисам
-	ua, err := grpc.Dial("localhost:50053", grpc.WithInsecure())
+	ua, err := grpc.Dial(envOr("USER_SERVICE_ADDR", "localhost:50053"), grpc.WithInsecure())
 	if err != nil {
 		log.Fatal("cannot dial user service:", err)
 	}
-	ga, err := grpc.Dial("localhost:50051", grpc.WithInsecure())
+	ga, err := grpc.Dial(gameAddr, grpc.WithInsecure())
 	if err != nil {
 		log.Fatal("cannot dial game service:", err)
 	}
-	wa, err := grpc.Dial("localhost:50052", grpc.WithInsecure())
+	wa, err := grpc.Dial(envOr("WALLET_SERVICE_ADDR", "localhost:50052"), grpc.WithInsecure())
 	if err != nil {
 		log.Fatal("cannot dial wallet service:", err)
 	}
+	ka, err := grpc.Dial(kenoAddr, grpc.WithInsecure())
+	if err != nil {
+		log.Fatal("cannot dial keno service:", err)
+	}
+	ja, err := grpc.Dial(envOr("JACKPOT_SERVICE_ADDR", "localhost:50055"), grpc.WithInsecure())
+	if err != nil {
+		log.Fatal("cannot dial jackpot service:", err)
+	}
+	ca, err := grpc.Dial(envOr("CHAT_SERVICE_ADDR", "localhost:50056"), grpc.WithInsecure())
+	if err != nil {
+		log.Fatal("cannot dial chat service:", err)
+	}
 
 	userClient := userpb.NewUserServiceClient(ua)
 	gameClient := gamepb.NewGameServiceClient(ga)
 	walletClient := walletpb.NewWalletServiceClient(wa)
+	kenoClient := kenopb.NewKenoServiceClient(ka)
+	jackpotClient := jackpotpb.NewJackpotServiceClient(ja)
+	chatClient := chatpb.NewChatServiceClient(ca)
+
+	// каталог игр: каждый провайдер сам описывает свои игры
+	games, err := newGameRegistry(strings.Split(envOr("GAME_PROVIDERS", gameAddr+","+kenoAddr), ","))
+	if err != nil {
+		log.Fatal(err)
+	}
+	go games.run(context.Background(), 30*time.Second)
 
 	// JWT-секрет (в продакшне загружать из os.Getenv)
 	secret := []byte("your_super_secret_key_here")
 
+	// WebSocket для живых трансляций (crash и т.п.)
+	upgrader := websocket.Upgrader{ReadBufferSize: 1024, WriteBufferSize: 1024}
+
 	gin.SetMode(gin.ReleaseMode)
 	r := gin.New()
 	r.Use(gin.Logger(), gin.Recovery())
@@ -48,7 +88,7 @@ func main() {
 	{
 		// === Публичные методы ===
 
-		// Регистрация: создаём пользователя, шлём код, записываем name/surname и сразу даём 1000 кредита
+		// Регистрация: создаём пользователя, шлём код, записываем name/surname и даём приветственный бонус
 		api.POST("/register", func(c *gin.Context) {
 			var body struct {
 				Username string `json:"username"`
@@ -74,15 +114,13 @@ func main() {
 				return
 			}
 
-			// 2) Начисляем стартовый баланс 1000
-			if _, err := walletClient.UpdateBalance(context.Background(), &walletpb.WalletUpdateRequest{
-				UserId: regResp.UserId,
-				Amount: 1000,
-			}); err != nil {
-				log.Printf("warning: cannot set initial balance: %v", err)
+			// 2) Приветственный бонус — на бонусный баланс, с отыгрышем
+			b, err := grantWelcomeBonus(walletClient, regResp.UserId)
+			if err != nil {
+				log.Printf("warning: cannot grant welcome bonus: %v", err)
 			}
 
-			// 3) Отдаём user_id и баланс
+			// 3) Отдаём user_id, баланс и бонус
 			wr, err := walletClient.GetBalance(context.Background(), &walletpb.WalletRequest{
 				UserId: regResp.UserId,
 			})
@@ -94,10 +132,15 @@ func main() {
 				return
 			}
 
-			c.JSON(http.StatusOK, gin.H{
-				"user_id": regResp.UserId,
-				"balance": wr.Balance,
-			})
+			out := gin.H{
+				"user_id":  regResp.UserId,
+				"balance":  formatMoney(wr.Balance),
+				"currency": wr.Balance.GetCurrency(),
+			}
+			if b != nil {
+				out["bonus"] = bonusJSON(b)
+			}
+			c.JSON(http.StatusOK, out)
 		})
 
 		// Подтверждение e-mail кодом
@@ -149,6 +192,145 @@ func main() {
 			})
 		})
 
+		// Crash: живой множитель текущего раунда (публичные данные, без JWT)
+		api.GET("/crash/live", func(c *gin.Context) {
+			conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			// клиент ничего не присылает, читаем только чтобы заметить закрытие
+			go func() {
+				defer cancel()
+				for {
+					if _, _, err := conn.ReadMessage(); err != nil {
+						return
+					}
+				}
+			}()
+
+			stream, err := gameClient.WatchCrash(ctx, &gamepb.WatchCrashRequest{})
+			if err != nil {
+				conn.WriteJSON(gin.H{"error": err.Error()})
+				return
+			}
+			for {
+				st, err := stream.Recv()
+				if err != nil {
+					return
+				}
+				conn.SetWriteDeadline(time.Now().Add(5 * time.Second))
+				if err := conn.WriteJSON(gin.H{
+					"round_id":    st.RoundId,
+					"phase":       st.Phase,
+					"multiplier":  st.Multiplier,
+					"crash_point": st.CrashPoint,
+					"seed_hash":   st.SeedHash,
+					"server_seed": st.ServerSeed,
+					"starts_at":   st.StartsAt,
+					"players":     st.Players,
+				}); err != nil {
+					return
+				}
+			}
+		})
+
+		// Джекпот: текущая сумма, последние выигрыши и живое обновление суммы
+		api.GET("/jackpots", func(c *gin.Context) {
+			resp, err := jackpotClient.GetJackpots(context.Background(), &jackpotpb.GetJackpotsRequest{})
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusOK, jackpotsJSON(resp))
+		})
+		api.GET("/jackpots/wins", func(c *gin.Context) {
+			limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+			resp, err := jackpotClient.ListWins(context.Background(), &jackpotpb.ListWinsRequest{Limit: int32(limit)})
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			wins := make([]gin.H, 0, len(resp.Wins))
+			for _, w := range resp.Wins {
+				wins = append(wins, jackpotWinJSON(w))
+			}
+			c.JSON(http.StatusOK, gin.H{"wins": wins})
+		})
+		api.GET("/jackpots/live", func(c *gin.Context) {
+			conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go func() {
+				defer cancel()
+				for {
+					if _, _, err := conn.ReadMessage(); err != nil {
+						return
+					}
+				}
+			}()
+
+			stream, err := jackpotClient.WatchJackpots(ctx, &jackpotpb.WatchJackpotsRequest{})
+			if err != nil {
+				conn.WriteJSON(gin.H{"error": err.Error()})
+				return
+			}
+			for {
+				st, err := stream.Recv()
+				if err != nil {
+					return
+				}
+				conn.SetWriteDeadline(time.Now().Add(5 * time.Second))
+				if err := conn.WriteJSON(jackpotsJSON(st)); err != nil {
+					return
+				}
+			}
+		})
+
+		// Кено: ближайший тираж с таблицей выплат и история тиражей
+		api.GET("/keno/next", func(c *gin.Context) {
+			resp, err := kenoClient.NextDraw(context.Background(), &kenopb.NextDrawRequest{})
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusOK, resp)
+		})
+		api.GET("/keno/draws", func(c *gin.Context) {
+			limit, _ := strconv.Atoi(c.Query("limit"))
+			before, _ := strconv.ParseInt(c.Query("before"), 10, 64)
+			resp, err := kenoClient.ListDraws(context.Background(), &kenopb.ListDrawsRequest{
+				Limit:  int32(limit),
+				Before: before,
+			})
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusOK, resp)
+		})
+		api.GET("/keno/draws/:draw_no", func(c *gin.Context) {
+			no, err := strconv.ParseInt(c.Param("draw_no"), 10, 64)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid draw_no"})
+				return
+			}
+			resp, err := kenoClient.GetDraw(context.Background(), &kenopb.GetDrawRequest{DrawNo: no})
+			if err != nil {
+				c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusOK, resp)
+		})
+
 		// === Защищённые методы (JWT) ===
 		protected := api.Group("/")
 		protected.Use(func(c *gin.Context) {
@@ -171,10 +353,37 @@ func main() {
 				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid token claims"})
 				return
 			}
+			// демо-токен выдаёт только /api/demo: claim и префикс sub должны совпадать
+			demo, _ := claims["demo"].(bool)
+			if demo != strings.HasPrefix(sub, demoPrefix) {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid token claims"})
+				return
+			}
+			if demo && !demoAllowed(c.FullPath()) {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "not available in demo mode"})
+				return
+			}
 			c.Set("user_id", sub)
+			c.Set("demo", demo)
+
+			// забаненных в чате не пускаем на маршруты чата, остальное им доступно
+			if strings.HasPrefix(c.FullPath(), "/api/chat/") {
+				banned, err := chatBanned(c.Request.Context(), chatClient, sub)
+				if err != nil {
+					c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+					return
+				}
+				if banned {
+					c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "banned from chat"})
+					return
+				}
+			}
 			c.Next()
 		})
 
+		// Каталог игр: /api/games, /api/games/:game_id/{start,act,settle}
+		registerGameRoutes(api, protected, games)
+
 		// Профиль
 		protected.GET("/profile", func(c *gin.Context) {
 			uid := c.GetString("user_id")
@@ -183,7 +392,38 @@ func main() {
 				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 				return
 			}
-			c.JSON(http.StatusOK, resp)
+			// полученные бейджи; если кошелёк недоступен — профиль всё равно отдаём
+			badges := []gin.H{}
+			if ar, err := walletClient.GetAchievements(context.Background(), &walletpb.AchievementsRequest{UserId: uid}); err == nil {
+				for _, a := range ar.Achievements {
+					if a.Unlocked {
+						badges = append(badges, achievementJSON(a))
+					}
+				}
+			} else {
+				log.Printf("[profile] achievements for %s: %v", uid, err)
+			}
+			c.JSON(http.StatusOK, gin.H{
+				"user_id":  resp.UserId,
+				"username": resp.Username,
+				"email":    resp.Email,
+				"name":     resp.Name,
+				"surname":  resp.Surname,
+				"badges":   badges,
+			})
+		})
+		// Достижения: прогресс по всем правилам
+		protected.GET("/profile/achievements", func(c *gin.Context) {
+			resp, err := walletClient.GetAchievements(context.Background(), &walletpb.AchievementsRequest{UserId: c.GetString("user_id")})
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			list := make([]gin.H, 0, len(resp.Achievements))
+			for _, a := range resp.Achievements {
+				list = append(list, achievementJSON(a))
+			}
+			c.JSON(http.StatusOK, gin.H{"achievements": list})
 		})
 		protected.PUT("/profile", func(c *gin.Context) {
 			var body struct {
@@ -221,9 +461,10 @@ func main() {
 		// Игра + баланс
 		protected.POST("/new_game", func(c *gin.Context) {
 			uid := c.GetString("user_id")
-			gr, err := gameClient.NewGame(context.Background(), &gamepb.NewGameRequest{})
+			// ставка списывается при раздаче: не хватает денег — 402 и раздачи нет
+			gr, err := gameClient.NewGame(context.Background(), &gamepb.NewGameRequest{UserId: uid})
 			if err != nil {
-				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				c.JSON(errStatus(err, http.StatusInternalServerError), gin.H{"error": err.Error()})
 				return
 			}
 			wr, err := walletClient.GetBalance(context.Background(), &walletpb.WalletRequest{UserId: uid})
@@ -236,15 +477,16 @@ func main() {
 				"player_cards": gr.PlayerCards,
 				"dealer_cards": gr.DealerCards,
 				"player_total": gr.PlayerTotal,
-				"balance":      wr.Balance,
+				"balance":      formatMoney(wr.Balance),
+				"currency":     wr.Balance.GetCurrency(),
 			})
 		})
 		protected.POST("/hit", func(c *gin.Context) {
 			uid := c.GetString("user_id")
 			sid := c.Query("session_id")
-			hr, err := gameClient.Hit(context.Background(), &gamepb.HitRequest{SessionId: sid})
+			hr, err := gameClient.Hit(context.Background(), &gamepb.HitRequest{SessionId: sid, UserId: uid})
 			if err != nil {
-				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				c.JSON(errStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
 				return
 			}
 			wr, err := walletClient.GetBalance(context.Background(), &walletpb.WalletRequest{UserId: uid})
@@ -256,29 +498,20 @@ func main() {
 				"player_cards": hr.PlayerCards,
 				"player_total": hr.PlayerTotal,
 				"finished":     hr.Finished,
-				"balance":      wr.Balance,
+				"balance":      formatMoney(wr.Balance),
+				"currency":     wr.Balance.GetCurrency(),
 			})
 		})
 		protected.POST("/stand", func(c *gin.Context) {
 			uid := c.GetString("user_id")
 			sid := c.Query("session_id")
-			sr, err := gameClient.Stand(context.Background(), &gamepb.StandRequest{SessionId: sid})
+			// расчёт раздачи (выигрыш, возврат при ничьей) делает game_service
+			sr, err := gameClient.Stand(context.Background(), &gamepb.StandRequest{SessionId: sid, UserId: uid})
 			if err != nil {
-				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				c.JSON(errStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
 				return
 			}
-			// корректировка баланса
-			var delta int32
-			switch sr.Outcome {
-			case "win":
-				delta = 200
-			case "lose":
-				delta = -100
-			}
-			wur, err := walletClient.UpdateBalance(context.Background(), &walletpb.WalletUpdateRequest{
-				UserId: uid,
-				Amount: delta,
-			})
+			wr, err := walletClient.GetBalance(context.Background(), &walletpb.WalletRequest{UserId: uid})
 			if err != nil {
 				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 				return
@@ -287,17 +520,652 @@ func main() {
 				"dealer_cards": sr.DealerCards,
 				"dealer_total": sr.DealerTotal,
 				"outcome":      sr.Outcome,
-				"balance":      wur.NewBalance,
+				"balance":      formatMoney(wr.Balance),
+				"currency":     wr.Balance.GetCurrency(),
+			})
+		})
+		// Crash: ставка на следующий раунд и вывод
+		protected.POST("/crash/bet", func(c *gin.Context) {
+			var body struct {
+				Amount      int32   `json:"amount"`
+				AutoCashout float64 `json:"auto_cashout"`
+				Currency    string  `json:"currency"`
+			}
+			if err := c.ShouldBindJSON(&body); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			uid := c.GetString("user_id")
+			br, err := gameClient.PlaceCrashBet(context.Background(), &gamepb.CrashBetRequest{
+				UserId:      uid,
+				Amount:      body.Amount,
+				AutoCashout: body.AutoCashout,
+				Currency:    body.Currency,
+			})
+			if err != nil {
+				c.JSON(errStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+				return
+			}
+			cur := gameMoney(0, br.Currency).Currency
+			c.JSON(http.StatusOK, gin.H{
+				"round_id":     br.RoundId,
+				"amount":       gameAmount(int64(br.Amount), cur),
+				"auto_cashout": br.AutoCashout,
+				"balance":      gameAmount(int64(br.Balance), cur),
+				"currency":     cur,
+			})
+		})
+		protected.POST("/crash/cashout", func(c *gin.Context) {
+			var body struct {
+				RoundId string `json:"round_id"`
+			}
+			if err := c.ShouldBindJSON(&body); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			uid := c.GetString("user_id")
+			cr, err := gameClient.CrashCashout(context.Background(), &gamepb.CrashCashoutRequest{
+				UserId:  uid,
+				RoundId: body.RoundId,
+			})
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			cur := gameMoney(0, cr.Currency).Currency
+			c.JSON(http.StatusOK, gin.H{
+				"round_id":   cr.RoundId,
+				"multiplier": cr.Multiplier,
+				"payout":     gameAmount(int64(cr.Payout), cur),
+				"currency":   cur,
+			})
+		})
+
+		// Texas Hold'em: столы, посадка, ходы, уход и поток состояния стола
+		protected.GET("/holdem/tables", func(c *gin.Context) {
+			resp, err := gameClient.ListHoldemTables(context.Background(), &gamepb.ListHoldemTablesRequest{})
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusOK, resp)
+		})
+		protected.POST("/holdem/join", func(c *gin.Context) {
+			var body struct {
+				TableId string `json:"table_id"`
+				BuyIn   int32  `json:"buy_in"`
+				Seat    int32  `json:"seat"`
+			}
+			if err := c.ShouldBindJSON(&body); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			// повтор с тем же Idempotency-Key не списывает бай-ин второй раз
+			key := c.GetHeader("Idempotency-Key")
+			if key == "" {
+				key = uuid.New().String()
+			}
+			resp, err := gameClient.HoldemJoin(context.Background(), &gamepb.HoldemJoinRequest{
+				TableId: body.TableId,
+				UserId:  c.GetString("user_id"),
+				BuyIn:   body.BuyIn,
+				Seat:    body.Seat,
+				JoinId:  key,
+			})
+			if err != nil {
+				c.JSON(errStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+				return
+			}
+			// стек — фишки стола, баланс — деньги кошелька
+			c.JSON(http.StatusOK, gin.H{
+				"table_id": resp.TableId,
+				"seat":     resp.Seat,
+				"stack":    resp.Stack,
+				"balance":  gameAmount(int64(resp.Balance), ""),
+				"currency": mainCurrency,
+			})
+		})
+		protected.POST("/holdem/act", func(c *gin.Context) {
+			var body struct {
+				TableId string `json:"table_id"`
+				Action  string `json:"action"`
+				Amount  int32  `json:"amount"`
+			}
+			if err := c.ShouldBindJSON(&body); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			resp, err := gameClient.HoldemAct(context.Background(), &gamepb.HoldemActRequest{
+				TableId: body.TableId,
+				UserId:  c.GetString("user_id"),
+				Action:  body.Action,
+				Amount:  body.Amount,
+			})
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusOK, resp)
+		})
+		protected.POST("/holdem/leave", func(c *gin.Context) {
+			var body struct {
+				TableId string `json:"table_id"`
+			}
+			if err := c.ShouldBindJSON(&body); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			resp, err := gameClient.HoldemLeave(context.Background(), &gamepb.HoldemLeaveRequest{
+				TableId: body.TableId,
+				UserId:  c.GetString("user_id"),
+			})
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{
+				"cashed_out": gameAmount(int64(resp.CashedOut), ""),
+				"pending":    resp.Pending,
+				"currency":   mainCurrency,
+			})
+		})
+		// Hold'em для зрителей: только открытая информация, без ходов и без входа.
+		// Запросы от клиента не читаются — соединение только на чтение. Зритель —
+		// вошедший игрок, каждый занимает место в лимите зрителей стола.
+		protected.GET("/holdem/spectate", func(c *gin.Context) {
+			tableId := c.Query("table_id")
+			conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go func() {
+				defer cancel()
+				for {
+					if _, _, err := conn.ReadMessage(); err != nil {
+						return
+					}
+				}
+			}()
+
+			stream, err := gameClient.SpectateHoldem(ctx, &gamepb.SpectateHoldemRequest{TableId: tableId, UserId: c.GetString("user_id")})
+			if err != nil {
+				conn.WriteJSON(gin.H{"error": err.Error()})
+				return
+			}
+			for {
+				st, err := stream.Recv()
+				if err != nil {
+					conn.WriteJSON(gin.H{"error": err.Error()})
+					return
+				}
+				conn.SetWriteDeadline(time.Now().Add(5 * time.Second))
+				if err := conn.WriteJSON(st); err != nil {
+					return
+				}
+			}
+		})
+
+		// поток состояния стола: свои карманные карты видит только сам игрок.
+		// В том же соединении — чат стола: клиент шлёт {"chat": "текст"},
+		// сообщения приходят как {"chat": {...}}.
+		protected.GET("/holdem/ws", func(c *gin.Context) {
+			uid := c.GetString("user_id")
+			tableId := c.Query("table_id")
+			ws, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+			if err != nil {
+				return
+			}
+			conn := &wsConn{Conn: ws}
+			defer conn.Close()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			// забаненный играет дальше, но чата стола не видит
+			var cs *chatSession
+			if banned, err := chatBanned(ctx, chatClient, uid); err != nil {
+				log.Printf("[holdem/ws] chat sanctions for %s: %v", uid, err)
+			} else if !banned {
+				cs = newChatSession(ctx, chatClient, userClient, conn, "holdem:"+tableId, uid)
+				go cs.relay(ctx)
+			}
+			go func() {
+				defer cancel()
+				for {
+					_, data, err := conn.ReadMessage()
+					if err != nil {
+						return
+					}
+					if cs != nil {
+						cs.handle(ctx, data)
+					}
+				}
+			}()
+
+			stream, err := gameClient.WatchHoldem(ctx, &gamepb.WatchHoldemRequest{TableId: tableId, UserId: uid})
+			if err != nil {
+				conn.send(gin.H{"error": err.Error()})
+				return
+			}
+			for {
+				st, err := stream.Recv()
+				if err != nil {
+					conn.send(gin.H{"error": err.Error()})
+					return
+				}
+				if err := conn.send(st); err != nil {
+					return
+				}
+			}
+		})
+
+		// Кено: покупка билетов и свои билеты
+		protected.POST("/keno/tickets", func(c *gin.Context) {
+			var body struct {
+				Picks    []int32 `json:"picks"`
+				Stake    int32   `json:"stake"`
+				Draws    int32   `json:"draws"`
+				Currency string  `json:"currency"`
+			}
+			if err := c.ShouldBindJSON(&body); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			resp, err := kenoClient.BuyTicket(context.Background(), &kenopb.BuyTicketRequest{
+				UserId:   c.GetString("user_id"),
+				Picks:    body.Picks,
+				Stake:    body.Stake,
+				Draws:    body.Draws,
+				Currency: body.Currency,
+			})
+			if err != nil {
+				c.JSON(errStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+				return
+			}
+			cur := gameMoney(0, resp.Currency).Currency
+			c.JSON(http.StatusOK, gin.H{
+				"tickets":  ticketsJSON(resp.Tickets),
+				"balance":  gameAmount(int64(resp.Balance), cur),
+				"currency": cur,
+			})
+		})
+		protected.GET("/keno/tickets", func(c *gin.Context) {
+			limit, _ := strconv.Atoi(c.Query("limit"))
+			drawNo, _ := strconv.ParseInt(c.Query("draw_no"), 10, 64)
+			resp, err := kenoClient.ListTickets(context.Background(), &kenopb.ListTicketsRequest{
+				UserId: c.GetString("user_id"),
+				Limit:  int32(limit),
+				DrawNo: drawNo,
+				Status: c.Query("status"),
+			})
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{"tickets": ticketsJSON(resp.Tickets)})
+		})
+
+		// Mines: поле с минами, каждый безопасный ход увеличивает множитель
+		protected.POST("/mines/start", func(c *gin.Context) {
+			var body struct {
+				GridSize int32  `json:"grid_size"`
+				Mines    int32  `json:"mines"`
+				Stake    int32  `json:"stake"`
+				Currency string `json:"currency"`
+			}
+			if err := c.ShouldBindJSON(&body); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			resp, err := gameClient.MinesStart(context.Background(), &gamepb.MinesStartRequest{
+				UserId:   c.GetString("user_id"),
+				GridSize: body.GridSize,
+				Mines:    body.Mines,
+				Stake:    body.Stake,
+				Currency: body.Currency,
+			})
+			if err != nil {
+				c.JSON(errStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusOK, minesJSON(resp))
+		})
+		protected.POST("/mines/reveal", func(c *gin.Context) {
+			var body struct {
+				SessionId string `json:"session_id"`
+				Tile      int32  `json:"tile"`
+			}
+			if err := c.ShouldBindJSON(&body); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			resp, err := gameClient.MinesReveal(context.Background(), &gamepb.MinesRevealRequest{
+				UserId:    c.GetString("user_id"),
+				SessionId: body.SessionId,
+				Tile:      body.Tile,
+			})
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusOK, minesJSON(resp))
+		})
+		protected.POST("/mines/cashout", func(c *gin.Context) {
+			var body struct {
+				SessionId string `json:"session_id"`
+			}
+			if err := c.ShouldBindJSON(&body); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			resp, err := gameClient.MinesCashout(context.Background(), &gamepb.MinesCashoutRequest{
+				UserId:    c.GetString("user_id"),
+				SessionId: body.SessionId,
+			})
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusOK, minesJSON(resp))
+		})
+		protected.GET("/mines/:session_id", func(c *gin.Context) {
+			resp, err := gameClient.MinesGet(context.Background(), &gamepb.MinesGetRequest{
+				UserId:    c.GetString("user_id"),
+				SessionId: c.Param("session_id"),
+			})
+			if err != nil {
+				c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusOK, minesJSON(resp))
+		})
+
+		// Турниры: свой стек фишек, таблица лидеров, призы по окончании
+		api.GET("/tournaments", func(c *gin.Context) {
+			limit, _ := strconv.Atoi(c.Query("limit"))
+			resp, err := gameClient.ListTournaments(context.Background(), &gamepb.ListTournamentsRequest{
+				Status: c.Query("status"),
+				Limit:  int32(limit),
+			})
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			ts := make([]gin.H, 0, len(resp.Tournaments))
+			for _, t := range resp.Tournaments {
+				ts = append(ts, tournamentJSON(t))
+			}
+			c.JSON(http.StatusOK, gin.H{"tournaments": ts})
+		})
+		api.GET("/tournaments/:tournament_id", func(c *gin.Context) {
+			resp, err := gameClient.GetTournament(context.Background(), &gamepb.GetTournamentRequest{TournamentId: c.Param("tournament_id")})
+			if err != nil {
+				c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusOK, tournamentJSON(resp))
+		})
+		api.GET("/tournaments/:tournament_id/leaderboard", func(c *gin.Context) {
+			limit, _ := strconv.Atoi(c.Query("limit"))
+			resp, err := gameClient.TournamentLeaderboard(context.Background(), &gamepb.TournamentLeaderboardRequest{
+				TournamentId: c.Param("tournament_id"),
+				Limit:        int32(limit),
+			})
+			if err != nil {
+				c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+				return
+			}
+			out := gin.H{"top": entriesJSON(resp.Top)}
+			if resp.Me != nil {
+				out["me"] = entryJSON(resp.Me)
+			}
+			c.JSON(http.StatusOK, out)
+		})
+		protected.GET("/tournaments/:tournament_id/me", func(c *gin.Context) {
+			resp, err := gameClient.TournamentLeaderboard(context.Background(), &gamepb.TournamentLeaderboardRequest{
+				TournamentId: c.Param("tournament_id"),
+				UserId:       c.GetString("user_id"),
+				Limit:        1,
+			})
+			if err != nil {
+				c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+				return
+			}
+			if resp.Me == nil {
+				c.JSON(http.StatusNotFound, gin.H{"error": "not registered in this tournament"})
+				return
+			}
+			c.JSON(http.StatusOK, entryJSON(resp.Me))
+		})
+		protected.POST("/tournaments/:tournament_id/join", func(c *gin.Context) {
+			resp, err := gameClient.JoinTournament(context.Background(), &gamepb.JoinTournamentRequest{
+				TournamentId: c.Param("tournament_id"),
+				UserId:       c.GetString("user_id"),
 			})
+			if err != nil {
+				c.JSON(errStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{
+				"entry":    entryJSON(resp.Entry),
+				"balance":  gameAmount(int64(resp.Balance), ""),
+				"currency": mainCurrency,
+			})
+		})
+		protected.POST("/tournaments/:tournament_id/play", func(c *gin.Context) {
+			var body struct {
+				Action string `json:"action"`
+				Bet    int32  `json:"bet"`
+			}
+			if err := c.ShouldBindJSON(&body); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			resp, err := gameClient.TournamentPlay(context.Background(), &gamepb.TournamentPlayRequest{
+				TournamentId: c.Param("tournament_id"),
+				UserId:       c.GetString("user_id"),
+				Action:       body.Action,
+				Bet:          body.Bet,
+			})
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			// ставки и выигрыш — в фишках, деньги только у записи (приз)
+			c.JSON(http.StatusOK, gin.H{
+				"entry":        entryJSON(resp.Entry),
+				"player_cards": resp.PlayerCards,
+				"dealer_cards": resp.DealerCards,
+				"player_total": resp.PlayerTotal,
+				"dealer_total": resp.DealerTotal,
+				"outcome":      resp.Outcome,
+				"reels":        resp.Reels,
+				"win":          resp.Win,
+				"finished":     resp.Finished,
+			})
+		})
+
+		// === Админка: только user_id из ADMIN_USER_IDS ===
+		admins := idSet(os.Getenv("ADMIN_USER_IDS"))
+
+		// Чат: лобби, история, модерация (MODERATOR_USER_IDS и администраторы)
+		moderators := idSet(os.Getenv("MODERATOR_USER_IDS"))
+		for id := range admins {
+			moderators[id] = true
+		}
+		registerChatRoutes(protected, chatClient, userClient, upgrader, moderators)
+
+		// Демо-режим: анонимная игра на виртуальные кредиты
+		registerDemoRoutes(api, protected, secret, userClient, walletClient)
+
+		admin := protected.Group("/admin")
+		admin.Use(func(c *gin.Context) {
+			if !admins[c.GetString("user_id")] {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "admin only"})
+				return
+			}
+			c.Next()
+		})
+
+		admin.POST("/tournaments", func(c *gin.Context) {
+			var body struct {
+				Name          string  `json:"name"`
+				Game          string  `json:"game"`
+				BuyIn         int32   `json:"buy_in"`
+				StartingChips int32   `json:"starting_chips"`
+				MaxHands      int32   `json:"max_hands"`
+				StartsAt      int64   `json:"starts_at"`
+				EndsAt        int64   `json:"ends_at"`
+				Payouts       []int32 `json:"payouts"`
+				Guarantee     int32   `json:"guarantee"`
+			}
+			if err := c.ShouldBindJSON(&body); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			resp, err := gameClient.CreateTournament(context.Background(), &gamepb.CreateTournamentRequest{
+				Name:          body.Name,
+				Game:          body.Game,
+				BuyIn:         body.BuyIn,
+				StartingChips: body.StartingChips,
+				MaxHands:      body.MaxHands,
+				StartsAt:      body.StartsAt,
+				EndsAt:        body.EndsAt,
+				Payouts:       body.Payouts,
+				Guarantee:     body.Guarantee,
+			})
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusOK, tournamentJSON(resp))
 		})
+		admin.PUT("/tournaments/:tournament_id/schedule", func(c *gin.Context) {
+			var body struct {
+				StartsAt int64 `json:"starts_at"`
+				EndsAt   int64 `json:"ends_at"`
+			}
+			if err := c.ShouldBindJSON(&body); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			resp, err := gameClient.ScheduleTournament(context.Background(), &gamepb.ScheduleTournamentRequest{
+				TournamentId: c.Param("tournament_id"),
+				StartsAt:     body.StartsAt,
+				EndsAt:       body.EndsAt,
+			})
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusOK, tournamentJSON(resp))
+		})
+		admin.POST("/tournaments/:tournament_id/cancel", func(c *gin.Context) {
+			resp, err := gameClient.CancelTournament(context.Background(), &gamepb.CancelTournamentRequest{
+				TournamentId: c.Param("tournament_id"),
+			})
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusOK, tournamentJSON(resp))
+		})
+
+		// Выгрузка журнала аудита в NDJSON (по строке на запись) — для проверки
+		// через cmd/auditverify -file
+		admin.GET("/audit/export", func(c *gin.Context) {
+			fromSeq, _ := strconv.ParseInt(c.Query("from_seq"), 10, 64)
+			toSeq, _ := strconv.ParseInt(c.Query("to_seq"), 10, 64)
+			stream, err := walletClient.ExportAudit(c.Request.Context(), &walletpb.AuditExportRequest{
+				FromSeq: fromSeq,
+				ToSeq:   toSeq,
+			})
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			first, err := stream.Recv()
+			if err != nil && err != io.EOF {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			c.Header("Content-Type", "application/x-ndjson")
+			c.Header("Content-Disposition", `attachment; filename="audit.ndjson"`)
+			c.Status(http.StatusOK)
+			enc := json.NewEncoder(c.Writer)
+			for e := first; e != nil; {
+				if err := enc.Encode(e); err != nil {
+					return
+				}
+				if e, err = stream.Recv(); err != nil {
+					if err != io.EOF {
+						// заголовки уже ушли: обрываем выгрузку, auditverify увидит незаконченный хвост
+						log.Printf("[audit export] %v", err)
+					}
+					return
+				}
+			}
+		})
+
+		// Кошелёк: журнал проводок, сверка баланса
+		registerWalletRoutes(protected, admin, walletClient)
+		registerPaymentRoutes(api, protected, admin, walletClient)
+		registerTransferRoutes(protected, secret, userClient, walletClient)
+		registerBonusRoutes(protected, admin, walletClient)
+
+		// Фактический RTP и риск по играм: window = 15m | 1h | 24h, game — фильтр
+		admin.GET("/rtp", func(c *gin.Context) {
+			resp, err := walletClient.GetRtpStats(context.Background(), &walletpb.RtpStatsRequest{
+				Window: c.Query("window"),
+				Game:   c.Query("game"),
+			})
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusOK, resp)
+		})
+
+		// Лидерборды: board = profit | biggest_win | streak, period = day | week | all
+		protected.GET("/leaderboard", func(c *gin.Context) {
+			limit, _ := strconv.Atoi(c.Query("limit"))
+			resp, err := walletClient.GetLeaderboard(context.Background(), &walletpb.LeaderboardRequest{
+				Board:  c.Query("board"),
+				Period: c.Query("period"),
+				UserId: c.GetString("user_id"),
+				Limit:  int32(limit),
+			})
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusOK, leaderboardJSON(resp))
+		})
+
+		// ?currency= — подкошелёк, по умолчанию основной
 		protected.GET("/wallet", func(c *gin.Context) {
 			uid := c.GetString("user_id")
-			wr, err := walletClient.GetBalance(context.Background(), &walletpb.WalletRequest{UserId: uid})
+			wr, err := walletClient.GetBalance(context.Background(), &walletpb.WalletRequest{UserId: uid, Currency: c.Query("currency")})
 			if err != nil {
 				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 				return
 			}
-			c.JSON(http.StatusOK, gin.H{"balance": wr.Balance})
+			out := gin.H{
+				"balance":  formatMoney(wr.Balance),
+				"held":     formatMoney(wr.Held),
+				"currency": wr.Balance.GetCurrency(),
+			}
+			// бонусы — только в основной валюте
+			if c.Query("currency") == "" && !c.GetBool("demo") {
+				if br, err := walletClient.ListBonuses(context.Background(), &walletpb.WalletRequest{UserId: uid}); err == nil {
+					out["bonus"] = formatMoney(br.Balance)
+				}
+			}
+			c.JSON(http.StatusOK, out)
 		})
 	}
 
@@ -320,3 +1188,21 @@ func main() {
 	log.Printf("API-Gateway listening on :%s …", port)
 	log.Fatal(srv.ListenAndServe())
 }
+
+// idSet разбирает список user_id через запятую.
+func idSet(list string) map[string]bool {
+	ids := make(map[string]bool)
+	for _, id := range strings.Split(list, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids[id] = true
+		}
+	}
+	return ids
+}
+
+func envOr(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
@@ -0,0 +1,703 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	userpb "github.com/Arsencchikkk/final/casino/proto/user"
+	walletpb "github.com/Arsencchikkk/final/casino/proto/wallet"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// welcomeBonus — приветственный бонус новому игроку, в кредитах
+const welcomeBonus = 1000
+
+// Кошелёк считает деньги в int64 минимальных единицах валюты; в JSON суммы
+// уходят десятичной строкой ("12.34") рядом с кодом валюты. Кредит игр —
+// одна единица валюты кошелька.
+const creditMinor = 100
+
+// currencyExponent — знаков после запятой у валюты (как в wallet_service).
+var currencyExponent = map[string]int{
+	"USD": 2,
+	"EUR": 2,
+	"GBP": 2,
+	"KZT": 2,
+	"RUB": 2,
+	"JPY": 0,
+	"CRD": 2, // внутренние игровые кредиты
+}
+
+// formatMinor пишет сумму в минимальных единицах десятичной строкой.
+func formatMinor(amount int64, exp int) string {
+	sign := ""
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+	if exp == 0 {
+		return fmt.Sprintf("%s%d", sign, amount)
+	}
+	div := int64(1)
+	for i := 0; i < exp; i++ {
+		div *= 10
+	}
+	return fmt.Sprintf("%s%d.%0*d", sign, amount/div, exp, amount%div)
+}
+
+// parseMinor читает десятичную строку ("12.34", "5") в минимальные единицы
+// валюты; больше знаков после запятой, чем у валюты, — ошибка. Пустая
+// валюта — основная валюта кошелька, у неё два знака.
+func parseMinor(v, currency string) (int64, error) {
+	exp, ok := currencyExponent[currency]
+	if currency == "" {
+		exp, ok = 2, true
+	}
+	if !ok {
+		return 0, fmt.Errorf("unknown currency %q", currency)
+	}
+	whole, frac, _ := strings.Cut(strings.TrimSpace(v), ".")
+	if whole == "" || strings.HasPrefix(whole, "-") || strings.HasPrefix(whole, "+") || len(frac) > exp {
+		return 0, fmt.Errorf("amount must be a positive decimal with at most %d decimal places", exp)
+	}
+	frac += strings.Repeat("0", exp-len(frac))
+	n, err := strconv.ParseInt(whole+frac, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("bad amount %q", v)
+	}
+	return n, nil
+}
+
+func formatMoney(m *walletpb.Money) string {
+	exp, ok := currencyExponent[m.GetCurrency()]
+	if !ok {
+		exp = 2
+	}
+	return formatMinor(m.GetAmount(), exp)
+}
+
+// moneyJSON — сумма для вложенных объектов: {"amount": "12.34", "currency": "USD"}
+func moneyJSON(m *walletpb.Money) gin.H {
+	return gin.H{"amount": formatMoney(m), "currency": m.GetCurrency()}
+}
+
+// creditsMoney — сумма в кредитах как деньги в валюте кошелька.
+func creditsMoney(n int64) *walletpb.Money {
+	return &walletpb.Money{Amount: n * creditMinor}
+}
+
+// mainCurrency — основная валюта кошелька (WALLET_CURRENCY): суммы в ней
+// приходят из игр без кода валюты.
+var mainCurrency = "USD"
+
+// gameMoney — сумма из игры (целые кредиты) как деньги в валюте раунда;
+// пустая валюта — основная.
+func gameMoney(n int64, currency string) *walletpb.Money {
+	if currency == "" {
+		currency = mainCurrency
+	}
+	return &walletpb.Money{Amount: n * creditMinor, Currency: currency}
+}
+
+// gameAmount — сумма из игры десятичной строкой, как formatMoney.
+func gameAmount(n int64, currency string) string {
+	return formatMoney(gameMoney(n, currency))
+}
+
+func txJSON(t *walletpb.Transaction) gin.H {
+	out := gin.H{
+		"tx_id":         t.TxId,
+		"user_id":       t.UserId,
+		"seq":           t.Seq,
+		"type":          t.Type,
+		"ref":           t.Ref,
+		"amount":        formatMoney(t.Amount),
+		"currency":      t.Amount.GetCurrency(),
+		"counter":       t.Counter,
+		"balance_after": formatMoney(t.BalanceAfter),
+		"created_at":    t.CreatedAt,
+	}
+	if t.Rate != "" {
+		out["rate"] = t.Rate
+	}
+	return out
+}
+
+func txListJSON(resp *walletpb.ListTransactionsResponse) gin.H {
+	txs := make([]gin.H, 0, len(resp.Transactions))
+	for _, t := range resp.Transactions {
+		txs = append(txs, txJSON(t))
+	}
+	return gin.H{"transactions": txs, "next_before_seq": resp.NextBeforeSeq}
+}
+
+func achievementJSON(a *walletpb.Achievement) gin.H {
+	out := gin.H{
+		"id":          a.Id,
+		"name":        a.Name,
+		"description": a.Description,
+		"target":      a.Target,
+		"progress":    a.Progress,
+		"unlocked":    a.Unlocked,
+		"unlocked_at": a.UnlockedAt,
+	}
+	if a.Bonus.GetAmount() > 0 {
+		out["bonus"] = moneyJSON(a.Bonus)
+	}
+	return out
+}
+
+// leaderboardJSON: у досок profit и biggest_win очки — деньги (минимальные
+// единицы валюты кошелька), у streak — число побед.
+func leaderboardJSON(resp *walletpb.LeaderboardResponse) gin.H {
+	entry := func(e *walletpb.LeaderboardEntry) gin.H {
+		out := gin.H{"rank": e.Rank, "user_id": e.UserId, "score": e.Score}
+		if resp.Board != "streak" {
+			out["score"] = formatMinor(e.Score, 2)
+		}
+		return out
+	}
+	top := make([]gin.H, 0, len(resp.Top))
+	for _, e := range resp.Top {
+		top = append(top, entry(e))
+	}
+	out := gin.H{"board": resp.Board, "period": resp.Period, "top": top, "resets_at": resp.ResetsAt}
+	if resp.Me != nil {
+		out["me"] = entry(resp.Me)
+	}
+	return out
+}
+
+// errStatus — HTTP-код для ошибки сервиса: нехватка денег (FailedPrecondition
+// от кошелька) — 402, остальное — fallback.
+func errStatus(err error, fallback int) int {
+	if status.Code(err) == codes.FailedPrecondition {
+		return http.StatusPaymentRequired
+	}
+	return fallback
+}
+
+// listTxRequest собирает фильтры журнала из query:
+// ?currency=KZT&type=bet,win&ref=…&from=…&to=… (unix мс)&before=<seq>&limit=…
+func listTxRequest(c *gin.Context, userId string) *walletpb.ListTransactionsRequest {
+	req := &walletpb.ListTransactionsRequest{UserId: userId, Ref: c.Query("ref"), Currency: c.Query("currency")}
+	for _, t := range strings.Split(c.Query("type"), ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			req.Types = append(req.Types, t)
+		}
+	}
+	req.From, _ = strconv.ParseInt(c.Query("from"), 10, 64)
+	req.To, _ = strconv.ParseInt(c.Query("to"), 10, 64)
+	req.BeforeSeq, _ = strconv.ParseInt(c.Query("before"), 10, 64)
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	req.Limit = int32(limit)
+	return req
+}
+
+func rateJSON(r *walletpb.Rate) gin.H {
+	return gin.H{"from": r.From, "to": r.To, "rate": r.Rate, "updated_at": r.UpdatedAt, "updated_by": r.UpdatedBy}
+}
+
+// grantWelcomeBonus — приветственный бонус при регистрации.
+func grantWelcomeBonus(wallet walletpb.WalletServiceClient, userId string) (*walletpb.Bonus, error) {
+	return wallet.GrantBonus(context.Background(), &walletpb.GrantBonusRequest{
+		UserId:         userId,
+		Kind:           "welcome",
+		Amount:         creditsMoney(welcomeBonus),
+		IdempotencyKey: "welcome-bonus:" + userId,
+	})
+}
+
+func bonusJSON(b *walletpb.Bonus) gin.H {
+	out := gin.H{
+		"bonus_id":       b.BonusId,
+		"kind":           b.Kind,
+		"amount":         formatMoney(b.Amount),
+		"balance":        formatMoney(b.Balance),
+		"currency":       b.Amount.GetCurrency(),
+		"wager_required": formatMoney(b.WagerRequired),
+		"wagered":        formatMoney(b.Wagered),
+		"status":         b.Status,
+		"expires_at":     b.ExpiresAt,
+		"created_at":     b.CreatedAt,
+	}
+	switch b.Status {
+	case "unlocked":
+		out["released"] = formatMoney(b.Released)
+		out["closed_at"] = b.ClosedAt
+	case "expired":
+		out["forfeited"] = formatMoney(b.Forfeited)
+		out["closed_at"] = b.ClosedAt
+	}
+	return out
+}
+
+// registerBonusRoutes — бонусы игрока и ручное начисление reload-бонуса админом.
+func registerBonusRoutes(protected, admin *gin.RouterGroup, wallet walletpb.WalletServiceClient) {
+	protected.GET("/wallet/bonuses", func(c *gin.Context) {
+		resp, err := wallet.ListBonuses(context.Background(), &walletpb.WalletRequest{UserId: c.GetString("user_id")})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		list := make([]gin.H, 0, len(resp.Bonuses))
+		for _, b := range resp.Bonuses {
+			list = append(list, bonusJSON(b))
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"bonuses":  list,
+			"balance":  formatMoney(resp.Balance),
+			"currency": resp.Balance.GetCurrency(),
+		})
+	})
+	admin.POST("/bonuses", func(c *gin.Context) {
+		var body struct {
+			UserId string `json:"user_id"`
+			Amount string `json:"amount"`
+		}
+		if err := c.ShouldBindJSON(&body); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		amount, err := parseMinor(body.Amount, "")
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		key := c.GetHeader("Idempotency-Key")
+		if key == "" {
+			key = uuid.New().String()
+		}
+		b, err := wallet.GrantBonus(context.Background(), &walletpb.GrantBonusRequest{
+			UserId:         body.UserId,
+			Kind:           "reload",
+			Amount:         &walletpb.Money{Amount: amount},
+			IdempotencyKey: "bonus:" + key,
+		})
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, bonusJSON(b))
+	})
+}
+
+func paymentJSON(p *walletpb.Payment) gin.H {
+	out := gin.H{
+		"payment_id":   p.PaymentId,
+		"user_id":      p.UserId,
+		"kind":         p.Kind,
+		"amount":       formatMoney(p.Amount),
+		"currency":     p.Amount.GetCurrency(),
+		"status":       p.Status,
+		"provider":     p.Provider,
+		"provider_ref": p.ProviderRef,
+		"created_at":   p.CreatedAt,
+		"updated_at":   p.UpdatedAt,
+	}
+	if p.Reason != "" {
+		out["reason"] = p.Reason
+	}
+	if p.ReviewedBy != "" {
+		out["reviewed_by"] = p.ReviewedBy
+	}
+	return out
+}
+
+func paymentListJSON(resp *walletpb.ListPaymentsResponse) gin.H {
+	list := make([]gin.H, 0, len(resp.Payments))
+	for _, p := range resp.Payments {
+		list = append(list, paymentJSON(p))
+	}
+	return gin.H{"payments": list}
+}
+
+// registerPaymentRoutes — пополнение и вывод: {"amount": "50.00", "currency": "USD"}
+// (валюта по умолчанию — основная). Итог платежа приходит от провайдера на
+// webhook; выводы выше порога ждут решения админа.
+func registerPaymentRoutes(api, protected, admin *gin.RouterGroup, wallet walletpb.WalletServiceClient) {
+	request := func(kind string) gin.HandlerFunc {
+		return func(c *gin.Context) {
+			var body struct {
+				Amount   string `json:"amount"`
+				Currency string `json:"currency"`
+			}
+			if err := c.ShouldBindJSON(&body); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			cur := strings.ToUpper(body.Currency)
+			amount, err := parseMinor(body.Amount, cur)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			uid := c.GetString("user_id")
+			key := c.GetHeader("Idempotency-Key")
+			if key == "" {
+				key = uuid.New().String()
+			}
+			req := &walletpb.PaymentRequest{
+				UserId:         uid,
+				Amount:         &walletpb.Money{Amount: amount, Currency: cur},
+				IdempotencyKey: kind + ":" + uid + ":" + key,
+			}
+			call := wallet.RequestDeposit
+			if kind == "withdrawal" {
+				call = wallet.RequestWithdrawal
+			}
+			p, err := call(context.Background(), req)
+			if err != nil {
+				c.JSON(errStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusOK, paymentJSON(p))
+		}
+	}
+	protected.POST("/wallet/deposits", request("deposit"))
+	protected.POST("/wallet/withdrawals", request("withdrawal"))
+	protected.GET("/wallet/payments", func(c *gin.Context) {
+		limit, _ := strconv.Atoi(c.Query("limit"))
+		resp, err := wallet.ListPayments(context.Background(), &walletpb.ListPaymentsRequest{
+			UserId: c.GetString("user_id"),
+			Status: c.Query("status"),
+			Kind:   c.Query("kind"),
+			Limit:  int32(limit),
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, paymentListJSON(resp))
+	})
+
+	// Уведомления провайдера: тело и подпись уходят в кошелёк как есть
+	api.POST("/payments/webhook/:provider", func(c *gin.Context) {
+		payload, err := io.ReadAll(io.LimitReader(c.Request.Body, 64<<10))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		p, err := wallet.PaymentWebhook(context.Background(), &walletpb.PaymentWebhookRequest{
+			Provider:  c.Param("provider"),
+			Payload:   payload,
+			Signature: c.GetHeader("X-Signature"),
+		})
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"payment_id": p.PaymentId, "status": p.Status})
+	})
+
+	// Очередь выводов на проверку: ?status=pending&kind=withdrawal
+	admin.GET("/payments", func(c *gin.Context) {
+		limit, _ := strconv.Atoi(c.Query("limit"))
+		resp, err := wallet.ListPayments(context.Background(), &walletpb.ListPaymentsRequest{
+			UserId: c.Query("user_id"),
+			Status: c.Query("status"),
+			Kind:   c.Query("kind"),
+			Limit:  int32(limit),
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, paymentListJSON(resp))
+	})
+	review := func(approve bool) gin.HandlerFunc {
+		return func(c *gin.Context) {
+			var body struct {
+				Reason string `json:"reason"`
+			}
+			// тело необязательно
+			_ = c.ShouldBindJSON(&body)
+			p, err := wallet.ReviewWithdrawal(context.Background(), &walletpb.ReviewWithdrawalRequest{
+				PaymentId: c.Param("payment_id"),
+				Approve:   approve,
+				AdminId:   c.GetString("user_id"),
+				Reason:    body.Reason,
+			})
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusOK, paymentJSON(p))
+		}
+	}
+	admin.POST("/payments/:payment_id/approve", review(true))
+	admin.POST("/payments/:payment_id/reject", review(false))
+}
+
+// transferConfirmTTL — сколько живёт подтверждение перевода.
+const transferConfirmTTL = 2 * time.Minute
+
+// registerTransferRoutes — перевод другому игроку в два шага. POST
+// /wallet/transfers {"to": "<username>", "amount": "10.00", "currency": "USD"}
+// проверяет получателя и отдаёт подписанный confirmation_token (без sub —
+// за токен входа не сойдёт); POST /wallet/transfers/confirm с ним проводит
+// перевод. Повторное подтверждение тем же токеном денег второй раз не
+// двигает: jti — ключ идемпотентности.
+func registerTransferRoutes(protected *gin.RouterGroup, secret []byte, users userpb.UserServiceClient, wallet walletpb.WalletServiceClient) {
+	protected.POST("/wallet/transfers", func(c *gin.Context) {
+		var body struct {
+			To       string `json:"to"`
+			Amount   string `json:"amount"`
+			Currency string `json:"currency"`
+		}
+		if err := c.ShouldBindJSON(&body); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		cur := strings.ToUpper(body.Currency)
+		amount, err := parseMinor(body.Amount, cur)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if amount <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "amount must be positive"})
+			return
+		}
+		uid := c.GetString("user_id")
+		me, err := users.GetProfile(context.Background(), &userpb.GetProfileRequest{UserId: uid})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if !me.Verified {
+			c.JSON(http.StatusForbidden, gin.H{"error": "verify your email before sending transfers"})
+			return
+		}
+		to, err := users.FindUser(context.Background(), &userpb.FindUserRequest{Username: body.To})
+		if err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "recipient not found"})
+			return
+		}
+		if to.UserId == uid {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "cannot transfer to yourself"})
+			return
+		}
+		if !to.Verified {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "recipient has not verified their email"})
+			return
+		}
+		exp := time.Now().Add(transferConfirmTTL)
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			"typ":         "transfer",
+			"jti":         uuid.New().String(),
+			"from":        uid,
+			"to":          to.UserId,
+			"to_username": to.Username,
+			"amount":      strconv.FormatInt(amount, 10),
+			"currency":    cur,
+			"exp":         exp.Unix(),
+		}).SignedString(secret)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"confirmation_token": token,
+			"to":                 to.Username,
+			"amount":             formatMoney(&walletpb.Money{Amount: amount, Currency: cur}),
+			"currency":           cur,
+			"expires_at":         exp.Unix(),
+		})
+	})
+	protected.POST("/wallet/transfers/confirm", func(c *gin.Context) {
+		var body struct {
+			ConfirmationToken string `json:"confirmation_token"`
+		}
+		if err := c.ShouldBindJSON(&body); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		token, err := jwt.Parse(body.ConfirmationToken, func(t *jwt.Token) (interface{}, error) {
+			return secret, nil
+		})
+		if err != nil || !token.Valid {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid or expired confirmation"})
+			return
+		}
+		claims := token.Claims.(jwt.MapClaims)
+		typ, _ := claims["typ"].(string)
+		from, _ := claims["from"].(string)
+		to, _ := claims["to"].(string)
+		jti, _ := claims["jti"].(string)
+		amountStr, _ := claims["amount"].(string)
+		cur, _ := claims["currency"].(string)
+		amount, err := strconv.ParseInt(amountStr, 10, 64)
+		uid := c.GetString("user_id")
+		if typ != "transfer" || from != uid || to == "" || jti == "" || err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid or expired confirmation"})
+			return
+		}
+		resp, err := wallet.Transfer(context.Background(), &walletpb.TransferRequest{
+			FromUserId:     uid,
+			ToUserId:       to,
+			Amount:         &walletpb.Money{Amount: amount, Currency: cur},
+			IdempotencyKey: "transfer:" + jti,
+		})
+		if err != nil {
+			c.JSON(errStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"transfer_id":     resp.TransferId,
+			"to":              claims["to_username"],
+			"amount":          formatMoney(resp.Credit.GetAmount()),
+			"currency":        resp.Balance.GetCurrency(),
+			"balance":         formatMoney(resp.Balance),
+			"daily_remaining": moneyJSON(resp.DailyRemaining),
+			"transaction":     txJSON(resp.Debit),
+		})
+	})
+}
+
+func registerWalletRoutes(protected, admin *gin.RouterGroup, wallet walletpb.WalletServiceClient) {
+	// Все подкошельки игрока, основной — первым
+	protected.GET("/wallets", func(c *gin.Context) {
+		resp, err := wallet.ListWallets(context.Background(), &walletpb.WalletRequest{UserId: c.GetString("user_id")})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		wallets := make([]gin.H, 0, len(resp.Wallets))
+		for _, w := range resp.Wallets {
+			wallets = append(wallets, gin.H{
+				"currency": w.Balance.GetCurrency(),
+				"balance":  formatMoney(w.Balance),
+				"held":     formatMoney(w.Held),
+			})
+		}
+		c.JSON(http.StatusOK, gin.H{"wallets": wallets})
+	})
+
+	// Курсы обмена и обмен между своими подкошельками. Повтор запроса с тем же
+	// заголовком Idempotency-Key не меняет кошелёк второй раз.
+	protected.GET("/wallet/rates", func(c *gin.Context) {
+		resp, err := wallet.ListRates(context.Background(), &walletpb.ListRatesRequest{})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		rates := make([]gin.H, 0, len(resp.Rates))
+		for _, r := range resp.Rates {
+			rates = append(rates, rateJSON(r))
+		}
+		c.JSON(http.StatusOK, gin.H{"rates": rates})
+	})
+	protected.POST("/wallet/convert", func(c *gin.Context) {
+		var body struct {
+			Amount string `json:"amount"` // "12.34" в валюте from
+			From   string `json:"from"`
+			To     string `json:"to"`
+		}
+		if err := c.ShouldBindJSON(&body); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		from := strings.ToUpper(body.From)
+		amount, err := parseMinor(body.Amount, from)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		uid := c.GetString("user_id")
+		key := c.GetHeader("Idempotency-Key")
+		if key == "" {
+			key = uuid.New().String()
+		}
+		resp, err := wallet.Convert(context.Background(), &walletpb.ConvertRequest{
+			UserId:         uid,
+			Amount:         &walletpb.Money{Amount: amount, Currency: from},
+			ToCurrency:     body.To,
+			IdempotencyKey: "convert:" + uid + ":" + key,
+		})
+		if err != nil {
+			c.JSON(errStatus(err, http.StatusBadRequest), gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"conversion_id": resp.ConversionId,
+			"debited":       moneyJSON(resp.Debited),
+			"credited":      moneyJSON(resp.Credited),
+			"rate":          resp.Rate,
+			"from_balance":  moneyJSON(resp.FromBalance),
+			"to_balance":    moneyJSON(resp.ToBalance),
+		})
+	})
+
+	// Таблица курсов: {"from": "USD", "to": "KZT", "rate": "472.15"}; пустой
+	// rate снимает пару
+	admin.PUT("/rates", func(c *gin.Context) {
+		var body struct {
+			From string `json:"from"`
+			To   string `json:"to"`
+			Rate string `json:"rate"`
+		}
+		if err := c.ShouldBindJSON(&body); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		resp, err := wallet.SetRate(context.Background(), &walletpb.SetRateRequest{
+			From:    body.From,
+			To:      body.To,
+			Rate:    body.Rate,
+			AdminId: c.GetString("user_id"),
+		})
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, rateJSON(resp))
+	})
+
+	// Журнал своего кошелька: ставки, выигрыши, бонусы…
+	protected.GET("/wallet/transactions", func(c *gin.Context) {
+		resp, err := wallet.ListTransactions(context.Background(), listTxRequest(c, c.GetString("user_id")))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, txListJSON(resp))
+	})
+
+	// Журнал любого кошелька и сверка снимка баланса с журналом
+	admin.GET("/wallets/:user_id/transactions", func(c *gin.Context) {
+		resp, err := wallet.ListTransactions(context.Background(), listTxRequest(c, c.Param("user_id")))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, txListJSON(resp))
+	})
+	admin.POST("/wallets/:user_id/reconcile", func(c *gin.Context) {
+		resp, err := wallet.ReconcileBalance(context.Background(), &walletpb.ReconcileRequest{
+			UserId:   c.Param("user_id"),
+			Currency: c.Query("currency"),
+			Fix:      c.Query("fix") == "true",
+		})
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"snapshot": formatMoney(resp.Snapshot),
+			"ledger":   formatMoney(resp.Ledger),
+			"currency": resp.Snapshot.GetCurrency(),
+			"match":    resp.Match,
+			"fixed":    resp.Fixed,
+		})
+	})
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// слова по умолчанию; свой список — CHAT_BANNED_WORDS через запятую
+const defaultBannedWords = "fuck,shit,bitch,cunt,asshole,nigger,faggot"
+
+// ссылки: схема, www. или голый домен с популярной зоной
+var linkRe = regexp.MustCompile(`(?i)\b(?:https?://|www\.)\S+|\b[a-z0-9-]+(?:\.[a-z0-9-]+)*\.(?:com|net|org|ru|kz|io|gg|me|xyz|co|cc|tk|ly)\b(?:/\S*)?`)
+
+// filter маскирует запрещённые слова звёздочками и вырезает ссылки.
+type filter struct {
+	words      *regexp.Regexp
+	allowLinks bool
+}
+
+func newFilter(list string, allowLinks bool) *filter {
+	if strings.TrimSpace(list) == "" {
+		list = defaultBannedWords
+	}
+	var parts []string
+	for _, w := range strings.Split(list, ",") {
+		if w = strings.TrimSpace(w); w != "" {
+			parts = append(parts, regexp.QuoteMeta(w))
+		}
+	}
+	f := &filter{allowLinks: allowLinks}
+	if len(parts) > 0 {
+		// слово целиком и его формы: fuck, fucks, fucking, fucked
+		f.words = regexp.MustCompile(`(?i)\b(?:` + strings.Join(parts, "|") + `)(?:s|es|ed|er|ers|ing|y)?\b`)
+	}
+	return f
+}
+
+func (f *filter) clean(text string) string {
+	if !f.allowLinks {
+		text = linkRe.ReplaceAllString(text, "[link removed]")
+	}
+	if f.words != nil {
+		text = f.words.ReplaceAllStringFunc(text, func(w string) string {
+			return strings.Repeat("*", len([]rune(w)))
+		})
+	}
+	return text
+}
+
+// limiter — скользящее окно: не больше n сообщений от игрока за window.
+type limiter struct {
+	mu     sync.Mutex
+	n      int
+	window time.Duration
+	hits   map[string][]time.Time
+}
+
+func newLimiter(n int, window time.Duration) *limiter {
+	return &limiter{n: n, window: window, hits: make(map[string][]time.Time)}
+}
+
+func (l *limiter) allow(userId string, now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	from := now.Add(-l.window)
+	if len(l.hits) > 10000 {
+		// чистим тех, кто давно молчит, чтобы карта не росла бесконечно
+		for u, h := range l.hits {
+			if len(h) == 0 || h[len(h)-1].Before(from) {
+				delete(l.hits, u)
+			}
+		}
+	}
+	h := l.hits[userId]
+	for len(h) > 0 && !h[0].After(from) {
+		h = h[1:]
+	}
+	if len(h) >= l.n {
+		l.hits[userId] = h
+		return false
+	}
+	l.hits[userId] = append(h, now)
+	return true
+}
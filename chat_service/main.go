@@ -0,0 +1,259 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	chatpb "github.com/Arsencchikkk/final/casino/proto/chat"
+	"github.com/joho/godotenv"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc"
+)
+
+const historyMaxLimit = 100
+
+// комната: "lobby", "holdem:<table_id>" и т.п.
+var roomRe = regexp.MustCompile(`^[a-z0-9_-]+(:[a-zA-Z0-9_-]+)?$`)
+
+// MessageDoc — сообщение чата. Старые сообщения удаляет TTL-индекс по created_at.
+type MessageDoc struct {
+	ID        primitive.ObjectID `bson:"_id"`
+	Room      string             `bson:"room"`
+	UserId    string             `bson:"user_id"`
+	Username  string             `bson:"username"`
+	Text      string             `bson:"text"`
+	CreatedAt time.Time          `bson:"created_at"`
+}
+
+type server struct {
+	chatpb.UnimplementedChatServiceServer
+	messages  *mongo.Collection
+	sanctions *mongo.Collection
+	filter    *filter
+	limiter   *limiter
+	maxLen    int
+
+	// подписчики комнат; рассылка в памяти, поэтому сервис запускается в одном экземпляре
+	mu    sync.Mutex
+	rooms map[string]map[chan *chatpb.ChatMessage]struct{}
+}
+
+func envOr(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
+
+func envInt(key string, def int) int {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 1 {
+		log.Fatalf("%s: bad value %q", key, v)
+	}
+	return n
+}
+
+func NewServer(ctx context.Context) *server {
+	_ = godotenv.Load()
+
+	mongoURI := os.Getenv("MONGO_URI")
+	mongoDB := os.Getenv("MONGO_DB")
+	if mongoURI == "" || mongoDB == "" {
+		log.Fatal("MONGO_URI и MONGO_DB должны быть заданы")
+	}
+	retention := time.Duration(envInt("CHAT_RETENTION_HOURS", 72)) * time.Hour
+
+	log.Printf("[init] connecting to MongoDB at %s", mongoURI)
+	mClient, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoURI))
+	if err != nil {
+		log.Fatalf("[init][mongo] connect error: %v", err)
+	}
+	db := mClient.Database(mongoDB)
+	s := &server{
+		messages:  db.Collection(envOr("MONGO_MESSAGES_COL", "chat_messages")),
+		sanctions: db.Collection(envOr("MONGO_SANCTIONS_COL", "chat_sanctions")),
+		filter:    newFilter(os.Getenv("CHAT_BANNED_WORDS"), os.Getenv("CHAT_ALLOW_LINKS") == "true"),
+		limiter:   newLimiter(envInt("CHAT_RATE_LIMIT", 5), time.Duration(envInt("CHAT_RATE_WINDOW_SEC", 10))*time.Second),
+		maxLen:    envInt("CHAT_MAX_LEN", 300),
+		rooms:     make(map[string]map[chan *chatpb.ChatMessage]struct{}),
+	}
+
+	// хранение ограничено: сообщения старше CHAT_RETENTION_HOURS удаляет сам Mongo
+	if _, err := s.messages.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "room", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "created_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(int32(retention.Seconds()))},
+	}); err != nil {
+		log.Fatalf("[init][mongo] messages index: %v", err)
+	}
+	// истёкшие наказания тоже удаляются сами; у бессрочных until нет
+	if _, err := s.sanctions.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "user_id", Value: 1}}},
+		{Keys: bson.D{{Key: "until", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)},
+	}); err != nil {
+		log.Fatalf("[init][mongo] sanctions index: %v", err)
+	}
+	log.Printf("[init] chat: retention %s, max length %d", retention, s.maxLen)
+	return s
+}
+
+func (s *server) Send(ctx context.Context, req *chatpb.SendRequest) (*chatpb.ChatMessage, error) {
+	if req.UserId == "" {
+		return nil, fmt.Errorf("user_id required")
+	}
+	if !roomRe.MatchString(req.Room) {
+		return nil, fmt.Errorf("invalid room %q", req.Room)
+	}
+	text := strings.TrimSpace(req.Text)
+	if text == "" {
+		return nil, fmt.Errorf("empty message")
+	}
+	if len([]rune(text)) > s.maxLen {
+		return nil, fmt.Errorf("message is longer than %d characters", s.maxLen)
+	}
+
+	active, err := s.active(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	for _, sn := range active {
+		if sn.Kind == "ban" || sn.Kind == "mute" {
+			return nil, fmt.Errorf("you can't write to chat: %s", sn.describe())
+		}
+	}
+	if !s.limiter.allow(req.UserId, time.Now()) {
+		return nil, fmt.Errorf("too many messages, slow down")
+	}
+
+	d := MessageDoc{
+		ID:        primitive.NewObjectID(),
+		Room:      req.Room,
+		UserId:    req.UserId,
+		Username:  req.Username,
+		Text:      s.filter.clean(text),
+		CreatedAt: time.Now(),
+	}
+	if _, err := s.messages.InsertOne(ctx, d); err != nil {
+		log.Printf("[Send] mongo InsertOne error: %v", err)
+		return nil, err
+	}
+	msg := messageToPb(&d)
+	s.broadcast(msg)
+	return msg, nil
+}
+
+func (s *server) History(ctx context.Context, req *chatpb.HistoryRequest) (*chatpb.HistoryResponse, error) {
+	if !roomRe.MatchString(req.Room) {
+		return nil, fmt.Errorf("invalid room %q", req.Room)
+	}
+	limit := int64(req.Limit)
+	if limit <= 0 || limit > historyMaxLimit {
+		limit = 50
+	}
+	filter := bson.M{"room": req.Room}
+	if req.Before > 0 {
+		filter["created_at"] = bson.M{"$lt": time.Unix(req.Before, 0)}
+	}
+	cur, err := s.messages.Find(ctx, filter, options.Find().SetSort(bson.M{"created_at": -1}).SetLimit(limit))
+	if err != nil {
+		log.Printf("[History] mongo FIND error: %v", err)
+		return nil, err
+	}
+	var docs []MessageDoc
+	if err := cur.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	resp := &chatpb.HistoryResponse{}
+	// выбирали новые сверху, отдаём по порядку
+	for i := len(docs) - 1; i >= 0; i-- {
+		resp.Messages = append(resp.Messages, messageToPb(&docs[i]))
+	}
+	return resp, nil
+}
+
+// Watch шлёт новые сообщения комнаты; историю клиент берёт через History.
+func (s *server) Watch(req *chatpb.WatchRequest, stream chatpb.ChatService_WatchServer) error {
+	if !roomRe.MatchString(req.Room) {
+		return fmt.Errorf("invalid room %q", req.Room)
+	}
+	ch := make(chan *chatpb.ChatMessage, 32)
+	s.mu.Lock()
+	if s.rooms[req.Room] == nil {
+		s.rooms[req.Room] = make(map[chan *chatpb.ChatMessage]struct{})
+	}
+	s.rooms[req.Room][ch] = struct{}{}
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.rooms[req.Room], ch)
+		if len(s.rooms[req.Room]) == 0 {
+			delete(s.rooms, req.Room)
+		}
+		s.mu.Unlock()
+	}()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case msg := <-ch:
+			if err := stream.Send(msg); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// broadcast рассылает сообщение подписчикам комнаты; медленные пропускают сообщения.
+func (s *server) broadcast(msg *chatpb.ChatMessage) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for ch := range s.rooms[msg.Room] {
+		select {
+		case ch <- msg:
+		default:
+		}
+	}
+}
+
+func messageToPb(d *MessageDoc) *chatpb.ChatMessage {
+	return &chatpb.ChatMessage{
+		MessageId: d.ID.Hex(),
+		Room:      d.Room,
+		UserId:    d.UserId,
+		Username:  d.Username,
+		Text:      d.Text,
+		CreatedAt: d.CreatedAt.Unix(),
+	}
+}
+
+func main() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv := NewServer(ctx)
+
+	lis, err := net.Listen("tcp", ":50056")
+	if err != nil {
+		log.Fatalf("failed to listen: %v", err)
+	}
+	grpcSrv := grpc.NewServer()
+	chatpb.RegisterChatServiceServer(grpcSrv, srv)
+
+	log.Println("ChatService running on :50056")
+	log.Fatal(grpcSrv.Serve(lis))
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	chatpb "github.com/Arsencchikkk/final/casino/proto/chat"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SanctionDoc — мут или бан; _id = kind:user_id, у игрока не больше одного
+// наказания каждого вида. Until == nil — бессрочно.
+type SanctionDoc struct {
+	Id          string     `bson:"_id"`
+	UserId      string     `bson:"user_id"`
+	Kind        string     `bson:"kind"`
+	Reason      string     `bson:"reason"`
+	ModeratorId string     `bson:"moderator_id"`
+	CreatedAt   time.Time  `bson:"created_at"`
+	Until       *time.Time `bson:"until,omitempty"`
+}
+
+func (d *SanctionDoc) describe() string {
+	what := "banned"
+	if d.Kind == "mute" {
+		what = "muted"
+	}
+	if d.Until == nil {
+		return what
+	}
+	return what + " until " + d.Until.UTC().Format(time.RFC3339)
+}
+
+// active возвращает действующие наказания: TTL-индекс удаляет истёкшие не сразу.
+func (s *server) active(ctx context.Context, userId string) ([]SanctionDoc, error) {
+	cur, err := s.sanctions.Find(ctx, bson.M{
+		"user_id": userId,
+		"$or":     bson.A{bson.M{"until": bson.M{"$exists": false}}, bson.M{"until": bson.M{"$gt": time.Now()}}},
+	})
+	if err != nil {
+		log.Printf("[sanctions] mongo FIND error: %v", err)
+		return nil, err
+	}
+	var docs []SanctionDoc
+	if err := cur.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
+// Moderate выдаёт мут или бан; повторный вызов заменяет прежнее наказание того же вида.
+func (s *server) Moderate(ctx context.Context, req *chatpb.SanctionRequest) (*chatpb.Sanction, error) {
+	if req.UserId == "" || req.ModeratorId == "" {
+		return nil, fmt.Errorf("user_id and moderator_id required")
+	}
+	if req.Kind != "mute" && req.Kind != "ban" {
+		return nil, fmt.Errorf("kind must be mute or ban")
+	}
+	if req.Minutes < 0 {
+		return nil, fmt.Errorf("minutes can't be negative")
+	}
+	d := SanctionDoc{
+		Id:          req.Kind + ":" + req.UserId,
+		UserId:      req.UserId,
+		Kind:        req.Kind,
+		Reason:      req.Reason,
+		ModeratorId: req.ModeratorId,
+		CreatedAt:   time.Now(),
+	}
+	if req.Minutes > 0 {
+		until := d.CreatedAt.Add(time.Duration(req.Minutes) * time.Minute)
+		d.Until = &until
+	}
+	if _, err := s.sanctions.ReplaceOne(ctx, bson.M{"_id": d.Id}, d, options.Replace().SetUpsert(true)); err != nil {
+		log.Printf("[Moderate] mongo ReplaceOne error: %v", err)
+		return nil, err
+	}
+	log.Printf("[Moderate] %s %s by %s: %s", req.UserId, d.describe(), req.ModeratorId, req.Reason)
+	return sanctionToPb(&d), nil
+}
+
+func (s *server) Lift(ctx context.Context, req *chatpb.LiftRequest) (*chatpb.LiftResponse, error) {
+	if req.Kind != "mute" && req.Kind != "ban" {
+		return nil, fmt.Errorf("kind must be mute or ban")
+	}
+	res, err := s.sanctions.DeleteOne(ctx, bson.M{"_id": req.Kind + ":" + req.UserId})
+	if err != nil {
+		log.Printf("[Lift] mongo DeleteOne error: %v", err)
+		return nil, err
+	}
+	if res.DeletedCount > 0 {
+		log.Printf("[Lift] %s %s lifted by %s", req.UserId, req.Kind, req.ModeratorId)
+	}
+	return &chatpb.LiftResponse{Lifted: res.DeletedCount > 0}, nil
+}
+
+func (s *server) Sanctions(ctx context.Context, req *chatpb.SanctionsRequest) (*chatpb.SanctionsResponse, error) {
+	docs, err := s.active(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	resp := &chatpb.SanctionsResponse{}
+	for i := range docs {
+		switch docs[i].Kind {
+		case "ban":
+			resp.Banned = true
+		case "mute":
+			resp.Muted = true
+		}
+		resp.Sanctions = append(resp.Sanctions, sanctionToPb(&docs[i]))
+	}
+	return resp, nil
+}
+
+func sanctionToPb(d *SanctionDoc) *chatpb.Sanction {
+	sn := &chatpb.Sanction{
+		UserId:      d.UserId,
+		Kind:        d.Kind,
+		Reason:      d.Reason,
+		ModeratorId: d.ModeratorId,
+		CreatedAt:   d.CreatedAt.Unix(),
+	}
+	if d.Until != nil {
+		sn.Until = d.Until.Unix()
+	}
+	return sn
+}
@@ -0,0 +1,294 @@
+// auditverify walks the round audit log kept by the wallet service and checks
+// that nobody edited history: every entry must hash to its stored hash, point
+// at the hash of the entry before it, and seq numbers must have no gaps.
+//
+//	auditverify                               # straight from MongoDB (MONGO_URI, MONGO_DB)
+//	auditverify -file audit.ndjson            # an export from GET /api/admin/audit/export
+//	auditverify -file part.ndjson -from 5000  # an export that starts mid-chain
+//	auditverify -anchor /backup/audit.anchor  # also check against the last run
+//
+// The hash chain can't tell a log that was cut short from one that simply
+// ends there. With -anchor the head of every clean run is appended to a file
+// kept away from the database, and the next run fails if that entry is gone
+// or hashes differently.
+//
+// The exit status is 1 when anything is wrong, so it can run from cron.
+package main
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// entry is one audit record. From MongoDB settled_at is a date, in an export
+// it's unix milliseconds; both end up in SettledAt as milliseconds. Amounts
+// are minor currency units; entries written before the switch to Money have
+// no currency.
+type entry struct {
+	Seq       int64     `bson:"seq" json:"seq"`
+	PrevHash  string    `bson:"prev_hash" json:"prev_hash"`
+	Hash      string    `bson:"hash" json:"hash"`
+	UserId    string    `bson:"user_id" json:"user_id"`
+	Game      string    `bson:"game" json:"game"`
+	RoundId   string    `bson:"round_id" json:"round_id"`
+	Stake     int64     `bson:"stake" json:"-"`
+	Payout    int64     `bson:"payout" json:"-"`
+	Currency  string    `bson:"currency" json:"-"`
+	Tags      []string  `bson:"tags" json:"tags"`
+	SettledAt int64     `bson:"-" json:"settled_at"`
+	Time      time.Time `bson:"settled_at" json:"-"`
+}
+
+// money is how an export writes stake and payout.
+type money struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// exported is an export line: the entry plus its amounts as money.
+type exported struct {
+	entry
+	Stake  money `json:"stake"`
+	Payout money `json:"payout"`
+}
+
+// hash must stay byte-for-byte the same as auditHash in wallet_service/audit.go.
+func (e *entry) hash() string {
+	line := fmt.Sprintf("%d\n%s\n%s\n%s\n%s\n%d\n%d\n%s\n%d",
+		e.Seq, e.PrevHash, e.UserId, e.Game, e.RoundId, e.Stake, e.Payout,
+		strings.Join(e.Tags, ","), e.SettledAt)
+	if e.Currency != "" {
+		line += "\n" + e.Currency
+	}
+	sum := sha256.Sum256([]byte(line))
+	return hex.EncodeToString(sum[:])
+}
+
+// verifier checks entries one at a time, so the log never has to fit in memory.
+type verifier struct {
+	next     int64 // seq expected next
+	prevHash string
+	started  bool
+	checked  int64
+	problems int
+	maxShow  int
+
+	// head recorded by an earlier run, 0 without -anchor
+	anchorSeq  int64
+	anchorHash string
+	anchorSeen bool
+}
+
+func (v *verifier) report(format string, args ...interface{}) {
+	v.problems++
+	if v.problems <= v.maxShow {
+		fmt.Printf(format+"\n", args...)
+	}
+}
+
+func (v *verifier) check(e *entry) {
+	v.checked++
+	if got := e.hash(); got != e.Hash {
+		v.report("seq %d: TAMPERED, stored hash %.12s… but contents hash to %.12s…", e.Seq, e.Hash, got)
+	}
+	switch {
+	case e.Seq < v.next:
+		v.report("seq %d: OUT OF ORDER or duplicate, expected %d", e.Seq, v.next)
+		return
+	case e.Seq > v.next:
+		v.report("seq %d: GAP, entries %d..%d are missing", e.Seq, v.next, e.Seq-1)
+	case v.started || v.next == 1:
+		// a chain that starts at 1 begins with an empty prev_hash; an export
+		// that starts later has nothing to compare its first link with
+		if e.PrevHash != v.prevHash {
+			v.report("seq %d: BROKEN LINK, prev_hash %.12s… but entry %d has hash %.12s…", e.Seq, e.PrevHash, e.Seq-1, v.prevHash)
+		}
+	}
+	if e.Seq == v.anchorSeq {
+		v.anchorSeen = true
+		if e.Hash != v.anchorHash {
+			v.report("seq %d: REWRITTEN, anchored head was %.12s… but the log has %.12s…", e.Seq, v.anchorHash, e.Hash)
+		}
+	}
+	v.started = true
+	v.next = e.Seq + 1
+	// carry on from the stored hash so one edited entry is reported once, not
+	// as a break on every entry after it
+	v.prevHash = e.Hash
+}
+
+func envOr(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
+
+func main() {
+	file := flag.String("file", "", "verify an NDJSON export instead of MongoDB (- for stdin)")
+	mongoURI := flag.String("mongo-uri", os.Getenv("MONGO_URI"), "MongoDB URI")
+	mongoDB := flag.String("db", envOr("MONGO_DB", "casino"), "database")
+	mongoCol := flag.String("col", envOr("MONGO_AUDIT_COL", "audit_log"), "audit collection")
+	from := flag.Int64("from", 1, "seq the log (or export) is expected to start at")
+	maxShow := flag.Int("max", 50, "print at most this many problems")
+	anchor := flag.String("anchor", "", "file with the heads of earlier runs; a clean run appends its own")
+	flag.Parse()
+
+	if *from < 1 {
+		log.Fatal("-from must be at least 1")
+	}
+	v := &verifier{next: *from, maxShow: *maxShow}
+	var err error
+	if *anchor != "" {
+		if v.anchorSeq, v.anchorHash, err = readAnchor(*anchor); err != nil {
+			log.Fatal(err)
+		}
+		if v.anchorSeq > 0 && v.anchorSeq < *from {
+			fmt.Printf("anchor seq %d is before -from %d, not checked\n", v.anchorSeq, *from)
+			v.anchorSeq = 0
+		}
+	}
+	if *file != "" {
+		err = verifyFile(*file, v)
+	} else {
+		if *mongoURI == "" {
+			log.Fatal("set -mongo-uri (or MONGO_URI), or pass -file")
+		}
+		err = verifyMongo(*mongoURI, *mongoDB, *mongoCol, *from, v)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	if v.anchorSeq > 0 && !v.anchorSeen {
+		v.report("seq %d: TRUNCATED, the anchored head is gone, the log ends at %d", v.anchorSeq, v.next-1)
+	}
+
+	if v.problems > v.maxShow {
+		fmt.Printf("… %d more problems not shown\n", v.problems-v.maxShow)
+	}
+	if v.checked == 0 {
+		fmt.Println("no audit entries found")
+		if v.problems > 0 {
+			os.Exit(1)
+		}
+		return
+	}
+	fmt.Printf("checked %d entries, seq %d..%d, head %s\n", v.checked, *from, v.next-1, v.prevHash)
+	if v.problems > 0 {
+		fmt.Printf("FAILED: %d problems\n", v.problems)
+		os.Exit(1)
+	}
+	fmt.Println("OK: chain intact")
+	if *anchor != "" {
+		if err := appendAnchor(*anchor, v.next-1, v.prevHash); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+// readAnchor returns the last head in the anchor file, "seq hash time" per
+// line; a missing file is a first run.
+func readAnchor(path string) (int64, string, error) {
+	b, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return 0, "", nil
+	}
+	if err != nil {
+		return 0, "", err
+	}
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	last := strings.Fields(lines[len(lines)-1])
+	if len(last) == 0 {
+		return 0, "", nil
+	}
+	if len(last) < 2 {
+		return 0, "", fmt.Errorf("anchor %s: malformed line %q", path, lines[len(lines)-1])
+	}
+	seq, err := strconv.ParseInt(last[0], 10, 64)
+	if err != nil {
+		return 0, "", fmt.Errorf("anchor %s: %w", path, err)
+	}
+	return seq, last[1], nil
+}
+
+func appendAnchor(path string, seq int64, hash string) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(f, "%d %s %s\n", seq, hash, time.Now().UTC().Format(time.RFC3339)); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func verifyFile(path string, v *verifier) error {
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	line := 0
+	for sc.Scan() {
+		line++
+		if len(strings.TrimSpace(sc.Text())) == 0 {
+			continue
+		}
+		var x exported
+		if err := json.Unmarshal(sc.Bytes(), &x); err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+		e := x.entry
+		e.Stake, e.Payout, e.Currency = x.Stake.Amount, x.Payout.Amount, x.Stake.Currency
+		v.check(&e)
+	}
+	return sc.Err()
+}
+
+func verifyMongo(uri, db, col string, from int64, v *verifier) error {
+	ctx := context.Background()
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	if err != nil {
+		return fmt.Errorf("mongo connect: %w", err)
+	}
+	defer client.Disconnect(ctx)
+
+	cur, err := client.Database(db).Collection(col).Find(ctx,
+		bson.M{"seq": bson.M{"$gte": from}},
+		options.Find().SetSort(bson.D{{Key: "seq", Value: 1}}))
+	if err != nil {
+		return fmt.Errorf("mongo find: %w", err)
+	}
+	defer cur.Close(ctx)
+	for cur.Next(ctx) {
+		var e entry
+		if err := cur.Decode(&e); err != nil {
+			return fmt.Errorf("decode: %w", err)
+		}
+		e.SettledAt = e.Time.UnixMilli()
+		v.check(&e)
+	}
+	return cur.Err()
+}
@@ -0,0 +1,263 @@
+// casinobot runs synthetic players against the game service: load tests for
+// blackjack and seat fillers for the hold'em tables in staging.
+//
+//	casinobot -game blackjack -bots 50 -concurrency 10 -rounds 200 -strategy basic
+//	casinobot -game holdem -table holdem-micro -bots 4 -fund 1000 -strategy random
+//	casinobot -game blackjack -strategy scripted -script hit,stand
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"math/rand"
+	"os"
+	"os/signal"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	gamepb "github.com/Arsencchikkk/final/casino/proto/game"
+	walletpb "github.com/Arsencchikkk/final/casino/proto/wallet"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+)
+
+type config struct {
+	game        string
+	bots        int
+	concurrency int
+	rounds      int
+	strategy    string
+	script      string
+	prefix      string
+	fund        int
+	stake       int
+	table       string
+	buyIn       int
+	think       time.Duration
+	ramp        time.Duration
+}
+
+// result is one bot's report. Balances and Net are wallet minor units of
+// Currency. Blackjack through NewGame/Hit/Stand is dealt for the game
+// service's fixed stake, so there Net is counted in -stake units; hold'em Net
+// is the real wallet difference.
+type result struct {
+	Bot           string `json:"bot"`
+	Game          string `json:"game"`
+	Strategy      string `json:"strategy"`
+	Rounds        int    `json:"rounds"`
+	Wins          int    `json:"wins"`
+	Losses        int    `json:"losses"`
+	Pushes        int    `json:"pushes"`
+	Net           int64  `json:"net"`
+	BalanceBefore int64  `json:"balance_before"`
+	BalanceAfter  int64  `json:"balance_after"`
+	Currency      string `json:"currency,omitempty"`
+	Errors        int    `json:"errors"`
+	LastError     string `json:"last_error,omitempty"`
+	DurationMs    int64  `json:"duration_ms"`
+}
+
+func (r *result) fail(err error) {
+	r.Errors++
+	r.LastError = err.Error()
+}
+
+type bot struct {
+	id       string
+	cfg      *config
+	strategy Strategy
+	game     gamepb.GameServiceClient
+	wallet   walletpb.WalletServiceClient
+	res      *result
+}
+
+func envOr(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
+
+func main() {
+	cfg := &config{}
+	gameAddr := flag.String("game-addr", envOr("GAME_SERVICE_ADDR", "localhost:50051"), "game service address")
+	walletAddr := flag.String("wallet-addr", envOr("WALLET_SERVICE_ADDR", "localhost:50052"), "wallet service address")
+	flag.StringVar(&cfg.game, "game", "blackjack", "blackjack or holdem")
+	flag.IntVar(&cfg.bots, "bots", 10, "number of bots")
+	flag.IntVar(&cfg.concurrency, "concurrency", 5, "bots playing at the same time")
+	flag.IntVar(&cfg.rounds, "rounds", 50, "hands each bot plays")
+	flag.StringVar(&cfg.strategy, "strategy", "basic", "basic, random or scripted")
+	flag.StringVar(&cfg.script, "script", "", "actions for -strategy scripted, comma-separated")
+	flag.StringVar(&cfg.prefix, "prefix", "bot", "bot user ids are <prefix>-001, <prefix>-002, ...")
+	flag.IntVar(&cfg.fund, "fund", 0, "credit each bot's wallet with this much before it plays")
+	flag.IntVar(&cfg.stake, "stake", 100, "blackjack stake in credits, used for the net figure")
+	flag.StringVar(&cfg.table, "table", "holdem-micro", "hold'em table to sit at")
+	flag.IntVar(&cfg.buyIn, "buy-in", 0, "hold'em buy-in (0 = table minimum)")
+	flag.DurationVar(&cfg.think, "think", 100*time.Millisecond, "pause before each action")
+	flag.DurationVar(&cfg.ramp, "ramp", 50*time.Millisecond, "pause between bot starts")
+	timeout := flag.Duration("timeout", 10*time.Minute, "stop all bots after this long")
+	asJSON := flag.Bool("json", false, "print results as JSON")
+	flag.Parse()
+
+	if cfg.game != "blackjack" && cfg.game != "holdem" {
+		log.Fatalf("unknown game %q (blackjack, holdem)", cfg.game)
+	}
+	if cfg.bots < 1 || cfg.concurrency < 1 || cfg.rounds < 1 {
+		log.Fatal("-bots, -concurrency and -rounds must be positive")
+	}
+	if _, err := newStrategy(cfg.strategy, cfg.script, nil); err != nil {
+		log.Fatal(err)
+	}
+
+	ga, err := grpc.Dial(*gameAddr, grpc.WithInsecure())
+	if err != nil {
+		log.Fatalf("cannot dial game service: %v", err)
+	}
+	wa, err := grpc.Dial(*walletAddr, grpc.WithInsecure())
+	if err != nil {
+		log.Fatalf("cannot dial wallet service: %v", err)
+	}
+	game := gamepb.NewGameServiceClient(ga)
+	wallet := walletpb.NewWalletServiceClient(wa)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	ctx, cancel := context.WithTimeout(ctx, *timeout)
+	defer cancel()
+
+	results := make([]*result, cfg.bots)
+	sem := make(chan struct{}, cfg.concurrency)
+	var wg sync.WaitGroup
+	for i := 0; i < cfg.bots; i++ {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		id := fmt.Sprintf("%s-%03d", cfg.prefix, i+1)
+		strategy, _ := newStrategy(cfg.strategy, cfg.script, rand.New(rand.NewSource(time.Now().UnixNano()+int64(i))))
+		b := &bot{
+			id:       id,
+			cfg:      cfg,
+			strategy: strategy,
+			game:     game,
+			wallet:   wallet,
+			res:      &result{Bot: id, Game: cfg.game, Strategy: cfg.strategy},
+		}
+		results[i] = b.res
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			b.run(ctx)
+		}()
+		time.Sleep(cfg.ramp)
+	}
+	wg.Wait()
+
+	var done []*result
+	for _, r := range results {
+		if r != nil {
+			done = append(done, r)
+		}
+	}
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		enc.Encode(done)
+		return
+	}
+	report(done)
+}
+
+func (b *bot) run(ctx context.Context) {
+	start := time.Now()
+	defer func() { b.res.DurationMs = time.Since(start).Milliseconds() }()
+
+	if b.cfg.fund > 0 {
+		if _, err := b.wallet.UpdateBalance(ctx, &walletpb.WalletUpdateRequest{UserId: b.id, Amount: &walletpb.Money{Amount: int64(b.cfg.fund) * creditMinor}, Type: "adjustment", Ref: "casinobot", IdempotencyKey: "casinobot:fund:" + uuid.New().String()}); err != nil {
+			b.res.fail(fmt.Errorf("fund: %w", err))
+			return
+		}
+	}
+	b.res.BalanceBefore = b.balance(ctx)
+	switch b.cfg.game {
+	case "blackjack":
+		b.playBlackjack(ctx)
+	case "holdem":
+		b.playHoldem(ctx)
+	}
+	// the bot's own context may be done already, the final balance is still wanted
+	bctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	b.res.BalanceAfter = b.balance(bctx)
+	if b.cfg.game == "holdem" {
+		b.res.Net = b.res.BalanceAfter - b.res.BalanceBefore
+	}
+}
+
+// creditMinor is how many wallet minor units make one game credit.
+const creditMinor = 100
+
+// balance reports the wallet balance in minor units and records its currency.
+func (b *bot) balance(ctx context.Context) int64 {
+	wr, err := b.wallet.GetBalance(ctx, &walletpb.WalletRequest{UserId: b.id})
+	if err != nil {
+		b.res.fail(fmt.Errorf("balance: %w", err))
+		return 0
+	}
+	if c := wr.Balance.GetCurrency(); c != "" {
+		b.res.Currency = c
+	}
+	return wr.Balance.GetAmount()
+}
+
+// money renders minor units as a decimal amount, 1234 -> "12.34".
+func money(n int64) string {
+	sign := ""
+	if n < 0 {
+		sign, n = "-", -n
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, n/creditMinor, n%creditMinor)
+}
+
+func (b *bot) pause(ctx context.Context) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(b.cfg.think):
+		return true
+	}
+}
+
+func report(results []*result) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "bot\tgame\tstrategy\trounds\twins\tlosses\tpushes\tnet\tbalance\tcurrency\terrors\ttime\t")
+	var total result
+	for _, r := range results {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%d\t%d\t%d\t%s\t%s\t%s\t%d\t%s\t\n",
+			r.Bot, r.Game, r.Strategy, r.Rounds, r.Wins, r.Losses, r.Pushes, money(r.Net), money(r.BalanceAfter), r.Currency, r.Errors,
+			(time.Duration(r.DurationMs) * time.Millisecond).Round(time.Millisecond))
+		total.Rounds += r.Rounds
+		total.Wins += r.Wins
+		total.Losses += r.Losses
+		total.Pushes += r.Pushes
+		total.Net += r.Net
+		total.Errors += r.Errors
+	}
+	fmt.Fprintf(w, "total\t\t\t%d\t%d\t%d\t%d\t%s\t\t\t%d\t\t\n",
+		total.Rounds, total.Wins, total.Losses, total.Pushes, money(total.Net), total.Errors)
+	w.Flush()
+	for _, r := range results {
+		if r.LastError != "" {
+			fmt.Printf("%s: %d errors, last: %s\n", r.Bot, r.Errors, r.LastError)
+		}
+	}
+}
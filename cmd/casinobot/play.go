@@ -0,0 +1,202 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	gamepb "github.com/Arsencchikkk/final/casino/proto/game"
+	"github.com/google/uuid"
+)
+
+// --- blackjack ---
+
+func (b *bot) playBlackjack(ctx context.Context) {
+	for b.res.Rounds < b.cfg.rounds && ctx.Err() == nil {
+		if err := b.blackjackHand(ctx); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			b.res.fail(err)
+		}
+		if !b.pause(ctx) {
+			return
+		}
+	}
+}
+
+func (b *bot) blackjackHand(ctx context.Context) error {
+	gr, err := b.game.NewGame(ctx, &gamepb.NewGameRequest{UserId: b.id})
+	if err != nil {
+		return fmt.Errorf("new game: %w", err)
+	}
+	player := gr.PlayerCards
+	up := ""
+	if len(gr.DealerCards) > 0 {
+		up = gr.DealerCards[0]
+	}
+	for {
+		if total, _ := handTotal(player); total >= 21 {
+			break
+		}
+		if b.strategy.Blackjack(player, up) != "hit" {
+			break
+		}
+		hr, err := b.game.Hit(ctx, &gamepb.HitRequest{SessionId: gr.SessionId, UserId: b.id})
+		if err != nil {
+			return fmt.Errorf("hit: %w", err)
+		}
+		player = hr.PlayerCards
+		if hr.Finished {
+			break
+		}
+	}
+	sr, err := b.game.Stand(ctx, &gamepb.StandRequest{SessionId: gr.SessionId, UserId: b.id})
+	if err != nil {
+		return fmt.Errorf("stand: %w", err)
+	}
+	b.res.Rounds++
+	switch sr.Outcome {
+	case "win":
+		b.res.Wins++
+		b.res.Net += int64(b.cfg.stake) * creditMinor
+	case "lose":
+		b.res.Losses++
+		b.res.Net -= int64(b.cfg.stake) * creditMinor
+	default:
+		b.res.Pushes++
+	}
+	return nil
+}
+
+// --- hold'em ---
+
+// playHoldem takes a seat, follows the table stream and acts whenever it's
+// the bot's turn. After -rounds hands it leaves and the stack goes back to
+// the wallet.
+func (b *bot) playHoldem(ctx context.Context) {
+	table, err := b.tableInfo(ctx)
+	if err != nil {
+		b.res.fail(err)
+		return
+	}
+	buyIn := int32(b.cfg.buyIn)
+	if buyIn == 0 {
+		buyIn = table.MinBuyIn
+	}
+	jr, err := b.game.HoldemJoin(ctx, &gamepb.HoldemJoinRequest{TableId: b.cfg.table, UserId: b.id, BuyIn: buyIn, JoinId: uuid.New().String()})
+	if err != nil {
+		b.res.fail(fmt.Errorf("join: %w", err))
+		return
+	}
+	defer b.leaveHoldem()
+
+	wctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := b.game.WatchHoldem(wctx, &gamepb.WatchHoldemRequest{TableId: b.cfg.table, UserId: b.id})
+	if err != nil {
+		b.res.fail(fmt.Errorf("watch: %w", err))
+		return
+	}
+
+	var (
+		dealtIn  int64 // last hand the bot was dealt cards in
+		scored   int64
+		actedFor int64 // action deadline of the turn already answered
+	)
+	for {
+		st, err := stream.Recv()
+		if err != nil {
+			if ctx.Err() == nil {
+				b.res.fail(fmt.Errorf("watch: %w", err))
+			}
+			return
+		}
+		me := seatOf(st, jr.Seat)
+		if me == nil || me.UserId != b.id {
+			b.res.fail(fmt.Errorf("lost seat %d", jr.Seat))
+			return
+		}
+		if st.Street != "waiting" && st.Street != "showdown" && !me.SittingOut {
+			dealtIn = st.HandNo
+		}
+		if st.Street == "showdown" && dealtIn == st.HandNo && scored != dealtIn {
+			scored = dealtIn
+			b.res.Rounds++
+			b.scoreHoldem(st)
+			if b.res.Rounds >= b.cfg.rounds {
+				return
+			}
+		}
+		if me.Stack == 0 && (st.Street == "waiting" || st.Street == "showdown") {
+			// busted: nothing left to play with
+			return
+		}
+		if st.ToAct != jr.Seat || st.ActionDeadline == actedFor {
+			continue
+		}
+		actedFor = st.ActionDeadline
+		if !b.pause(ctx) {
+			return
+		}
+		action, amount := b.strategy.Holdem(st, me, table.BigBlind)
+		b.holdemAct(ctx, action, amount)
+	}
+}
+
+// holdemAct sends the strategy's move; an illegal one falls back to check, then fold.
+func (b *bot) holdemAct(ctx context.Context, action string, amount int32) {
+	for _, a := range []string{action, "check", "fold"} {
+		_, err := b.game.HoldemAct(ctx, &gamepb.HoldemActRequest{TableId: b.cfg.table, UserId: b.id, Action: a, Amount: amount})
+		if err == nil {
+			return
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		if a == "fold" {
+			b.res.fail(fmt.Errorf("act: %w", err))
+		}
+		amount = 0
+	}
+}
+
+func (b *bot) scoreHoldem(st *gamepb.HoldemTableState) {
+	for _, w := range st.Winners {
+		if w.UserId == b.id {
+			b.res.Wins++
+			return
+		}
+	}
+	b.res.Losses++
+}
+
+func (b *bot) leaveHoldem() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := b.game.HoldemLeave(ctx, &gamepb.HoldemLeaveRequest{TableId: b.cfg.table, UserId: b.id}); err != nil {
+		b.res.fail(fmt.Errorf("leave: %w", err))
+	}
+}
+
+func (b *bot) tableInfo(ctx context.Context) (*gamepb.HoldemTableInfo, error) {
+	tables, err := b.game.ListHoldemTables(ctx, &gamepb.ListHoldemTablesRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("list tables: %w", err)
+	}
+	for _, t := range tables.Tables {
+		if t.TableId == b.cfg.table {
+			return t, nil
+		}
+	}
+	return nil, fmt.Errorf("table %s not found", b.cfg.table)
+}
+
+func seatOf(st *gamepb.HoldemTableState, seat int32) *gamepb.HoldemSeat {
+	for _, s := range st.Seats {
+		if s.Seat == seat {
+			return s
+		}
+	}
+	return nil
+}
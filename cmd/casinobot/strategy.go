@@ -0,0 +1,184 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+
+	gamepb "github.com/Arsencchikkk/final/casino/proto/game"
+)
+
+// Strategy decides what a bot does. A strategy value belongs to one bot, so
+// implementations may keep state (the scripted one does) without locking.
+type Strategy interface {
+	// Blackjack returns "hit" or "stand".
+	Blackjack(player []string, dealerUp string) string
+	// Holdem returns an action for HoldemAct and, for "raise", the raise-to amount.
+	Holdem(st *gamepb.HoldemTableState, me *gamepb.HoldemSeat, bigBlind int32) (string, int32)
+}
+
+func newStrategy(name, script string, rng *rand.Rand) (Strategy, error) {
+	switch name {
+	case "basic":
+		return basicStrategy{}, nil
+	case "random":
+		return &randomStrategy{rng: rng}, nil
+	case "scripted":
+		var steps []string
+		for _, s := range strings.Split(script, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				steps = append(steps, s)
+			}
+		}
+		if len(steps) == 0 {
+			return nil, fmt.Errorf("scripted strategy needs -script, e.g. hit,stand or call,check,fold")
+		}
+		return &scriptedStrategy{steps: steps}, nil
+	}
+	return nil, fmt.Errorf("unknown strategy %q (basic, random, scripted)", name)
+}
+
+// rank strips the suit the game service appends ("10Hearts" -> "10").
+func rank(card string) string {
+	for _, suit := range []string{"Hearts", "Diamonds", "Clubs", "Spades"} {
+		if strings.HasSuffix(card, suit) {
+			return strings.TrimSuffix(card, suit)
+		}
+	}
+	return card
+}
+
+func rankValue(r string) int {
+	switch r {
+	case "A":
+		return 11
+	case "K", "Q", "J", "10":
+		return 10
+	}
+	var v int
+	fmt.Sscanf(r, "%d", &v)
+	return v
+}
+
+// handTotal mirrors the server's scoring and also reports whether an ace
+// still counts as 11.
+func handTotal(cards []string) (total int, soft bool) {
+	aces := 0
+	for _, c := range cards {
+		r := rank(c)
+		total += rankValue(r)
+		if r == "A" {
+			aces++
+		}
+	}
+	for total > 21 && aces > 0 {
+		total -= 10
+		aces--
+	}
+	return total, aces > 0
+}
+
+// --- basic ---
+
+// basicStrategy is textbook hit/stand play (the table has no doubles or
+// splits) and a tight-passive hold'em bot that only plays decent hands.
+type basicStrategy struct{}
+
+func (basicStrategy) Blackjack(player []string, dealerUp string) string {
+	total, soft := handTotal(player)
+	up := rankValue(rank(dealerUp))
+	if soft {
+		// soft 18 hits against 9, 10 and ace
+		if total >= 19 || (total == 18 && up <= 8) {
+			return "stand"
+		}
+		return "hit"
+	}
+	switch {
+	case total >= 17:
+		return "stand"
+	case total >= 13:
+		if up <= 6 {
+			return "stand"
+		}
+		return "hit"
+	case total == 12:
+		if up >= 4 && up <= 6 {
+			return "stand"
+		}
+		return "hit"
+	}
+	return "hit"
+}
+
+func (basicStrategy) Holdem(st *gamepb.HoldemTableState, me *gamepb.HoldemSeat, bigBlind int32) (string, int32) {
+	toCall := st.CurrentBet - me.Bet
+	strong, playable := false, false
+	if len(me.Cards) == 2 {
+		a, b := rankValue(rank(me.Cards[0])), rankValue(rank(me.Cards[1]))
+		pair := rank(me.Cards[0]) == rank(me.Cards[1])
+		strong = (pair && a >= 10) || a+b >= 21
+		playable = pair || a+b >= 18
+	}
+	switch {
+	case strong && st.Street == "preflop":
+		return "raise", st.CurrentBet + st.MinRaise
+	case toCall == 0:
+		return "check", 0
+	case playable && toCall <= 4*bigBlind:
+		return "call", 0
+	}
+	return "fold", 0
+}
+
+// --- random ---
+
+type randomStrategy struct{ rng *rand.Rand }
+
+func (s *randomStrategy) Blackjack(player []string, dealerUp string) string {
+	if total, _ := handTotal(player); total < 21 && s.rng.Intn(2) == 0 {
+		return "hit"
+	}
+	return "stand"
+}
+
+func (s *randomStrategy) Holdem(st *gamepb.HoldemTableState, me *gamepb.HoldemSeat, bigBlind int32) (string, int32) {
+	toCall := st.CurrentBet - me.Bet
+	switch n := s.rng.Intn(10); {
+	case n == 0:
+		return "raise", st.CurrentBet + st.MinRaise
+	case n < 3 && toCall > 0:
+		return "fold", 0
+	case toCall == 0:
+		return "check", 0
+	}
+	return "call", 0
+}
+
+// --- scripted ---
+
+// scriptedStrategy replays a fixed list of actions, wrapping around at the
+// end. Blackjack scripts use hit/stand, hold'em ones fold/check/call/allin
+// and raise (a min-raise).
+type scriptedStrategy struct {
+	steps []string
+	next  int
+}
+
+func (s *scriptedStrategy) step() string {
+	a := s.steps[s.next%len(s.steps)]
+	s.next++
+	return a
+}
+
+func (s *scriptedStrategy) Blackjack(player []string, dealerUp string) string {
+	return s.step()
+}
+
+func (s *scriptedStrategy) Holdem(st *gamepb.HoldemTableState, me *gamepb.HoldemSeat, bigBlind int32) (string, int32) {
+	a := s.step()
+	if a == "raise" {
+		return a, st.CurrentBet + st.MinRaise
+	}
+	return a, 0
+}
@@ -0,0 +1,208 @@
+// walletmigrate moves wallet data from int32 game credits to int64 minor
+// currency units: every stored amount is multiplied by 100 (one credit is one
+// unit of the wallet currency) and tagged with the currency. The wallet
+// service refuses to start until it has run.
+//
+//	walletmigrate                  # MONGO_URI, MONGO_DB, REDIS_URL, WALLET_CURRENCY from the env
+//	walletmigrate -dry-run         # only count what would change
+//
+// It is safe to run again, also after a crash halfway: a document counts as
+// migrated once it has a currency, a leaderboard once its key is in
+// lb:migrated, and once every board is done lb:migrated:done skips them all.
+// Boards go first: the wallet service starts only after the wallets are
+// migrated, so no board in minor units exists before that marker does. The
+// audit log is left alone; its old entries stay in credits and keep their
+// hashes.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/go-redis/redis/v8"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// creditMinor is how many minor units one game credit becomes.
+const creditMinor = 100
+
+// lbMigrated is the Redis set of leaderboard keys already scaled.
+const lbMigrated = "lb:migrated"
+
+// lbMigratedDone is set once every board is scaled. Boards the wallet service
+// creates afterwards are in minor units already and must not be touched.
+const lbMigratedDone = "lb:migrated:done"
+
+func envOr(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
+
+func main() {
+	mongoURI := flag.String("mongo-uri", os.Getenv("MONGO_URI"), "MongoDB URI")
+	mongoDB := flag.String("db", envOr("MONGO_DB", "casino"), "database")
+	redisURL := flag.String("redis-url", os.Getenv("REDIS_URL"), "Redis URL")
+	currency := flag.String("currency", envOr("WALLET_CURRENCY", "USD"), "wallet currency, must have 2 decimal places")
+	dryRun := flag.Bool("dry-run", false, "count what would change without writing")
+	flag.Parse()
+
+	if *mongoURI == "" || *redisURL == "" {
+		log.Fatal("set -mongo-uri and -redis-url (or MONGO_URI and REDIS_URL)")
+	}
+	ctx := context.Background()
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(*mongoURI))
+	if err != nil {
+		log.Fatalf("mongo connect: %v", err)
+	}
+	defer client.Disconnect(ctx)
+	db := client.Database(*mongoDB)
+
+	opt, err := redis.ParseURL(*redisURL)
+	if err != nil {
+		log.Fatalf("redis url: %v", err)
+	}
+	rdb := redis.NewClient(opt)
+	if err := rdb.Ping(ctx).Err(); err != nil {
+		log.Fatalf("redis ping: %v", err)
+	}
+
+	// before the wallets, see the package comment. Wallets that are all
+	// migrated mean an earlier run got past the boards (runs from before the
+	// done marker scaled boards right after the wallets), and the wallet
+	// service may have created new boards since.
+	wallets := db.Collection(envOr("MONGO_COLLECTION", "wallets"))
+	err = wallets.FindOne(ctx, bson.M{"currency": bson.M{"$exists": false}}).Err()
+	switch {
+	case err == mongo.ErrNoDocuments:
+		if !*dryRun {
+			if err := rdb.Set(ctx, lbMigratedDone, 1, 0).Err(); err != nil {
+				log.Fatalf("leaderboards: %v", err)
+			}
+		}
+		fmt.Println("leaderboards: already migrated")
+	case err != nil:
+		log.Fatalf("wallets: %v", err)
+	default:
+		n, err := migrateBoards(ctx, rdb, *dryRun)
+		if err != nil {
+			log.Fatalf("leaderboards: %v", err)
+		}
+		fmt.Printf("leaderboards: %d keys\n", n)
+	}
+
+	// collection -> amount fields; names follow the wallet service env
+	cols := []struct {
+		name   string
+		fields []string
+	}{
+		{envOr("MONGO_COLLECTION", "wallets"), []string{"balance", "held"}},
+		{envOr("MONGO_DEMO_COL", "demo_wallets"), []string{"balance", "held"}},
+		{envOr("MONGO_LEDGER_COL", "ledger"), []string{"amount", "balance_after"}},
+		{envOr("MONGO_HOLDS_COL", "holds"), []string{"amount", "captured"}},
+	}
+	for _, c := range cols {
+		n, err := migrateCollection(ctx, db.Collection(c.name), c.fields, *currency, *dryRun)
+		if err != nil {
+			log.Fatalf("%s: %v", c.name, err)
+		}
+		fmt.Printf("%s: %d documents\n", c.name, n)
+	}
+
+	// cached balances are in credits; the wallet service refills the cache
+	if !*dryRun {
+		for _, pattern := range []string{"balance:*", "held:*"} {
+			if err := deleteKeys(ctx, rdb, pattern); err != nil {
+				log.Fatalf("cache %s: %v", pattern, err)
+			}
+		}
+	}
+	if *dryRun {
+		fmt.Println("dry run, nothing written")
+		return
+	}
+	fmt.Println("OK: wallet data is in minor units of", *currency)
+}
+
+// migrateCollection scales fields of every document without a currency and
+// sets the currency in the same update, so each document changes exactly once.
+// A missing field comes out as 0, which reads the same as before.
+func migrateCollection(ctx context.Context, col *mongo.Collection, fields []string, currency string, dryRun bool) (int64, error) {
+	filter := bson.M{"currency": bson.M{"$exists": false}}
+	if dryRun {
+		return col.CountDocuments(ctx, filter)
+	}
+	mul := bson.M{}
+	for _, f := range fields {
+		mul[f] = int64(creditMinor)
+	}
+	res, err := col.UpdateMany(ctx, filter, bson.M{"$mul": mul, "$set": bson.M{"currency": currency}})
+	if err != nil {
+		return 0, err
+	}
+	return res.ModifiedCount, nil
+}
+
+// migrateBoards scales the money leaderboards (profit and biggest_win; streak
+// counts wins). Each key is rewritten by ZUNIONSTORE with weight 100 and keeps
+// its expiry; marking it in lbMigrated happens in the same MULTI.
+func migrateBoards(ctx context.Context, rdb *redis.Client, dryRun bool) (int, error) {
+	if done, err := rdb.Exists(ctx, lbMigratedDone).Result(); err != nil || done > 0 {
+		return 0, err
+	}
+	n := 0
+	for _, pattern := range []string{"lb:profit:*", "lb:biggest_win:*"} {
+		iter := rdb.Scan(ctx, 0, pattern, 100).Iterator()
+		for iter.Next(ctx) {
+			key := iter.Val()
+			done, err := rdb.SIsMember(ctx, lbMigrated, key).Result()
+			if err != nil {
+				return n, err
+			}
+			if done {
+				continue
+			}
+			n++
+			if dryRun {
+				continue
+			}
+			ttl, err := rdb.PTTL(ctx, key).Result()
+			if err != nil {
+				return n, err
+			}
+			if _, err := rdb.TxPipelined(ctx, func(p redis.Pipeliner) error {
+				p.ZUnionStore(ctx, key, &redis.ZStore{Keys: []string{key}, Weights: []float64{creditMinor}})
+				if ttl > 0 {
+					p.PExpire(ctx, key, ttl)
+				}
+				p.SAdd(ctx, lbMigrated, key)
+				return nil
+			}); err != nil {
+				return n, err
+			}
+		}
+		if err := iter.Err(); err != nil {
+			return n, err
+		}
+	}
+	if dryRun {
+		return n, nil
+	}
+	return n, rdb.Set(ctx, lbMigratedDone, 1, 0).Err()
+}
+
+func deleteKeys(ctx context.Context, rdb *redis.Client, pattern string) error {
+	iter := rdb.Scan(ctx, 0, pattern, 500).Iterator()
+	for iter.Next(ctx) {
+		if err := rdb.Del(ctx, iter.Val()).Err(); err != nil {
+			return err
+		}
+	}
+	return iter.Err()
+}
@@ -0,0 +1,574 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	catalogpb "github.com/Arsencchikkk/final/casino/proto/catalog"
+	pb "github.com/Arsencchikkk/final/casino/proto/game"
+	walletpb "github.com/Arsencchikkk/final/casino/proto/wallet"
+	"github.com/google/uuid"
+)
+
+// Game is the contract a game implements to show up in the catalog and be
+// played through the generic start/act/settle RPCs. Settle is idempotent:
+// calling it again returns the original result without paying twice.
+type Game interface {
+	Info() *catalogpb.GameInfo
+	Start(ctx context.Context, req *catalogpb.StartRequest) (*catalogpb.RoundState, error)
+	Act(ctx context.Context, req *catalogpb.ActRequest) (*catalogpb.RoundState, error)
+	Settle(ctx context.Context, req *catalogpb.SettleRequest) (*catalogpb.RoundState, error)
+}
+
+type providerServer struct {
+	catalogpb.UnimplementedGameProviderServer
+	games map[string]Game
+	order []string
+}
+
+func newProviderServer(games ...Game) *providerServer {
+	p := &providerServer{games: make(map[string]Game)}
+	for _, g := range games {
+		id := g.Info().GameId
+		p.games[id] = g
+		p.order = append(p.order, id)
+	}
+	return p
+}
+
+func (p *providerServer) game(id string) (Game, error) {
+	g, ok := p.games[id]
+	if !ok {
+		return nil, fmt.Errorf("unknown game %q", id)
+	}
+	return g, nil
+}
+
+func (p *providerServer) Catalog(ctx context.Context, _ *catalogpb.CatalogRequest) (*catalogpb.CatalogResponse, error) {
+	resp := &catalogpb.CatalogResponse{}
+	for _, id := range p.order {
+		resp.Games = append(resp.Games, p.games[id].Info())
+	}
+	return resp, nil
+}
+
+func (p *providerServer) Start(ctx context.Context, req *catalogpb.StartRequest) (*catalogpb.RoundState, error) {
+	g, err := p.game(req.GameId)
+	if err != nil {
+		return nil, err
+	}
+	if req.UserId == "" {
+		return nil, fmt.Errorf("user_id required")
+	}
+	if l := g.Info().Limits; l != nil && (req.Stake < l.MinStake || req.Stake > l.MaxStake) {
+		return nil, fmt.Errorf("stake must be between %d and %d", l.MinStake, l.MaxStake)
+	}
+	rs, err := g.Start(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	rs.GameId = req.GameId
+	return rs, nil
+}
+
+func (p *providerServer) Act(ctx context.Context, req *catalogpb.ActRequest) (*catalogpb.RoundState, error) {
+	g, err := p.game(req.GameId)
+	if err != nil {
+		return nil, err
+	}
+	rs, err := g.Act(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	rs.GameId = req.GameId
+	return rs, nil
+}
+
+func (p *providerServer) Settle(ctx context.Context, req *catalogpb.SettleRequest) (*catalogpb.RoundState, error) {
+	g, err := p.game(req.GameId)
+	if err != nil {
+		return nil, err
+	}
+	rs, err := g.Settle(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	rs.GameId = req.GameId
+	return rs, nil
+}
+
+func intParam(params map[string]string, name string, def int32) (int32, error) {
+	v, ok := params[name]
+	if !ok || v == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("param %s: expected an integer", name)
+	}
+	return int32(n), nil
+}
+
+func floatParam(params map[string]string, name string, def float64) (float64, error) {
+	v, ok := params[name]
+	if !ok || v == "" {
+		return def, nil
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return 0, fmt.Errorf("param %s: expected a number", name)
+	}
+	return f, nil
+}
+
+func stateJSON(v interface{}) string {
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
+// --- blackjack ---
+
+type blackjackGame struct{ s *gameServer }
+
+// blackjackRtp is the game's own return: no doubles, splits or 3:2 naturals,
+// so roughly 96%. The jackpot comes on top.
+const blackjackRtp = 0.96
+
+func (b blackjackGame) Info() *catalogpb.GameInfo {
+	return &catalogpb.GameInfo{
+		GameId:      "blackjack",
+		Name:        "Blackjack",
+		Description: "Beat the dealer without going over 21. Dealer stands on 17, a win pays 1:1.",
+		Kind:        "session",
+		Limits:      &catalogpb.BetLimits{MinStake: 1, MaxStake: 10000, MaxPayout: 20000},
+		Actions:     []string{"hit", "stand"},
+		Rtp:         blackjackRtp + b.s.jackpotRtp,
+	}
+}
+
+func (b blackjackGame) round(id string, sess *GameSession) *catalogpb.RoundState {
+	st := struct {
+		PlayerCards []string `json:"player_cards"`
+		PlayerTotal int      `json:"player_total"`
+		DealerCards []string `json:"dealer_cards"`
+		DealerTotal int      `json:"dealer_total,omitempty"`
+		Outcome     string   `json:"outcome,omitempty"`
+	}{
+		PlayerCards: cardsToStrings(sess.PlayerHand),
+		PlayerTotal: handValue(sess.PlayerHand),
+	}
+	rs := &catalogpb.RoundState{RoundId: id, Status: sess.State, Stake: sess.Stake, Currency: sess.Currency}
+	if sess.State == "finished" {
+		st.DealerCards = cardsToStrings(sess.DealerHand)
+		st.DealerTotal = handValue(sess.DealerHand)
+		st.Outcome = handOutcome(sess)
+		rs.Finished = true
+		rs.Payout = blackjackPayout(sess.Stake, st.Outcome)
+	} else {
+		// the hole card stays hidden until the player is done
+		st.DealerCards = cardsToStrings(sess.DealerHand[:1])
+	}
+	rs.StateJson = stateJSON(st)
+	return rs
+}
+
+func blackjackPayout(stake int32, outcome string) int32 {
+	switch outcome {
+	case "win":
+		return 2 * stake
+	case "push":
+		return stake
+	}
+	return 0
+}
+
+func blackjackTags(hand []Card) []string {
+	var tags []string
+	switch total := handValue(hand); {
+	case total == 21 && len(hand) == 2:
+		tags = append(tags, "natural")
+	case total == 21 && len(hand) >= 5:
+		tags = append(tags, "five_card_21")
+	}
+	return tags
+}
+
+// session returns the caller's own hand; sessMu must be held.
+func (b blackjackGame) session(userId, id string) (*GameSession, error) {
+	sess, ok := sessions[id]
+	if !ok || sess.UserId == "" || sess.UserId != userId {
+		return nil, fmt.Errorf("session not found")
+	}
+	return sess, nil
+}
+
+func (b blackjackGame) Start(ctx context.Context, req *catalogpb.StartRequest) (*catalogpb.RoundState, error) {
+	cur := roundCurrency(req.Currency)
+	id, hr, err := b.s.dealHand(ctx, req.UserId, req.Stake, cur)
+	if err != nil {
+		return nil, err
+	}
+	sessMu.Lock()
+	rs := b.round(id, sessions[id])
+	sessMu.Unlock()
+	rs.Balance = toCredits(hr.Balance)
+	contributeJackpot(b.s.jackpot, "blackjack", req.UserId, id, cur, req.Stake)
+	return rs, nil
+}
+
+func (b blackjackGame) Act(ctx context.Context, req *catalogpb.ActRequest) (*catalogpb.RoundState, error) {
+	sessMu.Lock()
+	sess, err := b.session(req.UserId, req.RoundId)
+	if err != nil {
+		sessMu.Unlock()
+		return nil, err
+	}
+	if sess.State != "playerTurn" {
+		sessMu.Unlock()
+		return nil, fmt.Errorf("hand is over")
+	}
+	switch req.Action {
+	case "hit":
+		playerHit(sess)
+	case "stand":
+		sess.State = "dealerTurn"
+		dealerPlay(sess)
+	default:
+		sessMu.Unlock()
+		return nil, fmt.Errorf("unknown action %q", req.Action)
+	}
+	finished := sess.State == "finished"
+	rs := b.round(req.RoundId, sess)
+	sessMu.Unlock()
+
+	if finished {
+		return b.settle(ctx, req.UserId, req.RoundId)
+	}
+	return rs, nil
+}
+
+// Settle stands for the player if the hand is still open, then pays it out once.
+func (b blackjackGame) Settle(ctx context.Context, req *catalogpb.SettleRequest) (*catalogpb.RoundState, error) {
+	sessMu.Lock()
+	sess, err := b.session(req.UserId, req.RoundId)
+	if err != nil {
+		sessMu.Unlock()
+		return nil, err
+	}
+	if sess.State == "playerTurn" {
+		sess.State = "dealerTurn"
+		dealerPlay(sess)
+	}
+	sessMu.Unlock()
+	return b.settle(ctx, req.UserId, req.RoundId)
+}
+
+func (b blackjackGame) settle(ctx context.Context, userId, id string) (*catalogpb.RoundState, error) {
+	sessMu.Lock()
+	sess, err := b.session(userId, id)
+	if err != nil {
+		sessMu.Unlock()
+		return nil, err
+	}
+	rs := b.round(id, sess)
+	if sess.Settled {
+		sessMu.Unlock()
+		return rs, nil
+	}
+	sess.Settled = true
+	sessMu.Unlock()
+
+	// the stake is taken only now; Capture can be repeated, so a retried
+	// Settle goes through it again safely
+	hr, err := b.s.wallet.Capture(ctx, &walletpb.CaptureRequest{HoldId: sess.HoldId})
+	if err != nil {
+		sessMu.Lock()
+		sess.Settled = false
+		sessMu.Unlock()
+		return nil, err
+	}
+	rs.Balance = toCredits(hr.Balance)
+	if rs.Payout > 0 {
+		wr, err := b.s.wallet.UpdateBalance(ctx, &walletpb.WalletUpdateRequest{UserId: userId, Amount: creditsIn(rs.Payout, rs.Currency), Type: "win", Ref: id, IdempotencyKey: "blackjack:" + id + ":win"})
+		if err != nil {
+			// let a later Settle retry the payment
+			sessMu.Lock()
+			sess.Settled = false
+			sessMu.Unlock()
+			return nil, err
+		}
+		rs.Balance = toCredits(wr.NewBalance)
+	}
+	tags := blackjackTags(sess.PlayerHand)
+	jackpot := blackjackJackpot(sess.PlayerHand)
+	if jackpot {
+		tags = append(tags, "777")
+	}
+	if err := b.s.results.record(ctx, &walletpb.GameResult{
+		UserId:  userId,
+		Game:    "blackjack",
+		RoundId: id,
+		Stake:   creditsIn(rs.Stake, rs.Currency),
+		Payout:  creditsIn(rs.Payout, rs.Currency),
+		Tags:    tags,
+		// the RTP monitor sees the hand's payout only, the jackpot is paid apart
+		Rtp: blackjackRtp,
+		// a win pays 1:1, there are no doubles or splits
+		Liability: creditsIn(rs.Stake*2, rs.Currency),
+	}); err != nil {
+		// the win is paid under its key, a later Settle only records the round
+		sessMu.Lock()
+		sess.Settled = false
+		sessMu.Unlock()
+		return nil, err
+	}
+	if jackpot {
+		if rs.Jackpot = b.s.claimJackpot(ctx, "blackjack", userId, id, rs.Currency, "777", rs.Stake); rs.Jackpot > 0 && rs.Balance > 0 {
+			rs.Balance += rs.Jackpot
+		}
+	}
+	return rs, nil
+}
+
+// --- mines ---
+
+type minesGame struct{ s *gameServer }
+
+func (minesGame) Info() *catalogpb.GameInfo {
+	return &catalogpb.GameInfo{
+		GameId:      "mines",
+		Name:        "Mines",
+		Description: "Reveal safe tiles, every one raises the multiplier. Cash out before you hit a mine.",
+		Kind:        "session",
+		Limits:      &catalogpb.BetLimits{MinStake: minesMinStake, MaxStake: minesMaxStake, MaxPayout: minesMaxPayout},
+		StartParams: []*catalogpb.ParamSpec{
+			{Name: "grid_size", Type: "int", Min: minesMinGrid, Max: minesMaxGrid, DefaultValue: "5", Description: "tiles per side"},
+			{Name: "mines", Type: "int", Min: 1, Max: minesMaxGrid*minesMaxGrid - 1, DefaultValue: "3", Description: "number of mines"},
+		},
+		Actions: []string{"reveal"},
+		ActionParams: []*catalogpb.ParamSpec{
+			{Name: "tile", Type: "int", Required: true, Min: 0, Max: minesMaxGrid*minesMaxGrid - 1, Description: "tile index, row by row"},
+		},
+		Rtp: 1 - minesHouseEdge,
+	}
+}
+
+func minesRound(st *pb.MinesState) *catalogpb.RoundState {
+	return &catalogpb.RoundState{
+		RoundId:   st.SessionId,
+		Status:    st.Status,
+		Finished:  st.Status != "active",
+		Stake:     st.Stake,
+		Payout:    st.Payout,
+		Balance:   st.Balance,
+		Currency:  st.Currency,
+		StateJson: stateJSON(st),
+	}
+}
+
+func (m minesGame) Start(ctx context.Context, req *catalogpb.StartRequest) (*catalogpb.RoundState, error) {
+	size, err := intParam(req.Params, "grid_size", 5)
+	if err != nil {
+		return nil, err
+	}
+	mines, err := intParam(req.Params, "mines", 3)
+	if err != nil {
+		return nil, err
+	}
+	st, err := m.s.MinesStart(ctx, &pb.MinesStartRequest{UserId: req.UserId, GridSize: size, Mines: mines, Stake: req.Stake, Currency: req.Currency})
+	if err != nil {
+		return nil, err
+	}
+	return minesRound(st), nil
+}
+
+func (m minesGame) Act(ctx context.Context, req *catalogpb.ActRequest) (*catalogpb.RoundState, error) {
+	if req.Action != "reveal" {
+		return nil, fmt.Errorf("unknown action %q", req.Action)
+	}
+	if _, ok := req.Params["tile"]; !ok {
+		return nil, fmt.Errorf("param tile required")
+	}
+	tile, err := intParam(req.Params, "tile", 0)
+	if err != nil {
+		return nil, err
+	}
+	st, err := m.s.MinesReveal(ctx, &pb.MinesRevealRequest{UserId: req.UserId, SessionId: req.RoundId, Tile: tile})
+	if err != nil {
+		return nil, err
+	}
+	return minesRound(st), nil
+}
+
+func (m minesGame) Settle(ctx context.Context, req *catalogpb.SettleRequest) (*catalogpb.RoundState, error) {
+	st, err := m.s.MinesCashout(ctx, &pb.MinesCashoutRequest{UserId: req.UserId, SessionId: req.RoundId})
+	if err != nil {
+		return nil, err
+	}
+	return minesRound(st), nil
+}
+
+// --- crash ---
+
+type crashGame struct{ e *crashEngine }
+
+func (crashGame) Info() *catalogpb.GameInfo {
+	return &catalogpb.GameInfo{
+		GameId:      "crash",
+		Name:        "Crash",
+		Description: "Bet before the round starts and cash out before the multiplier crashes.",
+		Kind:        "round",
+		Limits:      &catalogpb.BetLimits{MinStake: crashMinBet, MaxStake: crashMaxBet, MaxPayout: crashPayout(crashMaxBet, crashMaxPoint)},
+		StartParams: []*catalogpb.ParamSpec{
+			{Name: "auto_cashout", Type: "number", Min: 1.01, Max: crashMaxPoint / 100, Description: "cash out automatically at this multiplier"},
+		},
+		Actions: []string{"cashout"},
+		Rtp:     1 - crashHouseEdge,
+	}
+}
+
+func (c crashGame) Start(ctx context.Context, req *catalogpb.StartRequest) (*catalogpb.RoundState, error) {
+	auto, err := floatParam(req.Params, "auto_cashout", 0)
+	if err != nil {
+		return nil, err
+	}
+	br, err := c.e.PlaceBet(ctx, req.UserId, req.Stake, auto, req.Currency)
+	if err != nil {
+		return nil, err
+	}
+	return &catalogpb.RoundState{
+		RoundId:   br.RoundId,
+		Status:    "bet",
+		Stake:     br.Amount,
+		Balance:   br.Balance,
+		Currency:  br.Currency,
+		StateJson: stateJSON(br),
+	}, nil
+}
+
+func (c crashGame) Act(ctx context.Context, req *catalogpb.ActRequest) (*catalogpb.RoundState, error) {
+	if req.Action != "cashout" {
+		return nil, fmt.Errorf("unknown action %q", req.Action)
+	}
+	return c.cashout(ctx, req.UserId, req.RoundId)
+}
+
+// Settle cashes out right away; bets that ride to the crash are settled by the round loop.
+func (c crashGame) Settle(ctx context.Context, req *catalogpb.SettleRequest) (*catalogpb.RoundState, error) {
+	return c.cashout(ctx, req.UserId, req.RoundId)
+}
+
+func (c crashGame) cashout(ctx context.Context, userId, roundId string) (*catalogpb.RoundState, error) {
+	cr, err := c.e.Cashout(ctx, userId, roundId)
+	if err != nil {
+		return nil, err
+	}
+	return &catalogpb.RoundState{
+		RoundId:   cr.RoundId,
+		Status:    "cashed_out",
+		Finished:  true,
+		Payout:    cr.Payout,
+		StateJson: stateJSON(cr),
+	}, nil
+}
+
+// --- hold'em ---
+
+type holdemGame struct{ s *gameServer }
+
+func (holdemGame) Info() *catalogpb.GameInfo {
+	limits := &catalogpb.BetLimits{}
+	for i, ht := range holdemTables {
+		if i == 0 || ht.Config.MinBuyIn < limits.MinStake {
+			limits.MinStake = ht.Config.MinBuyIn
+		}
+		if ht.Config.MaxBuyIn > limits.MaxStake {
+			limits.MaxStake = ht.Config.MaxBuyIn
+		}
+	}
+	return &catalogpb.GameInfo{
+		GameId:      "holdem",
+		Name:        "Texas Hold'em",
+		Description: "No-limit cash tables against other players. The stake is your buy-in; settle leaves the table.",
+		Kind:        "table",
+		Limits:      limits,
+		StartParams: []*catalogpb.ParamSpec{
+			{Name: "table_id", Type: "string", Required: true, Description: "see /api/holdem/tables"},
+			{Name: "seat", Type: "int", Min: 1, Max: 9, Description: "preferred seat, any free seat if empty"},
+			{Name: "join_id", Type: "string", Description: "retrying a start with the same id buys in only once; a new one if empty"},
+		},
+		Actions: []string{"fold", "check", "call", "raise", "allin"},
+		ActionParams: []*catalogpb.ParamSpec{
+			{Name: "amount", Type: "int", Description: "raise to this amount"},
+		},
+	}
+}
+
+func (h holdemGame) Start(ctx context.Context, req *catalogpb.StartRequest) (*catalogpb.RoundState, error) {
+	// players at a table win each other's chips, so everyone buys in with the same money
+	if roundCurrency(req.Currency) != "" {
+		return nil, fmt.Errorf("hold'em tables play in %s", mainCurrency)
+	}
+	seat, err := intParam(req.Params, "seat", 0)
+	if err != nil {
+		return nil, err
+	}
+	joinId := req.Params["join_id"]
+	if joinId == "" {
+		joinId = uuid.New().String()
+	}
+	jr, err := h.s.HoldemJoin(ctx, &pb.HoldemJoinRequest{
+		TableId: req.Params["table_id"],
+		UserId:  req.UserId,
+		BuyIn:   req.Stake,
+		Seat:    seat,
+		JoinId:  joinId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &catalogpb.RoundState{
+		RoundId:   jr.TableId,
+		Status:    "seated",
+		Stake:     jr.Stack,
+		Balance:   jr.Balance,
+		StateJson: stateJSON(jr),
+	}, nil
+}
+
+func (h holdemGame) Act(ctx context.Context, req *catalogpb.ActRequest) (*catalogpb.RoundState, error) {
+	amount, err := intParam(req.Params, "amount", 0)
+	if err != nil {
+		return nil, err
+	}
+	st, err := h.s.HoldemAct(ctx, &pb.HoldemActRequest{
+		TableId: req.RoundId,
+		UserId:  req.UserId,
+		Action:  req.Action,
+		Amount:  amount,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &catalogpb.RoundState{RoundId: req.RoundId, Status: st.Street, StateJson: stateJSON(st)}, nil
+}
+
+func (h holdemGame) Settle(ctx context.Context, req *catalogpb.SettleRequest) (*catalogpb.RoundState, error) {
+	lr, err := h.s.HoldemLeave(ctx, &pb.HoldemLeaveRequest{TableId: req.RoundId, UserId: req.UserId})
+	if err != nil {
+		return nil, err
+	}
+	status := "left"
+	if lr.Pending {
+		status = "leaving"
+	}
+	return &catalogpb.RoundState{
+		RoundId:   req.RoundId,
+		Status:    status,
+		Finished:  !lr.Pending,
+		Payout:    lr.CashedOut,
+		StateJson: stateJSON(lr),
+	}, nil
+}
@@ -0,0 +1,685 @@
+package main
+
+import (
+	"context"
+	"crypto/hmac"
+	crand "crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"math"
+	"sync"
+	"time"
+
+	pb "github.com/Arsencchikkk/final/casino/proto/game"
+	jackpotpb "github.com/Arsencchikkk/final/casino/proto/jackpot"
+	walletpb "github.com/Arsencchikkk/final/casino/proto/wallet"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	crashBettingTime = 10 * time.Second
+	crashCooldown    = 3 * time.Second
+	crashTick        = 100 * time.Millisecond
+
+	// m(t) = e^(rate * ms), ~2x after 11.5s, ~10x after 38s
+	crashGrowthRate = 0.00006
+	crashHouseEdge  = 0.01
+
+	// multipliers are kept in hundredths: 100 = 1.00x
+	crashMinPoint = 100
+	crashMaxPoint = 1000000
+
+	crashMinBet = 1
+	crashMaxBet = 10000
+)
+
+type crashBet struct {
+	UserId      string
+	Amount      int32
+	Currency    string // "" for the main currency
+	AutoCashout int64  // hundredths, 0 = manual only
+	CashedOut   int64  // hundredths, 0 = still riding
+	Confirmed   bool   // stake debited by the wallet
+}
+
+type crashRound struct {
+	Id         string
+	Seed       []byte
+	SeedHash   string
+	CrashPoint int64
+	Phase      string // "betting", "running", "crashed"
+	StartsAt   time.Time
+	Bets       map[string]*crashBet
+}
+
+// CrashPayoutDoc is a win the wallet still owes. Rounds live in memory, so a
+// cash-out is written here before it's paid; whatever is still pending after a
+// wallet error or a restart is paid again by recoverPayouts under the same key.
+type CrashPayoutDoc struct {
+	Id        string    `bson:"_id"` // the wallet idempotency key
+	UserId    string    `bson:"user_id"`
+	RoundId   string    `bson:"round_id"`
+	Amount    int32     `bson:"amount"`
+	Currency  string    `bson:"currency,omitempty"`
+	Status    string    `bson:"status"` // "pending", "paid"
+	CreatedAt time.Time `bson:"created_at"`
+	PaidAt    time.Time `bson:"paid_at,omitempty"`
+}
+
+func crashWinKey(roundId, userId string) string {
+	return "crash:" + roundId + ":" + userId + ":win"
+}
+
+// CrashBetDoc is a bet as the wallet sees it. It is written before the stake
+// is debited ("placing"), moves to "riding" once the debit is back and to
+// "settled" once its round has crashed and any win is saved. A bet still open
+// after a restart belongs to a round that never got its crash result, so
+// recoverBets gives the stake back under crash:<round>:<user>:refund.
+type CrashBetDoc struct {
+	Id        string    `bson:"_id"` // round_id:user_id
+	RoundId   string    `bson:"round_id"`
+	UserId    string    `bson:"user_id"`
+	Amount    int32     `bson:"amount"`
+	Currency  string    `bson:"currency,omitempty"`
+	Status    string    `bson:"status"` // "placing", "riding", "settled", "refunded"
+	CreatedAt time.Time `bson:"created_at"`
+}
+
+func crashBetKey(roundId, userId string) string {
+	return "crash:" + roundId + ":" + userId + ":bet"
+}
+
+func crashRefundKey(roundId, userId string) string {
+	return "crash:" + roundId + ":" + userId + ":refund"
+}
+
+// crashEngine runs one shared round at a time and fans its state out to watchers.
+type crashEngine struct {
+	mu     sync.Mutex
+	round  *crashRound
+	subs   map[chan *pb.CrashState]struct{}
+	wallet walletpb.WalletServiceClient
+
+	jackpot jackpotpb.JackpotServiceClient
+
+	// unpaid wins, see CrashPayoutDoc
+	payouts *mongo.Collection
+	// open bets, see CrashBetDoc
+	bets    *mongo.Collection
+	results *resultOutbox
+}
+
+func newCrashEngine(wallet walletpb.WalletServiceClient, payouts, bets *mongo.Collection) *crashEngine {
+	return &crashEngine{
+		subs:    make(map[chan *pb.CrashState]struct{}),
+		wallet:  wallet,
+		payouts: payouts,
+		bets:    bets,
+	}
+}
+
+// crashPointFromSeed derives the crash point from the committed seed, so it can be
+// re-checked by anyone once the seed is revealed.
+func crashPointFromSeed(seed []byte, roundId string) int64 {
+	mac := hmac.New(sha256.New, seed)
+	mac.Write([]byte(roundId))
+	sum := mac.Sum(nil)
+
+	// 52 uniformly random bits -> r in [0, 1)
+	const e = float64(1 << 52)
+	r := float64(binary.BigEndian.Uint64(sum[:8])>>12) / e
+	point := int64(math.Floor((1 - crashHouseEdge) / (1 - r) * 100))
+	if point < crashMinPoint {
+		return crashMinPoint
+	}
+	if point > crashMaxPoint {
+		return crashMaxPoint
+	}
+	return point
+}
+
+func crashMultiplierAt(elapsed time.Duration) int64 {
+	if elapsed < 0 {
+		return crashMinPoint
+	}
+	ms := float64(elapsed / time.Millisecond)
+	return int64(math.Floor(100 * math.Exp(crashGrowthRate*ms)))
+}
+
+func crashPayout(amount int32, mult int64) int32 {
+	return int32(int64(amount) * mult / 100)
+}
+
+func toHundredths(m float64) int64 {
+	return int64(math.Round(m * 100))
+}
+
+// run drives rounds until ctx is cancelled: betting -> running -> crashed -> settle.
+func (e *crashEngine) run(ctx context.Context) {
+	for {
+		e.openRound()
+		if !sleepCtx(ctx, crashBettingTime) {
+			return
+		}
+		e.startRound()
+
+		ticker := time.NewTicker(crashTick)
+		for crashed := false; !crashed; {
+			select {
+			case <-ctx.Done():
+				ticker.Stop()
+				return
+			case <-ticker.C:
+				crashed = e.tick()
+			}
+		}
+		ticker.Stop()
+
+		e.settle(ctx)
+		if !sleepCtx(ctx, crashCooldown) {
+			return
+		}
+	}
+}
+
+func sleepCtx(ctx context.Context, d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-t.C:
+		return true
+	}
+}
+
+func (e *crashEngine) openRound() {
+	seed := make([]byte, 32)
+	if _, err := crand.Read(seed); err != nil {
+		log.Fatalf("[crash] seed: %v", err)
+	}
+	hash := sha256.Sum256(seed)
+	id := uuid.New().String()
+	r := &crashRound{
+		Id:         id,
+		Seed:       seed,
+		SeedHash:   hex.EncodeToString(hash[:]),
+		CrashPoint: crashPointFromSeed(seed, id),
+		Phase:      "betting",
+		StartsAt:   time.Now().Add(crashBettingTime),
+		Bets:       make(map[string]*crashBet),
+	}
+
+	e.mu.Lock()
+	e.round = r
+	e.broadcastLocked(crashMinPoint)
+	e.mu.Unlock()
+	log.Printf("[crash] round %s open, seed hash %s", r.Id, r.SeedHash)
+}
+
+func (e *crashEngine) startRound() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.round.Phase = "running"
+	e.round.StartsAt = time.Now()
+	e.broadcastLocked(crashMinPoint)
+}
+
+// tick advances the multiplier, fires auto cash-outs and reports whether the round crashed.
+func (e *crashEngine) tick() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	r := e.round
+	m := crashMultiplierAt(time.Since(r.StartsAt))
+	crashed := m >= r.CrashPoint
+	if crashed {
+		m = r.CrashPoint
+		r.Phase = "crashed"
+	}
+	for _, b := range r.Bets {
+		if b.Confirmed && b.CashedOut == 0 && b.AutoCashout > 0 &&
+			b.AutoCashout <= m && b.AutoCashout < r.CrashPoint {
+			b.CashedOut = b.AutoCashout
+		}
+	}
+	e.broadcastLocked(m)
+	return crashed
+}
+
+// settle pays every cashed-out bet of the crashed round through the wallet.
+func (e *crashEngine) settle(ctx context.Context) {
+	e.mu.Lock()
+	r := e.round
+	var payouts []CrashPayoutDoc
+	var results []*walletpb.GameResult
+	for _, b := range r.Bets {
+		if !b.Confirmed {
+			continue
+		}
+		res := &walletpb.GameResult{UserId: b.UserId, Game: "crash", RoundId: r.Id, Stake: creditsIn(b.Amount, b.Currency), Rtp: 1 - crashHouseEdge}
+		// a manual bet could have ridden to the cap, an auto one stops at its target
+		if b.AutoCashout > 0 {
+			res.Liability = creditsIn(crashPayout(b.Amount, b.AutoCashout), b.Currency)
+		} else {
+			res.Liability = creditsIn(crashPayout(b.Amount, crashMaxPoint), b.Currency)
+		}
+		if b.CashedOut > 0 {
+			won := crashPayout(b.Amount, b.CashedOut)
+			res.Payout = creditsIn(won, b.Currency)
+			payouts = append(payouts, CrashPayoutDoc{
+				Id:       crashWinKey(r.Id, b.UserId),
+				UserId:   b.UserId,
+				RoundId:  r.Id,
+				Amount:   won,
+				Currency: b.Currency,
+			})
+			for _, x := range []int64{2, 10, 100} {
+				if b.CashedOut >= x*100 {
+					res.Tags = append(res.Tags, fmt.Sprintf("x%d", x))
+				}
+			}
+		}
+		results = append(results, res)
+	}
+	bets := len(r.Bets)
+	e.mu.Unlock()
+
+	log.Printf("[crash] round %s crashed at %.2fx, %d bets, %d winners",
+		r.Id, float64(r.CrashPoint)/100, bets, len(payouts))
+	for _, p := range payouts {
+		// auto cash-outs reach the database only here
+		e.savePayout(ctx, p)
+	}
+	// the round's crash result: from here its bets are lost or owed a win
+	e.settleBets(ctx, r.Id)
+	if err := e.results.record(ctx, results...); err != nil {
+		log.Printf("[crash] round %s: %d results not recorded: %v", r.Id, len(results), err)
+	}
+	for _, p := range payouts {
+		e.pay(ctx, p)
+	}
+}
+
+// savePayout records a cash-out as owed. Manual cash-outs are saved twice, at
+// the cash-out and at settlement; the second write is a no-op.
+func (e *crashEngine) savePayout(ctx context.Context, p CrashPayoutDoc) {
+	if e.payouts == nil {
+		return
+	}
+	p.Status, p.CreatedAt = "pending", time.Now().UTC()
+	cctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if _, err := e.payouts.UpdateOne(cctx, bson.M{"_id": p.Id}, bson.M{"$setOnInsert": p}, options.Update().SetUpsert(true)); err != nil {
+		log.Printf("[crash] round %s: cannot record payout for %s: %v", p.RoundId, p.UserId, err)
+	}
+}
+
+// settleBets closes the round's bets once its wins are saved. A bet left open
+// would be refunded after a restart, so the write is retried a few times.
+func (e *crashEngine) settleBets(ctx context.Context, roundId string) {
+	if e.bets == nil {
+		return
+	}
+	for attempt := 1; ; attempt++ {
+		cctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		_, err := e.bets.UpdateMany(cctx, bson.M{"round_id": roundId, "status": "riding"}, bson.M{"$set": bson.M{"status": "settled"}})
+		cancel()
+		if err == nil {
+			return
+		}
+		log.Printf("[crash] round %s: cannot settle bets (attempt %d): %v", roundId, attempt, err)
+		if attempt == 3 || !sleepCtx(ctx, time.Second) {
+			return
+		}
+	}
+}
+
+// setBet moves a bet to status; a failed write is only logged, recoverBets
+// sorts the bet out from the wallet's side.
+func (e *crashEngine) setBet(roundId, userId, status string) {
+	if e.bets == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := e.bets.UpdateOne(ctx, bson.M{"_id": roundId + ":" + userId}, bson.M{"$set": bson.M{"status": status}}); err != nil {
+		log.Printf("[crash] round %s: bet of %s not marked %s: %v", roundId, userId, status, err)
+	}
+}
+
+// recoverBets refunds bets of rounds that never crashed: the service stopped
+// while they were riding. A bet still "placing" may or may not have been
+// debited, so the debit is sent again under its own key first; the wallet
+// either returns the original debit or takes it now, and the refund then
+// returns it either way. A bet with a saved win was cashed out before the
+// stop; recoverPayouts pays that win, so it is only closed here.
+//
+// Only bets placed before this process started are looked at; it runs at
+// startup and then every minute until every one of them is closed.
+func (e *crashEngine) recoverBets(ctx context.Context) {
+	started := time.Now().UTC()
+	for {
+		cur, err := e.bets.Find(ctx, bson.M{
+			"status":     bson.M{"$in": []string{"placing", "riding"}},
+			"created_at": bson.M{"$lt": started},
+		}, options.Find().SetLimit(1000))
+		var docs []CrashBetDoc
+		if err == nil {
+			err = cur.All(ctx, &docs)
+		}
+		if err != nil {
+			log.Printf("[crash] recover bets: %v", err)
+		}
+		refunded := 0
+		for _, b := range docs {
+			if e.refundBet(ctx, b) {
+				refunded++
+			}
+		}
+		if len(docs) > 0 {
+			log.Printf("[crash] closed %d of %d bets left open", refunded, len(docs))
+		}
+		if err == nil && refunded == len(docs) && len(docs) < 1000 {
+			return
+		}
+		if !sleepCtx(ctx, time.Minute) {
+			return
+		}
+	}
+}
+
+func (e *crashEngine) refundBet(ctx context.Context, b CrashBetDoc) bool {
+	cctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if e.payouts != nil {
+		n, err := e.payouts.CountDocuments(cctx, bson.M{"_id": crashWinKey(b.RoundId, b.UserId)})
+		if err != nil {
+			log.Printf("[crash] round %s: bet of %s: %v", b.RoundId, b.UserId, err)
+			return false
+		}
+		if n > 0 {
+			e.setBet(b.RoundId, b.UserId, "settled")
+			return true
+		}
+	}
+	if b.Status == "placing" {
+		_, err := e.wallet.UpdateBalance(cctx, &walletpb.WalletUpdateRequest{UserId: b.UserId, Amount: creditsIn(-b.Amount, b.Currency), Type: "bet", Ref: b.RoundId, IdempotencyKey: crashBetKey(b.RoundId, b.UserId)})
+		if status.Code(err) == codes.FailedPrecondition {
+			// the player can't cover it, so the stake was never taken
+			e.setBet(b.RoundId, b.UserId, "refunded")
+			return true
+		}
+		if err != nil {
+			log.Printf("[crash] round %s: re-debit %s: %v", b.RoundId, b.UserId, err)
+			return false
+		}
+	}
+	if _, err := e.wallet.UpdateBalance(cctx, &walletpb.WalletUpdateRequest{UserId: b.UserId, Amount: creditsIn(b.Amount, b.Currency), Type: "refund", Ref: b.RoundId, IdempotencyKey: crashRefundKey(b.RoundId, b.UserId)}); err != nil {
+		log.Printf("[crash] round %s: refund %d to %s failed: %v", b.RoundId, b.Amount, b.UserId, err)
+		return false
+	}
+	log.Printf("[crash] round %s never crashed, refunded %d to %s", b.RoundId, b.Amount, b.UserId)
+	e.setBet(b.RoundId, b.UserId, "refunded")
+	return true
+}
+
+// pay credits a win and marks it paid; a failure leaves it pending for recoverPayouts.
+func (e *crashEngine) pay(ctx context.Context, p CrashPayoutDoc) bool {
+	cctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	_, err := e.wallet.UpdateBalance(cctx, &walletpb.WalletUpdateRequest{
+		UserId:         p.UserId,
+		Amount:         creditsIn(p.Amount, p.Currency),
+		Type:           "win",
+		Ref:            p.RoundId,
+		IdempotencyKey: p.Id,
+	})
+	if err != nil {
+		log.Printf("[crash] round %s: pay %d to %s failed: %v", p.RoundId, p.Amount, p.UserId, err)
+		return false
+	}
+	if e.payouts != nil {
+		if _, err := e.payouts.UpdateOne(cctx, bson.M{"_id": p.Id}, bson.M{"$set": bson.M{"status": "paid", "paid_at": time.Now().UTC()}}); err != nil {
+			log.Printf("[crash] round %s: paid %s but not marked: %v", p.RoundId, p.UserId, err)
+		}
+	}
+	return true
+}
+
+// recoverPayouts pays wins left pending by a wallet outage or a restart, on
+// start and then every minute. Fresh ones belong to a round that is still
+// settling and are left to it.
+func (e *crashEngine) recoverPayouts(ctx context.Context) {
+	for {
+		cur, err := e.payouts.Find(ctx, bson.M{
+			"status":     "pending",
+			"created_at": bson.M{"$lt": time.Now().UTC().Add(-time.Minute)},
+		}, options.Find().SetLimit(1000))
+		var docs []CrashPayoutDoc
+		if err == nil {
+			err = cur.All(ctx, &docs)
+		}
+		if err != nil {
+			log.Printf("[crash] recover payouts: %v", err)
+		}
+		paid := 0
+		for _, p := range docs {
+			if e.pay(ctx, p) {
+				paid++
+			}
+		}
+		if len(docs) > 0 {
+			log.Printf("[crash] recovered %d of %d pending payouts", paid, len(docs))
+		}
+		if !sleepCtx(ctx, time.Minute) {
+			return
+		}
+	}
+}
+
+func (e *crashEngine) PlaceBet(ctx context.Context, userId string, amount int32, auto float64, currency string) (*pb.CrashBetResponse, error) {
+	if userId == "" {
+		return nil, fmt.Errorf("user_id required")
+	}
+	if amount < crashMinBet || amount > crashMaxBet {
+		return nil, fmt.Errorf("bet must be between %d and %d", crashMinBet, crashMaxBet)
+	}
+	autoCashout := toHundredths(auto)
+	if auto != 0 && (autoCashout <= crashMinPoint || autoCashout > crashMaxPoint) {
+		return nil, fmt.Errorf("auto cashout must be above 1.00x")
+	}
+
+	// reserve the seat first so the same user can't bet twice while the wallet call is in flight
+	e.mu.Lock()
+	r := e.round
+	if r == nil || r.Phase != "betting" {
+		e.mu.Unlock()
+		return nil, fmt.Errorf("betting is closed")
+	}
+	if _, ok := r.Bets[userId]; ok {
+		e.mu.Unlock()
+		return nil, fmt.Errorf("already bet in this round")
+	}
+	cur := roundCurrency(currency)
+	bet := &crashBet{UserId: userId, Amount: amount, Currency: cur, AutoCashout: autoCashout}
+	r.Bets[userId] = bet
+	e.mu.Unlock()
+	drop := func() {
+		e.mu.Lock()
+		delete(r.Bets, userId)
+		e.mu.Unlock()
+	}
+
+	// the bet is on record before any money moves, see CrashBetDoc
+	if e.bets != nil {
+		doc := CrashBetDoc{Id: r.Id + ":" + userId, RoundId: r.Id, UserId: userId, Amount: amount, Currency: cur, Status: "placing", CreatedAt: time.Now().UTC()}
+		if _, err := e.bets.InsertOne(ctx, doc); err != nil {
+			drop()
+			return nil, err
+		}
+	}
+	wr, err := e.wallet.UpdateBalance(ctx, &walletpb.WalletUpdateRequest{UserId: userId, Amount: creditsIn(-amount, cur), Type: "bet", Ref: r.Id, IdempotencyKey: crashBetKey(r.Id, userId)})
+	if err != nil {
+		drop()
+		// nothing was taken; if the wallet did take it after all, recoverBets
+		// finds the bet still placing and refunds it
+		if e.bets != nil && status.Code(err) == codes.FailedPrecondition {
+			e.setBet(r.Id, userId, "refunded")
+		}
+		return nil, err
+	}
+
+	e.mu.Lock()
+	settled := r.Phase == "crashed" || e.round != r
+	if !settled {
+		bet.Confirmed = true
+	}
+	e.mu.Unlock()
+
+	if settled {
+		// the round finished before the debit came back, give the stake back
+		if _, err := e.wallet.UpdateBalance(context.Background(), &walletpb.WalletUpdateRequest{UserId: userId, Amount: creditsIn(amount, cur), Type: "refund", Ref: r.Id, IdempotencyKey: crashRefundKey(r.Id, userId)}); err != nil {
+			// left riding: recoverBets refunds it under the same key
+			log.Printf("[crash] refund %d to %s failed: %v", amount, userId, err)
+			e.setBet(r.Id, userId, "riding")
+		} else {
+			e.setBet(r.Id, userId, "refunded")
+		}
+		return nil, fmt.Errorf("round already finished")
+	}
+	e.setBet(r.Id, userId, "riding")
+	contributeJackpot(e.jackpot, "crash", userId, r.Id, cur, amount)
+	return &pb.CrashBetResponse{
+		RoundId:     r.Id,
+		Amount:      amount,
+		AutoCashout: float64(autoCashout) / 100,
+		Balance:     toCredits(wr.NewBalance),
+		Currency:    cur,
+	}, nil
+}
+
+func (e *crashEngine) Cashout(ctx context.Context, userId, roundId string) (*pb.CrashCashoutResponse, error) {
+	e.mu.Lock()
+	r := e.round
+	if r == nil || (roundId != "" && r.Id != roundId) {
+		e.mu.Unlock()
+		return nil, fmt.Errorf("round not found")
+	}
+	if r.Phase != "running" {
+		e.mu.Unlock()
+		return nil, fmt.Errorf("round is not running")
+	}
+	b, ok := r.Bets[userId]
+	if !ok || !b.Confirmed {
+		e.mu.Unlock()
+		return nil, fmt.Errorf("no bet in this round")
+	}
+	if b.CashedOut > 0 {
+		e.mu.Unlock()
+		return nil, fmt.Errorf("already cashed out")
+	}
+	m := crashMultiplierAt(time.Since(r.StartsAt))
+	if m >= r.CrashPoint {
+		e.mu.Unlock()
+		return nil, fmt.Errorf("round crashed")
+	}
+	b.CashedOut = m
+	won := crashPayout(b.Amount, m)
+	e.mu.Unlock()
+
+	// the win is owed from here on, even if the service dies before the round settles
+	e.savePayout(ctx, CrashPayoutDoc{Id: crashWinKey(r.Id, userId), UserId: userId, RoundId: r.Id, Amount: won, Currency: b.Currency})
+	return &pb.CrashCashoutResponse{
+		RoundId:    r.Id,
+		Multiplier: float64(m) / 100,
+		Payout:     won,
+		Currency:   b.Currency,
+	}, nil
+}
+
+func (e *crashEngine) subscribe() chan *pb.CrashState {
+	ch := make(chan *pb.CrashState, 16)
+	e.mu.Lock()
+	e.subs[ch] = struct{}{}
+	if e.round != nil {
+		ch <- e.stateLocked(e.currentMultiplierLocked())
+	}
+	e.mu.Unlock()
+	return ch
+}
+
+func (e *crashEngine) unsubscribe(ch chan *pb.CrashState) {
+	e.mu.Lock()
+	delete(e.subs, ch)
+	e.mu.Unlock()
+}
+
+func (e *crashEngine) currentMultiplierLocked() int64 {
+	switch e.round.Phase {
+	case "running":
+		return crashMultiplierAt(time.Since(e.round.StartsAt))
+	case "crashed":
+		return e.round.CrashPoint
+	}
+	return crashMinPoint
+}
+
+func (e *crashEngine) stateLocked(m int64) *pb.CrashState {
+	r := e.round
+	st := &pb.CrashState{
+		RoundId:    r.Id,
+		Phase:      r.Phase,
+		Multiplier: float64(m) / 100,
+		SeedHash:   r.SeedHash,
+		StartsAt:   r.StartsAt.UnixMilli(),
+		Players:    int32(len(r.Bets)),
+	}
+	if r.Phase == "crashed" {
+		st.CrashPoint = float64(r.CrashPoint) / 100
+		st.ServerSeed = hex.EncodeToString(r.Seed)
+	}
+	return st
+}
+
+// broadcastLocked never blocks the round loop: slow watchers just miss ticks.
+func (e *crashEngine) broadcastLocked(m int64) {
+	st := e.stateLocked(m)
+	for ch := range e.subs {
+		select {
+		case ch <- st:
+		default:
+		}
+	}
+}
+
+func (s *gameServer) PlaceCrashBet(ctx context.Context, req *pb.CrashBetRequest) (*pb.CrashBetResponse, error) {
+	return s.crash.PlaceBet(ctx, req.UserId, req.Amount, req.AutoCashout, req.Currency)
+}
+
+func (s *gameServer) CrashCashout(ctx context.Context, req *pb.CrashCashoutRequest) (*pb.CrashCashoutResponse, error) {
+	return s.crash.Cashout(ctx, req.UserId, req.RoundId)
+}
+
+func (s *gameServer) WatchCrash(_ *pb.WatchCrashRequest, stream pb.GameService_WatchCrashServer) error {
+	ch := s.crash.subscribe()
+	defer s.crash.unsubscribe(ch)
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case st := <-ch:
+			if err := stream.Send(st); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,1396 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	pb "github.com/Arsencchikkk/final/casino/proto/game"
+	walletpb "github.com/Arsencchikkk/final/casino/proto/wallet"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type holdemConfig struct {
+	SmallBlind    int32
+	BigBlind      int32
+	MinBuyIn      int32
+	MaxBuyIn      int32
+	Seats         int
+	RakePercent   float64 // taken only from pots that saw a flop
+	RakeCap       int32
+	ActionTimeout time.Duration
+	MaxSpectators int32
+}
+
+// cash-game tables opened at startup
+var holdemTables = []struct {
+	Id     string
+	Config holdemConfig
+}{
+	{"holdem-micro", holdemConfig{SmallBlind: 1, BigBlind: 2, MinBuyIn: 40, MaxBuyIn: 200, Seats: 6, RakePercent: 5, RakeCap: 10, ActionTimeout: 30 * time.Second, MaxSpectators: 50}},
+	{"holdem-low", holdemConfig{SmallBlind: 5, BigBlind: 10, MinBuyIn: 200, MaxBuyIn: 1000, Seats: 6, RakePercent: 5, RakeCap: 30, ActionTimeout: 30 * time.Second, MaxSpectators: 50}},
+	{"holdem-high", holdemConfig{SmallBlind: 25, BigBlind: 50, MinBuyIn: 1000, MaxBuyIn: 5000, Seats: 9, RakePercent: 5, RakeCap: 100, ActionTimeout: 30 * time.Second, MaxSpectators: 100}},
+}
+
+// pause between hands so players can see the showdown
+const holdemHandPause = 5 * time.Second
+
+type holdemPlayer struct {
+	Seat        int // index into holdemTable.seats
+	UserId      string
+	JoinId      string // the join that bought this seat, see HoldemSeatDoc
+	Stack       int32
+	Bet         int32 // on the current street
+	Committed   int32 // in the current hand
+	Hole        []Card
+	InHand      bool
+	Folded      bool
+	AllIn       bool
+	Acted       bool // acted since the last full raise
+	RaiseLocked bool // a short all-in doesn't reopen raising
+	Leaving     bool
+	LastAction  string
+}
+
+type holdemPot struct {
+	Amount   int32
+	Eligible []int
+}
+
+type holdemCmd struct {
+	kind   string // "join", "leave", "act", "view"
+	userId string
+	joinId string
+	rejoin bool // the join already had its seat, see HoldemJoin
+	seat   int
+	buyIn  int32
+	action string
+	amount int32
+	reply  chan holdemReply
+}
+
+type holdemReply struct {
+	seat   int
+	stack  int32
+	cashed int32
+	// what leave owes the player, for the caller to pay; a leave in the
+	// middle of a hand is paid by the table when the hand ends
+	payout  *HoldemPayoutDoc
+	pending bool
+	state   *pb.HoldemTableState
+	err     error
+}
+
+// holdemSub is one stream of table updates. A spectator always gets the
+// public view, whoever they are.
+type holdemSub struct {
+	userId    string
+	spectator bool
+	ch        chan *pb.HoldemTableState
+}
+
+// holdemTable is driven by a single goroutine (run); every field below cmds is
+// owned by it and must not be touched from RPC handlers.
+type holdemTable struct {
+	id      string
+	cfg     holdemConfig
+	house   string
+	wallet  walletpb.WalletServiceClient
+	results *resultOutbox
+	store   *holdemStore
+	cmds    chan holdemCmd
+	seated  atomic.Int32
+
+	subMu      sync.Mutex
+	subs       map[*holdemSub]struct{}
+	spectators atomic.Int32
+
+	seats      []*holdemPlayer
+	handNo     int64
+	street     string // "waiting", "preflop", "flop", "turn", "river", "showdown"
+	deck       []Card
+	board      []Card
+	button     int
+	toAct      int
+	currentBet int32
+	minRaise   int32
+	deadline   time.Time // action timeout during a hand, next deal otherwise
+	revealed   bool
+	lastPots   []holdemPot
+	winners    []*pb.HoldemWinner
+	rake       int32
+}
+
+func newHoldemTable(id string, cfg holdemConfig, house string, wallet walletpb.WalletServiceClient, store *holdemStore) *holdemTable {
+	return &holdemTable{
+		id:     id,
+		cfg:    cfg,
+		house:  house,
+		wallet: wallet,
+		store:  store,
+		cmds:   make(chan holdemCmd),
+		subs:   make(map[*holdemSub]struct{}),
+		seats:  make([]*holdemPlayer, cfg.Seats),
+		street: "waiting",
+		button: -1,
+		toAct:  -1,
+	}
+}
+
+func (t *holdemTable) run(ctx context.Context) {
+	for {
+		var wake <-chan time.Time
+		var timer *time.Timer
+		if !t.deadline.IsZero() {
+			timer = time.NewTimer(time.Until(t.deadline))
+			wake = timer.C
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case cmd := <-t.cmds:
+			cmd.reply <- t.handle(cmd)
+		case <-wake:
+			t.onWake()
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		t.schedule()
+		t.publish()
+	}
+}
+
+// do hands a command to the table goroutine. Once sent, the reply is always
+// awaited so that a cancelled caller can't lose track of a seat it paid for.
+func (t *holdemTable) do(ctx context.Context, cmd holdemCmd) holdemReply {
+	cmd.reply = make(chan holdemReply, 1)
+	select {
+	case t.cmds <- cmd:
+	case <-ctx.Done():
+		return holdemReply{err: ctx.Err()}
+	}
+	return <-cmd.reply
+}
+
+func (t *holdemTable) handle(cmd holdemCmd) holdemReply {
+	switch cmd.kind {
+	case "join":
+		if cmd.rejoin && t.player(cmd.userId) == nil {
+			// seated by an earlier run of the service, recover cashes it out
+			return holdemReply{err: fmt.Errorf("this join is over, join again with a new join_id")}
+		}
+		return t.join(cmd.userId, cmd.joinId, cmd.seat, cmd.buyIn)
+	case "leave":
+		return t.leave(cmd.userId)
+	case "act":
+		p := t.player(cmd.userId)
+		if p == nil {
+			return holdemReply{err: fmt.Errorf("not seated at this table")}
+		}
+		if !t.handActive() || t.toAct != p.Seat {
+			return holdemReply{err: fmt.Errorf("not your turn")}
+		}
+		if err := t.apply(p, cmd.action, cmd.amount); err != nil {
+			return holdemReply{err: err}
+		}
+		t.progress(p.Seat)
+		return holdemReply{state: t.view(cmd.userId)}
+	case "view":
+		return holdemReply{state: t.view(cmd.userId)}
+	case "seated":
+		if t.player(cmd.userId) == nil {
+			return holdemReply{err: fmt.Errorf("not seated at this table")}
+		}
+		return holdemReply{}
+	}
+	return holdemReply{err: fmt.Errorf("unknown command %q", cmd.kind)}
+}
+
+func (t *holdemTable) player(userId string) *holdemPlayer {
+	for _, p := range t.seats {
+		if p != nil && p.UserId == userId {
+			return p
+		}
+	}
+	return nil
+}
+
+func (t *holdemTable) join(userId, joinId string, seat int, buyIn int32) holdemReply {
+	if p := t.player(userId); p != nil {
+		if p.JoinId == joinId {
+			// a retried join that got its seat the first time
+			return holdemReply{seat: p.Seat + 1, stack: p.Stack}
+		}
+		return holdemReply{err: fmt.Errorf("already seated at this table")}
+	}
+	idx := -1
+	if seat > 0 {
+		if seat > len(t.seats) || t.seats[seat-1] != nil {
+			return holdemReply{err: fmt.Errorf("seat %d is not available", seat)}
+		}
+		idx = seat - 1
+	} else {
+		for i, p := range t.seats {
+			if p == nil {
+				idx = i
+				break
+			}
+		}
+	}
+	if idx < 0 {
+		return holdemReply{err: fmt.Errorf("table is full")}
+	}
+	p := &holdemPlayer{Seat: idx, UserId: userId, JoinId: joinId, Stack: buyIn}
+	if err := t.store.save(t.id, t.handNo, []*holdemPlayer{p}, nil, nil); err != nil {
+		return holdemReply{err: fmt.Errorf("cannot take the seat right now, try again")}
+	}
+	t.seats[idx] = p
+	log.Printf("[holdem %s] %s sits at seat %d with %d", t.id, userId, idx+1, buyIn)
+	return holdemReply{seat: idx + 1, stack: buyIn}
+}
+
+// leave cashes the stack out right away, or after the hand if the player is still in it.
+func (t *holdemTable) leave(userId string) holdemReply {
+	p := t.player(userId)
+	if p == nil {
+		return holdemReply{err: fmt.Errorf("not seated at this table")}
+	}
+	if t.handActive() && p.InHand {
+		p.Leaving = true
+		if !p.Folded {
+			if t.toAct == p.Seat {
+				t.apply(p, "fold", 0)
+				t.progress(p.Seat)
+			} else {
+				p.Folded = true
+				p.LastAction = "fold"
+				if t.contenders() == 1 {
+					t.finishUncontested()
+				}
+			}
+		}
+		// the hand may have just ended and paid the player out already
+		if t.seats[p.Seat] == p {
+			return holdemReply{pending: true}
+		}
+		return holdemReply{cashed: p.Stack}
+	}
+	var payouts []HoldemPayoutDoc
+	if p.Stack > 0 {
+		payouts = append(payouts, t.cashOut(p))
+	}
+	// the seat is given up only once the stack is owed on record
+	if err := t.store.save(t.id, t.handNo, nil, []*holdemPlayer{p}, payouts); err != nil {
+		return holdemReply{err: fmt.Errorf("cannot cash out right now, try again")}
+	}
+	t.seats[p.Seat] = nil
+	log.Printf("[holdem %s] %s leaves with %d", t.id, userId, p.Stack)
+	rep := holdemReply{cashed: p.Stack}
+	if len(payouts) > 0 {
+		rep.payout = &payouts[0]
+	}
+	return rep
+}
+
+func (t *holdemTable) handActive() bool {
+	switch t.street {
+	case "preflop", "flop", "turn", "river":
+		return true
+	}
+	return false
+}
+
+func (t *holdemTable) canAct(i int) bool {
+	p := t.seats[i]
+	return p != nil && p.InHand && !p.Folded && !p.AllIn
+}
+
+func (t *holdemTable) contenders() int {
+	n := 0
+	for _, p := range t.seats {
+		if p != nil && p.InHand && !p.Folded {
+			n++
+		}
+	}
+	return n
+}
+
+// nextSeat returns the first seat after from (wrapping) that satisfies ok, or -1.
+func (t *holdemTable) nextSeat(from int, ok func(int) bool) int {
+	n := len(t.seats)
+	for i := 1; i <= n; i++ {
+		j := ((from+i)%n + n) % n
+		if ok(j) {
+			return j
+		}
+	}
+	return -1
+}
+
+func (t *holdemTable) readyPlayers() int {
+	n := 0
+	for _, p := range t.seats {
+		if p != nil && p.Stack > 0 && !p.Leaving {
+			n++
+		}
+	}
+	return n
+}
+
+func (t *holdemTable) schedule() {
+	if t.handActive() {
+		return
+	}
+	if t.readyPlayers() < 2 {
+		t.deadline = time.Time{}
+		if t.street != "showdown" {
+			t.street = "waiting"
+		}
+		return
+	}
+	if t.deadline.IsZero() {
+		t.deadline = time.Now().Add(holdemHandPause)
+	}
+}
+
+func (t *holdemTable) onWake() {
+	if time.Now().Before(t.deadline) {
+		return
+	}
+	if !t.handActive() {
+		t.deadline = time.Time{}
+		if t.readyPlayers() >= 2 {
+			t.startHand()
+		}
+		return
+	}
+	// action timeout: check if free, fold otherwise
+	p := t.seats[t.toAct]
+	action := "fold"
+	if p.Bet == t.currentBet {
+		action = "check"
+	}
+	log.Printf("[holdem %s] seat %d timed out, auto-%s", t.id, p.Seat+1, action)
+	t.apply(p, action, 0)
+	t.progress(p.Seat)
+}
+
+func (t *holdemTable) startHand() {
+	t.handNo++
+	t.deck = newDeck()
+	t.board = nil
+	t.revealed = false
+	t.lastPots = nil
+	t.winners = nil
+	t.rake = 0
+	t.currentBet = 0
+	t.minRaise = t.cfg.BigBlind
+	for _, p := range t.seats {
+		if p == nil {
+			continue
+		}
+		*p = holdemPlayer{Seat: p.Seat, UserId: p.UserId, JoinId: p.JoinId, Stack: p.Stack, Leaving: p.Leaving}
+		p.InHand = p.Stack > 0 && !p.Leaving
+	}
+	inHand := func(i int) bool { return t.seats[i] != nil && t.seats[i].InHand }
+
+	t.button = t.nextSeat(t.button, inHand)
+	sb := t.nextSeat(t.button, inHand)
+	if t.contenders() == 2 {
+		// heads-up: the button posts the small blind and acts first preflop
+		sb = t.button
+	}
+	bb := t.nextSeat(sb, inHand)
+	t.street = "preflop"
+	t.blind(t.seats[sb], t.cfg.SmallBlind, "small blind")
+	t.blind(t.seats[bb], t.cfg.BigBlind, "big blind")
+	t.currentBet = t.cfg.BigBlind
+
+	// two cards each, starting left of the button
+	var order []int
+	for seat := t.button; ; {
+		seat = t.nextSeat(seat, inHand)
+		order = append(order, seat)
+		if seat == t.button {
+			break
+		}
+	}
+	for round := 0; round < 2; round++ {
+		for _, seat := range order {
+			t.seats[seat].Hole = append(t.seats[seat].Hole, t.draw())
+		}
+	}
+	log.Printf("[holdem %s] hand #%d, button seat %d", t.id, t.handNo, t.button+1)
+	t.progress(bb)
+}
+
+func (t *holdemTable) blind(p *holdemPlayer, amount int32, label string) {
+	if amount > p.Stack {
+		amount = p.Stack
+	}
+	t.put(p, amount)
+	p.LastAction = label
+}
+
+func (t *holdemTable) draw() Card {
+	c := t.deck[0]
+	t.deck = t.deck[1:]
+	return c
+}
+
+func (t *holdemTable) put(p *holdemPlayer, amount int32) {
+	p.Stack -= amount
+	p.Bet += amount
+	p.Committed += amount
+	if p.Stack == 0 {
+		p.AllIn = true
+	}
+}
+
+// apply validates and performs one betting action; raise amounts are "raise to".
+func (t *holdemTable) apply(p *holdemPlayer, action string, amount int32) error {
+	toCall := t.currentBet - p.Bet
+	switch action {
+	case "fold":
+		p.Folded = true
+	case "check":
+		if toCall > 0 {
+			return fmt.Errorf("cannot check, %d to call", toCall)
+		}
+	case "call":
+		if toCall > p.Stack {
+			toCall = p.Stack
+		}
+		t.put(p, toCall)
+	case "raise", "allin":
+		target := amount
+		if action == "allin" {
+			target = p.Bet + p.Stack
+		}
+		if target-p.Bet > p.Stack {
+			return fmt.Errorf("not enough chips")
+		}
+		if target <= t.currentBet {
+			if action == "allin" {
+				t.put(p, p.Stack)
+				break
+			}
+			return fmt.Errorf("raise must be above %d", t.currentBet)
+		}
+		if p.RaiseLocked {
+			return fmt.Errorf("raising is closed, call or fold")
+		}
+		allIn := target-p.Bet == p.Stack
+		full := target-t.currentBet >= t.minRaise
+		if !full && !allIn {
+			return fmt.Errorf("minimum raise is to %d", t.currentBet+t.minRaise)
+		}
+		t.put(p, target-p.Bet)
+		if full {
+			t.minRaise = target - t.currentBet
+		}
+		t.currentBet = target
+		for i, o := range t.seats {
+			if o == nil || o == p || !t.canAct(i) {
+				continue
+			}
+			if full {
+				o.RaiseLocked = false
+			} else if o.Acted {
+				o.RaiseLocked = true
+			}
+			o.Acted = false
+		}
+	default:
+		return fmt.Errorf("unknown action %q", action)
+	}
+	p.Acted = true
+	p.LastAction = action
+	return nil
+}
+
+// progress moves the hand on after seat from acted: next player, next street or showdown.
+func (t *holdemTable) progress(from int) {
+	if t.contenders() == 1 {
+		t.finishUncontested()
+		return
+	}
+	needsAction := func(i int) bool {
+		return t.canAct(i) && (!t.seats[i].Acted || t.seats[i].Bet < t.currentBet)
+	}
+	if next := t.nextSeat(from, needsAction); next >= 0 {
+		t.toAct = next
+		t.deadline = time.Now().Add(t.cfg.ActionTimeout)
+		return
+	}
+
+	// betting round is over
+	for _, p := range t.seats {
+		if p != nil {
+			p.Bet = 0
+			p.Acted = false
+			p.RaiseLocked = false
+		}
+	}
+	t.currentBet = 0
+	t.minRaise = t.cfg.BigBlind
+
+	active := 0
+	for i := range t.seats {
+		if t.canAct(i) {
+			active++
+		}
+	}
+	if t.street == "river" || active < 2 {
+		// nobody left to bet against: run the board out
+		for len(t.board) < 5 {
+			t.dealStreet()
+		}
+		t.showdown()
+		return
+	}
+	t.dealStreet()
+	t.toAct = t.nextSeat(t.button, t.canAct)
+	t.deadline = time.Now().Add(t.cfg.ActionTimeout)
+}
+
+func (t *holdemTable) dealStreet() {
+	t.draw() // burn
+	switch len(t.board) {
+	case 0:
+		t.board = append(t.board, t.draw(), t.draw(), t.draw())
+		t.street = "flop"
+	case 3:
+		t.board = append(t.board, t.draw())
+		t.street = "turn"
+	default:
+		t.board = append(t.board, t.draw())
+		t.street = "river"
+	}
+}
+
+// buildPots splits the committed chips into a main pot and side pots by all-in level.
+func (t *holdemTable) buildPots() []holdemPot {
+	var levels []int32
+	for _, p := range t.seats {
+		if p != nil && p.InHand && !p.Folded {
+			levels = appendLevel(levels, p.Committed)
+		}
+	}
+	var pots []holdemPot
+	var prev int32
+	for _, lvl := range levels {
+		pot := holdemPot{}
+		for i, p := range t.seats {
+			if p == nil || !p.InHand {
+				continue
+			}
+			pot.Amount += min32(p.Committed, lvl) - min32(p.Committed, prev)
+			if !p.Folded && p.Committed >= lvl {
+				pot.Eligible = append(pot.Eligible, i)
+			}
+		}
+		if pot.Amount > 0 {
+			pots = append(pots, pot)
+		}
+		prev = lvl
+	}
+	// chips folded above the highest live level go to the last pot
+	for _, p := range t.seats {
+		if p != nil && p.InHand && p.Committed > prev && len(pots) > 0 {
+			pots[len(pots)-1].Amount += p.Committed - prev
+		}
+	}
+	return pots
+}
+
+// appendLevel inserts v into the sorted, de-duplicated levels slice.
+func appendLevel(levels []int32, v int32) []int32 {
+	for i, l := range levels {
+		if l == v {
+			return levels
+		}
+		if l > v {
+			levels = append(levels[:i+1], levels[i:]...)
+			levels[i] = v
+			return levels
+		}
+	}
+	return append(levels, v)
+}
+
+func min32(a, b int32) int32 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// returnUncalled gives back the part of the biggest bet nobody matched.
+func (t *holdemTable) returnUncalled() {
+	var top *holdemPlayer
+	var second int32
+	for _, p := range t.seats {
+		if p == nil || !p.InHand {
+			continue
+		}
+		switch {
+		case top == nil || p.Committed > top.Committed:
+			if top != nil {
+				second = top.Committed
+			}
+			top = p
+		case p.Committed > second:
+			second = p.Committed
+		}
+	}
+	if top != nil && top.Committed > second {
+		diff := top.Committed - second
+		top.Committed -= diff
+		top.Stack += diff
+	}
+}
+
+// takeRake removes the house cut from the pots, main pot first; no flop, no drop.
+func (t *holdemTable) takeRake(pots []holdemPot) int32 {
+	if len(t.board) < 3 || t.cfg.RakePercent <= 0 {
+		return 0
+	}
+	var total int32
+	for _, p := range pots {
+		total += p.Amount
+	}
+	rake := int32(float64(total) * t.cfg.RakePercent / 100)
+	if t.cfg.RakeCap > 0 && rake > t.cfg.RakeCap {
+		rake = t.cfg.RakeCap
+	}
+	left := rake
+	for i := range pots {
+		cut := min32(left, pots[i].Amount)
+		pots[i].Amount -= cut
+		left -= cut
+	}
+	return rake
+}
+
+func (t *holdemTable) showdown() {
+	t.street = "showdown"
+	t.revealed = true
+	t.returnUncalled()
+	pots := t.buildPots()
+	t.rake = t.takeRake(pots)
+
+	scores := map[int]handScore{}
+	best := map[int][]Card{}
+	for i, p := range t.seats {
+		if p != nil && p.InHand && !p.Folded {
+			scores[i], best[i] = bestHand(append(append([]Card{}, p.Hole...), t.board...))
+		}
+	}
+
+	won := map[int]int32{}
+	for _, pot := range pots {
+		var top handScore
+		var winners []int
+		for _, i := range pot.Eligible {
+			switch s := scores[i]; {
+			case winners == nil || s > top:
+				top, winners = s, []int{i}
+			case s == top:
+				winners = append(winners, i)
+			}
+		}
+		share := pot.Amount / int32(len(winners))
+		odd := pot.Amount - share*int32(len(winners))
+		// odd chips go to the winners closest to the left of the button
+		for i, seat := 0, t.button; i < len(t.seats) && len(winners) > 0; i++ {
+			seat = t.nextSeat(seat, func(int) bool { return true })
+			for _, w := range winners {
+				if w == seat {
+					won[w] += share
+					if odd > 0 {
+						won[w]++
+						odd--
+					}
+				}
+			}
+		}
+	}
+
+	for i, p := range t.seats {
+		amount, ok := won[i]
+		if !ok {
+			continue
+		}
+		p.Stack += amount
+		t.winners = append(t.winners, &pb.HoldemWinner{
+			Seat:   int32(i + 1),
+			UserId: p.UserId,
+			Amount: amount,
+			Hand:   scores[i].Name(),
+			Cards:  cardsToStrings(best[i]),
+		})
+	}
+	t.lastPots = pots
+	t.endHand()
+}
+
+// finishUncontested gives everything to the last player standing, cards stay hidden.
+func (t *holdemTable) finishUncontested() {
+	t.street = "showdown"
+	t.returnUncalled()
+	pots := t.buildPots()
+	t.rake = t.takeRake(pots)
+	var total int32
+	for _, pot := range pots {
+		total += pot.Amount
+	}
+	for i, p := range t.seats {
+		if p != nil && p.InHand && !p.Folded {
+			p.Stack += total
+			t.winners = []*pb.HoldemWinner{{Seat: int32(i + 1), UserId: p.UserId, Amount: total}}
+		}
+	}
+	t.lastPots = pots
+	t.endHand()
+}
+
+func (t *holdemTable) endHand() {
+	t.toAct = -1
+	t.currentBet = 0
+	t.deadline = time.Now().Add(holdemHandPause)
+	for _, p := range t.seats {
+		if p != nil {
+			p.Bet = 0
+		}
+	}
+	var payouts []HoldemPayoutDoc
+	if t.rake > 0 {
+		payouts = append(payouts, t.store.payout(t.id, strconv.FormatInt(t.handNo, 10), t.house, t.rake, "adjustment", "rake"))
+	}
+	won := map[string]int32{}
+	tags := map[string][]string{}
+	for _, w := range t.winners {
+		won[w.UserId] += w.Amount
+		if w.Hand == handNames[fourOfAKind] || w.Hand == handNames[straightFlush] {
+			tags[w.UserId] = []string{"quads_plus"}
+		}
+	}
+	var results []*walletpb.GameResult
+	for _, p := range t.seats {
+		if p != nil && p.InHand {
+			results = append(results, &walletpb.GameResult{
+				UserId:  p.UserId,
+				Game:    "holdem",
+				RoundId: fmt.Sprintf("%s#%d", t.id, t.handNo),
+				Stake:   credits(p.Committed),
+				Payout:  credits(won[p.UserId]),
+				Tags:    tags[p.UserId],
+				// player against player: no theoretical RTP and no house liability
+				Table:   t.id,
+				RuleSet: fmt.Sprintf("%d/%d", t.cfg.SmallBlind, t.cfg.BigBlind),
+			})
+		}
+	}
+	if err := t.results.record(context.Background(), results...); err != nil {
+		log.Printf("[holdem] table %s hand %d: results not recorded: %v", t.id, t.handNo, err)
+	}
+	var stay, gone []*holdemPlayer
+	for i, p := range t.seats {
+		if p == nil {
+			continue
+		}
+		if p.Leaving {
+			t.seats[i] = nil
+			gone = append(gone, p)
+			if p.Stack > 0 {
+				payouts = append(payouts, t.cashOut(p))
+			}
+		} else if p.Stack == 0 {
+			t.seats[i] = nil
+			gone = append(gone, p)
+		} else {
+			stay = append(stay, p)
+		}
+	}
+	// the hand's stacks and what it owes go to Mongo in one write; if that
+	// fails, the money is still paid under the same keys
+	if err := t.store.save(t.id, t.handNo, stay, gone, payouts); err != nil {
+		log.Printf("[holdem %s] hand #%d not stored: %v", t.id, t.handNo, err)
+	}
+	for _, po := range payouts {
+		t.store.pay(context.Background(), po)
+	}
+	log.Printf("[holdem %s] hand #%d done, rake %d", t.id, t.handNo, t.rake)
+}
+
+// cashOut is what a player leaving the table is owed for their stack.
+func (t *holdemTable) cashOut(p *holdemPlayer) HoldemPayoutDoc {
+	return t.store.payout(t.id, p.JoinId, p.UserId, p.Stack, "win", "cash out")
+}
+
+// Hold'em money outside the wallet lives in Mongo so a restart of the game
+// service can give it back: seats with their stacks as of the last finished
+// hand, and every payment the tables owe (cash-outs, buy-in refunds, rake).
+// A hand cut short by a restart is void, so a seat found open at startup is
+// cashed out for its stored stack.
+//
+// Every wallet call uses a key of the form holdem:<table>:<hand>:<user>:<typ>.
+// A seat's money moves once per sitting, so for buy-ins, refunds and
+// cash-outs the join id (given by the client) stands in for the hand.
+
+// HoldemSeatDoc is one sitting at a table. It is written before the buy-in is
+// debited ("joining"), becomes "seated" once the table gives the player a
+// seat, and "left" once the stack is owed back as a HoldemPayoutDoc.
+type HoldemSeatDoc struct {
+	Id        string    `bson:"_id"` // holdem:<table>:<join_id>:<user>:seat
+	TableId   string    `bson:"table_id"`
+	UserId    string    `bson:"user_id"`
+	JoinId    string    `bson:"join_id"`
+	BuyIn     int32     `bson:"buy_in"`
+	Seat      int       `bson:"seat"`
+	Stack     int32     `bson:"stack"`
+	Status    string    `bson:"status"` // "joining", "seated", "left"
+	CreatedAt time.Time `bson:"created_at"`
+	UpdatedAt time.Time `bson:"updated_at"`
+}
+
+// HoldemPayoutDoc is money a table owes a wallet, paid under its id.
+type HoldemPayoutDoc struct {
+	Id        string    `bson:"_id"` // the idempotency key
+	TableId   string    `bson:"table_id"`
+	UserId    string    `bson:"user_id"`
+	Amount    int32     `bson:"amount"`
+	Type      string    `bson:"type"` // ledger type
+	Why       string    `bson:"why"`
+	Status    string    `bson:"status"` // "pending", "paid"
+	CreatedAt time.Time `bson:"created_at"`
+}
+
+func holdemKey(table, hand, userId, typ string) string {
+	return "holdem:" + table + ":" + hand + ":" + userId + ":" + typ
+}
+
+func holdemSeatId(table, joinId, userId string) string {
+	return holdemKey(table, joinId, userId, "seat")
+}
+
+type holdemStore struct {
+	wallet  walletpb.WalletServiceClient
+	seats   *mongo.Collection
+	payouts *mongo.Collection
+	// the last stored hand number of each table, so hand keys stay unique
+	// across restarts
+	tables *mongo.Collection
+}
+
+func (st *holdemStore) payout(table, hand, userId string, amount int32, typ, why string) HoldemPayoutDoc {
+	return HoldemPayoutDoc{
+		Id:        holdemKey(table, hand, userId, typ),
+		TableId:   table,
+		UserId:    userId,
+		Amount:    amount,
+		Type:      typ,
+		Why:       why,
+		Status:    "pending",
+		CreatedAt: time.Now().UTC(),
+	}
+}
+
+// handNo returns the last hand number stored for a table.
+func (st *holdemStore) handNo(ctx context.Context, table string) (int64, error) {
+	var d struct {
+		HandNo int64 `bson:"hand_no"`
+	}
+	err := st.tables.FindOne(ctx, bson.M{"_id": table}).Decode(&d)
+	if err == mongo.ErrNoDocuments {
+		return 0, nil
+	}
+	return d.HandNo, err
+}
+
+// save stores, in one transaction, the stacks of players still seated, the
+// players gone from the table and what the table owes. It is retried a few
+// times before giving up.
+func (st *holdemStore) save(table string, handNo int64, stay, gone []*holdemPlayer, payouts []HoldemPayoutDoc) error {
+	write := func(sc mongo.SessionContext) (interface{}, error) {
+		now := time.Now().UTC()
+		for _, p := range stay {
+			if _, err := st.seats.UpdateOne(sc, bson.M{"_id": holdemSeatId(table, p.JoinId, p.UserId)}, bson.M{"$set": bson.M{
+				"seat": p.Seat, "stack": p.Stack, "status": "seated", "updated_at": now,
+			}}); err != nil {
+				return nil, err
+			}
+		}
+		for _, p := range gone {
+			if _, err := st.seats.UpdateOne(sc, bson.M{"_id": holdemSeatId(table, p.JoinId, p.UserId)}, bson.M{"$set": bson.M{
+				"stack": 0, "status": "left", "updated_at": now,
+			}}); err != nil {
+				return nil, err
+			}
+		}
+		for _, po := range payouts {
+			if _, err := st.payouts.UpdateOne(sc, bson.M{"_id": po.Id}, bson.M{"$setOnInsert": po}, options.Update().SetUpsert(true)); err != nil {
+				return nil, err
+			}
+		}
+		_, err := st.tables.UpdateOne(sc, bson.M{"_id": table}, bson.M{"$max": bson.M{"hand_no": handNo}}, options.Update().SetUpsert(true))
+		return nil, err
+	}
+	var err error
+	for attempt := 1; attempt <= 3; attempt++ {
+		if err = st.inTx(write); err == nil {
+			return nil
+		}
+		log.Printf("[holdem %s] store (attempt %d): %v", table, attempt, err)
+		time.Sleep(200 * time.Millisecond)
+	}
+	return err
+}
+
+func (st *holdemStore) inTx(fn func(sc mongo.SessionContext) (interface{}, error)) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	sess, err := st.seats.Database().Client().StartSession()
+	if err != nil {
+		return err
+	}
+	defer sess.EndSession(context.Background())
+	_, err = sess.WithTransaction(ctx, fn)
+	return err
+}
+
+// pay credits a payout and marks it paid; a failure leaves it pending for
+// recover.
+func (st *holdemStore) pay(ctx context.Context, po HoldemPayoutDoc) bool {
+	cctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if _, err := st.wallet.UpdateBalance(cctx, &walletpb.WalletUpdateRequest{UserId: po.UserId, Amount: credits(po.Amount), Type: po.Type, Ref: po.TableId, IdempotencyKey: po.Id}); err != nil {
+		log.Printf("[holdem %s] %s: credit %d to %s failed: %v", po.TableId, po.Why, po.Amount, po.UserId, err)
+		return false
+	}
+	if _, err := st.payouts.UpdateOne(cctx, bson.M{"_id": po.Id}, bson.M{"$set": bson.M{"status": "paid"}}); err != nil {
+		log.Printf("[holdem %s] payout %s not marked paid: %v", po.TableId, po.Id, err)
+	}
+	return true
+}
+
+// startSeat records a join before its buy-in is debited. A join id seen
+// before returns the stored sitting instead.
+func (st *holdemStore) startSeat(ctx context.Context, d HoldemSeatDoc) (HoldemSeatDoc, error) {
+	_, err := st.seats.InsertOne(ctx, d)
+	if mongo.IsDuplicateKeyError(err) {
+		var found HoldemSeatDoc
+		if err := st.seats.FindOne(ctx, bson.M{"_id": d.Id}).Decode(&found); err != nil {
+			return d, err
+		}
+		if found.UserId != d.UserId || found.BuyIn != d.BuyIn {
+			return d, fmt.Errorf("join_id was already used for another join")
+		}
+		return found, nil
+	}
+	return d, err
+}
+
+// closeSeat gives up a sitting that never reached the table or outlived it,
+// together with what it is owed (nil when nothing is).
+func (st *holdemStore) closeSeat(d HoldemSeatDoc, po *HoldemPayoutDoc) error {
+	return st.inTx(func(sc mongo.SessionContext) (interface{}, error) {
+		if _, err := st.seats.UpdateOne(sc, bson.M{"_id": d.Id}, bson.M{"$set": bson.M{
+			"stack": 0, "status": "left", "updated_at": time.Now().UTC(),
+		}}); err != nil {
+			return nil, err
+		}
+		if po == nil {
+			return nil, nil
+		}
+		_, err := st.payouts.UpdateOne(sc, bson.M{"_id": po.Id}, bson.M{"$setOnInsert": po}, options.Update().SetUpsert(true))
+		return nil, err
+	})
+}
+
+// refundJoin hands back a buy-in the table did not take.
+func (st *holdemStore) refundJoin(ctx context.Context, d HoldemSeatDoc) bool {
+	po := st.payout(d.TableId, d.JoinId, d.UserId, d.BuyIn, "refund", "buy-in refund")
+	if err := st.closeSeat(d, &po); err != nil {
+		log.Printf("[holdem %s] join %s of %s not closed: %v", d.TableId, d.JoinId, d.UserId, err)
+	}
+	return st.pay(ctx, po)
+}
+
+// recoverSeat settles a sitting left open by an earlier run of the service:
+// a seat is cashed out for its stored stack, a join that may or may not have
+// been debited gets its debit sent again under the same key (the wallet
+// either returns the first one or takes it now) and is then refunded.
+func (st *holdemStore) recoverSeat(ctx context.Context, d HoldemSeatDoc) bool {
+	if d.Status == "seated" {
+		if d.Stack == 0 {
+			return st.closeSeat(d, nil) == nil
+		}
+		po := st.payout(d.TableId, d.JoinId, d.UserId, d.Stack, "win", "cash out")
+		if err := st.closeSeat(d, &po); err != nil {
+			log.Printf("[holdem %s] seat of %s not closed: %v", d.TableId, d.UserId, err)
+			return false
+		}
+		return st.pay(ctx, po)
+	}
+	cctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	_, err := st.wallet.UpdateBalance(cctx, &walletpb.WalletUpdateRequest{UserId: d.UserId, Amount: credits(-d.BuyIn), Type: "bet", Ref: d.TableId, IdempotencyKey: holdemKey(d.TableId, d.JoinId, d.UserId, "buyin"), CashOnly: true})
+	if status.Code(err) == codes.FailedPrecondition {
+		// the player can't cover it, so the buy-in was never taken
+		return st.closeSeat(d, nil) == nil
+	}
+	if err != nil {
+		log.Printf("[holdem %s] join %s of %s: re-debit: %v", d.TableId, d.JoinId, d.UserId, err)
+		return false
+	}
+	return st.refundJoin(ctx, d)
+}
+
+// recover gives back what hold'em owed when the service last stopped: seats
+// opened before started, and payouts still pending after a minute. It runs
+// every minute, like crashEngine.recoverPayouts.
+func (st *holdemStore) recover(ctx context.Context, started time.Time) {
+	for {
+		cur, err := st.seats.Find(ctx, bson.M{
+			"status":     bson.M{"$in": []string{"joining", "seated"}},
+			"created_at": bson.M{"$lt": started},
+		}, options.Find().SetLimit(1000))
+		var seats []HoldemSeatDoc
+		if err == nil {
+			err = cur.All(ctx, &seats)
+		}
+		if err != nil {
+			log.Printf("[holdem] recover seats: %v", err)
+		}
+		closed := 0
+		for _, d := range seats {
+			if st.recoverSeat(ctx, d) {
+				closed++
+			}
+		}
+		if len(seats) > 0 {
+			log.Printf("[holdem] closed %d of %d seats left from before the restart", closed, len(seats))
+		}
+
+		cur, err = st.payouts.Find(ctx, bson.M{
+			"status":     "pending",
+			"created_at": bson.M{"$lt": time.Now().UTC().Add(-time.Minute)},
+		}, options.Find().SetLimit(1000))
+		var docs []HoldemPayoutDoc
+		if err == nil {
+			err = cur.All(ctx, &docs)
+		}
+		if err != nil {
+			log.Printf("[holdem] recover payouts: %v", err)
+		}
+		paid := 0
+		for _, po := range docs {
+			if st.pay(ctx, po) {
+				paid++
+			}
+		}
+		if len(docs) > 0 {
+			log.Printf("[holdem] recovered %d of %d pending payouts", paid, len(docs))
+		}
+		if !sleepCtx(ctx, time.Minute) {
+			return
+		}
+	}
+}
+
+func cardsToStrings(cards []Card) []string {
+	out := make([]string, len(cards))
+	for i, c := range cards {
+		out[i] = cardToString(c)
+	}
+	return out
+}
+
+// view renders the table for one viewer: only their own hole cards, plus
+// every live hand once it was shown down.
+func (t *holdemTable) view(viewer string) *pb.HoldemTableState {
+	st := &pb.HoldemTableState{
+		TableId:    t.id,
+		HandNo:     t.handNo,
+		Street:     t.street,
+		Board:      cardsToStrings(t.board),
+		CurrentBet: t.currentBet,
+		MinRaise:   t.minRaise,
+		Winners:    t.winners,
+		Rake:       t.rake,
+		Spectators: t.spectators.Load(),
+	}
+	if t.button >= 0 {
+		st.Button = int32(t.button + 1)
+	}
+	if t.handActive() {
+		st.ToAct = int32(t.toAct + 1)
+		st.ActionDeadline = t.deadline.UnixMilli()
+	}
+	for i, p := range t.seats {
+		if p == nil {
+			continue
+		}
+		seat := &pb.HoldemSeat{
+			Seat:       int32(i + 1),
+			UserId:     p.UserId,
+			Stack:      p.Stack,
+			Bet:        p.Bet,
+			Folded:     p.Folded,
+			AllIn:      p.AllIn,
+			SittingOut: !p.InHand,
+			LastAction: p.LastAction,
+		}
+		if (viewer != "" && p.UserId == viewer) || (t.revealed && p.InHand && !p.Folded) {
+			seat.Cards = cardsToStrings(p.Hole)
+		}
+		st.Seats = append(st.Seats, seat)
+	}
+	pots := t.lastPots
+	if t.handActive() {
+		pots = t.buildPots()
+	}
+	for _, pot := range pots {
+		pp := &pb.HoldemPot{Amount: pot.Amount}
+		for _, i := range pot.Eligible {
+			pp.Seats = append(pp.Seats, int32(i+1))
+		}
+		st.Pots = append(st.Pots, pp)
+	}
+	return st
+}
+
+func (t *holdemTable) subscribe(userId string) *holdemSub {
+	sub := &holdemSub{userId: userId, ch: make(chan *pb.HoldemTableState, 8)}
+	t.subMu.Lock()
+	t.subs[sub] = struct{}{}
+	t.subMu.Unlock()
+	return sub
+}
+
+// spectate adds a watch-only stream, as long as the table's viewer limit allows.
+func (t *holdemTable) spectate() (*holdemSub, error) {
+	t.subMu.Lock()
+	defer t.subMu.Unlock()
+	if t.spectators.Load() >= t.cfg.MaxSpectators {
+		return nil, fmt.Errorf("table is full of spectators (%d)", t.cfg.MaxSpectators)
+	}
+	sub := &holdemSub{spectator: true, ch: make(chan *pb.HoldemTableState, 8)}
+	t.subs[sub] = struct{}{}
+	t.spectators.Add(1)
+	return sub, nil
+}
+
+func (t *holdemTable) unsubscribe(sub *holdemSub) {
+	t.subMu.Lock()
+	if _, ok := t.subs[sub]; ok && sub.spectator {
+		t.spectators.Add(-1)
+	}
+	delete(t.subs, sub)
+	t.subMu.Unlock()
+}
+
+// publish pushes a personalised snapshot to every watcher; slow ones skip updates.
+func (t *holdemTable) publish() {
+	n := int32(0)
+	for _, p := range t.seats {
+		if p != nil {
+			n++
+		}
+	}
+	t.seated.Store(n)
+
+	t.subMu.Lock()
+	defer t.subMu.Unlock()
+	for sub := range t.subs {
+		select {
+		case sub.ch <- t.view(sub.userId):
+		default:
+		}
+	}
+}
+
+func (s *gameServer) holdemTable(id string) (*holdemTable, error) {
+	t, ok := s.holdem[id]
+	if !ok {
+		return nil, fmt.Errorf("table not found")
+	}
+	return t, nil
+}
+
+func (s *gameServer) ListHoldemTables(ctx context.Context, _ *pb.ListHoldemTablesRequest) (*pb.ListHoldemTablesResponse, error) {
+	resp := &pb.ListHoldemTablesResponse{}
+	for _, ht := range holdemTables {
+		t := s.holdem[ht.Id]
+		resp.Tables = append(resp.Tables, &pb.HoldemTableInfo{
+			TableId:     t.id,
+			SmallBlind:  t.cfg.SmallBlind,
+			BigBlind:    t.cfg.BigBlind,
+			MinBuyIn:    t.cfg.MinBuyIn,
+			MaxBuyIn:    t.cfg.MaxBuyIn,
+			Seats:       int32(t.cfg.Seats),
+			Players:     t.seated.Load(),
+			RakePercent: t.cfg.RakePercent,
+			RakeCap:     t.cfg.RakeCap,
+
+			Spectators:    t.spectators.Load(),
+			MaxSpectators: t.cfg.MaxSpectators,
+		})
+	}
+	return resp, nil
+}
+
+func (s *gameServer) HoldemJoin(ctx context.Context, req *pb.HoldemJoinRequest) (*pb.HoldemJoinResponse, error) {
+	t, err := s.holdemTable(req.TableId)
+	if err != nil {
+		return nil, err
+	}
+	if req.UserId == "" {
+		return nil, fmt.Errorf("user_id required")
+	}
+	if isDemo(req.UserId) {
+		return nil, errDemoPvP
+	}
+	if req.BuyIn < t.cfg.MinBuyIn || req.BuyIn > t.cfg.MaxBuyIn {
+		return nil, fmt.Errorf("buy-in must be between %d and %d", t.cfg.MinBuyIn, t.cfg.MaxBuyIn)
+	}
+	if req.JoinId == "" {
+		return nil, fmt.Errorf("join_id required")
+	}
+
+	// the join is on record before any money moves, see HoldemSeatDoc
+	now := time.Now().UTC()
+	d, err := t.store.startSeat(ctx, HoldemSeatDoc{
+		Id:        holdemSeatId(t.id, req.JoinId, req.UserId),
+		TableId:   t.id,
+		UserId:    req.UserId,
+		JoinId:    req.JoinId,
+		BuyIn:     req.BuyIn,
+		Status:    "joining",
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if d.Status == "left" {
+		return nil, fmt.Errorf("this join is over, join again with a new join_id")
+	}
+	wr, err := s.wallet.UpdateBalance(ctx, &walletpb.WalletUpdateRequest{UserId: req.UserId, Amount: credits(-req.BuyIn), Type: "bet", Ref: t.id, IdempotencyKey: holdemKey(t.id, req.JoinId, req.UserId, "buyin"), CashOnly: true})
+	if err != nil {
+		// nothing was taken; any other error leaves the join open for a
+		// retry, or for recover after a restart
+		if status.Code(err) == codes.FailedPrecondition && d.Status == "joining" {
+			if cerr := t.store.closeSeat(d, nil); cerr != nil {
+				log.Printf("[holdem %s] join %s of %s not closed: %v", t.id, req.JoinId, req.UserId, cerr)
+			}
+		}
+		return nil, err
+	}
+	rep := t.do(ctx, holdemCmd{kind: "join", userId: req.UserId, joinId: req.JoinId, rejoin: d.Status == "seated", seat: int(req.Seat), buyIn: req.BuyIn})
+	if rep.err != nil {
+		if d.Status == "joining" {
+			t.store.refundJoin(context.Background(), d)
+		}
+		return nil, rep.err
+	}
+	return &pb.HoldemJoinResponse{
+		TableId: t.id,
+		Seat:    int32(rep.seat),
+		Stack:   rep.stack,
+		Balance: toCredits(wr.NewBalance),
+	}, nil
+}
+
+func (s *gameServer) HoldemAct(ctx context.Context, req *pb.HoldemActRequest) (*pb.HoldemTableState, error) {
+	t, err := s.holdemTable(req.TableId)
+	if err != nil {
+		return nil, err
+	}
+	rep := t.do(ctx, holdemCmd{kind: "act", userId: req.UserId, action: req.Action, amount: req.Amount})
+	return rep.state, rep.err
+}
+
+func (s *gameServer) HoldemLeave(ctx context.Context, req *pb.HoldemLeaveRequest) (*pb.HoldemLeaveResponse, error) {
+	t, err := s.holdemTable(req.TableId)
+	if err != nil {
+		return nil, err
+	}
+	rep := t.do(ctx, holdemCmd{kind: "leave", userId: req.UserId})
+	if rep.err != nil {
+		return nil, rep.err
+	}
+	if rep.payout != nil {
+		t.store.pay(ctx, *rep.payout)
+	}
+	return &pb.HoldemLeaveResponse{CashedOut: rep.cashed, Pending: rep.pending}, nil
+}
+
+// WatchHoldem streams a seated player's view of the table, hole cards
+// included. Anyone else gets the spectator view.
+func (s *gameServer) WatchHoldem(req *pb.WatchHoldemRequest, stream pb.GameService_WatchHoldemServer) error {
+	t, err := s.holdemTable(req.TableId)
+	if err != nil {
+		return err
+	}
+	if rep := t.do(stream.Context(), holdemCmd{kind: "seated", userId: req.UserId}); rep.err != nil {
+		// no seat: the public view, counted against the table's viewer limit
+		return s.SpectateHoldem(&pb.SpectateHoldemRequest{TableId: req.TableId, UserId: req.UserId}, stream)
+	}
+	sub := t.subscribe(req.UserId)
+	defer t.unsubscribe(sub)
+	return t.stream(sub, stream)
+}
+
+// SpectateHoldem streams the public view of a table to someone without a seat.
+// Spectators have no command path into the table, so they can't act.
+func (s *gameServer) SpectateHoldem(req *pb.SpectateHoldemRequest, stream pb.GameService_SpectateHoldemServer) error {
+	t, err := s.holdemTable(req.TableId)
+	if err != nil {
+		return err
+	}
+	sub, err := t.spectate()
+	if err != nil {
+		return err
+	}
+	defer t.unsubscribe(sub)
+	log.Printf("[holdem] %s: spectator %q watching", t.id, req.UserId)
+	return t.stream(sub, stream)
+}
+
+type holdemStream interface {
+	Send(*pb.HoldemTableState) error
+	Context() context.Context
+}
+
+// stream sends the first frame straight away, then every change.
+func (t *holdemTable) stream(sub *holdemSub, stream holdemStream) error {
+	rep := t.do(stream.Context(), holdemCmd{kind: "view", userId: sub.userId})
+	if rep.err != nil {
+		return rep.err
+	}
+	if err := stream.Send(rep.state); err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case st := <-sub.ch:
+			if err := stream.Send(st); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"sort"
+	"strconv"
+)
+
+// hand categories, weakest first
+const (
+	highCard = iota
+	onePair
+	twoPair
+	threeOfAKind
+	straight
+	flush
+	fullHouse
+	fourOfAKind
+	straightFlush
+)
+
+var handNames = []string{
+	"high card", "pair", "two pair", "three of a kind", "straight",
+	"flush", "full house", "four of a kind", "straight flush",
+}
+
+// pokerRank maps a card rank to 2..14 (ace high).
+func pokerRank(c Card) int {
+	switch c.Rank {
+	case "A":
+		return 14
+	case "K":
+		return 13
+	case "Q":
+		return 12
+	case "J":
+		return 11
+	default:
+		v, _ := strconv.Atoi(c.Rank)
+		return v
+	}
+}
+
+// handScore orders hands: a higher score always wins, equal scores split.
+// Layout: category<<20 | five 4-bit tie-breakers.
+type handScore uint32
+
+func (h handScore) Category() int { return int(h >> 20) }
+
+func (h handScore) Name() string { return handNames[h.Category()] }
+
+func makeScore(cat int, kickers ...int) handScore {
+	s := uint32(cat) << 20
+	for i := 0; i < len(kickers) && i < 5; i++ {
+		s |= uint32(kickers[i]) << (16 - 4*i)
+	}
+	return handScore(s)
+}
+
+// scoreFive ranks exactly five cards.
+func scoreFive(cards []Card) handScore {
+	ranks := make([]int, 5)
+	suited := true
+	for i, c := range cards {
+		ranks[i] = pokerRank(c)
+		if c.Suit != cards[0].Suit {
+			suited = false
+		}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(ranks)))
+
+	// straight, including the wheel A-2-3-4-5
+	isStraight, high := true, ranks[0]
+	for i := 1; i < 5; i++ {
+		if ranks[i] != ranks[i-1]-1 {
+			isStraight = false
+			break
+		}
+	}
+	if !isStraight && ranks[0] == 14 && ranks[1] == 5 && ranks[2] == 4 && ranks[3] == 3 && ranks[4] == 2 {
+		isStraight, high = true, 5
+	}
+
+	// group by rank: bigger groups first, then higher rank
+	counts := map[int]int{}
+	for _, r := range ranks {
+		counts[r]++
+	}
+	groups := make([]int, 0, len(counts))
+	for r := range counts {
+		groups = append(groups, r)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if counts[groups[i]] != counts[groups[j]] {
+			return counts[groups[i]] > counts[groups[j]]
+		}
+		return groups[i] > groups[j]
+	})
+
+	switch {
+	case isStraight && suited:
+		return makeScore(straightFlush, high)
+	case counts[groups[0]] == 4:
+		return makeScore(fourOfAKind, groups...)
+	case counts[groups[0]] == 3 && counts[groups[1]] == 2:
+		return makeScore(fullHouse, groups...)
+	case suited:
+		return makeScore(flush, ranks...)
+	case isStraight:
+		return makeScore(straight, high)
+	case counts[groups[0]] == 3:
+		return makeScore(threeOfAKind, groups...)
+	case counts[groups[0]] == 2 && counts[groups[1]] == 2:
+		return makeScore(twoPair, groups...)
+	case counts[groups[0]] == 2:
+		return makeScore(onePair, groups...)
+	}
+	return makeScore(highCard, ranks...)
+}
+
+// bestHand picks the strongest five-card hand out of hole + board (5 to 7 cards).
+func bestHand(cards []Card) (handScore, []Card) {
+	var best handScore
+	var bestCards []Card
+	n := len(cards)
+	pick := make([]Card, 5)
+	for a := 0; a < n; a++ {
+		for b := a + 1; b < n; b++ {
+			for c := b + 1; c < n; c++ {
+				for d := c + 1; d < n; d++ {
+					for e := d + 1; e < n; e++ {
+						pick[0], pick[1], pick[2], pick[3], pick[4] = cards[a], cards[b], cards[c], cards[d], cards[e]
+						if s := scoreFive(pick); bestCards == nil || s > best {
+							best = s
+							bestCards = append([]Card(nil), pick...)
+						}
+					}
+				}
+			}
+		}
+	}
+	return best, bestCards
+}
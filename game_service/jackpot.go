@@ -0,0 +1,329 @@
+package main
+
+import (
+	"context"
+	crand "crypto/rand"
+	"fmt"
+	"log"
+	"math/big"
+	"time"
+
+	catalogpb "github.com/Arsencchikkk/final/casino/proto/catalog"
+	jackpotpb "github.com/Arsencchikkk/final/casino/proto/jackpot"
+	walletpb "github.com/Arsencchikkk/final/casino/proto/wallet"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// contributeJackpot feeds a confirmed stake into the progressive jackpot.
+// It runs in the background and only logs failures: the round itself never
+// depends on the jackpot service being up. The pool is kept in the main
+// currency, so only main-currency rounds feed it (and win it).
+func contributeJackpot(jp jackpotpb.JackpotServiceClient, game, userId, roundId, currency string, stake int32) {
+	if jp == nil || stake <= 0 || isDemo(userId) || currency != "" {
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if _, err := jp.Contribute(ctx, &jackpotpb.ContributeRequest{Game: game, UserId: userId, RoundId: roundId, Stake: stake}); err != nil {
+			log.Printf("[jackpot] contribute %d from %s/%s: %v", stake, game, roundId, err)
+		}
+	}()
+}
+
+// JackpotClaimDoc is a jackpot claim the jackpot service didn't accept. The
+// round is already settled, so retryJackpotClaims keeps sending it until it
+// goes through.
+type JackpotClaimDoc struct {
+	Id        string    `bson:"_id"` // game:round_id, like the jackpot service's own key
+	Game      string    `bson:"game"`
+	UserId    string    `bson:"user_id"`
+	RoundId   string    `bson:"round_id"`
+	Trigger   string    `bson:"trigger"`
+	Stake     int32     `bson:"stake"`
+	Status    string    `bson:"status"` // "pending", "claimed"
+	Amount    int32     `bson:"amount,omitempty"`
+	Error     string    `bson:"error,omitempty"`
+	CreatedAt time.Time `bson:"created_at"`
+}
+
+// claimJackpot asks the jackpot service to pay the pool for a triggering round
+// and returns the amount won. Claims are keyed by game and round, so calling
+// it again for the same round is safe; a failed one is stored and retried.
+func (s *gameServer) claimJackpot(ctx context.Context, game, userId, roundId, currency, trigger string, stake int32) int32 {
+	if s.jackpot == nil || isDemo(userId) || currency != "" {
+		return 0
+	}
+	w, err := s.jackpot.Claim(ctx, &jackpotpb.ClaimRequest{Game: game, UserId: userId, RoundId: roundId, Trigger: trigger, Stake: stake})
+	if err != nil {
+		log.Printf("[jackpot] claim %s for %s/%s: %v, will retry", trigger, game, roundId, err)
+		c := JackpotClaimDoc{
+			Id:        game + ":" + roundId,
+			Game:      game,
+			UserId:    userId,
+			RoundId:   roundId,
+			Trigger:   trigger,
+			Stake:     stake,
+			Status:    "pending",
+			Error:     err.Error(),
+			CreatedAt: time.Now().UTC(),
+		}
+		if s.jackpotClaims == nil {
+			return 0
+		}
+		if _, err := s.jackpotClaims.UpdateOne(context.Background(), bson.M{"_id": c.Id}, bson.M{"$setOnInsert": c}, options.Update().SetUpsert(true)); err != nil {
+			log.Printf("[jackpot] cannot store claim %s: %v", c.Id, err)
+		}
+		return 0
+	}
+	if w.WinId == "" {
+		// the stake is below the jackpot minimum
+		return 0
+	}
+	log.Printf("[jackpot] %s won %d on %s/%s (%s)", userId, w.Amount, game, roundId, w.Status)
+	return w.Amount
+}
+
+// retryJackpotClaims resends stored claims every minute until the jackpot
+// service accepts them.
+func (s *gameServer) retryJackpotClaims(ctx context.Context) {
+	for {
+		cur, err := s.jackpotClaims.Find(ctx, bson.M{"status": "pending"}, options.Find().SetLimit(100))
+		var claims []JackpotClaimDoc
+		if err == nil {
+			err = cur.All(ctx, &claims)
+		}
+		if err != nil {
+			log.Printf("[jackpot] retry claims: %v", err)
+		}
+		for _, c := range claims {
+			cctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+			w, err := s.jackpot.Claim(cctx, &jackpotpb.ClaimRequest{Game: c.Game, UserId: c.UserId, RoundId: c.RoundId, Trigger: c.Trigger, Stake: c.Stake})
+			cancel()
+			if err != nil {
+				log.Printf("[jackpot] retry claim %s: %v", c.Id, err)
+				s.jackpotClaims.UpdateOne(ctx, bson.M{"_id": c.Id}, bson.M{"$set": bson.M{"error": err.Error()}})
+				continue
+			}
+			log.Printf("[jackpot] %s won %d on %s (retried, %s)", c.UserId, w.Amount, c.Id, w.Status)
+			if _, err := s.jackpotClaims.UpdateOne(ctx, bson.M{"_id": c.Id}, bson.M{"$set": bson.M{"status": "claimed", "amount": w.Amount}, "$unset": bson.M{"error": ""}}); err != nil {
+				log.Printf("[jackpot] claim %s accepted but not marked: %v", c.Id, err)
+			}
+		}
+		if !sleepCtx(ctx, time.Minute) {
+			return
+		}
+	}
+}
+
+// blackjackJackpot reports whether the player's hand is the suited 7-7-7
+// jackpot hand.
+func blackjackJackpot(hand []Card) bool {
+	if len(hand) != 3 {
+		return false
+	}
+	for _, c := range hand {
+		if c.Rank != "7" || c.Suit != hand[0].Suit {
+			return false
+		}
+	}
+	return true
+}
+
+// --- slots ---
+
+// slotsGame is the real-money version of the tournament slot machine. A spin
+// settles immediately, so there is nothing to act on or settle afterwards.
+type slotsGame struct{ s *gameServer }
+
+// slotsRtp is the reels' own return, 31316/32768; the jackpot comes on top.
+const slotsRtp = 0.9557
+
+// Three sevens spin the jackpot reel, which has one JACKPOT stop among
+// slotJackpotStops. Together with 27/32768 for the sevens that is about one
+// spin in 1.24 million.
+const slotJackpotStops = 1024
+
+func (g slotsGame) Info() *catalogpb.GameInfo {
+	return &catalogpb.GameInfo{
+		GameId:      "slots",
+		Name:        "Slots",
+		Description: "Three reels: three of a kind pays up to 100x, two cherries pay 2x. Three sevens spin the jackpot reel for the progressive jackpot.",
+		Kind:        "instant",
+		Limits:      &catalogpb.BetLimits{MinStake: 1, MaxStake: 1000, MaxPayout: 100000},
+		Rtp:         slotsRtp + g.s.jackpotRtp,
+	}
+}
+
+// spinJackpotReel reports whether the jackpot reel stopped on JACKPOT.
+func spinJackpotReel() (bool, error) {
+	n, err := crand.Int(crand.Reader, big.NewInt(slotJackpotStops))
+	if err != nil {
+		return false, err
+	}
+	return n.Int64() == 0, nil
+}
+
+// SlotsSpinDoc is a spin with something to pay. It is stored before the win
+// is paid and dropped once the win, the jackpot claim and the audit record
+// have gone through, so a wallet outage or a restart doesn't lose the payout:
+// retrySlots pays what is left under the same keys.
+type SlotsSpinDoc struct {
+	Id        string    `bson:"_id"`
+	UserId    string    `bson:"user_id"`
+	Currency  string    `bson:"currency,omitempty"`
+	Stake     int32     `bson:"stake"`
+	Payout    int32     `bson:"payout"`
+	Reels     []string  `bson:"reels"`
+	Jackpot   bool      `bson:"jackpot_reel,omitempty"`
+	CreatedAt time.Time `bson:"created_at"`
+}
+
+func (d *SlotsSpinDoc) sevens() bool {
+	return d.Reels[0] == "SEVEN" && d.Reels[1] == "SEVEN" && d.Reels[2] == "SEVEN"
+}
+
+func (d *SlotsSpinDoc) result() *walletpb.GameResult {
+	var tags []string
+	if d.sevens() {
+		tags = append(tags, "777")
+	}
+	if d.Jackpot {
+		tags = append(tags, "jackpot")
+	}
+	return &walletpb.GameResult{
+		UserId:  d.UserId,
+		Game:    "slots",
+		RoundId: d.Id,
+		Stake:   creditsIn(d.Stake, d.Currency),
+		Payout:  creditsIn(d.Payout, d.Currency),
+		Tags:    tags,
+		// the RTP monitor sees the reels' payout only, the jackpot is paid apart
+		Rtp: slotsRtp,
+		// three of a kind pays at most 100x
+		Liability: creditsIn(d.Stake*100, d.Currency),
+	}
+}
+
+func (g slotsGame) Start(ctx context.Context, req *catalogpb.StartRequest) (*catalogpb.RoundState, error) {
+	id := uuid.New().String()
+	cur := roundCurrency(req.Currency)
+	wr, err := g.s.wallet.UpdateBalance(ctx, &walletpb.WalletUpdateRequest{UserId: req.UserId, Amount: creditsIn(-req.Stake, cur), Type: "bet", Ref: id, IdempotencyKey: "slots:" + id + ":bet"})
+	if err != nil {
+		return nil, err
+	}
+
+	d := &SlotsSpinDoc{Id: id, UserId: req.UserId, Currency: cur, Stake: req.Stake, CreatedAt: time.Now().UTC()}
+	d.Reels, d.Payout, err = spinSlots(req.Stake)
+	if err == nil && d.sevens() {
+		d.Jackpot, err = spinJackpotReel()
+	}
+	if err == nil && (d.Payout > 0 || d.Jackpot) {
+		// the win is stored before anything is paid
+		_, err = g.s.slots.InsertOne(ctx, d)
+	}
+	if err != nil {
+		// the stake is already taken, so give it back
+		if _, rerr := g.s.wallet.UpdateBalance(context.Background(), &walletpb.WalletUpdateRequest{UserId: req.UserId, Amount: creditsIn(req.Stake, cur), Type: "refund", Ref: id, IdempotencyKey: "slots:" + id + ":refund"}); rerr != nil {
+			log.Printf("[slots] refund %d to %s failed: %v", req.Stake, req.UserId, rerr)
+		}
+		return nil, err
+	}
+	contributeJackpot(g.s.jackpot, "slots", req.UserId, id, cur, req.Stake)
+
+	rs := &catalogpb.RoundState{
+		RoundId:  id,
+		Status:   "finished",
+		Finished: true,
+		Stake:    req.Stake,
+		Payout:   d.Payout,
+		Balance:  toCredits(wr.NewBalance),
+		Currency: cur,
+		StateJson: stateJSON(struct {
+			Reels   []string `json:"reels"`
+			Jackpot bool     `json:"jackpot_reel,omitempty"`
+		}{d.Reels, d.Jackpot}),
+	}
+	if d.Payout == 0 && !d.Jackpot {
+		if err := g.s.results.record(ctx, d.result()); err != nil {
+			log.Printf("[slots] round %s: result not recorded: %v", id, err)
+		}
+		return rs, nil
+	}
+	paid, jackpot, err := g.s.paySpin(ctx, d)
+	if err != nil {
+		// the spin is stored, retrySlots pays it
+		return nil, err
+	}
+	if paid != nil {
+		rs.Balance = toCredits(paid.NewBalance)
+	}
+	if rs.Jackpot = jackpot; jackpot > 0 {
+		rs.Balance += jackpot
+	}
+	return rs, nil
+}
+
+// paySpin pays a stored spin under slots:<id>:win, claims the jackpot for it,
+// records the round and drops the spin. Every step is keyed by the spin, so
+// it can be repeated until it goes through.
+func (s *gameServer) paySpin(ctx context.Context, d *SlotsSpinDoc) (*walletpb.WalletUpdateResponse, int32, error) {
+	var wr *walletpb.WalletUpdateResponse
+	if d.Payout > 0 {
+		var err error
+		if wr, err = s.wallet.UpdateBalance(ctx, &walletpb.WalletUpdateRequest{UserId: d.UserId, Amount: creditsIn(d.Payout, d.Currency), Type: "win", Ref: d.Id, IdempotencyKey: "slots:" + d.Id + ":win"}); err != nil {
+			log.Printf("[slots] payout %d to %s for %s failed: %v", d.Payout, d.UserId, d.Id, err)
+			return nil, 0, err
+		}
+	}
+	var jackpot int32
+	if d.Jackpot {
+		// a claim the jackpot service misses is stored and retried by retryJackpotClaims
+		jackpot = s.claimJackpot(ctx, "slots", d.UserId, d.Id, d.Currency, "777", d.Stake)
+	}
+	if err := s.results.record(ctx, d.result()); err != nil {
+		log.Printf("[slots] spin %s paid, result not recorded: %v", d.Id, err)
+		return nil, 0, err
+	}
+	if _, err := s.slots.DeleteOne(ctx, bson.M{"_id": d.Id}); err != nil {
+		// paid again under the same keys, which changes nothing
+		log.Printf("[slots] spin %s paid but not dropped: %v", d.Id, err)
+	}
+	return wr, jackpot, nil
+}
+
+// retrySlots pays spins left unpaid by a wallet outage or a restart, at
+// startup and then every minute. Spins younger than a minute may still be
+// in Start.
+func (s *gameServer) retrySlots(ctx context.Context) {
+	for {
+		cur, err := s.slots.Find(ctx,
+			bson.M{"created_at": bson.M{"$lt": time.Now().Add(-time.Minute)}},
+			options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}).SetLimit(100))
+		var spins []SlotsSpinDoc
+		if err == nil {
+			err = cur.All(ctx, &spins)
+		}
+		if err != nil {
+			log.Printf("[slots] retry: %v", err)
+		}
+		for i := range spins {
+			if _, _, err := s.paySpin(ctx, &spins[i]); err != nil {
+				log.Printf("[slots] retry %s: %v", spins[i].Id, err)
+			}
+		}
+		if !sleepCtx(ctx, time.Minute) {
+			return
+		}
+	}
+}
+
+func (slotsGame) Act(ctx context.Context, req *catalogpb.ActRequest) (*catalogpb.RoundState, error) {
+	return nil, fmt.Errorf("slots rounds settle on the spin")
+}
+
+func (slotsGame) Settle(ctx context.Context, req *catalogpb.SettleRequest) (*catalogpb.RoundState, error) {
+	return nil, fmt.Errorf("slots rounds settle on the spin")
+}
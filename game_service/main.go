@@ -6,12 +6,21 @@ import (
 	"log"
 	"math/rand"
 	"net"
+	"os"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
+	catalogpb "github.com/Arsencchikkk/final/casino/proto/catalog"
 	pb "github.com/Arsencchikkk/final/casino/proto/game"
+	jackpotpb "github.com/Arsencchikkk/final/casino/proto/jackpot"
+	walletpb "github.com/Arsencchikkk/final/casino/proto/wallet"
 	"github.com/google/uuid"
+	"github.com/joho/godotenv"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 	"google.golang.org/grpc"
 )
 
@@ -37,12 +46,23 @@ func cardToString(c Card) string {
 }
 
 func newDeck() []Card {
+	return newShoe(1)
+}
+
+// blackjackDecks is the size of the blackjack shoe; with more than one deck a
+// suited 7-7-7 (the jackpot hand) can be dealt.
+const blackjackDecks = 6
+
+// newShoe shuffles the given number of 52-card decks together.
+func newShoe(decks int) []Card {
 	suits := []string{"Hearts", "Diamonds", "Clubs", "Spades"}
 	ranks := []string{"2", "3", "4", "5", "6", "7", "8", "9", "10", "J", "Q", "K", "A"}
-	deck := make([]Card, 0, 52)
-	for _, s := range suits {
-		for _, r := range ranks {
-			deck = append(deck, Card{Rank: r, Suit: s})
+	deck := make([]Card, 0, 52*decks)
+	for i := 0; i < decks; i++ {
+		for _, s := range suits {
+			for _, r := range ranks {
+				deck = append(deck, Card{Rank: r, Suit: s})
+			}
 		}
 	}
 	rand.Seed(time.Now().UnixNano())
@@ -73,6 +93,14 @@ type GameSession struct {
 	PlayerHand []Card
 	DealerHand []Card
 	State      string // "playerTurn", "dealerTurn", "finished"
+
+	// set when the hand is played with a stake
+	UserId   string
+	Stake    int32
+	Currency string // "" for the main currency
+	// the wallet hold on the stake, captured when the hand settles
+	HoldId  string
+	Settled bool
 }
 
 var (
@@ -81,7 +109,7 @@ var (
 )
 
 func newSession() string {
-	d := newDeck()
+	d := newShoe(blackjackDecks)
 	// deal: player 0, dealer 1, player 2, dealer 3
 	player := []Card{d[0], d[2]}
 	dealer := []Card{d[1], d[3]}
@@ -106,121 +134,362 @@ func dealerPlay(s *GameSession) {
 	s.State = "finished"
 }
 
+// playerHit deals one card while it's the player's turn; a bust ends the hand.
+func playerHit(s *GameSession) {
+	if s.State == "playerTurn" && len(s.Deck) > 0 {
+		s.PlayerHand = append(s.PlayerHand, s.Deck[0])
+		s.Deck = s.Deck[1:]
+	}
+	if handValue(s.PlayerHand) > 21 {
+		s.State = "finished"
+	}
+}
+
+// handOutcome is "win", "lose" or "push" once the dealer has played.
+func handOutcome(s *GameSession) string {
+	dTotal := handValue(s.DealerHand)
+	pTotal := handValue(s.PlayerHand)
+	switch {
+	case pTotal > 21:
+		return "lose"
+	case dTotal > 21 || pTotal > dTotal:
+		return "win"
+	case pTotal < dTotal:
+		return "lose"
+	}
+	return "push"
+}
+
 type gameServer struct {
 	pb.UnimplementedGameServiceServer
+	wallet walletpb.WalletServiceClient
+	crash  *crashEngine
+	holdem map[string]*holdemTable
+	mines  *mongo.Collection
+	// slots spins whose win isn't paid yet
+	slots *mongo.Collection
+
+	// progressive jackpot shared with the other game services; nil disables it
+	jackpot jackpotpb.JackpotServiceClient
+	// claims the jackpot service didn't accept yet
+	jackpotClaims *mongo.Collection
+	// the jackpot's share of the published RTP: over time the pool pays back
+	// what it takes from stakes (JACKPOT_RATE_BP, as set for the jackpot service)
+	jackpotRtp float64
+	// settled rounds on their way to the wallet's audit log
+	results *resultOutbox
+
+	tournaments *mongo.Collection
+	entries     *mongo.Collection
+}
+
+// Demo players ("demo:" user ids) have a virtual wallet of their own. They may
+// play any house-banked game, but never against real players' money: hold'em
+// tables and tournaments pay one player from another's stake, and the jackpot
+// is funded by real stakes.
+const demoPrefix = "demo:"
+
+var errDemoPvP = fmt.Errorf("not available in demo mode, create an account to play with real players")
+
+func isDemo(userId string) bool {
+	return strings.HasPrefix(userId, demoPrefix)
 }
 
-func (s *gameServer) NewGame(ctx context.Context, _ *pb.NewGameRequest) (*pb.NewGameResponse, error) {
+// legacyStake is what the legacy blackjack (/new_game … /stand) deals for, in
+// credits of the main currency.
+const legacyStake = 100
+
+// blackjackHoldTTL is how long a hand may stay open, in seconds. Hands live in
+// memory only: after a restart nobody settles them, and the wallet releases
+// the stake once the hold runs out.
+const blackjackHoldTTL = 3600
+
+// dealHand deals a blackjack hand and holds its stake in the wallet; the hold
+// is captured when the hand settles. A player who can't cover the stake gets
+// FailedPrecondition from the wallet and no hand at all.
+func (s *gameServer) dealHand(ctx context.Context, userId string, stake int32, currency string) (string, *walletpb.HoldResponse, error) {
 	id := newSession()
+	hr, err := s.wallet.Reserve(ctx, &walletpb.ReserveRequest{UserId: userId, Amount: creditsIn(stake, currency), RoundId: id, TtlSeconds: blackjackHoldTTL, IdempotencyKey: "blackjack:" + id + ":bet"})
+	if err != nil {
+		sessMu.Lock()
+		delete(sessions, id)
+		sessMu.Unlock()
+		return "", nil, err
+	}
+	sessMu.Lock()
+	sess := sessions[id]
+	sess.UserId, sess.Stake, sess.Currency, sess.HoldId = userId, stake, currency, hr.Hold.GetHoldId()
+	sessMu.Unlock()
+	return id, hr, nil
+}
 
-	sessMu.RLock()
-	session := sessions[id]
-	sessMu.RUnlock()
+// NewGame deals a legacy hand for legacyStake, see dealHand.
+func (s *gameServer) NewGame(ctx context.Context, req *pb.NewGameRequest) (*pb.NewGameResponse, error) {
+	if req.UserId == "" {
+		return nil, fmt.Errorf("user_id is required")
+	}
+	id, hr, err := s.dealHand(ctx, req.UserId, legacyStake, "")
+	if err != nil {
+		return nil, err
+	}
 
+	sessMu.Lock()
+	session := sessions[id]
 	// player cards
-	pc := make([]string, len(session.PlayerHand))
-	for i, c := range session.PlayerHand {
-		pc[i] = cardToString(c)
-	}
+	pc := cardsToStrings(session.PlayerHand)
 	// dealer cards (both, from the start)
-	dc := make([]string, len(session.DealerHand))
-	for i, c := range session.DealerHand {
-		dc[i] = cardToString(c)
-	}
+	dc := cardsToStrings(session.DealerHand)
+	total := handValue(session.PlayerHand)
+	sessMu.Unlock()
+	contributeJackpot(s.jackpot, "blackjack", req.UserId, id, "", legacyStake)
 
 	return &pb.NewGameResponse{
 		SessionId:   id,
 		PlayerCards: pc,
 		DealerCards: dc,
-		PlayerTotal: int32(handValue(session.PlayerHand)),
-		Balance:     0, // stub; plug in wallet call if you like
+		PlayerTotal: int32(total),
+		Balance:     toCredits(hr.Balance),
 	}, nil
 }
 
+// Hit deals the player a card; a bust settles the hand as a loss.
 func (s *gameServer) Hit(ctx context.Context, req *pb.HitRequest) (*pb.HitResponse, error) {
+	bj := blackjackGame{s}
 	sessMu.Lock()
-	defer sessMu.Unlock()
-
-	session, ok := sessions[req.SessionId]
-	if !ok {
-		return nil, fmt.Errorf("session not found")
+	session, err := bj.session(req.UserId, req.SessionId)
+	if err != nil {
+		sessMu.Unlock()
+		return nil, err
 	}
 	// Only allow hits while in “playerTurn”
-	if session.State == "playerTurn" && len(session.Deck) > 0 {
-		session.PlayerHand = append(session.PlayerHand, session.Deck[0])
-		session.Deck = session.Deck[1:]
-	}
+	playerHit(session)
 
 	val := handValue(session.PlayerHand)
-	finished := false
-	if val > 21 {
-		session.State = "finished"
-		finished = true
-	}
-
-	pc := make([]string, len(session.PlayerHand))
-	for i, c := range session.PlayerHand {
-		pc[i] = cardToString(c)
-	}
+	finished := session.State == "finished"
+	pc := cardsToStrings(session.PlayerHand)
+	sessMu.Unlock()
 
-	return &pb.HitResponse{
+	resp := &pb.HitResponse{
 		PlayerCards: pc,
 		PlayerTotal: int32(val),
 		Finished:    finished,
-		Balance:     0,
-	}, nil
+	}
+	if finished {
+		rs, err := bj.settle(ctx, req.UserId, req.SessionId)
+		if err != nil {
+			return nil, err
+		}
+		resp.Balance = rs.Balance
+	}
+	return resp, nil
 }
 
+// Stand plays the dealer out and settles the hand through the same path as
+// catalog blackjack, so the win is paid once under blackjack:<id>:win.
 func (s *gameServer) Stand(ctx context.Context, req *pb.StandRequest) (*pb.StandResponse, error) {
+	bj := blackjackGame{s}
 	sessMu.Lock()
-	session, ok := sessions[req.SessionId]
-	if !ok {
+	session, err := bj.session(req.UserId, req.SessionId)
+	if err != nil {
 		sessMu.Unlock()
-		return nil, fmt.Errorf("session not found")
+		return nil, err
 	}
 	// If still in player turn, run dealer
 	if session.State == "playerTurn" {
 		session.State = "dealerTurn"
 		dealerPlay(session)
 	}
-
-	// dealer cards & totals
-	dc := make([]string, len(session.DealerHand))
-	for i, c := range session.DealerHand {
-		dc[i] = cardToString(c)
-	}
+	dc := cardsToStrings(session.DealerHand)
 	dTotal := handValue(session.DealerHand)
-	pTotal := handValue(session.PlayerHand)
+	outcome := handOutcome(session)
+	sessMu.Unlock()
 
-	// decide outcome
-	outcome := "push"
-	switch {
-	case pTotal > 21:
-		outcome = "lose"
-	case dTotal > 21 || pTotal > dTotal:
-		outcome = "win"
-	case pTotal < dTotal:
-		outcome = "lose"
+	rs, err := bj.settle(ctx, req.UserId, req.SessionId)
+	if err != nil {
+		return nil, err
 	}
-
-	sessMu.Unlock()
 	return &pb.StandResponse{
 		DealerCards: dc,
 		DealerTotal: int32(dTotal),
 		Outcome:     outcome,
-		Balance:     0,
+		Balance:     rs.Balance,
 	}, nil
 }
 
 func main() {
+	_ = godotenv.Load()
+
+	// persistent sessions (mines) live in Mongo
+	mongoURI := os.Getenv("MONGO_URI")
+	mongoDB := os.Getenv("MONGO_DB")
+	if mongoURI == "" || mongoDB == "" {
+		log.Fatal("MONGO_URI and MONGO_DB must be set")
+	}
+	minesColName := os.Getenv("MONGO_MINES_COL")
+	if minesColName == "" {
+		minesColName = "mines_sessions"
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	mClient, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoURI))
+	if err != nil {
+		log.Fatalf("mongo connect error: %v", err)
+	}
+	minesCol := mClient.Database(mongoDB).Collection(minesColName)
+	if _, err := minesCol.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}},
+	}); err != nil {
+		log.Fatalf("mongo index error: %v", err)
+	}
+
+	db := mClient.Database(mongoDB)
+	tournamentsCol := db.Collection(envOr("MONGO_TOURNAMENTS_COL", "tournaments"))
+	if _, err := tournamentsCol.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "status", Value: 1}, {Key: "starts_at", Value: 1}},
+	}); err != nil {
+		log.Fatalf("mongo index error: %v", err)
+	}
+	entriesCol := db.Collection(envOr("MONGO_ENTRIES_COL", "tournament_entries"))
+	if _, err := entriesCol.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "tournament_id", Value: 1}, {Key: "chips", Value: -1}, {Key: "hands_played", Value: 1}, {Key: "joined_at", Value: 1}},
+	}); err != nil {
+		log.Fatalf("mongo index error: %v", err)
+	}
+	jackpotClaimsCol := db.Collection(envOr("MONGO_JACKPOT_CLAIMS_COL", "jackpot_claims"))
+	if _, err := jackpotClaimsCol.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "status", Value: 1}},
+	}); err != nil {
+		log.Fatalf("mongo index error: %v", err)
+	}
+	slotsCol := db.Collection(envOr("MONGO_SLOTS_SPINS_COL", "slots_spins"))
+	if _, err := slotsCol.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "created_at", Value: 1}},
+	}); err != nil {
+		log.Fatalf("mongo index error: %v", err)
+	}
+	crashPayoutsCol := db.Collection(envOr("MONGO_CRASH_PAYOUTS_COL", "crash_payouts"))
+	if _, err := crashPayoutsCol.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: 1}},
+	}); err != nil {
+		log.Fatalf("mongo index error: %v", err)
+	}
+	crashBetsCol := db.Collection(envOr("MONGO_CRASH_BETS_COL", "crash_bets"))
+	if _, err := crashBetsCol.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "status", Value: 1}, {Key: "round_id", Value: 1}},
+	}); err != nil {
+		log.Fatalf("mongo index error: %v", err)
+	}
+
+	walletAddr := os.Getenv("WALLET_SERVICE_ADDR")
+	if walletAddr == "" {
+		walletAddr = "localhost:50052"
+	}
+	wa, err := grpc.Dial(walletAddr, grpc.WithInsecure())
+	if err != nil {
+		log.Fatalf("cannot dial wallet service: %v", err)
+	}
+	wallet := walletpb.NewWalletServiceClient(wa)
+	ja, err := grpc.Dial(envOr("JACKPOT_SERVICE_ADDR", "localhost:50055"), grpc.WithInsecure())
+	if err != nil {
+		log.Fatalf("cannot dial jackpot service: %v", err)
+	}
+	jackpot := jackpotpb.NewJackpotServiceClient(ja)
+	resultsCol := db.Collection(envOr("MONGO_RESULTS_OUTBOX_COL", "results_outbox"))
+	if _, err := resultsCol.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "created_at", Value: 1}},
+	}); err != nil {
+		log.Fatalf("mongo index error: %v", err)
+	}
+	results := &resultOutbox{wallet: wallet, col: resultsCol}
+	go results.retryResults(context.Background())
+
+	crash := newCrashEngine(wallet, crashPayoutsCol, crashBetsCol)
+	crash.jackpot = jackpot
+	crash.results = results
+	go crash.run(context.Background())
+	go crash.recoverPayouts(context.Background())
+	go crash.recoverBets(context.Background())
+
+	// bets in other currencies go to the player's sub-wallet in that currency
+	mainCurrency = envOr("WALLET_CURRENCY", mainCurrency)
+
+	// rake from the poker tables goes to this wallet
+	house := os.Getenv("HOUSE_WALLET_ID")
+	if house == "" {
+		house = "house"
+	}
+	// hold'em seats, stacks and what the tables owe, see HoldemSeatDoc
+	holdemSeatsCol := db.Collection(envOr("MONGO_HOLDEM_SEATS_COL", "holdem_seats"))
+	if _, err := holdemSeatsCol.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: 1}},
+	}); err != nil {
+		log.Fatalf("mongo index error: %v", err)
+	}
+	holdemPayoutsCol := db.Collection(envOr("MONGO_HOLDEM_PAYOUTS_COL", "holdem_payouts"))
+	if _, err := holdemPayoutsCol.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: 1}},
+	}); err != nil {
+		log.Fatalf("mongo index error: %v", err)
+	}
+	holdemStore := &holdemStore{
+		wallet:  wallet,
+		seats:   holdemSeatsCol,
+		payouts: holdemPayoutsCol,
+		tables:  db.Collection(envOr("MONGO_HOLDEM_TABLES_COL", "holdem_tables")),
+	}
+	go holdemStore.recover(context.Background(), time.Now().UTC())
+	holdem := make(map[string]*holdemTable)
+	for _, ht := range holdemTables {
+		t := newHoldemTable(ht.Id, ht.Config, house, wallet, holdemStore)
+		t.results = results
+		if t.handNo, err = holdemStore.handNo(ctx, ht.Id); err != nil {
+			log.Fatalf("holdem table %s: %v", ht.Id, err)
+		}
+		holdem[ht.Id] = t
+		go t.run(context.Background())
+	}
+
 	lis, err := net.Listen("tcp", ":50051")
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
 	}
+	gs := &gameServer{
+		wallet:      wallet,
+		crash:       crash,
+		holdem:      holdem,
+		mines:       minesCol,
+		slots:       slotsCol,
+		tournaments: tournamentsCol,
+		entries:     entriesCol,
+		jackpot:     jackpot,
+
+		jackpotClaims: jackpotClaimsCol,
+		results:       results,
+	}
+	jackpotBp, err := strconv.Atoi(envOr("JACKPOT_RATE_BP", "100"))
+	if err != nil || jackpotBp < 0 || jackpotBp > 10000 {
+		log.Fatalf("JACKPOT_RATE_BP: bad value %q", os.Getenv("JACKPOT_RATE_BP"))
+	}
+	gs.jackpotRtp = float64(jackpotBp) / 10000
+	go gs.recoverMines(context.Background())
+	go gs.runTournaments(context.Background())
+	go gs.retryJackpotClaims(context.Background())
+	go gs.retrySlots(context.Background())
+
 	srv := grpc.NewServer()
-	pb.RegisterGameServiceServer(srv, &gameServer{})
+	pb.RegisterGameServiceServer(srv, gs)
+	catalogpb.RegisterGameProviderServer(srv, newProviderServer(
+		blackjackGame{gs}, minesGame{gs}, crashGame{crash}, holdemGame{gs}, slotsGame{gs},
+	))
 	log.Println("Game Service listening on :50051")
 	if err := srv.Serve(lis); err != nil {
 		log.Fatalf("serve error: %v", err)
 	}
 }
+
+func envOr(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
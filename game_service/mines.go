@@ -0,0 +1,368 @@
+package main
+
+import (
+	"context"
+	crand "crypto/rand"
+	"fmt"
+	"log"
+	"math"
+	"math/big"
+	"sort"
+	"time"
+
+	pb "github.com/Arsencchikkk/final/casino/proto/game"
+	walletpb "github.com/Arsencchikkk/final/casino/proto/wallet"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	minesMinGrid   = 3
+	minesMaxGrid   = 8
+	minesMinStake  = 1
+	minesMaxStake  = 10000
+	minesMaxPayout = 1000000
+	minesHouseEdge = 0.01
+)
+
+// MinesDoc is a mines session as stored in Mongo. Version guards every write,
+// so two concurrent requests can't both reveal or both cash out.
+type MinesDoc struct {
+	Id        string    `bson:"_id"`
+	UserId    string    `bson:"user_id"`
+	GridSize  int32     `bson:"grid_size"`
+	Mines     []int32   `bson:"mines"`
+	Revealed  []int32   `bson:"revealed"`
+	Stake     int32     `bson:"stake"`
+	Currency  string    `bson:"currency,omitempty"` // "" for the main currency
+	Status    string    `bson:"status"`             // "active", "lost", "cashing", "cashed"
+	Payout    int32     `bson:"payout"`
+	Version   int32     `bson:"version"`
+	CreatedAt time.Time `bson:"created_at"`
+	UpdatedAt time.Time `bson:"updated_at"`
+}
+
+// minesMultiplier is (1 - edge) / P(safe reveals in a row), where P follows the
+// hypergeometric distribution: prod (tiles-i)/(tiles-mines-i).
+func minesMultiplier(tiles, mines, safe int) float64 {
+	m := 1 - minesHouseEdge
+	for i := 0; i < safe; i++ {
+		m *= float64(tiles-i) / float64(tiles-mines-i)
+	}
+	return math.Floor(m*100) / 100
+}
+
+func minesPayout(stake int32, mult float64) int32 {
+	p := math.Floor(float64(stake) * mult)
+	if p > minesMaxPayout {
+		return minesMaxPayout
+	}
+	return int32(p)
+}
+
+func placeMines(tiles, mines int) ([]int32, error) {
+	all := make([]int32, tiles)
+	for i := range all {
+		all[i] = int32(i)
+	}
+	for i := 0; i < mines; i++ {
+		j, err := crand.Int(crand.Reader, big.NewInt(int64(tiles-i)))
+		if err != nil {
+			return nil, err
+		}
+		k := i + int(j.Int64())
+		all[i], all[k] = all[k], all[i]
+	}
+	out := append([]int32(nil), all[:mines]...)
+	sort.Slice(out, func(a, b int) bool { return out[a] < out[b] })
+	return out, nil
+}
+
+func (d *MinesDoc) tiles() int { return int(d.GridSize * d.GridSize) }
+
+func (d *MinesDoc) isMine(tile int32) bool {
+	for _, m := range d.Mines {
+		if m == tile {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *MinesDoc) isRevealed(tile int32) bool {
+	for _, r := range d.Revealed {
+		if r == tile {
+			return true
+		}
+	}
+	return false
+}
+
+// safeRevealed counts revealed tiles that weren't mines.
+func (d *MinesDoc) safeRevealed() int {
+	n := 0
+	for _, r := range d.Revealed {
+		if !d.isMine(r) {
+			n++
+		}
+	}
+	return n
+}
+
+// tags describes a cashed session for achievements.
+func (d *MinesDoc) tags() []string {
+	if d.safeRevealed() == d.tiles()-len(d.Mines) {
+		return []string{"full_clear"}
+	}
+	return nil
+}
+
+// result reports a finished session to the wallet. The liability is what a
+// full clear would have paid.
+func (d *MinesDoc) result(payout int32, tags []string) *walletpb.GameResult {
+	mines := len(d.Mines)
+	return &walletpb.GameResult{
+		UserId:    d.UserId,
+		Game:      "mines",
+		RoundId:   d.Id,
+		Stake:     creditsIn(d.Stake, d.Currency),
+		Payout:    creditsIn(payout, d.Currency),
+		Tags:      tags,
+		RuleSet:   fmt.Sprintf("%dx%d/%d", d.GridSize, d.GridSize, mines),
+		Rtp:       1 - minesHouseEdge,
+		Liability: creditsIn(minesPayout(d.Stake, minesMultiplier(d.tiles(), mines, d.tiles()-mines)), d.Currency),
+	}
+}
+
+func (d *MinesDoc) toState() *pb.MinesState {
+	safe := d.safeRevealed()
+	st := &pb.MinesState{
+		SessionId:  d.Id,
+		GridSize:   d.GridSize,
+		Mines:      int32(len(d.Mines)),
+		Stake:      d.Stake,
+		Revealed:   d.Revealed,
+		Status:     d.Status,
+		Multiplier: 1,
+		Payout:     d.Payout,
+		Currency:   d.Currency,
+	}
+	if safe > 0 {
+		st.Multiplier = minesMultiplier(d.tiles(), len(d.Mines), safe)
+	}
+	if d.Status == "active" {
+		st.NextMultiplier = minesMultiplier(d.tiles(), len(d.Mines), safe+1)
+		if safe > 0 {
+			st.Payout = minesPayout(d.Stake, st.Multiplier)
+		}
+	} else {
+		st.MineTiles = d.Mines
+	}
+	return st
+}
+
+// loadMines only finds sessions owned by userId; someone else's id looks like a missing one.
+func (s *gameServer) loadMines(ctx context.Context, userId, sessionId string) (*MinesDoc, error) {
+	var d MinesDoc
+	err := s.mines.FindOne(ctx, bson.M{"_id": sessionId, "user_id": userId}).Decode(&d)
+	if err == mongo.ErrNoDocuments {
+		return nil, fmt.Errorf("session not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// saveMines writes d back only if nobody else changed it since it was loaded.
+func (s *gameServer) saveMines(ctx context.Context, d *MinesDoc, set bson.M) error {
+	set["updated_at"] = time.Now()
+	res, err := s.mines.UpdateOne(ctx,
+		bson.M{"_id": d.Id, "version": d.Version},
+		bson.M{"$set": set, "$inc": bson.M{"version": 1}})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return fmt.Errorf("session was updated concurrently, retry")
+	}
+	d.Version++
+	return nil
+}
+
+func (s *gameServer) MinesStart(ctx context.Context, req *pb.MinesStartRequest) (*pb.MinesState, error) {
+	if req.UserId == "" {
+		return nil, fmt.Errorf("user_id required")
+	}
+	if req.GridSize < minesMinGrid || req.GridSize > minesMaxGrid {
+		return nil, fmt.Errorf("grid size must be between %d and %d", minesMinGrid, minesMaxGrid)
+	}
+	tiles := int(req.GridSize * req.GridSize)
+	if req.Mines < 1 || int(req.Mines) >= tiles {
+		return nil, fmt.Errorf("mines must be between 1 and %d", tiles-1)
+	}
+	if req.Stake < minesMinStake || req.Stake > minesMaxStake {
+		return nil, fmt.Errorf("stake must be between %d and %d", minesMinStake, minesMaxStake)
+	}
+	mines, err := placeMines(tiles, int(req.Mines))
+	if err != nil {
+		return nil, err
+	}
+
+	id := uuid.New().String()
+	cur := roundCurrency(req.Currency)
+	wr, err := s.wallet.UpdateBalance(ctx, &walletpb.WalletUpdateRequest{UserId: req.UserId, Amount: creditsIn(-req.Stake, cur), Type: "bet", Ref: id, IdempotencyKey: "mines:" + id + ":bet"})
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	d := &MinesDoc{
+		Id:        id,
+		UserId:    req.UserId,
+		GridSize:  req.GridSize,
+		Mines:     mines,
+		Revealed:  []int32{},
+		Stake:     req.Stake,
+		Currency:  cur,
+		Status:    "active",
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if _, err := s.mines.InsertOne(ctx, d); err != nil {
+		log.Printf("[mines] insert session failed: %v, refunding %d to %s", err, req.Stake, req.UserId)
+		if _, rerr := s.wallet.UpdateBalance(context.Background(), &walletpb.WalletUpdateRequest{UserId: req.UserId, Amount: creditsIn(req.Stake, cur), Type: "refund", Ref: id, IdempotencyKey: "mines:" + id + ":refund"}); rerr != nil {
+			log.Printf("[mines] refund failed: %v", rerr)
+		}
+		return nil, err
+	}
+	contributeJackpot(s.jackpot, "mines", req.UserId, d.Id, cur, req.Stake)
+	st := d.toState()
+	st.Balance = toCredits(wr.NewBalance)
+	return st, nil
+}
+
+func (s *gameServer) MinesReveal(ctx context.Context, req *pb.MinesRevealRequest) (*pb.MinesState, error) {
+	d, err := s.loadMines(ctx, req.UserId, req.SessionId)
+	if err != nil {
+		return nil, err
+	}
+	if d.Status != "active" {
+		return nil, fmt.Errorf("game is over")
+	}
+	if req.Tile < 0 || int(req.Tile) >= d.tiles() {
+		return nil, fmt.Errorf("tile out of range")
+	}
+	if d.isRevealed(req.Tile) {
+		return nil, fmt.Errorf("tile already revealed")
+	}
+
+	if d.isMine(req.Tile) {
+		d.Revealed = append(d.Revealed, req.Tile)
+		d.Status = "lost"
+		if err := s.saveMines(ctx, d, bson.M{"revealed": d.Revealed, "status": d.Status}); err != nil {
+			return nil, err
+		}
+		if err := s.results.record(ctx, d.result(0, nil)); err != nil {
+			log.Printf("[mines] session %s: result not recorded: %v", d.Id, err)
+		}
+		return d.toState(), nil
+	}
+
+	d.Revealed = append(d.Revealed, req.Tile)
+	if err := s.saveMines(ctx, d, bson.M{"revealed": d.Revealed}); err != nil {
+		return nil, err
+	}
+	// nothing left to win: every safe tile is open or the payout hit the cap
+	mult := minesMultiplier(d.tiles(), len(d.Mines), len(d.Revealed))
+	if len(d.Revealed) == d.tiles()-len(d.Mines) || minesPayout(d.Stake, mult) >= minesMaxPayout {
+		return s.settleMines(ctx, d)
+	}
+	return d.toState(), nil
+}
+
+func (s *gameServer) MinesCashout(ctx context.Context, req *pb.MinesCashoutRequest) (*pb.MinesState, error) {
+	d, err := s.loadMines(ctx, req.UserId, req.SessionId)
+	if err != nil {
+		return nil, err
+	}
+	switch d.Status {
+	case "cashed":
+		// repeated cash-out returns the original result, never pays twice
+		return d.toState(), nil
+	case "cashing":
+		// an earlier payout failed: retry it under the same wallet key
+		return s.payMines(ctx, d)
+	case "lost":
+		return nil, fmt.Errorf("game is over")
+	}
+	if len(d.Revealed) == 0 {
+		return nil, fmt.Errorf("reveal at least one tile first")
+	}
+	return s.settleMines(ctx, d)
+}
+
+// settleMines moves the session active -> cashing (only one caller can win that
+// write) and pays it. A session left in "cashing" is paid again by the next
+// cash-out or by recoverMines on the next start.
+func (s *gameServer) settleMines(ctx context.Context, d *MinesDoc) (*pb.MinesState, error) {
+	d.Status, d.Payout = "cashing", minesPayout(d.Stake, minesMultiplier(d.tiles(), len(d.Mines), len(d.Revealed)))
+	if err := s.saveMines(ctx, d, bson.M{"status": d.Status, "payout": d.Payout}); err != nil {
+		return nil, err
+	}
+	return s.payMines(ctx, d)
+}
+
+// payMines credits a "cashing" session's payout and marks it cashed. The wallet
+// key makes every retry pay at most once.
+func (s *gameServer) payMines(ctx context.Context, d *MinesDoc) (*pb.MinesState, error) {
+	wr, err := s.wallet.UpdateBalance(ctx, &walletpb.WalletUpdateRequest{UserId: d.UserId, Amount: creditsIn(d.Payout, d.Currency), Type: "win", Ref: d.Id, IdempotencyKey: "mines:" + d.Id + ":win"})
+	if err != nil {
+		log.Printf("[mines] session %s: pay %d to %s failed: %v", d.Id, d.Payout, d.UserId, err)
+		return nil, err
+	}
+	// the round goes to the outbox before the session leaves "cashing", so a
+	// failure here is retried together with the payout
+	if err := s.results.record(ctx, d.result(d.Payout, d.tags())); err != nil {
+		log.Printf("[mines] session %s paid, result not recorded: %v", d.Id, err)
+		return nil, err
+	}
+	d.Status = "cashed"
+	if err := s.saveMines(ctx, d, bson.M{"status": d.Status}); err != nil {
+		log.Printf("[mines] session %s paid but not marked cashed: %v", d.Id, err)
+	}
+	st := d.toState()
+	st.Balance = toCredits(wr.NewBalance)
+	return st, nil
+}
+
+func (s *gameServer) MinesGet(ctx context.Context, req *pb.MinesGetRequest) (*pb.MinesState, error) {
+	d, err := s.loadMines(ctx, req.UserId, req.SessionId)
+	if err != nil {
+		return nil, err
+	}
+	return d.toState(), nil
+}
+
+// recoverMines finishes settlements interrupted by a crash or a wallet outage.
+func (s *gameServer) recoverMines(ctx context.Context) {
+	cur, err := s.mines.Find(ctx, bson.M{"status": "cashing"}, options.Find().SetLimit(1000))
+	if err != nil {
+		log.Printf("[mines] recover: %v", err)
+		return
+	}
+	var docs []MinesDoc
+	if err := cur.All(ctx, &docs); err != nil {
+		log.Printf("[mines] recover: %v", err)
+		return
+	}
+	for i := range docs {
+		if _, err := s.payMines(ctx, &docs[i]); err != nil {
+			log.Printf("[mines] recover %s: %v", docs[i].Id, err)
+		}
+	}
+	if len(docs) > 0 {
+		log.Printf("[mines] recovered %d unsettled sessions", len(docs))
+	}
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"strings"
+
+	walletpb "github.com/Arsencchikkk/final/casino/proto/wallet"
+)
+
+// The wallet keeps int64 minor units; games count whole credits, one credit
+// being one unit of the currency the round is played in.
+const creditMinor = 100
+
+// mainCurrency is the wallet's main currency (WALLET_CURRENCY). Rounds in it
+// carry an empty currency, like requests that don't pick one.
+var mainCurrency = "USD"
+
+// roundCurrency normalizes the currency a player bets in: "" for the main one.
+// The wallet rejects currencies it doesn't keep.
+func roundCurrency(c string) string {
+	c = strings.ToUpper(strings.TrimSpace(c))
+	if c == mainCurrency {
+		return ""
+	}
+	return c
+}
+
+// credits converts a credit amount into wallet money in the main currency.
+func credits(n int32) *walletpb.Money {
+	return creditsIn(n, "")
+}
+
+// creditsIn converts a credit amount into wallet money in a round's currency.
+func creditsIn(n int32, currency string) *walletpb.Money {
+	return &walletpb.Money{Amount: int64(n) * creditMinor, Currency: currency}
+}
+
+// toCredits converts a wallet balance back into whole credits for game
+// responses; a fraction of a credit (after a decimal deposit) is dropped.
+func toCredits(m *walletpb.Money) int32 {
+	return int32(m.GetAmount() / creditMinor)
+}
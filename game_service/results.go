@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	walletpb "github.com/Arsencchikkk/final/casino/proto/wallet"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/protobuf/proto"
+)
+
+// Settled rounds reach the wallet's audit log and leaderboards through an
+// outbox. A settlement writes its rounds to Mongo before it is acknowledged;
+// they are handed to the wallet right away and deleted once the wallet has
+// appended them. Whatever the wallet didn't take - it was down, the call
+// timed out, the process stopped - stays in the outbox and is sent again by
+// retryResults. The wallet records a round once however often it arrives.
+type ResultDoc struct {
+	Id        string    `bson:"_id"`    // game:round:user
+	Result    []byte    `bson:"result"` // proto-encoded walletpb.GameResult
+	CreatedAt time.Time `bson:"created_at"`
+}
+
+const (
+	resultsBatch      = 100
+	resultsRetryEvery = 30 * time.Second
+)
+
+type resultOutbox struct {
+	wallet walletpb.WalletServiceClient
+	col    *mongo.Collection
+}
+
+func resultId(r *walletpb.GameResult) string {
+	return r.Game + ":" + r.RoundId + ":" + r.UserId
+}
+
+// record stores settled rounds in the outbox and delivers them in the
+// background. Once it returns nil the rounds will reach the wallet; an error
+// means nothing durable was written and the caller should keep its settlement
+// open for a retry where it can.
+func (o *resultOutbox) record(ctx context.Context, results ...*walletpb.GameResult) error {
+	if len(results) == 0 {
+		return nil
+	}
+	now := time.Now().UTC()
+	docs := make([]interface{}, 0, len(results))
+	for _, r := range results {
+		b, err := proto.Marshal(r)
+		if err != nil {
+			return err
+		}
+		docs = append(docs, ResultDoc{Id: resultId(r), Result: b, CreatedAt: now})
+	}
+	cctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	// a settlement that is retried writes its rounds again
+	if _, err := o.col.InsertMany(cctx, docs, options.InsertMany().SetOrdered(false)); err != nil && !onlyDuplicates(err) {
+		return fmt.Errorf("results outbox: %w", err)
+	}
+	go o.deliver(context.Background(), results)
+	return nil
+}
+
+// onlyDuplicates reports whether every failed insert hit an existing _id.
+func onlyDuplicates(err error) bool {
+	var bwe mongo.BulkWriteException
+	if !errors.As(err, &bwe) || bwe.WriteConcernError != nil || len(bwe.WriteErrors) == 0 {
+		return false
+	}
+	for _, we := range bwe.WriteErrors {
+		if we.Code != 11000 {
+			return false
+		}
+	}
+	return true
+}
+
+// deliver hands rounds to the wallet and drops them from the outbox.
+func (o *resultOutbox) deliver(ctx context.Context, results []*walletpb.GameResult) bool {
+	cctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if _, err := o.wallet.RecordResults(cctx, &walletpb.RecordResultsRequest{Results: results}); err != nil {
+		log.Printf("[results] deliver %d results: %v", len(results), err)
+		return false
+	}
+	ids := make([]string, len(results))
+	for i, r := range results {
+		ids[i] = resultId(r)
+	}
+	// a failed delete only means the rounds are sent once more
+	if _, err := o.col.DeleteMany(cctx, bson.M{"_id": bson.M{"$in": ids}}); err != nil {
+		log.Printf("[results] drop %d delivered results: %v", len(ids), err)
+	}
+	return true
+}
+
+// retryResults sends the outbox to the wallet again every resultsRetryEvery,
+// oldest rounds first.
+func (o *resultOutbox) retryResults(ctx context.Context) {
+	for {
+		o.redeliver(ctx)
+		if !sleepCtx(ctx, resultsRetryEvery) {
+			return
+		}
+	}
+}
+
+func (o *resultOutbox) redeliver(ctx context.Context) {
+	// younger rounds are still being delivered by record
+	cur, err := o.col.Find(ctx,
+		bson.M{"created_at": bson.M{"$lt": time.Now().Add(-resultsRetryEvery)}},
+		options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}).SetLimit(10*resultsBatch))
+	if err != nil {
+		log.Printf("[results] outbox scan error: %v", err)
+		return
+	}
+	var docs []ResultDoc
+	if err := cur.All(ctx, &docs); err != nil {
+		log.Printf("[results] outbox scan error: %v", err)
+		return
+	}
+	var batch []*walletpb.GameResult
+	for i, d := range docs {
+		r := &walletpb.GameResult{}
+		if err := proto.Unmarshal(d.Result, r); err != nil {
+			log.Printf("[results] outbox entry %s is unreadable: %v", d.Id, err)
+			continue
+		}
+		batch = append(batch, r)
+		if len(batch) == resultsBatch || i == len(docs)-1 {
+			// the wallet is still unavailable, wait for the next pass
+			if !o.deliver(ctx, batch) {
+				return
+			}
+			batch = nil
+		}
+	}
+	if len(batch) > 0 {
+		o.deliver(ctx, batch)
+	}
+}
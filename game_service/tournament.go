@@ -0,0 +1,802 @@
+package main
+
+import (
+	"context"
+	crand "crypto/rand"
+	"fmt"
+	"log"
+	"math/big"
+	"time"
+
+	pb "github.com/Arsencchikkk/final/casino/proto/game"
+	walletpb "github.com/Arsencchikkk/final/casino/proto/wallet"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	tourneyMaxBuyIn    = 100000
+	tourneyMinChips    = 100
+	tourneyMaxChips    = 1000000
+	tourneyMaxHands    = 10000
+	tourneyTick        = 5 * time.Second
+	tourneyDefaultList = 50
+)
+
+var tourneyDefaultPayouts = []int32{50, 30, 20}
+
+// TournamentDoc is a tournament as stored in Mongo. BuyIns sums the buy-ins
+// taken; PrizePool is the larger of that and the guarantee, so the house only
+// adds the overlay when too few players joined.
+type TournamentDoc struct {
+	Id            string    `bson:"_id"`
+	Name          string    `bson:"name"`
+	Game          string    `bson:"game"` // "blackjack", "slots"
+	BuyIn         int32     `bson:"buy_in"`
+	StartingChips int32     `bson:"starting_chips"`
+	MaxHands      int32     `bson:"max_hands"`
+	StartsAt      time.Time `bson:"starts_at"`
+	EndsAt        time.Time `bson:"ends_at"`
+	Payouts       []int32   `bson:"payouts"`
+	Guarantee     int32     `bson:"guarantee"`
+	Status        string    `bson:"status"` // "scheduled", "running", "finishing", "finished", "cancelled"
+	PrizePool     int32     `bson:"prize_pool"`
+	BuyIns        int32     `bson:"buy_ins"`
+	Entries       int32     `bson:"entries"`
+	CreatedAt     time.Time `bson:"created_at"`
+}
+
+// TourneyHand is a blackjack hand in progress; the bet is already off the stack.
+type TourneyHand struct {
+	Deck   []Card `bson:"deck"`
+	Player []Card `bson:"player"`
+	Dealer []Card `bson:"dealer"`
+	Bet    int32  `bson:"bet"`
+}
+
+// EntryDoc is one player's seat in a tournament. Chips never touch the real
+// wallet; only the buy-in, refunds and prizes do.
+type EntryDoc struct {
+	Id           string       `bson:"_id"` // tournament_id + ":" + user_id
+	TournamentId string       `bson:"tournament_id"`
+	UserId       string       `bson:"user_id"`
+	BuyIn        int32        `bson:"buy_in"`
+	Chips        int32        `bson:"chips"`
+	HandsPlayed  int32        `bson:"hands_played"`
+	Hand         *TourneyHand `bson:"hand"`
+	Rank         int32        `bson:"rank"`
+	Prize        int32        `bson:"prize"`
+	Paid         bool         `bson:"paid"`
+	Refunded     bool         `bson:"refunded"`
+	Version      int32        `bson:"version"`
+	JoinedAt     time.Time    `bson:"joined_at"`
+	UpdatedAt    time.Time    `bson:"updated_at"`
+}
+
+func entryId(tournamentId, userId string) string { return tournamentId + ":" + userId }
+
+// leaderboardSort ranks by chips, then fewer hands used, then who joined first.
+var leaderboardSort = bson.D{{Key: "chips", Value: -1}, {Key: "hands_played", Value: 1}, {Key: "joined_at", Value: 1}}
+
+// --- slots ---
+
+// three reels, 32 stops each; RTP is about 95.6%
+var slotReel = []struct {
+	Symbol string
+	Weight int
+	Three  int32 // pays this many bets for three in a row
+}{
+	{"CHERRY", 6, 20},
+	{"LEMON", 10, 6},
+	{"BELL", 8, 15},
+	{"BAR", 5, 40},
+	{"SEVEN", 3, 100},
+}
+
+// two cherries anywhere (and no third) pay this many bets
+const slotTwoCherries = 2
+
+func spinSlots(bet int32) ([]string, int32, error) {
+	total := 0
+	for _, s := range slotReel {
+		total += s.Weight
+	}
+	reels := make([]string, 3)
+	pays := make([]int32, 3)
+	for i := range reels {
+		n, err := crand.Int(crand.Reader, big.NewInt(int64(total)))
+		if err != nil {
+			return nil, 0, err
+		}
+		r := int(n.Int64())
+		for _, s := range slotReel {
+			if r < s.Weight {
+				reels[i], pays[i] = s.Symbol, s.Three
+				break
+			}
+			r -= s.Weight
+		}
+	}
+	if reels[0] == reels[1] && reels[1] == reels[2] {
+		return reels, bet * pays[0], nil
+	}
+	cherries := 0
+	for _, r := range reels {
+		if r == "CHERRY" {
+			cherries++
+		}
+	}
+	if cherries == 2 {
+		return reels, bet * slotTwoCherries, nil
+	}
+	return reels, 0, nil
+}
+
+// --- conversion ---
+
+func (t *TournamentDoc) toPb() *pb.Tournament {
+	return &pb.Tournament{
+		TournamentId:  t.Id,
+		Name:          t.Name,
+		Game:          t.Game,
+		BuyIn:         t.BuyIn,
+		StartingChips: t.StartingChips,
+		MaxHands:      t.MaxHands,
+		StartsAt:      t.StartsAt.Unix(),
+		EndsAt:        t.EndsAt.Unix(),
+		Payouts:       t.Payouts,
+		Guarantee:     t.Guarantee,
+		Status:        t.Status,
+		PrizePool:     t.pool(),
+		Entries:       t.Entries,
+	}
+}
+
+func (e *EntryDoc) toPb(t *TournamentDoc) *pb.TournamentEntry {
+	left := int32(-1)
+	if t.MaxHands > 0 {
+		left = t.MaxHands - e.HandsPlayed
+		if e.Hand != nil {
+			left-- // the open hand already counts
+		}
+	}
+	return &pb.TournamentEntry{
+		TournamentId: e.TournamentId,
+		UserId:       e.UserId,
+		Chips:        e.Chips,
+		HandsPlayed:  e.HandsPlayed,
+		HandsLeft:    left,
+		Rank:         e.Rank,
+		Prize:        e.Prize,
+		InHand:       e.Hand != nil,
+	}
+}
+
+// --- storage ---
+
+func (s *gameServer) loadTournament(ctx context.Context, id string) (*TournamentDoc, error) {
+	var t TournamentDoc
+	err := s.tournaments.FindOne(ctx, bson.M{"_id": id}).Decode(&t)
+	if err == mongo.ErrNoDocuments {
+		return nil, fmt.Errorf("tournament not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (s *gameServer) loadEntry(ctx context.Context, tournamentId, userId string) (*EntryDoc, error) {
+	var e EntryDoc
+	err := s.entries.FindOne(ctx, bson.M{"_id": entryId(tournamentId, userId)}).Decode(&e)
+	if err == mongo.ErrNoDocuments {
+		return nil, fmt.Errorf("not registered in this tournament")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// saveEntry writes chips, hands and the open hand back if nobody changed the entry meanwhile.
+func (s *gameServer) saveEntry(ctx context.Context, e *EntryDoc) error {
+	e.UpdatedAt = time.Now()
+	res, err := s.entries.UpdateOne(ctx,
+		bson.M{"_id": e.Id, "version": e.Version},
+		bson.M{
+			"$set": bson.M{"chips": e.Chips, "hands_played": e.HandsPlayed, "hand": e.Hand, "updated_at": e.UpdatedAt},
+			"$inc": bson.M{"version": 1},
+		})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return fmt.Errorf("entry was updated concurrently, retry")
+	}
+	e.Version++
+	return nil
+}
+
+// --- admin ---
+
+func validPayouts(p []int32) error {
+	sum := int32(0)
+	for _, v := range p {
+		if v <= 0 {
+			return fmt.Errorf("payout shares must be positive")
+		}
+		sum += v
+	}
+	if sum != 100 {
+		return fmt.Errorf("payout shares must add up to 100")
+	}
+	return nil
+}
+
+func (s *gameServer) CreateTournament(ctx context.Context, req *pb.CreateTournamentRequest) (*pb.Tournament, error) {
+	if req.Name == "" {
+		return nil, fmt.Errorf("name required")
+	}
+	if req.Game != "blackjack" && req.Game != "slots" {
+		return nil, fmt.Errorf("game must be blackjack or slots")
+	}
+	if req.BuyIn < 0 || req.BuyIn > tourneyMaxBuyIn {
+		return nil, fmt.Errorf("buy-in must be between 0 and %d", tourneyMaxBuyIn)
+	}
+	if req.StartingChips < tourneyMinChips || req.StartingChips > tourneyMaxChips {
+		return nil, fmt.Errorf("starting chips must be between %d and %d", tourneyMinChips, tourneyMaxChips)
+	}
+	if req.MaxHands < 0 || req.MaxHands > tourneyMaxHands {
+		return nil, fmt.Errorf("max hands must be between 0 and %d", tourneyMaxHands)
+	}
+	if req.Guarantee < 0 {
+		return nil, fmt.Errorf("guarantee can't be negative")
+	}
+	starts, ends := time.Unix(req.StartsAt, 0), time.Unix(req.EndsAt, 0)
+	if !ends.After(starts) || !ends.After(time.Now()) {
+		return nil, fmt.Errorf("ends_at must be after starts_at and in the future")
+	}
+	payouts := req.Payouts
+	if len(payouts) == 0 {
+		payouts = tourneyDefaultPayouts
+	}
+	if err := validPayouts(payouts); err != nil {
+		return nil, err
+	}
+
+	t := &TournamentDoc{
+		Id:            uuid.New().String(),
+		Name:          req.Name,
+		Game:          req.Game,
+		BuyIn:         req.BuyIn,
+		StartingChips: req.StartingChips,
+		MaxHands:      req.MaxHands,
+		StartsAt:      starts,
+		EndsAt:        ends,
+		Payouts:       payouts,
+		Guarantee:     req.Guarantee,
+		Status:        "scheduled",
+		PrizePool:     req.Guarantee,
+		CreatedAt:     time.Now(),
+	}
+	if _, err := s.tournaments.InsertOne(ctx, t); err != nil {
+		return nil, err
+	}
+	log.Printf("[tournament] created %s %q (%s) %s - %s", t.Id, t.Name, t.Game, starts.Format(time.RFC3339), ends.Format(time.RFC3339))
+	return t.toPb(), nil
+}
+
+func (s *gameServer) ScheduleTournament(ctx context.Context, req *pb.ScheduleTournamentRequest) (*pb.Tournament, error) {
+	starts, ends := time.Unix(req.StartsAt, 0), time.Unix(req.EndsAt, 0)
+	if !ends.After(starts) || !ends.After(time.Now()) {
+		return nil, fmt.Errorf("ends_at must be after starts_at and in the future")
+	}
+	var t TournamentDoc
+	err := s.tournaments.FindOneAndUpdate(ctx,
+		bson.M{"_id": req.TournamentId, "status": "scheduled"},
+		bson.M{"$set": bson.M{"starts_at": starts, "ends_at": ends}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&t)
+	if err == mongo.ErrNoDocuments {
+		return nil, fmt.Errorf("only scheduled tournaments can be rescheduled")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return t.toPb(), nil
+}
+
+// CancelTournament stops a tournament that hasn't finished and refunds every
+// buy-in. Calling it again on a cancelled tournament retries failed refunds.
+func (s *gameServer) CancelTournament(ctx context.Context, req *pb.CancelTournamentRequest) (*pb.Tournament, error) {
+	var t TournamentDoc
+	err := s.tournaments.FindOneAndUpdate(ctx,
+		bson.M{"_id": req.TournamentId, "status": bson.M{"$in": []string{"scheduled", "running", "cancelled"}}},
+		bson.M{"$set": bson.M{"status": "cancelled"}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&t)
+	if err == mongo.ErrNoDocuments {
+		return nil, fmt.Errorf("tournament not found or already finished")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := s.refundEntries(ctx, &t); err != nil {
+		return nil, err
+	}
+	log.Printf("[tournament] %s cancelled", t.Id)
+	return t.toPb(), nil
+}
+
+// refundEntries claims each unrefunded entry (so a racing join rollback can't
+// refund it too) and pays its buy-in back. Every entry has its own wallet key,
+// so a refund that went through but timed out is not paid again when the
+// entry is unclaimed and the cancel retried.
+func (s *gameServer) refundEntries(ctx context.Context, t *TournamentDoc) error {
+	cur, err := s.entries.Find(ctx, bson.M{"tournament_id": t.Id, "refunded": false, "buy_in": bson.M{"$gt": 0}})
+	if err != nil {
+		return err
+	}
+	var list []EntryDoc
+	if err := cur.All(ctx, &list); err != nil {
+		return err
+	}
+	var failed error
+	for _, e := range list {
+		res, err := s.entries.UpdateOne(ctx, bson.M{"_id": e.Id, "refunded": false}, bson.M{"$set": bson.M{"refunded": true}})
+		if err != nil {
+			return err
+		}
+		if res.ModifiedCount == 0 {
+			continue
+		}
+		if _, err := s.wallet.UpdateBalance(ctx, &walletpb.WalletUpdateRequest{UserId: e.UserId, Amount: credits(e.BuyIn), Type: "refund", Ref: t.Id, IdempotencyKey: "tournament:" + t.Id + ":refund:" + e.Id}); err != nil {
+			log.Printf("[tournament] %s refund to %s failed: %v", t.Id, e.UserId, err)
+			if _, uerr := s.entries.UpdateOne(context.Background(), bson.M{"_id": e.Id}, bson.M{"$set": bson.M{"refunded": false}}); uerr != nil {
+				log.Printf("[tournament] %s unclaim refund %s: %v", t.Id, e.Id, uerr)
+			}
+			failed = err
+		}
+	}
+	return failed
+}
+
+// --- players ---
+
+func (s *gameServer) ListTournaments(ctx context.Context, req *pb.ListTournamentsRequest) (*pb.ListTournamentsResponse, error) {
+	filter := bson.M{}
+	if req.Status != "" {
+		filter["status"] = req.Status
+	}
+	limit := int64(req.Limit)
+	if limit <= 0 || limit > tourneyDefaultList {
+		limit = tourneyDefaultList
+	}
+	cur, err := s.tournaments.Find(ctx, filter, options.Find().SetSort(bson.M{"starts_at": -1}).SetLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	var list []TournamentDoc
+	if err := cur.All(ctx, &list); err != nil {
+		return nil, err
+	}
+	resp := &pb.ListTournamentsResponse{}
+	for i := range list {
+		resp.Tournaments = append(resp.Tournaments, list[i].toPb())
+	}
+	return resp, nil
+}
+
+func (s *gameServer) GetTournament(ctx context.Context, req *pb.GetTournamentRequest) (*pb.Tournament, error) {
+	t, err := s.loadTournament(ctx, req.TournamentId)
+	if err != nil {
+		return nil, err
+	}
+	return t.toPb(), nil
+}
+
+// JoinTournament takes the buy-in and hands out the starting stack. Late
+// registration stays open until the tournament ends.
+func (s *gameServer) JoinTournament(ctx context.Context, req *pb.JoinTournamentRequest) (*pb.JoinTournamentResponse, error) {
+	if req.UserId == "" {
+		return nil, fmt.Errorf("user_id required")
+	}
+	if isDemo(req.UserId) {
+		return nil, errDemoPvP
+	}
+	t, err := s.loadTournament(ctx, req.TournamentId)
+	if err != nil {
+		return nil, err
+	}
+	if (t.Status != "scheduled" && t.Status != "running") || !time.Now().Before(t.EndsAt) {
+		return nil, fmt.Errorf("registration is closed")
+	}
+	if _, err := s.loadEntry(ctx, t.Id, req.UserId); err == nil {
+		return nil, fmt.Errorf("already registered")
+	}
+
+	// a fresh key per attempt: after a refund the same user may join again
+	attempt := "tournament:" + t.Id + ":" + req.UserId + ":" + uuid.New().String()
+	var balance int32
+	if t.BuyIn > 0 {
+		wr, err := s.wallet.UpdateBalance(ctx, &walletpb.WalletUpdateRequest{UserId: req.UserId, Amount: credits(-t.BuyIn), Type: "bet", Ref: t.Id, IdempotencyKey: attempt + ":buyin", CashOnly: true})
+		if err != nil {
+			return nil, err
+		}
+		balance = toCredits(wr.NewBalance)
+	} else {
+		wr, err := s.wallet.GetBalance(ctx, &walletpb.WalletRequest{UserId: req.UserId})
+		if err == nil {
+			balance = toCredits(wr.Balance)
+		}
+	}
+	refund := func(reason error) {
+		if t.BuyIn == 0 {
+			return
+		}
+		log.Printf("[tournament] %s join %s failed: %v, refunding %d", t.Id, req.UserId, reason, t.BuyIn)
+		if _, err := s.wallet.UpdateBalance(context.Background(), &walletpb.WalletUpdateRequest{UserId: req.UserId, Amount: credits(t.BuyIn), Type: "refund", Ref: t.Id, IdempotencyKey: attempt + ":refund"}); err != nil {
+			log.Printf("[tournament] refund failed: %v", err)
+		}
+	}
+
+	now := time.Now()
+	e := &EntryDoc{
+		Id:           entryId(t.Id, req.UserId),
+		TournamentId: t.Id,
+		UserId:       req.UserId,
+		BuyIn:        t.BuyIn,
+		Chips:        t.StartingChips,
+		JoinedAt:     now,
+		UpdatedAt:    now,
+	}
+	if _, err := s.entries.InsertOne(ctx, e); err != nil {
+		refund(err)
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, fmt.Errorf("already registered")
+		}
+		return nil, err
+	}
+	res, err := s.tournaments.UpdateOne(ctx,
+		bson.M{"_id": t.Id, "status": bson.M{"$in": []string{"scheduled", "running"}}},
+		joinPoolUpdate(t.BuyIn))
+	if err == nil && res.MatchedCount == 0 {
+		err = fmt.Errorf("registration is closed")
+	}
+	if err != nil {
+		// cancelled or finished meanwhile; refund unless the cancel already did
+		if d, derr := s.entries.DeleteOne(context.Background(), bson.M{"_id": e.Id, "refunded": false}); derr == nil && d.DeletedCount == 1 {
+			refund(err)
+		}
+		return nil, err
+	}
+	return &pb.JoinTournamentResponse{Entry: e.toPb(t), Balance: balance}, nil
+}
+
+// TournamentPlay plays one slot spin or one blackjack move with tournament chips.
+func (s *gameServer) TournamentPlay(ctx context.Context, req *pb.TournamentPlayRequest) (*pb.TournamentPlayResponse, error) {
+	t, err := s.loadTournament(ctx, req.TournamentId)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if t.Status != "running" || now.Before(t.StartsAt) || !now.Before(t.EndsAt) {
+		return nil, fmt.Errorf("tournament is not running")
+	}
+	e, err := s.loadEntry(ctx, t.Id, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	newHand := req.Action == "spin" || req.Action == "deal"
+	if newHand {
+		if e.Hand != nil {
+			return nil, fmt.Errorf("finish the current hand first")
+		}
+		if t.MaxHands > 0 && e.HandsPlayed >= t.MaxHands {
+			return nil, fmt.Errorf("no hands left")
+		}
+		if req.Bet < 1 || req.Bet > e.Chips {
+			return nil, fmt.Errorf("bet must be between 1 and %d", e.Chips)
+		}
+	}
+
+	resp := &pb.TournamentPlayResponse{}
+	bet := req.Bet
+	switch t.Game {
+	case "slots":
+		if req.Action != "spin" {
+			return nil, fmt.Errorf("unknown action %q", req.Action)
+		}
+		reels, win, err := spinSlots(req.Bet)
+		if err != nil {
+			return nil, err
+		}
+		e.Chips += win - req.Bet
+		e.HandsPlayed++
+		resp.Reels, resp.Win, resp.Finished = reels, win, true
+
+	case "blackjack":
+		var sess *GameSession
+		switch req.Action {
+		case "deal":
+			d := newDeck()
+			e.Chips -= req.Bet
+			e.Hand = &TourneyHand{Deck: d[4:], Player: []Card{d[0], d[2]}, Dealer: []Card{d[1], d[3]}, Bet: req.Bet}
+			sess = &GameSession{Deck: e.Hand.Deck, PlayerHand: e.Hand.Player, DealerHand: e.Hand.Dealer, State: "playerTurn"}
+		case "hit", "stand":
+			if e.Hand == nil {
+				return nil, fmt.Errorf("no hand in progress, deal first")
+			}
+			sess = &GameSession{Deck: e.Hand.Deck, PlayerHand: e.Hand.Player, DealerHand: e.Hand.Dealer, State: "playerTurn"}
+			if req.Action == "hit" {
+				playerHit(sess)
+			} else {
+				dealerPlay(sess)
+			}
+			e.Hand.Deck, e.Hand.Player, e.Hand.Dealer = sess.Deck, sess.PlayerHand, sess.DealerHand
+		default:
+			return nil, fmt.Errorf("unknown action %q", req.Action)
+		}
+
+		resp.PlayerCards = cardsToStrings(sess.PlayerHand)
+		resp.PlayerTotal = int32(handValue(sess.PlayerHand))
+		if sess.State == "finished" {
+			resp.Outcome = handOutcome(sess)
+			bet = e.Hand.Bet
+			resp.Win = blackjackPayout(bet, resp.Outcome)
+			resp.DealerCards = cardsToStrings(sess.DealerHand)
+			resp.DealerTotal = int32(handValue(sess.DealerHand))
+			resp.Finished = true
+			e.Chips += resp.Win
+			e.HandsPlayed++
+			e.Hand = nil
+		} else {
+			resp.DealerCards = cardsToStrings(sess.DealerHand[:1])
+		}
+
+	default:
+		return nil, fmt.Errorf("unsupported game %q", t.Game)
+	}
+
+	if err := s.saveEntry(ctx, e); err != nil {
+		return nil, err
+	}
+	if resp.Finished {
+		s.recordHand(ctx, t, e, bet, resp.Win)
+	}
+	resp.Entry = e.toPb(t)
+	return resp, nil
+}
+
+// tournamentChips is the currency tournament hands are audited in: chips
+// never touch a wallet, so the wallet keeps them out of leaderboards and RTP.
+const tournamentChips = "CHIPS"
+
+// recordHand sends a finished hand to the wallet's audit log. The hand is
+// numbered by hands_played, which the saved entry has already counted.
+func (s *gameServer) recordHand(ctx context.Context, t *TournamentDoc, e *EntryDoc, bet, win int32) {
+	if err := s.results.record(ctx, &walletpb.GameResult{
+		UserId:  e.UserId,
+		Game:    "tournament:" + t.Game,
+		RoundId: fmt.Sprintf("%s#%d", e.Id, e.HandsPlayed),
+		Stake:   &walletpb.Money{Amount: int64(bet), Currency: tournamentChips},
+		Payout:  &walletpb.Money{Amount: int64(win), Currency: tournamentChips},
+	}); err != nil {
+		log.Printf("[tournament] %s hand %d: result not recorded: %v", e.Id, e.HandsPlayed, err)
+	}
+}
+
+func (s *gameServer) TournamentLeaderboard(ctx context.Context, req *pb.TournamentLeaderboardRequest) (*pb.TournamentLeaderboardResponse, error) {
+	t, err := s.loadTournament(ctx, req.TournamentId)
+	if err != nil {
+		return nil, err
+	}
+	limit := int64(req.Limit)
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	cur, err := s.entries.Find(ctx, bson.M{"tournament_id": t.Id}, options.Find().SetSort(leaderboardSort).SetLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	var top []EntryDoc
+	if err := cur.All(ctx, &top); err != nil {
+		return nil, err
+	}
+	resp := &pb.TournamentLeaderboardResponse{}
+	for i := range top {
+		pe := top[i].toPb(t)
+		pe.Rank = int32(i + 1)
+		resp.Top = append(resp.Top, pe)
+	}
+
+	if req.UserId != "" {
+		if e, err := s.loadEntry(ctx, t.Id, req.UserId); err == nil {
+			ahead, err := s.entries.CountDocuments(ctx, bson.M{"tournament_id": t.Id, "$or": bson.A{
+				bson.M{"chips": bson.M{"$gt": e.Chips}},
+				bson.M{"chips": e.Chips, "hands_played": bson.M{"$lt": e.HandsPlayed}},
+				bson.M{"chips": e.Chips, "hands_played": e.HandsPlayed, "joined_at": bson.M{"$lt": e.JoinedAt}},
+			}})
+			if err != nil {
+				return nil, err
+			}
+			resp.Me = e.toPb(t)
+			resp.Me.Rank = int32(ahead) + 1
+		}
+	}
+	return resp, nil
+}
+
+// --- lifecycle ---
+
+// runTournaments starts scheduled tournaments and finishes the ones whose time
+// is up (or, with a hand limit, where everybody has played out).
+func (s *gameServer) runTournaments(ctx context.Context) {
+	tick := time.NewTicker(tourneyTick)
+	defer tick.Stop()
+	for {
+		s.tournamentTick(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick.C:
+		}
+	}
+}
+
+func (s *gameServer) tournamentTick(ctx context.Context) {
+	now := time.Now()
+	if res, err := s.tournaments.UpdateMany(ctx,
+		bson.M{"status": "scheduled", "starts_at": bson.M{"$lte": now}},
+		bson.M{"$set": bson.M{"status": "running"}}); err != nil {
+		log.Printf("[tournament] start: %v", err)
+	} else if res.ModifiedCount > 0 {
+		log.Printf("[tournament] %d tournaments started", res.ModifiedCount)
+	}
+
+	cur, err := s.tournaments.Find(ctx, bson.M{"status": bson.M{"$in": []string{"running", "finishing"}}})
+	if err != nil {
+		log.Printf("[tournament] scan: %v", err)
+		return
+	}
+	var list []TournamentDoc
+	if err := cur.All(ctx, &list); err != nil {
+		log.Printf("[tournament] scan: %v", err)
+		return
+	}
+	for i := range list {
+		t := &list[i]
+		done := t.Status == "finishing" || !now.Before(t.EndsAt)
+		if !done && t.MaxHands > 0 && t.Entries > 0 {
+			left, err := s.entries.CountDocuments(ctx, bson.M{"tournament_id": t.Id, "$or": bson.A{
+				bson.M{"hands_played": bson.M{"$lt": t.MaxHands}},
+				bson.M{"hand": bson.M{"$ne": nil}},
+			}})
+			done = err == nil && left == 0
+		}
+		if done {
+			if err := s.finishTournament(ctx, t); err != nil {
+				log.Printf("[tournament] finish %s: %v", t.Id, err)
+			}
+		}
+	}
+}
+
+// joinPoolUpdate adds one buy-in and recomputes the pool as max(guarantee,
+// buy-ins). Tournaments created before buy_ins was kept had the buy-ins added
+// on top of the guarantee, so theirs are recovered from the pool.
+func joinPoolUpdate(buyIn int32) mongo.Pipeline {
+	buyIns := bson.M{"$ifNull": bson.A{"$buy_ins", bson.M{"$subtract": bson.A{"$prize_pool", "$guarantee"}}}}
+	return mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{"buy_ins": bson.M{"$add": bson.A{buyIns, buyIn}}, "entries": bson.M{"$add": bson.A{"$entries", 1}}}}},
+		{{Key: "$set", Value: bson.M{"prize_pool": bson.M{"$max": bson.A{"$guarantee", "$buy_ins"}}}}},
+	}
+}
+
+// pool is the prize pool to pay out. Older tournaments stored the guarantee
+// plus the buy-ins and no buy_ins field.
+func (t *TournamentDoc) pool() int32 {
+	if t.BuyIns > 0 || t.PrizePool <= t.Guarantee {
+		return t.PrizePool
+	}
+	if buyIns := t.PrizePool - t.Guarantee; buyIns > t.Guarantee {
+		return buyIns
+	}
+	return t.Guarantee
+}
+
+// finishTournament closes play, ranks the entries, pays prizes from the pool in
+// one wallet batch and marks it finished. Prizes are wins against the house, so
+// a pool topped up to the guarantee costs the house the overlay through them
+// and needs no separate charge.
+// Every step can be repeated, so a failed payout is retried on the next tick.
+func (s *gameServer) finishTournament(ctx context.Context, t *TournamentDoc) error {
+	if t.Status == "running" {
+		res, err := s.tournaments.UpdateOne(ctx, bson.M{"_id": t.Id, "status": "running"}, bson.M{"$set": bson.M{"status": "finishing"}})
+		if err != nil {
+			return err
+		}
+		if res.MatchedCount == 0 {
+			return nil // cancelled or finished meanwhile
+		}
+		t.Status = "finishing"
+	}
+
+	// hands still open at the bell are voided and the bet goes back on the stack
+	cur, err := s.entries.Find(ctx, bson.M{"tournament_id": t.Id, "hand": bson.M{"$ne": nil}})
+	if err != nil {
+		return err
+	}
+	var open []EntryDoc
+	if err := cur.All(ctx, &open); err != nil {
+		return err
+	}
+	for i := range open {
+		e := &open[i]
+		e.Chips += e.Hand.Bet
+		e.Hand = nil
+		if err := s.saveEntry(ctx, e); err != nil {
+			return err
+		}
+	}
+
+	cur, err = s.entries.Find(ctx, bson.M{"tournament_id": t.Id}, options.Find().SetSort(leaderboardSort))
+	if err != nil {
+		return err
+	}
+	var ranked []EntryDoc
+	if err := cur.All(ctx, &ranked); err != nil {
+		return err
+	}
+
+	// fewer players than paid places: their shares are scaled up to the whole pool
+	places := len(t.Payouts)
+	if len(ranked) < places {
+		places = len(ranked)
+	}
+	pool := t.pool()
+	share := int32(0)
+	for _, p := range t.Payouts[:places] {
+		share += p
+	}
+	prizes := make([]int32, places)
+	given := int32(0)
+	for i := 0; i < places; i++ {
+		prizes[i] = int32(int64(pool) * int64(t.Payouts[i]) / int64(share))
+		given += prizes[i]
+	}
+	if places > 0 {
+		prizes[0] += pool - given // rounding leftovers go to the winner
+	}
+
+	var deltas []*walletpb.BalanceDelta
+	var toPay []string
+	for i := range ranked {
+		e := &ranked[i]
+		prize := int32(0)
+		if i < places {
+			prize = prizes[i]
+		}
+		if _, err := s.entries.UpdateOne(ctx, bson.M{"_id": e.Id}, bson.M{"$set": bson.M{"rank": i + 1, "prize": prize}}); err != nil {
+			return err
+		}
+		if prize > 0 && !e.Paid {
+			deltas = append(deltas, &walletpb.BalanceDelta{UserId: e.UserId, Amount: credits(prize), Type: "win", Ref: t.Id})
+			toPay = append(toPay, e.Id)
+		}
+	}
+	if len(deltas) > 0 {
+		if _, err := s.wallet.BatchUpdateBalance(ctx, &walletpb.BatchUpdateRequest{Updates: deltas, IdempotencyKey: "tournament:" + t.Id + ":prizes"}); err != nil {
+			return err
+		}
+		if _, err := s.entries.UpdateMany(ctx, bson.M{"_id": bson.M{"$in": toPay}}, bson.M{"$set": bson.M{"paid": true}}); err != nil {
+			log.Printf("[tournament] %s paid but not marked: %v", t.Id, err)
+		}
+	}
+
+	if _, err := s.tournaments.UpdateOne(ctx, bson.M{"_id": t.Id}, bson.M{"$set": bson.M{"status": "finished"}}); err != nil {
+		return err
+	}
+	log.Printf("[tournament] %s finished: %d entries, pool %d", t.Id, len(ranked), pool)
+	return nil
+}
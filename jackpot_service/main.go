@@ -0,0 +1,455 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	jackpotpb "github.com/Arsencchikkk/final/casino/proto/jackpot"
+	walletpb "github.com/Arsencchikkk/final/casino/proto/wallet"
+	"github.com/joho/godotenv"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc"
+)
+
+const (
+	poolId = "global"
+	// как часто WatchJackpots перечитывает фонд
+	watchEvery = time.Second
+)
+
+// PoolDoc — фонд джекпота. Сумма хранится в сотых долях кредита, чтобы
+// 1% от ставки 1 не терялся при округлении. last_* — последний выигрыш;
+// фонд и запись о выигрыше меняются одной транзакцией, а по last_* recover
+// восстанавливает запись, потерянную до того, как Claim стал транзакционным.
+type PoolDoc struct {
+	Id          string    `bson:"_id"`
+	AmountCents int64     `bson:"amount_cents"`
+	Generation  int64     `bson:"generation"`
+	LastClaim   string    `bson:"last_claim"`
+	LastUser    string    `bson:"last_user"`
+	LastGame    string    `bson:"last_game"`
+	LastRound   string    `bson:"last_round"`
+	LastTrigger string    `bson:"last_trigger"`
+	LastAmount  int64     `bson:"last_amount"`
+	UpdatedAt   time.Time `bson:"updated_at"`
+}
+
+// WinDoc — выигрыш джекпота; _id = game:round_id, один раунд — один выигрыш.
+type WinDoc struct {
+	Id        string    `bson:"_id"`
+	PoolId    string    `bson:"pool_id"`
+	Game      string    `bson:"game"`
+	UserId    string    `bson:"user_id"`
+	RoundId   string    `bson:"round_id"`
+	Trigger   string    `bson:"trigger"`
+	Amount    int32     `bson:"amount"`
+	Status    string    `bson:"status"` // "pending", "paying", "paid"
+	CreatedAt time.Time `bson:"created_at"`
+	PaidAt    time.Time `bson:"paid_at,omitempty"`
+}
+
+type server struct {
+	jackpotpb.UnimplementedJackpotServiceServer
+	pools    *mongo.Collection
+	wins     *mongo.Collection
+	wallet   walletpb.WalletServiceClient
+	rateBp   int64 // процент со ставки, базисные пункты
+	seed     int64 // начальная сумма после выигрыша, кредиты
+	minStake int32
+	triggers map[string]bool // "game:trigger"
+}
+
+func envOr(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
+
+func envInt(key string, def int64) int64 {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || n < 0 {
+		log.Fatalf("%s: bad value %q", key, v)
+	}
+	return n
+}
+
+func NewServer(ctx context.Context) *server {
+	_ = godotenv.Load()
+
+	mongoURI := os.Getenv("MONGO_URI")
+	mongoDB := os.Getenv("MONGO_DB")
+	if mongoURI == "" || mongoDB == "" {
+		log.Fatal("MONGO_URI и MONGO_DB должны быть заданы")
+	}
+
+	s := &server{
+		rateBp:   envInt("JACKPOT_RATE_BP", 100),
+		seed:     envInt("JACKPOT_SEED", 1000),
+		minStake: int32(envInt("JACKPOT_MIN_STAKE", 10)),
+		triggers: make(map[string]bool),
+	}
+	if s.rateBp > 10000 {
+		log.Fatal("JACKPOT_RATE_BP не может быть больше 10000")
+	}
+	// какие редкие исходы в каких играх выигрывают фонд
+	for _, t := range strings.Split(envOr("JACKPOT_TRIGGERS", "blackjack:777,slots:777"), ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			s.triggers[t] = true
+		}
+	}
+
+	log.Printf("[init] connecting to MongoDB at %s", mongoURI)
+	mClient, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoURI))
+	if err != nil {
+		log.Fatalf("[init][mongo] connect error: %v", err)
+	}
+	db := mClient.Database(mongoDB)
+	s.pools = db.Collection(envOr("MONGO_POOLS_COL", "jackpot_pools"))
+	s.wins = db.Collection(envOr("MONGO_WINS_COL", "jackpot_wins"))
+	if _, err := s.wins.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "created_at", Value: -1}},
+	}); err != nil {
+		log.Fatalf("[init][mongo] wins index: %v", err)
+	}
+	// фонд создаётся один раз с начальной суммой
+	if _, err := s.pools.UpdateOne(ctx,
+		bson.M{"_id": poolId},
+		bson.M{"$setOnInsert": bson.M{"amount_cents": s.seed * 100, "generation": 0, "updated_at": time.Now()}},
+		options.Update().SetUpsert(true)); err != nil {
+		log.Fatalf("[init][mongo] pool: %v", err)
+	}
+	log.Printf("[init] jackpot: rate %d bp, seed %d, triggers %v", s.rateBp, s.seed, s.triggers)
+
+	wa, err := grpc.Dial(envOr("WALLET_SERVICE_ADDR", "localhost:50052"), grpc.WithInsecure())
+	if err != nil {
+		log.Fatalf("[init] cannot dial wallet service: %v", err)
+	}
+	s.wallet = walletpb.NewWalletServiceClient(wa)
+	return s
+}
+
+func (s *server) Contribute(ctx context.Context, req *jackpotpb.ContributeRequest) (*jackpotpb.ContributeResponse, error) {
+	if req.Stake < s.minStake {
+		return &jackpotpb.ContributeResponse{}, nil
+	}
+	// stake * rate_bp / 10000 кредитов = stake * rate_bp / 100 сотых
+	cents := int64(req.Stake) * s.rateBp / 100
+	var p PoolDoc
+	err := s.pools.FindOneAndUpdate(ctx,
+		bson.M{"_id": poolId},
+		bson.M{"$inc": bson.M{"amount_cents": cents}, "$set": bson.M{"updated_at": time.Now()}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&p)
+	if err != nil {
+		log.Printf("[Contribute] mongo error: %v", err)
+		return nil, err
+	}
+	return &jackpotpb.ContributeResponse{ContributedCents: cents, Amount: p.AmountCents / 100}, nil
+}
+
+// Claim выдаёт фонд за раунд. Списание фонда и запись о выигрыше
+// (_id = game:round) делаются в одной транзакции: раунд, у которого записи
+// нет, фонд не забирал, так что повторный Claim после сбоя не заберёт новый
+// фонд, набранный после чужого выигрыша. Повторный Claim того же раунда
+// возвращает ту же запись.
+func (s *server) Claim(ctx context.Context, req *jackpotpb.ClaimRequest) (*jackpotpb.JackpotWin, error) {
+	if req.UserId == "" || req.RoundId == "" {
+		return nil, fmt.Errorf("user_id and round_id required")
+	}
+	if !s.triggers[req.Game+":"+req.Trigger] {
+		return nil, fmt.Errorf("%q is not a jackpot trigger for %s", req.Trigger, req.Game)
+	}
+	key := req.Game + ":" + req.RoundId
+
+	if w, err := s.findWin(ctx, key); err != nil || w != nil {
+		if w != nil {
+			return s.pay(ctx, w)
+		}
+		return nil, err
+	}
+	// новый выигрыш — только за ставку, которая пополняла фонд; как и
+	// Contribute, мелкую ставку молча пропускаем: повторять тут нечего
+	if req.Stake < s.minStake {
+		return &jackpotpb.JackpotWin{}, nil
+	}
+
+	sess, err := s.pools.Database().Client().StartSession()
+	if err != nil {
+		return nil, err
+	}
+	defer sess.EndSession(context.Background())
+	var w *WinDoc
+	fresh := false
+	_, err = sess.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		// параллельный Claim того же раунда мог успеть раньше
+		found, err := s.findWin(sc, key)
+		if err != nil {
+			return nil, err
+		}
+		if found != nil {
+			w, fresh = found, false
+			return nil, nil
+		}
+		var before PoolDoc
+		err = s.pools.FindOneAndUpdate(sc,
+			bson.M{"_id": poolId},
+			// копейки сверх целых кредитов остаются в фонде
+			[]bson.M{{"$set": bson.M{
+				"amount_cents": bson.M{"$add": bson.A{s.seed * 100, bson.M{"$mod": bson.A{"$amount_cents", 100}}}},
+				"generation":   bson.M{"$add": bson.A{"$generation", 1}},
+				"last_claim":   bson.M{"$literal": key},
+				"last_user":    bson.M{"$literal": req.UserId},
+				"last_game":    bson.M{"$literal": req.Game},
+				"last_round":   bson.M{"$literal": req.RoundId},
+				"last_trigger": bson.M{"$literal": req.Trigger},
+				"last_amount":  bson.M{"$toLong": bson.M{"$trunc": bson.M{"$divide": bson.A{"$amount_cents", 100}}}},
+				"updated_at":   time.Now(),
+			}}},
+			options.FindOneAndUpdate().SetReturnDocument(options.Before)).Decode(&before)
+		if err != nil {
+			return nil, err
+		}
+		w = &WinDoc{
+			Id:        key,
+			PoolId:    poolId,
+			Game:      req.Game,
+			UserId:    req.UserId,
+			RoundId:   req.RoundId,
+			Trigger:   req.Trigger,
+			Amount:    int32(before.AmountCents / 100),
+			Status:    "pending",
+			CreatedAt: time.Now(),
+		}
+		fresh = true
+		_, err = s.wins.InsertOne(sc, w)
+		return nil, err
+	})
+	if err != nil {
+		log.Printf("[Claim] %s: %v", key, err)
+		return nil, err
+	}
+	if fresh {
+		log.Printf("[Claim] JACKPOT %d to %s (%s)", w.Amount, w.UserId, key)
+	}
+	return s.pay(ctx, w)
+}
+
+func (s *server) findWin(ctx context.Context, id string) (*WinDoc, error) {
+	var w WinDoc
+	err := s.wins.FindOne(ctx, bson.M{"_id": id}).Decode(&w)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
+
+// pay: pending -> paying (выплачивает только тот, кто сделал этот переход),
+// кошелёк, paying -> paid. Запись, застрявшая в paying, могла уже быть
+// зачислена; recover доплачивает её с тем же ключом идемпотентности, так что
+// второго зачисления не будет.
+func (s *server) pay(ctx context.Context, w *WinDoc) (*jackpotpb.JackpotWin, error) {
+	if w.Status != "pending" {
+		return winToPb(w), nil
+	}
+	res, err := s.wins.UpdateOne(ctx, bson.M{"_id": w.Id, "status": "pending"}, bson.M{"$set": bson.M{"status": "paying"}})
+	if err != nil {
+		return nil, err
+	}
+	if res.ModifiedCount == 0 {
+		// платит кто-то другой
+		cur, err := s.findWin(ctx, w.Id)
+		if err != nil || cur == nil {
+			return nil, fmt.Errorf("jackpot win %s: %v", w.Id, err)
+		}
+		return winToPb(cur), nil
+	}
+	w.Status = "paying"
+
+	if err := s.credit(ctx, w); err != nil {
+		// вернём в pending — следующий Claim или recover повторит с тем же ключом
+		if _, uerr := s.wins.UpdateOne(context.Background(), bson.M{"_id": w.Id, "status": "paying"}, bson.M{"$set": bson.M{"status": "pending"}}); uerr != nil {
+			log.Printf("[pay] %s: revert to pending: %v", w.Id, uerr)
+		}
+		return nil, err
+	}
+	return winToPb(w), nil
+}
+
+// credit зачисляет выигрыш в paying и отмечает его paid. Ключ
+// jackpot:<id> у кошелька один на выигрыш, повтор второй раз не платит.
+func (s *server) credit(ctx context.Context, w *WinDoc) error {
+	if _, err := s.wallet.UpdateBalance(ctx, &walletpb.WalletUpdateRequest{UserId: w.UserId, Amount: credits(w.Amount), Type: "win", Ref: w.Id, IdempotencyKey: "jackpot:" + w.Id}); err != nil {
+		log.Printf("[pay] %s: wallet error: %v", w.Id, err)
+		return err
+	}
+	w.Status, w.PaidAt = "paid", time.Now()
+	if _, err := s.wins.UpdateOne(ctx, bson.M{"_id": w.Id}, bson.M{"$set": bson.M{"status": w.Status, "paid_at": w.PaidAt}}); err != nil {
+		log.Printf("[pay] %s paid but not marked: %v", w.Id, err)
+	}
+	return nil
+}
+
+// recover восстанавливает запись о последнем выигрыше, если фонд списан, а
+// записи нет, и доплачивает выигрыши в pending и paying.
+func (s *server) recover(ctx context.Context) {
+	var p PoolDoc
+	if err := s.pools.FindOne(ctx, bson.M{"_id": poolId}).Decode(&p); err != nil {
+		log.Printf("[recover] pool: %v", err)
+		return
+	}
+	if p.LastClaim != "" {
+		w := &WinDoc{
+			Id:        p.LastClaim,
+			PoolId:    poolId,
+			Game:      p.LastGame,
+			UserId:    p.LastUser,
+			RoundId:   p.LastRound,
+			Trigger:   p.LastTrigger,
+			Amount:    int32(p.LastAmount),
+			Status:    "pending",
+			CreatedAt: p.UpdatedAt,
+		}
+		if _, err := s.wins.InsertOne(ctx, w); err == nil {
+			log.Printf("[recover] restored win %s", w.Id)
+		} else if !mongo.IsDuplicateKeyError(err) {
+			log.Printf("[recover] restore %s: %v", w.Id, err)
+		}
+	}
+
+	cur, err := s.wins.Find(ctx, bson.M{"status": bson.M{"$in": []string{"pending", "paying"}}})
+	if err != nil {
+		log.Printf("[recover] wins: %v", err)
+		return
+	}
+	var wins []WinDoc
+	if err := cur.All(ctx, &wins); err != nil {
+		log.Printf("[recover] wins: %v", err)
+		return
+	}
+	for i := range wins {
+		w := &wins[i]
+		if w.Status == "paying" {
+			// упали посреди выплаты: повтор с тем же ключом
+			if err := s.credit(ctx, w); err != nil {
+				log.Printf("[recover] pay %s (%d to %s): %v", w.Id, w.Amount, w.UserId, err)
+			}
+			continue
+		}
+		if _, err := s.pay(ctx, w); err != nil {
+			log.Printf("[recover] pay %s: %v", w.Id, err)
+		}
+	}
+}
+
+func (s *server) jackpots(ctx context.Context) (*jackpotpb.GetJackpotsResponse, error) {
+	var p PoolDoc
+	if err := s.pools.FindOne(ctx, bson.M{"_id": poolId}).Decode(&p); err != nil {
+		return nil, err
+	}
+	return &jackpotpb.GetJackpotsResponse{Jackpots: []*jackpotpb.Jackpot{{
+		PoolId:     p.Id,
+		Amount:     p.AmountCents / 100,
+		Seed:       s.seed,
+		RateBp:     int32(s.rateBp),
+		LastWinner: p.LastUser,
+		LastAmount: p.LastAmount,
+		UpdatedAt:  p.UpdatedAt.Unix(),
+	}}}, nil
+}
+
+func (s *server) GetJackpots(ctx context.Context, _ *jackpotpb.GetJackpotsRequest) (*jackpotpb.GetJackpotsResponse, error) {
+	return s.jackpots(ctx)
+}
+
+// WatchJackpots шлёт состояние сразу и затем при каждом изменении суммы.
+// Фонд перечитывается из Mongo, так что видны взносы со всех экземпляров.
+func (s *server) WatchJackpots(_ *jackpotpb.WatchJackpotsRequest, stream jackpotpb.JackpotService_WatchJackpotsServer) error {
+	ctx := stream.Context()
+	t := time.NewTicker(watchEvery)
+	defer t.Stop()
+	var last int64 = -1
+	for {
+		resp, err := s.jackpots(ctx)
+		if err != nil {
+			return err
+		}
+		if amount := resp.Jackpots[0].Amount; amount != last {
+			if err := stream.Send(resp); err != nil {
+				return err
+			}
+			last = amount
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-t.C:
+		}
+	}
+}
+
+func (s *server) ListWins(ctx context.Context, req *jackpotpb.ListWinsRequest) (*jackpotpb.ListWinsResponse, error) {
+	limit := int64(req.Limit)
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	cur, err := s.wins.Find(ctx, bson.M{}, options.Find().SetSort(bson.M{"created_at": -1}).SetLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	var wins []WinDoc
+	if err := cur.All(ctx, &wins); err != nil {
+		return nil, err
+	}
+	resp := &jackpotpb.ListWinsResponse{}
+	for i := range wins {
+		resp.Wins = append(resp.Wins, winToPb(&wins[i]))
+	}
+	return resp, nil
+}
+
+func winToPb(w *WinDoc) *jackpotpb.JackpotWin {
+	return &jackpotpb.JackpotWin{
+		WinId:     w.Id,
+		PoolId:    w.PoolId,
+		Game:      w.Game,
+		UserId:    w.UserId,
+		RoundId:   w.RoundId,
+		Trigger:   w.Trigger,
+		Amount:    w.Amount,
+		Status:    w.Status,
+		CreatedAt: w.CreatedAt.Unix(),
+	}
+}
+
+func main() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv := NewServer(ctx)
+	go srv.recover(context.Background())
+
+	lis, err := net.Listen("tcp", ":50055")
+	if err != nil {
+		log.Fatalf("failed to listen: %v", err)
+	}
+	grpcSrv := grpc.NewServer()
+	jackpotpb.RegisterJackpotServiceServer(grpcSrv, srv)
+
+	log.Println("JackpotService running on :50055")
+	log.Fatal(grpcSrv.Serve(lis))
+}
@@ -0,0 +1,12 @@
+package main
+
+import walletpb "github.com/Arsencchikkk/final/casino/proto/wallet"
+
+// Кошелёк считает в int64 минимальных единицах своей валюты, здесь суммы —
+// целые кредиты, кредит — одна единица этой валюты.
+const creditMinor = 100
+
+// credits — сумма в кредитах как деньги кошелька (в его валюте).
+func credits(n int32) *walletpb.Money {
+	return &walletpb.Money{Amount: int64(n) * creditMinor}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	catalogpb "github.com/Arsencchikkk/final/casino/proto/catalog"
+	kenopb "github.com/Arsencchikkk/final/casino/proto/keno"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// provider публикует кено в общем каталоге игр (catalog.GameProvider).
+// Раунд = билет: start покупает билеты, settle возвращает результат тиража.
+type provider struct {
+	catalogpb.UnimplementedGameProviderServer
+	s *server
+}
+
+func kenoInfo() *catalogpb.GameInfo {
+	return &catalogpb.GameInfo{
+		GameId:      "keno",
+		Name:        "Keno",
+		Description: "Выберите от 1 до 10 чисел из 80; каждые несколько минут разыгрываются 20 шаров.",
+		Kind:        "draw",
+		Limits: &catalogpb.BetLimits{
+			MinStake:  kenoMinStake,
+			MaxStake:  kenoMaxStake,
+			MaxPayout: int32(kenoMaxStake * kenoPayTable[10][10] / 10),
+		},
+		StartParams: []*catalogpb.ParamSpec{
+			{Name: "picks", Type: "int_list", Required: true, Min: 1, Max: kenoBalls, Description: "числа через запятую, от 1 до 10 штук"},
+			{Name: "draws", Type: "int", Min: 1, Max: kenoMaxDraws, DefaultValue: "1", Description: "на сколько тиражей подряд, ставка за каждый"},
+		},
+		Rtp: 0.91,
+	}
+}
+
+func (p *provider) Catalog(ctx context.Context, _ *catalogpb.CatalogRequest) (*catalogpb.CatalogResponse, error) {
+	return &catalogpb.CatalogResponse{Games: []*catalogpb.GameInfo{kenoInfo()}}, nil
+}
+
+func (p *provider) Start(ctx context.Context, req *catalogpb.StartRequest) (*catalogpb.RoundState, error) {
+	if req.GameId != "keno" {
+		return nil, fmt.Errorf("unknown game %q", req.GameId)
+	}
+	var picks []int32
+	for _, f := range strings.Split(req.Params["picks"], ",") {
+		if f = strings.TrimSpace(f); f == "" {
+			continue
+		}
+		n, err := strconv.Atoi(f)
+		if err != nil {
+			return nil, fmt.Errorf("param picks: expected comma-separated numbers")
+		}
+		picks = append(picks, int32(n))
+	}
+	draws := 1
+	if v := req.Params["draws"]; v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("param draws: expected an integer")
+		}
+		draws = n
+	}
+
+	resp, err := p.s.BuyTicket(ctx, &kenopb.BuyTicketRequest{UserId: req.UserId, Picks: picks, Stake: req.Stake, Draws: int32(draws)})
+	if err != nil {
+		return nil, err
+	}
+	// раунд — первый билет, остальные видны в state
+	rs := ticketRound(resp.Tickets[0])
+	rs.Balance = resp.Balance
+	b, _ := json.Marshal(resp)
+	rs.StateJson = string(b)
+	return rs, nil
+}
+
+func (p *provider) Act(ctx context.Context, req *catalogpb.ActRequest) (*catalogpb.RoundState, error) {
+	return nil, fmt.Errorf("keno has no actions, wait for the draw")
+}
+
+// Settle ничего не платит сам — выплаты делает планировщик тиражей; здесь только статус билета.
+func (p *provider) Settle(ctx context.Context, req *catalogpb.SettleRequest) (*catalogpb.RoundState, error) {
+	id, err := primitive.ObjectIDFromHex(req.RoundId)
+	if err != nil {
+		return nil, fmt.Errorf("ticket not found")
+	}
+	var t TicketDoc
+	err = p.s.tickets.FindOne(ctx, bson.M{"_id": id, "user_id": req.UserId}).Decode(&t)
+	if err == mongo.ErrNoDocuments {
+		return nil, fmt.Errorf("ticket not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ticketRound(ticketToPb(t)), nil
+}
+
+func ticketRound(t *kenopb.Ticket) *catalogpb.RoundState {
+	b, _ := json.Marshal(t)
+	return &catalogpb.RoundState{
+		GameId:    "keno",
+		RoundId:   t.TicketId,
+		Status:    t.Status,
+		Finished:  t.Status != "pending",
+		Stake:     t.Stake,
+		Payout:    t.Payout,
+		StateJson: string(b),
+	}
+}
@@ -0,0 +1,717 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"log"
+	"math/big"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	catalogpb "github.com/Arsencchikkk/final/casino/proto/catalog"
+	jackpotpb "github.com/Arsencchikkk/final/casino/proto/jackpot"
+	kenopb "github.com/Arsencchikkk/final/casino/proto/keno"
+	walletpb "github.com/Arsencchikkk/final/casino/proto/wallet"
+	"github.com/joho/godotenv"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc"
+)
+
+const (
+	kenoBalls    = 80
+	kenoDrawn    = 20
+	kenoMaxPicks = 10
+	kenoMinStake = 1
+	kenoMaxStake = 1000
+	// сколько тиражей вперёд можно купить одним запросом
+	kenoMaxDraws = 10
+	// продажа на тираж закрывается за столько до его начала
+	kenoSalesCutoff = 10 * time.Second
+	// билетов в одном RecordResults
+	resultsBatch = 500
+)
+
+// kenoPayTable: picks -> hits -> выплата в десятых долях ставки (36 = 3.6x).
+// RTP по строкам — от 87% до 95%.
+var kenoPayTable = map[int]map[int]int64{
+	1:  {1: 36},
+	2:  {1: 10, 2: 90},
+	3:  {2: 20, 3: 460},
+	4:  {2: 15, 3: 50, 4: 1100},
+	5:  {3: 30, 4: 150, 5: 8000},
+	6:  {3: 20, 4: 60, 5: 900, 6: 16000},
+	7:  {3: 10, 4: 40, 5: 180, 6: 3500, 7: 60000},
+	8:  {4: 20, 5: 140, 6: 1000, 7: 15000, 8: 100000},
+	9:  {4: 10, 5: 60, 6: 450, 7: 4000, 8: 40000, 9: 250000},
+	10: {0: 30, 5: 20, 6: 240, 7: 1400, 8: 10000, 9: 50000, 10: 1000000},
+}
+
+// DrawDoc — тираж в Mongo
+type DrawDoc struct {
+	DrawNo  int64     `bson:"draw_no"`
+	DrawAt  time.Time `bson:"draw_at"`
+	Numbers []int32   `bson:"numbers"`
+	Status  string    `bson:"status"` // "drawn", "settled"
+	Tickets int32     `bson:"tickets"`
+	Paid    int32     `bson:"paid"`
+}
+
+// TicketDoc — билет игрока на один тираж
+type TicketDoc struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	UserId    string             `bson:"user_id"`
+	DrawNo    int64              `bson:"draw_no"`
+	Picks     []int32            `bson:"picks"`
+	Stake     int32              `bson:"stake"`
+	Currency  string             `bson:"currency,omitempty"` // "" — основная
+	Status    string             `bson:"status"`             // "pending", "won", "lost", "paid"
+	Hits      int32              `bson:"hits"`
+	Payout    int32              `bson:"payout"`
+	CreatedAt time.Time          `bson:"created_at"`
+	// рассчитан, но ещё не принят кошельком (журнал аудита, лидерборды)
+	Unrecorded bool `bson:"unrecorded,omitempty"`
+	// ref ставки в кошельке: покупка на несколько тиражей списывается одной
+	// проводкой по первому билету, и выплаты всех её билетов идут под тем же
+	// ref — так кошелёк делит их между деньгами и бонусом, как делил ставку
+	BetRef string `bson:"bet_ref,omitempty"`
+}
+
+// betRef — ref ставки билета; у билетов, купленных до bet_ref, это он сам.
+func (t TicketDoc) betRef() string {
+	if t.BetRef != "" {
+		return t.BetRef
+	}
+	return t.ID.Hex()
+}
+
+type server struct {
+	kenopb.UnimplementedKenoServiceServer
+	draws    *mongo.Collection
+	tickets  *mongo.Collection
+	wallet   walletpb.WalletServiceClient
+	jackpot  jackpotpb.JackpotServiceClient
+	interval time.Duration
+	// основная валюта кошелька: билеты в ней идут без валюты
+	currency string
+}
+
+func NewServer(ctx context.Context) *server {
+	_ = godotenv.Load()
+
+	mongoURI := os.Getenv("MONGO_URI")
+	mongoDB := os.Getenv("MONGO_DB")
+	if mongoURI == "" || mongoDB == "" {
+		log.Fatal("MONGO_URI и MONGO_DB должны быть заданы")
+	}
+	drawsCol := envOr("MONGO_DRAWS_COL", "keno_draws")
+	ticketsCol := envOr("MONGO_TICKETS_COL", "keno_tickets")
+
+	// интервал тиражей в минутах
+	interval := 5 * time.Minute
+	if v := os.Getenv("KENO_DRAW_INTERVAL_MIN"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			log.Fatalf("KENO_DRAW_INTERVAL_MIN: bad value %q", v)
+		}
+		interval = time.Duration(n) * time.Minute
+	}
+
+	log.Printf("[init] connecting to MongoDB at %s", mongoURI)
+	mClient, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoURI))
+	if err != nil {
+		log.Fatalf("[init][mongo] connect error: %v", err)
+	}
+	db := mClient.Database(mongoDB)
+	s := &server{
+		draws:    db.Collection(drawsCol),
+		tickets:  db.Collection(ticketsCol),
+		interval: interval,
+		currency: envOr("WALLET_CURRENCY", "USD"),
+	}
+
+	// уникальный номер тиража — гарантия, что тираж разыгрывается один раз
+	if _, err := s.draws.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "draw_no", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}); err != nil {
+		log.Fatalf("[init][mongo] draws index: %v", err)
+	}
+	if _, err := s.tickets.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "draw_no", Value: 1}, {Key: "status", Value: 1}}},
+		// catchUp на каждом тираже ищет незакрытые билеты по статусу
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "draw_no", Value: 1}}},
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "unrecorded", Value: 1}}, Options: options.Index().SetSparse(true)},
+	}); err != nil {
+		log.Fatalf("[init][mongo] tickets index: %v", err)
+	}
+	log.Printf("[init] MongoDB connected: DB=%s, draws=%s, tickets=%s", mongoDB, drawsCol, ticketsCol)
+
+	walletAddr := envOr("WALLET_SERVICE_ADDR", "localhost:50052")
+	wa, err := grpc.Dial(walletAddr, grpc.WithInsecure())
+	if err != nil {
+		log.Fatalf("[init] cannot dial wallet service: %v", err)
+	}
+	s.wallet = walletpb.NewWalletServiceClient(wa)
+
+	ja, err := grpc.Dial(envOr("JACKPOT_SERVICE_ADDR", "localhost:50055"), grpc.WithInsecure())
+	if err != nil {
+		log.Fatalf("[init] cannot dial jackpot service: %v", err)
+	}
+	s.jackpot = jackpotpb.NewJackpotServiceClient(ja)
+	return s
+}
+
+func envOr(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
+
+// Тиражи идут по сетке: тираж N проходит в момент N*interval от эпохи.
+func (s *server) drawTime(no int64) time.Time {
+	return time.Unix(0, 0).Add(time.Duration(no) * s.interval)
+}
+
+func (s *server) drawNoAt(t time.Time) int64 {
+	return int64(t.Sub(time.Unix(0, 0)) / s.interval)
+}
+
+// firstOpenDraw — ближайший тираж, на который ещё продаются билеты
+func (s *server) firstOpenDraw(now time.Time) int64 {
+	no := s.drawNoAt(now) + 1
+	if s.drawTime(no).Sub(now) < kenoSalesCutoff {
+		no++
+	}
+	return no
+}
+
+func validatePicks(picks []int32) error {
+	if len(picks) < 1 || len(picks) > kenoMaxPicks {
+		return fmt.Errorf("pick 1 to %d numbers", kenoMaxPicks)
+	}
+	seen := make(map[int32]bool)
+	for _, n := range picks {
+		if n < 1 || n > kenoBalls {
+			return fmt.Errorf("numbers must be between 1 and %d", kenoBalls)
+		}
+		if seen[n] {
+			return fmt.Errorf("number %d picked twice", n)
+		}
+		seen[n] = true
+	}
+	return nil
+}
+
+func kenoPayout(picks []int32, numbers []int32, stake int32) (int32, int32) {
+	drawn := make(map[int32]bool, len(numbers))
+	for _, n := range numbers {
+		drawn[n] = true
+	}
+	var hits int32
+	for _, p := range picks {
+		if drawn[p] {
+			hits++
+		}
+	}
+	tenths := kenoPayTable[len(picks)][int(hits)]
+	return hits, int32(int64(stake) * tenths / 10)
+}
+
+// kenoMaxPayout — самая крупная выплата билета с таким числом чисел
+func kenoMaxPayout(picks int, stake int32) int32 {
+	var best int64
+	for _, tenths := range kenoPayTable[picks] {
+		if tenths > best {
+			best = tenths
+		}
+	}
+	return int32(int64(stake) * best / 10)
+}
+
+// drawNumbers тянет 20 различных шаров из 80 (crypto/rand)
+func drawNumbers() ([]int32, error) {
+	balls := make([]int32, kenoBalls)
+	for i := range balls {
+		balls[i] = int32(i + 1)
+	}
+	for i := 0; i < kenoDrawn; i++ {
+		j, err := rand.Int(rand.Reader, big.NewInt(int64(kenoBalls-i)))
+		if err != nil {
+			return nil, err
+		}
+		k := i + int(j.Int64())
+		balls[i], balls[k] = balls[k], balls[i]
+	}
+	out := balls[:kenoDrawn]
+	sort.Slice(out, func(a, b int) bool { return out[a] < out[b] })
+	return out, nil
+}
+
+func (s *server) BuyTicket(ctx context.Context, req *kenopb.BuyTicketRequest) (*kenopb.BuyTicketResponse, error) {
+	if req.UserId == "" {
+		return nil, fmt.Errorf("user_id required")
+	}
+	if err := validatePicks(req.Picks); err != nil {
+		return nil, err
+	}
+	if req.Stake < kenoMinStake || req.Stake > kenoMaxStake {
+		return nil, fmt.Errorf("stake must be between %d and %d", kenoMinStake, kenoMaxStake)
+	}
+	draws := req.Draws
+	if draws == 0 {
+		draws = 1
+	}
+	if draws < 1 || draws > kenoMaxDraws {
+		return nil, fmt.Errorf("draws must be between 1 and %d", kenoMaxDraws)
+	}
+	total := req.Stake * draws
+	cur := s.roundCurrency(req.Currency)
+	log.Printf("[BuyTicket] user=%s picks=%v stake=%d %s draws=%d", req.UserId, req.Picks, req.Stake, cur, draws)
+
+	// 1) по билету на каждый тираж
+	picks := append([]int32(nil), req.Picks...)
+	sort.Slice(picks, func(a, b int) bool { return picks[a] < picks[b] })
+	now := time.Now()
+	first := s.firstOpenDraw(now)
+	firstId := primitive.NewObjectID()
+	docs := make([]interface{}, 0, draws)
+	tickets := make([]TicketDoc, 0, draws)
+	for i := int64(0); i < int64(draws); i++ {
+		t := TicketDoc{
+			ID:        firstId,
+			UserId:    req.UserId,
+			DrawNo:    first + i,
+			Picks:     picks,
+			Stake:     req.Stake,
+			Currency:  cur,
+			Status:    "pending",
+			CreatedAt: now,
+			BetRef:    firstId.Hex(),
+		}
+		if i > 0 {
+			t.ID = primitive.NewObjectID()
+		}
+		docs = append(docs, t)
+		tickets = append(tickets, t)
+	}
+
+	// 2) списываем ставку за все тиражи, в журнале кошелька — по первому билету
+	ref := firstId.Hex()
+	wr, err := s.wallet.UpdateBalance(ctx, &walletpb.WalletUpdateRequest{UserId: req.UserId, Amount: creditsIn(-total, cur), Type: "bet", Ref: ref, IdempotencyKey: "keno:" + ref + ":bet"})
+	if err != nil {
+		return nil, err
+	}
+
+	// 3) сохраняем билеты
+	if _, err := s.tickets.InsertMany(ctx, docs); err != nil {
+		log.Printf("[BuyTicket] mongo InsertMany error: %v, refunding %d", err, total)
+		if _, rerr := s.wallet.UpdateBalance(context.Background(), &walletpb.WalletUpdateRequest{UserId: req.UserId, Amount: creditsIn(total, cur), Type: "refund", Ref: ref, IdempotencyKey: "keno:" + ref + ":refund"}); rerr != nil {
+			log.Printf("[BuyTicket] refund error: %v", rerr)
+		}
+		return nil, err
+	}
+
+	// 4) процент со ставок — в общий джекпот; на покупку билета не влияет.
+	// Фонд — в основной валюте, его пополняют только билеты в ней
+	go func() {
+		if cur != "" {
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		for _, t := range tickets {
+			if strings.HasPrefix(t.UserId, "demo:") {
+				// демо-ставки виртуальные, в настоящий фонд не идут
+				return
+			}
+			if _, err := s.jackpot.Contribute(ctx, &jackpotpb.ContributeRequest{
+				Game: "keno", UserId: t.UserId, RoundId: t.ID.Hex(), Stake: t.Stake,
+			}); err != nil {
+				log.Printf("[BuyTicket] jackpot contribute error: %v", err)
+				return
+			}
+		}
+	}()
+
+	resp := &kenopb.BuyTicketResponse{Balance: toCredits(wr.NewBalance), Currency: cur}
+	for _, t := range tickets {
+		resp.Tickets = append(resp.Tickets, ticketToPb(t))
+	}
+	return resp, nil
+}
+
+func (s *server) NextDraw(ctx context.Context, _ *kenopb.NextDrawRequest) (*kenopb.NextDrawResponse, error) {
+	no := s.firstOpenDraw(time.Now())
+	resp := &kenopb.NextDrawResponse{
+		DrawNo:      no,
+		DrawAt:      s.drawTime(no).Unix(),
+		IntervalSec: int32(s.interval / time.Second),
+	}
+	for picks := 1; picks <= kenoMaxPicks; picks++ {
+		row := &kenopb.PayTableRow{Picks: int32(picks)}
+		var hits []int
+		for h := range kenoPayTable[picks] {
+			hits = append(hits, h)
+		}
+		sort.Ints(hits)
+		for _, h := range hits {
+			row.Payouts = append(row.Payouts, &kenopb.PayTableEntry{
+				Hits:       int32(h),
+				Multiplier: float64(kenoPayTable[picks][h]) / 10,
+			})
+		}
+		resp.PayTable = append(resp.PayTable, row)
+	}
+	return resp, nil
+}
+
+func (s *server) ListDraws(ctx context.Context, req *kenopb.ListDrawsRequest) (*kenopb.ListDrawsResponse, error) {
+	filter := bson.M{}
+	if req.Before > 0 {
+		filter["draw_no"] = bson.M{"$lt": req.Before}
+	}
+	opts := options.Find().SetSort(bson.M{"draw_no": -1}).SetLimit(int64(clampLimit(req.Limit)))
+	cur, err := s.draws.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var docs []DrawDoc
+	if err := cur.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	resp := &kenopb.ListDrawsResponse{}
+	for _, d := range docs {
+		resp.Draws = append(resp.Draws, drawToPb(d))
+	}
+	return resp, nil
+}
+
+func (s *server) GetDraw(ctx context.Context, req *kenopb.GetDrawRequest) (*kenopb.Draw, error) {
+	var d DrawDoc
+	err := s.draws.FindOne(ctx, bson.M{"draw_no": req.DrawNo}).Decode(&d)
+	if err == mongo.ErrNoDocuments {
+		return nil, fmt.Errorf("draw not found")
+	} else if err != nil {
+		return nil, err
+	}
+	return drawToPb(d), nil
+}
+
+func (s *server) ListTickets(ctx context.Context, req *kenopb.ListTicketsRequest) (*kenopb.ListTicketsResponse, error) {
+	if req.UserId == "" {
+		return nil, fmt.Errorf("user_id required")
+	}
+	filter := bson.M{"user_id": req.UserId}
+	if req.DrawNo > 0 {
+		filter["draw_no"] = req.DrawNo
+	}
+	if req.Status != "" {
+		filter["status"] = req.Status
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "draw_no", Value: -1}}).SetLimit(int64(clampLimit(req.Limit)))
+	cur, err := s.tickets.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var docs []TicketDoc
+	if err := cur.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	resp := &kenopb.ListTicketsResponse{}
+	for _, t := range docs {
+		resp.Tickets = append(resp.Tickets, ticketToPb(t))
+	}
+	return resp, nil
+}
+
+func clampLimit(limit int32) int32 {
+	if limit <= 0 {
+		return 20
+	}
+	if limit > 100 {
+		return 100
+	}
+	return limit
+}
+
+func drawToPb(d DrawDoc) *kenopb.Draw {
+	return &kenopb.Draw{
+		DrawNo:  d.DrawNo,
+		DrawAt:  d.DrawAt.Unix(),
+		Numbers: d.Numbers,
+		Status:  d.Status,
+		Tickets: d.Tickets,
+		Paid:    d.Paid,
+	}
+}
+
+func ticketToPb(t TicketDoc) *kenopb.Ticket {
+	return &kenopb.Ticket{
+		TicketId:  t.ID.Hex(),
+		UserId:    t.UserId,
+		DrawNo:    t.DrawNo,
+		Picks:     t.Picks,
+		Stake:     t.Stake,
+		Status:    t.Status,
+		Hits:      t.Hits,
+		Payout:    t.Payout,
+		CreatedAt: t.CreatedAt.Unix(),
+		Currency:  t.Currency,
+	}
+}
+
+// runScheduler проводит тиражи по сетке и догоняет незакрытые: после рестарта
+// и после каждого тиража.
+func (s *server) runScheduler(ctx context.Context) {
+	s.catchUp(ctx)
+	for {
+		no := s.drawNoAt(time.Now()) + 1
+		t := time.NewTimer(time.Until(s.drawTime(no)))
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return
+		case <-t.C:
+		}
+		if err := s.runDraw(ctx, no); err != nil {
+			log.Printf("[draw %d] error: %v", no, err)
+		}
+		// тиражи, расчёт которых сорвался (кошелёк или Mongo), доводим
+		// на каждом такте, а не только после рестарта
+		s.catchUp(ctx)
+		// заодно досылаем то, что кошелёк не принял в прошлых тиражах
+		if err := s.reportResults(ctx, bson.M{"unrecorded": true}); err != nil {
+			log.Printf("[results] %v", err)
+		}
+	}
+}
+
+// catchUp рассчитывает тиражи, которые не закрылись: сервис остановился или
+// расчёт прервался ошибкой
+func (s *server) catchUp(ctx context.Context) {
+	var pending []int64
+
+	cur, err := s.draws.Find(ctx, bson.M{"status": "drawn"})
+	if err == nil {
+		var docs []DrawDoc
+		if err := cur.All(ctx, &docs); err == nil {
+			for _, d := range docs {
+				pending = append(pending, d.DrawNo)
+			}
+		}
+	}
+	current := s.drawNoAt(time.Now())
+	nos, err := s.tickets.Distinct(ctx, "draw_no", bson.M{
+		"status":  bson.M{"$in": []string{"pending", "won"}},
+		"draw_no": bson.M{"$lte": current},
+	})
+	if err == nil {
+		for _, v := range nos {
+			if no, ok := v.(int64); ok {
+				pending = append(pending, no)
+			}
+		}
+	}
+	if err != nil {
+		log.Printf("[catchUp] mongo error: %v", err)
+	}
+
+	sort.Slice(pending, func(a, b int) bool { return pending[a] < pending[b] })
+	for i, no := range pending {
+		if i > 0 && pending[i-1] == no {
+			continue
+		}
+		log.Printf("[catchUp] settling draw %d", no)
+		if err := s.runDraw(ctx, no); err != nil {
+			log.Printf("[draw %d] error: %v", no, err)
+		}
+	}
+}
+
+// runDraw разыгрывает тираж (один раз — за это отвечает уникальный индекс)
+// и рассчитывает все его билеты. Повторный вызов безопасен.
+func (s *server) runDraw(ctx context.Context, no int64) error {
+	numbers, err := drawNumbers()
+	if err != nil {
+		return err
+	}
+	draw := DrawDoc{DrawNo: no, DrawAt: s.drawTime(no), Numbers: numbers, Status: "drawn"}
+	if _, err := s.draws.InsertOne(ctx, draw); mongo.IsDuplicateKeyError(err) {
+		// уже разыгран — берём сохранённые числа
+		if err := s.draws.FindOne(ctx, bson.M{"draw_no": no}).Decode(&draw); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	} else {
+		log.Printf("[draw %d] numbers %v", no, numbers)
+	}
+	return s.settle(ctx, draw)
+}
+
+// settle: pending -> won/lost, пакетная выплата через кошелёк, won -> paid.
+func (s *server) settle(ctx context.Context, draw DrawDoc) error {
+	cur, err := s.tickets.Find(ctx, bson.M{"draw_no": draw.DrawNo, "status": "pending"})
+	if err != nil {
+		return err
+	}
+	var pending []TicketDoc
+	if err := cur.All(ctx, &pending); err != nil {
+		return err
+	}
+	if len(pending) > 0 {
+		models := make([]mongo.WriteModel, 0, len(pending))
+		for _, t := range pending {
+			hits, payout := kenoPayout(t.Picks, draw.Numbers, t.Stake)
+			status := "lost"
+			if payout > 0 {
+				status = "won"
+			}
+			// пометка unrecorded пишется вместе с расчётом: раунд дойдёт до
+			// журнала аудита, даже если кошелёк сейчас недоступен
+			models = append(models, mongo.NewUpdateOneModel().
+				SetFilter(bson.M{"_id": t.ID, "status": "pending"}).
+				SetUpdate(bson.M{"$set": bson.M{"status": status, "hits": hits, "payout": payout, "unrecorded": true}}))
+		}
+		if _, err := s.tickets.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false)); err != nil {
+			return err
+		}
+	}
+	// выплаты от кошелька-лидерборда не зависят: не принятое дошлём позже
+	if err := s.reportResults(ctx, bson.M{"draw_no": draw.DrawNo, "unrecorded": true}); err != nil {
+		log.Printf("[draw %d] results: %v", draw.DrawNo, err)
+	}
+
+	// выплачиваем всё, что выиграно, но ещё не зачислено
+	cur, err = s.tickets.Find(ctx, bson.M{"draw_no": draw.DrawNo, "status": "won"})
+	if err != nil {
+		return err
+	}
+	var won []TicketDoc
+	if err := cur.All(ctx, &won); err != nil {
+		return err
+	}
+	if len(won) > 0 {
+		// повтор после сбоя выплатит тот же набор билетов тем же ключом
+		req := &walletpb.BatchUpdateRequest{IdempotencyKey: fmt.Sprintf("keno:draw:%d:payouts", draw.DrawNo)}
+		ids := make([]primitive.ObjectID, 0, len(won))
+		for _, t := range won {
+			req.Updates = append(req.Updates, &walletpb.BalanceDelta{UserId: t.UserId, Amount: creditsIn(t.Payout, t.Currency), Type: "win", Ref: t.betRef()})
+			ids = append(ids, t.ID)
+		}
+		if _, err := s.wallet.BatchUpdateBalance(ctx, req); err != nil {
+			return fmt.Errorf("wallet batch: %w", err)
+		}
+		if _, err := s.tickets.UpdateMany(ctx,
+			bson.M{"_id": bson.M{"$in": ids}, "status": "won"},
+			bson.M{"$set": bson.M{"status": "paid"}}); err != nil {
+			return err
+		}
+	}
+
+	// итоги тиража
+	agg, err := s.tickets.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"draw_no": draw.DrawNo}}},
+		{{Key: "$group", Value: bson.M{"_id": nil, "tickets": bson.M{"$sum": 1}, "paid": bson.M{"$sum": "$payout"}}}},
+	})
+	if err != nil {
+		return err
+	}
+	var totals []struct {
+		Tickets int32 `bson:"tickets"`
+		Paid    int32 `bson:"paid"`
+	}
+	if err := agg.All(ctx, &totals); err != nil {
+		return err
+	}
+	set := bson.M{"status": "settled", "tickets": int32(0), "paid": int32(0)}
+	if len(totals) > 0 {
+		set["tickets"], set["paid"] = totals[0].Tickets, totals[0].Paid
+	}
+	if _, err := s.draws.UpdateOne(ctx, bson.M{"draw_no": draw.DrawNo}, bson.M{"$set": set}); err != nil {
+		return err
+	}
+	log.Printf("[draw %d] settled: %v tickets, paid %v", draw.DrawNo, set["tickets"], set["paid"])
+	return nil
+}
+
+// reportResults отправляет рассчитанные билеты с пометкой unrecorded в
+// кошелёк пачками и снимает пометку. Кошелёк учитывает раунд один раз, так
+// что повторная отправка безопасна.
+func (s *server) reportResults(ctx context.Context, filter bson.M) error {
+	for {
+		cur, err := s.tickets.Find(ctx, filter, options.Find().SetLimit(resultsBatch))
+		if err != nil {
+			return err
+		}
+		var tickets []TicketDoc
+		if err := cur.All(ctx, &tickets); err != nil {
+			return err
+		}
+		if len(tickets) == 0 {
+			return nil
+		}
+		results := make([]*walletpb.GameResult, 0, len(tickets))
+		ids := make([]primitive.ObjectID, 0, len(tickets))
+		for _, t := range tickets {
+			results = append(results, ticketResult(t))
+			ids = append(ids, t.ID)
+		}
+		if _, err := s.wallet.RecordResults(ctx, &walletpb.RecordResultsRequest{Results: results}); err != nil {
+			return fmt.Errorf("record %d results: %w", len(results), err)
+		}
+		if _, err := s.tickets.UpdateMany(ctx, bson.M{"_id": bson.M{"$in": ids}}, bson.M{"$unset": bson.M{"unrecorded": ""}}); err != nil {
+			return err
+		}
+		if len(tickets) < resultsBatch {
+			return nil
+		}
+	}
+}
+
+// ticketResult — итог рассчитанного билета для кошелька.
+func ticketResult(t TicketDoc) *walletpb.GameResult {
+	res := &walletpb.GameResult{
+		UserId:    t.UserId,
+		Game:      "keno",
+		RoundId:   t.ID.Hex(),
+		Stake:     creditsIn(t.Stake, t.Currency),
+		Payout:    creditsIn(t.Payout, t.Currency),
+		RuleSet:   fmt.Sprintf("spots_%d", len(t.Picks)),
+		Rtp:       kenoInfo().Rtp,
+		Liability: creditsIn(kenoMaxPayout(len(t.Picks), t.Stake), t.Currency),
+	}
+	for n := int32(5); n <= t.Hits; n++ {
+		res.Tags = append(res.Tags, fmt.Sprintf("hits_%d", n))
+	}
+	return res
+}
+
+func main() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	srv := NewServer(ctx)
+	go srv.runScheduler(context.Background())
+
+	lis, err := net.Listen("tcp", ":50054")
+	if err != nil {
+		log.Fatalf("failed to listen: %v", err)
+	}
+	grpcSrv := grpc.NewServer()
+	kenopb.RegisterKenoServiceServer(grpcSrv, srv)
+	catalogpb.RegisterGameProviderServer(grpcSrv, &provider{s: srv})
+
+	log.Println("KenoService running on :50054")
+	log.Fatal(grpcSrv.Serve(lis))
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"strings"
+
+	walletpb "github.com/Arsencchikkk/final/casino/proto/wallet"
+)
+
+// Кошелёк считает в int64 минимальных единицах валюты, здесь суммы — целые
+// кредиты, кредит — одна единица валюты билета.
+const creditMinor = 100
+
+// credits — сумма в кредитах как деньги кошелька в основной валюте.
+func credits(n int32) *walletpb.Money {
+	return creditsIn(n, "")
+}
+
+// creditsIn — сумма в кредитах в валюте билета ("" — основная).
+func creditsIn(n int32, currency string) *walletpb.Money {
+	return &walletpb.Money{Amount: int64(n) * creditMinor, Currency: currency}
+}
+
+// roundCurrency — валюта ставки из запроса, "" для основной. Незнакомую
+// валюту отклонит кошелёк.
+func (s *server) roundCurrency(c string) string {
+	c = strings.ToUpper(strings.TrimSpace(c))
+	if c == s.currency {
+		return ""
+	}
+	return c
+}
+
+// toCredits — баланс кошелька в целых кредитах для ответа; доли кредита
+// (после пополнения с копейками) отбрасываются.
+func toCredits(m *walletpb.Money) int32 {
+	return int32(m.GetAmount() / creditMinor)
+}
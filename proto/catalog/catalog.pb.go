@@ -0,0 +1,845 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.30.2
+// source: catalog.proto
+
+package catalog
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// --- Метаданные и лимиты ---
+type BetLimits struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	MinStake int32                  `protobuf:"varint,1,opt,name=min_stake,json=minStake,proto3" json:"min_stake,omitempty"`
+	MaxStake int32                  `protobuf:"varint,2,opt,name=max_stake,json=maxStake,proto3" json:"max_stake,omitempty"`
+	// максимальная выплата за один раунд
+	MaxPayout     int32 `protobuf:"varint,3,opt,name=max_payout,json=maxPayout,proto3" json:"max_payout,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BetLimits) Reset() {
+	*x = BetLimits{}
+	mi := &file_catalog_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BetLimits) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BetLimits) ProtoMessage() {}
+
+func (x *BetLimits) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BetLimits.ProtoReflect.Descriptor instead.
+func (*BetLimits) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *BetLimits) GetMinStake() int32 {
+	if x != nil {
+		return x.MinStake
+	}
+	return 0
+}
+
+func (x *BetLimits) GetMaxStake() int32 {
+	if x != nil {
+		return x.MaxStake
+	}
+	return 0
+}
+
+func (x *BetLimits) GetMaxPayout() int32 {
+	if x != nil {
+		return x.MaxPayout
+	}
+	return 0
+}
+
+// описание параметра старта/хода
+type ParamSpec struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"` // "int", "number", "string", "int_list"
+	Required      bool                   `protobuf:"varint,3,opt,name=required,proto3" json:"required,omitempty"`
+	Min           float64                `protobuf:"fixed64,4,opt,name=min,proto3" json:"min,omitempty"`
+	Max           float64                `protobuf:"fixed64,5,opt,name=max,proto3" json:"max,omitempty"`
+	DefaultValue  string                 `protobuf:"bytes,6,opt,name=default_value,json=defaultValue,proto3" json:"default_value,omitempty"`
+	Description   string                 `protobuf:"bytes,7,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ParamSpec) Reset() {
+	*x = ParamSpec{}
+	mi := &file_catalog_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ParamSpec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParamSpec) ProtoMessage() {}
+
+func (x *ParamSpec) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParamSpec.ProtoReflect.Descriptor instead.
+func (*ParamSpec) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ParamSpec) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ParamSpec) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ParamSpec) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
+func (x *ParamSpec) GetMin() float64 {
+	if x != nil {
+		return x.Min
+	}
+	return 0
+}
+
+func (x *ParamSpec) GetMax() float64 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+func (x *ParamSpec) GetDefaultValue() string {
+	if x != nil {
+		return x.DefaultValue
+	}
+	return ""
+}
+
+func (x *ParamSpec) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type GameInfo struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	GameId       string                 `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	Name         string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description  string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Kind         string                 `protobuf:"bytes,4,opt,name=kind,proto3" json:"kind,omitempty"` // "session", "round", "table", "draw"
+	Limits       *BetLimits             `protobuf:"bytes,5,opt,name=limits,proto3" json:"limits,omitempty"`
+	StartParams  []*ParamSpec           `protobuf:"bytes,6,rep,name=start_params,json=startParams,proto3" json:"start_params,omitempty"`
+	Actions      []string               `protobuf:"bytes,7,rep,name=actions,proto3" json:"actions,omitempty"`
+	ActionParams []*ParamSpec           `protobuf:"bytes,8,rep,name=action_params,json=actionParams,proto3" json:"action_params,omitempty"`
+	// теоретический RTP (0.99 = 99%)
+	Rtp           float64 `protobuf:"fixed64,9,opt,name=rtp,proto3" json:"rtp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GameInfo) Reset() {
+	*x = GameInfo{}
+	mi := &file_catalog_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameInfo) ProtoMessage() {}
+
+func (x *GameInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameInfo.ProtoReflect.Descriptor instead.
+func (*GameInfo) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GameInfo) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *GameInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GameInfo) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *GameInfo) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *GameInfo) GetLimits() *BetLimits {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+func (x *GameInfo) GetStartParams() []*ParamSpec {
+	if x != nil {
+		return x.StartParams
+	}
+	return nil
+}
+
+func (x *GameInfo) GetActions() []string {
+	if x != nil {
+		return x.Actions
+	}
+	return nil
+}
+
+func (x *GameInfo) GetActionParams() []*ParamSpec {
+	if x != nil {
+		return x.ActionParams
+	}
+	return nil
+}
+
+func (x *GameInfo) GetRtp() float64 {
+	if x != nil {
+		return x.Rtp
+	}
+	return 0
+}
+
+type CatalogRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CatalogRequest) Reset() {
+	*x = CatalogRequest{}
+	mi := &file_catalog_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CatalogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CatalogRequest) ProtoMessage() {}
+
+func (x *CatalogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CatalogRequest.ProtoReflect.Descriptor instead.
+func (*CatalogRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{3}
+}
+
+type CatalogResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Games         []*GameInfo            `protobuf:"bytes,1,rep,name=games,proto3" json:"games,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CatalogResponse) Reset() {
+	*x = CatalogResponse{}
+	mi := &file_catalog_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CatalogResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CatalogResponse) ProtoMessage() {}
+
+func (x *CatalogResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CatalogResponse.ProtoReflect.Descriptor instead.
+func (*CatalogResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CatalogResponse) GetGames() []*GameInfo {
+	if x != nil {
+		return x.Games
+	}
+	return nil
+}
+
+// --- Раунд: start / act / settle ---
+type StartRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	GameId string                 `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	UserId string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Stake  int32                  `protobuf:"varint,3,opt,name=stake,proto3" json:"stake,omitempty"`
+	Params map[string]string      `protobuf:"bytes,4,rep,name=params,proto3" json:"params,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// подкошелёк ставки (код валюты), пусто — основной; выигрыш идёт туда же
+	Currency      string `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartRequest) Reset() {
+	*x = StartRequest{}
+	mi := &file_catalog_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartRequest) ProtoMessage() {}
+
+func (x *StartRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartRequest.ProtoReflect.Descriptor instead.
+func (*StartRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *StartRequest) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *StartRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *StartRequest) GetStake() int32 {
+	if x != nil {
+		return x.Stake
+	}
+	return 0
+}
+
+func (x *StartRequest) GetParams() map[string]string {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+func (x *StartRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type ActRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GameId        string                 `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	RoundId       string                 `protobuf:"bytes,3,opt,name=round_id,json=roundId,proto3" json:"round_id,omitempty"`
+	Action        string                 `protobuf:"bytes,4,opt,name=action,proto3" json:"action,omitempty"`
+	Params        map[string]string      `protobuf:"bytes,5,rep,name=params,proto3" json:"params,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ActRequest) Reset() {
+	*x = ActRequest{}
+	mi := &file_catalog_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActRequest) ProtoMessage() {}
+
+func (x *ActRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActRequest.ProtoReflect.Descriptor instead.
+func (*ActRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ActRequest) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *ActRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ActRequest) GetRoundId() string {
+	if x != nil {
+		return x.RoundId
+	}
+	return ""
+}
+
+func (x *ActRequest) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *ActRequest) GetParams() map[string]string {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+type SettleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GameId        string                 `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	RoundId       string                 `protobuf:"bytes,3,opt,name=round_id,json=roundId,proto3" json:"round_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SettleRequest) Reset() {
+	*x = SettleRequest{}
+	mi := &file_catalog_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SettleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SettleRequest) ProtoMessage() {}
+
+func (x *SettleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SettleRequest.ProtoReflect.Descriptor instead.
+func (*SettleRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SettleRequest) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *SettleRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SettleRequest) GetRoundId() string {
+	if x != nil {
+		return x.RoundId
+	}
+	return ""
+}
+
+type RoundState struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	GameId   string                 `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	RoundId  string                 `protobuf:"bytes,2,opt,name=round_id,json=roundId,proto3" json:"round_id,omitempty"`
+	Status   string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Finished bool                   `protobuf:"varint,4,opt,name=finished,proto3" json:"finished,omitempty"`
+	Stake    int32                  `protobuf:"varint,5,opt,name=stake,proto3" json:"stake,omitempty"`
+	Payout   int32                  `protobuf:"varint,6,opt,name=payout,proto3" json:"payout,omitempty"`
+	Balance  int32                  `protobuf:"varint,7,opt,name=balance,proto3" json:"balance,omitempty"`
+	// состояние конкретной игры в JSON
+	StateJson string `protobuf:"bytes,8,opt,name=state_json,json=stateJson,proto3" json:"state_json,omitempty"`
+	// выигрыш джекпота в этом раунде (уже зачислен на баланс)
+	Jackpot int32 `protobuf:"varint,9,opt,name=jackpot,proto3" json:"jackpot,omitempty"`
+	// валюта ставки и balance, пусто — основная
+	Currency      string `protobuf:"bytes,10,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoundState) Reset() {
+	*x = RoundState{}
+	mi := &file_catalog_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoundState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoundState) ProtoMessage() {}
+
+func (x *RoundState) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoundState.ProtoReflect.Descriptor instead.
+func (*RoundState) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RoundState) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *RoundState) GetRoundId() string {
+	if x != nil {
+		return x.RoundId
+	}
+	return ""
+}
+
+func (x *RoundState) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *RoundState) GetFinished() bool {
+	if x != nil {
+		return x.Finished
+	}
+	return false
+}
+
+func (x *RoundState) GetStake() int32 {
+	if x != nil {
+		return x.Stake
+	}
+	return 0
+}
+
+func (x *RoundState) GetPayout() int32 {
+	if x != nil {
+		return x.Payout
+	}
+	return 0
+}
+
+func (x *RoundState) GetBalance() int32 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+func (x *RoundState) GetStateJson() string {
+	if x != nil {
+		return x.StateJson
+	}
+	return ""
+}
+
+func (x *RoundState) GetJackpot() int32 {
+	if x != nil {
+		return x.Jackpot
+	}
+	return 0
+}
+
+func (x *RoundState) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+var File_catalog_proto protoreflect.FileDescriptor
+
+const file_catalog_proto_rawDesc = "" +
+	"\n" +
+	"\rcatalog.proto\x12\acatalog\"d\n" +
+	"\tBetLimits\x12\x1b\n" +
+	"\tmin_stake\x18\x01 \x01(\x05R\bminStake\x12\x1b\n" +
+	"\tmax_stake\x18\x02 \x01(\x05R\bmaxStake\x12\x1d\n" +
+	"\n" +
+	"max_payout\x18\x03 \x01(\x05R\tmaxPayout\"\xba\x01\n" +
+	"\tParamSpec\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x1a\n" +
+	"\brequired\x18\x03 \x01(\bR\brequired\x12\x10\n" +
+	"\x03min\x18\x04 \x01(\x01R\x03min\x12\x10\n" +
+	"\x03max\x18\x05 \x01(\x01R\x03max\x12#\n" +
+	"\rdefault_value\x18\x06 \x01(\tR\fdefaultValue\x12 \n" +
+	"\vdescription\x18\a \x01(\tR\vdescription\"\xb5\x02\n" +
+	"\bGameInfo\x12\x17\n" +
+	"\agame_id\x18\x01 \x01(\tR\x06gameId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x12\n" +
+	"\x04kind\x18\x04 \x01(\tR\x04kind\x12*\n" +
+	"\x06limits\x18\x05 \x01(\v2\x12.catalog.BetLimitsR\x06limits\x125\n" +
+	"\fstart_params\x18\x06 \x03(\v2\x12.catalog.ParamSpecR\vstartParams\x12\x18\n" +
+	"\aactions\x18\a \x03(\tR\aactions\x127\n" +
+	"\raction_params\x18\b \x03(\v2\x12.catalog.ParamSpecR\factionParams\x12\x10\n" +
+	"\x03rtp\x18\t \x01(\x01R\x03rtp\"\x10\n" +
+	"\x0eCatalogRequest\":\n" +
+	"\x0fCatalogResponse\x12'\n" +
+	"\x05games\x18\x01 \x03(\v2\x11.catalog.GameInfoR\x05games\"\xe8\x01\n" +
+	"\fStartRequest\x12\x17\n" +
+	"\agame_id\x18\x01 \x01(\tR\x06gameId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05stake\x18\x03 \x01(\x05R\x05stake\x129\n" +
+	"\x06params\x18\x04 \x03(\v2!.catalog.StartRequest.ParamsEntryR\x06params\x12\x1a\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrency\x1a9\n" +
+	"\vParamsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xe5\x01\n" +
+	"\n" +
+	"ActRequest\x12\x17\n" +
+	"\agame_id\x18\x01 \x01(\tR\x06gameId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x19\n" +
+	"\bround_id\x18\x03 \x01(\tR\aroundId\x12\x16\n" +
+	"\x06action\x18\x04 \x01(\tR\x06action\x127\n" +
+	"\x06params\x18\x05 \x03(\v2\x1f.catalog.ActRequest.ParamsEntryR\x06params\x1a9\n" +
+	"\vParamsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\\\n" +
+	"\rSettleRequest\x12\x17\n" +
+	"\agame_id\x18\x01 \x01(\tR\x06gameId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x19\n" +
+	"\bround_id\x18\x03 \x01(\tR\aroundId\"\x91\x02\n" +
+	"\n" +
+	"RoundState\x12\x17\n" +
+	"\agame_id\x18\x01 \x01(\tR\x06gameId\x12\x19\n" +
+	"\bround_id\x18\x02 \x01(\tR\aroundId\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x1a\n" +
+	"\bfinished\x18\x04 \x01(\bR\bfinished\x12\x14\n" +
+	"\x05stake\x18\x05 \x01(\x05R\x05stake\x12\x16\n" +
+	"\x06payout\x18\x06 \x01(\x05R\x06payout\x12\x18\n" +
+	"\abalance\x18\a \x01(\x05R\abalance\x12\x1d\n" +
+	"\n" +
+	"state_json\x18\b \x01(\tR\tstateJson\x12\x18\n" +
+	"\ajackpot\x18\t \x01(\x05R\ajackpot\x12\x1a\n" +
+	"\bcurrency\x18\n" +
+	" \x01(\tR\bcurrency2\xe9\x01\n" +
+	"\fGameProvider\x12<\n" +
+	"\aCatalog\x12\x17.catalog.CatalogRequest\x1a\x18.catalog.CatalogResponse\x123\n" +
+	"\x05Start\x12\x15.catalog.StartRequest\x1a\x13.catalog.RoundState\x12/\n" +
+	"\x03Act\x12\x13.catalog.ActRequest\x1a\x13.catalog.RoundState\x125\n" +
+	"\x06Settle\x12\x16.catalog.SettleRequest\x1a\x13.catalog.RoundStateB4Z2github.com/Arsencchikkk/final/casino/proto/catalogb\x06proto3"
+
+var (
+	file_catalog_proto_rawDescOnce sync.Once
+	file_catalog_proto_rawDescData []byte
+)
+
+func file_catalog_proto_rawDescGZIP() []byte {
+	file_catalog_proto_rawDescOnce.Do(func() {
+		file_catalog_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_catalog_proto_rawDesc), len(file_catalog_proto_rawDesc)))
+	})
+	return file_catalog_proto_rawDescData
+}
+
+var file_catalog_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_catalog_proto_goTypes = []any{
+	(*BetLimits)(nil),       // 0: catalog.BetLimits
+	(*ParamSpec)(nil),       // 1: catalog.ParamSpec
+	(*GameInfo)(nil),        // 2: catalog.GameInfo
+	(*CatalogRequest)(nil),  // 3: catalog.CatalogRequest
+	(*CatalogResponse)(nil), // 4: catalog.CatalogResponse
+	(*StartRequest)(nil),    // 5: catalog.StartRequest
+	(*ActRequest)(nil),      // 6: catalog.ActRequest
+	(*SettleRequest)(nil),   // 7: catalog.SettleRequest
+	(*RoundState)(nil),      // 8: catalog.RoundState
+	nil,                     // 9: catalog.StartRequest.ParamsEntry
+	nil,                     // 10: catalog.ActRequest.ParamsEntry
+}
+var file_catalog_proto_depIdxs = []int32{
+	0,  // 0: catalog.GameInfo.limits:type_name -> catalog.BetLimits
+	1,  // 1: catalog.GameInfo.start_params:type_name -> catalog.ParamSpec
+	1,  // 2: catalog.GameInfo.action_params:type_name -> catalog.ParamSpec
+	2,  // 3: catalog.CatalogResponse.games:type_name -> catalog.GameInfo
+	9,  // 4: catalog.StartRequest.params:type_name -> catalog.StartRequest.ParamsEntry
+	10, // 5: catalog.ActRequest.params:type_name -> catalog.ActRequest.ParamsEntry
+	3,  // 6: catalog.GameProvider.Catalog:input_type -> catalog.CatalogRequest
+	5,  // 7: catalog.GameProvider.Start:input_type -> catalog.StartRequest
+	6,  // 8: catalog.GameProvider.Act:input_type -> catalog.ActRequest
+	7,  // 9: catalog.GameProvider.Settle:input_type -> catalog.SettleRequest
+	4,  // 10: catalog.GameProvider.Catalog:output_type -> catalog.CatalogResponse
+	8,  // 11: catalog.GameProvider.Start:output_type -> catalog.RoundState
+	8,  // 12: catalog.GameProvider.Act:output_type -> catalog.RoundState
+	8,  // 13: catalog.GameProvider.Settle:output_type -> catalog.RoundState
+	10, // [10:14] is the sub-list for method output_type
+	6,  // [6:10] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_catalog_proto_init() }
+func file_catalog_proto_init() {
+	if File_catalog_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_catalog_proto_rawDesc), len(file_catalog_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_catalog_proto_goTypes,
+		DependencyIndexes: file_catalog_proto_depIdxs,
+		MessageInfos:      file_catalog_proto_msgTypes,
+	}.Build()
+	File_catalog_proto = out.File
+	file_catalog_proto_goTypes = nil
+	file_catalog_proto_depIdxs = nil
+}
@@ -0,0 +1,235 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v6.30.2
+// source: catalog.proto
+
+package catalog
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	GameProvider_Catalog_FullMethodName = "/catalog.GameProvider/Catalog"
+	GameProvider_Start_FullMethodName   = "/catalog.GameProvider/Start"
+	GameProvider_Act_FullMethodName     = "/catalog.GameProvider/Act"
+	GameProvider_Settle_FullMethodName  = "/catalog.GameProvider/Settle"
+)
+
+// GameProviderClient is the client API for GameProvider service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type GameProviderClient interface {
+	Catalog(ctx context.Context, in *CatalogRequest, opts ...grpc.CallOption) (*CatalogResponse, error)
+	Start(ctx context.Context, in *StartRequest, opts ...grpc.CallOption) (*RoundState, error)
+	Act(ctx context.Context, in *ActRequest, opts ...grpc.CallOption) (*RoundState, error)
+	Settle(ctx context.Context, in *SettleRequest, opts ...grpc.CallOption) (*RoundState, error)
+}
+
+type gameProviderClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGameProviderClient(cc grpc.ClientConnInterface) GameProviderClient {
+	return &gameProviderClient{cc}
+}
+
+func (c *gameProviderClient) Catalog(ctx context.Context, in *CatalogRequest, opts ...grpc.CallOption) (*CatalogResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CatalogResponse)
+	err := c.cc.Invoke(ctx, GameProvider_Catalog_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameProviderClient) Start(ctx context.Context, in *StartRequest, opts ...grpc.CallOption) (*RoundState, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RoundState)
+	err := c.cc.Invoke(ctx, GameProvider_Start_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameProviderClient) Act(ctx context.Context, in *ActRequest, opts ...grpc.CallOption) (*RoundState, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RoundState)
+	err := c.cc.Invoke(ctx, GameProvider_Act_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameProviderClient) Settle(ctx context.Context, in *SettleRequest, opts ...grpc.CallOption) (*RoundState, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RoundState)
+	err := c.cc.Invoke(ctx, GameProvider_Settle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GameProviderServer is the server API for GameProvider service.
+// All implementations must embed UnimplementedGameProviderServer
+// for forward compatibility.
+type GameProviderServer interface {
+	Catalog(context.Context, *CatalogRequest) (*CatalogResponse, error)
+	Start(context.Context, *StartRequest) (*RoundState, error)
+	Act(context.Context, *ActRequest) (*RoundState, error)
+	Settle(context.Context, *SettleRequest) (*RoundState, error)
+	mustEmbedUnimplementedGameProviderServer()
+}
+
+// UnimplementedGameProviderServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedGameProviderServer struct{}
+
+func (UnimplementedGameProviderServer) Catalog(context.Context, *CatalogRequest) (*CatalogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Catalog not implemented")
+}
+func (UnimplementedGameProviderServer) Start(context.Context, *StartRequest) (*RoundState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Start not implemented")
+}
+func (UnimplementedGameProviderServer) Act(context.Context, *ActRequest) (*RoundState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Act not implemented")
+}
+func (UnimplementedGameProviderServer) Settle(context.Context, *SettleRequest) (*RoundState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Settle not implemented")
+}
+func (UnimplementedGameProviderServer) mustEmbedUnimplementedGameProviderServer() {}
+func (UnimplementedGameProviderServer) testEmbeddedByValue()                      {}
+
+// UnsafeGameProviderServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GameProviderServer will
+// result in compilation errors.
+type UnsafeGameProviderServer interface {
+	mustEmbedUnimplementedGameProviderServer()
+}
+
+func RegisterGameProviderServer(s grpc.ServiceRegistrar, srv GameProviderServer) {
+	// If the following call pancis, it indicates UnimplementedGameProviderServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&GameProvider_ServiceDesc, srv)
+}
+
+func _GameProvider_Catalog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CatalogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameProviderServer).Catalog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameProvider_Catalog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameProviderServer).Catalog(ctx, req.(*CatalogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameProvider_Start_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameProviderServer).Start(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameProvider_Start_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameProviderServer).Start(ctx, req.(*StartRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameProvider_Act_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ActRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameProviderServer).Act(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameProvider_Act_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameProviderServer).Act(ctx, req.(*ActRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameProvider_Settle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SettleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameProviderServer).Settle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameProvider_Settle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameProviderServer).Settle(ctx, req.(*SettleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// GameProvider_ServiceDesc is the grpc.ServiceDesc for GameProvider service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var GameProvider_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "catalog.GameProvider",
+	HandlerType: (*GameProviderServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Catalog",
+			Handler:    _GameProvider_Catalog_Handler,
+		},
+		{
+			MethodName: "Start",
+			Handler:    _GameProvider_Start_Handler,
+		},
+		{
+			MethodName: "Act",
+			Handler:    _GameProvider_Act_Handler,
+		},
+		{
+			MethodName: "Settle",
+			Handler:    _GameProvider_Settle_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "catalog.proto",
+}
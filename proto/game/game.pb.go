@@ -23,7 +23,9 @@ const (
 
 // --- NewGame: returns both dealer cards + balance ---
 type NewGameRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// игрок, с которого при раздаче списывается ставка
+	UserId        string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -58,6 +60,13 @@ func (*NewGameRequest) Descriptor() ([]byte, []int) {
 	return file_game_proto_rawDescGZIP(), []int{0}
 }
 
+func (x *NewGameRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
 type NewGameResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// уникальный идентификатор сессии
@@ -143,6 +152,7 @@ func (x *NewGameResponse) GetBalance() int32 {
 type HitRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -184,6 +194,13 @@ func (x *HitRequest) GetSessionId() string {
 	return ""
 }
 
+func (x *HitRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
 type HitResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	PlayerCards   []string               `protobuf:"bytes,1,rep,name=player_cards,json=playerCards,proto3" json:"player_cards,omitempty"`
@@ -256,6 +273,7 @@ func (x *HitResponse) GetBalance() int32 {
 type StandRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -297,6 +315,13 @@ func (x *StandRequest) GetSessionId() string {
 	return ""
 }
 
+func (x *StandRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
 type StandResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	DealerCards   []string               `protobuf:"bytes,1,rep,name=dealer_cards,json=dealerCards,proto3" json:"dealer_cards,omitempty"`
@@ -365,41 +390,3120 @@ func (x *StandResponse) GetBalance() int32 {
 	return 0
 }
 
-var File_game_proto protoreflect.FileDescriptor
+// --- Crash: общий раунд, множитель растёт до заранее зафиксированной точки ---
+type CrashBetRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Amount int32                  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	// авто-вывод при достижении множителя (0 — только вручную)
+	AutoCashout float64 `protobuf:"fixed64,3,opt,name=auto_cashout,json=autoCashout,proto3" json:"auto_cashout,omitempty"`
+	// подкошелёк ставки, пусто — основной
+	Currency      string `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_game_proto_rawDesc = "" +
-	"\n" +
-	"\n" +
-	"game.proto\x12\x04game\"\x10\n" +
-	"\x0eNewGameRequest\"\xb3\x01\n" +
-	"\x0fNewGameResponse\x12\x1d\n" +
-	"\n" +
-	"session_id\x18\x01 \x01(\tR\tsessionId\x12!\n" +
-	"\fplayer_cards\x18\x02 \x03(\tR\vplayerCards\x12!\n" +
-	"\fdealer_cards\x18\x03 \x03(\tR\vdealerCards\x12!\n" +
-	"\fplayer_total\x18\x04 \x01(\x05R\vplayerTotal\x12\x18\n" +
-	"\abalance\x18\x05 \x01(\x05R\abalance\"+\n" +
-	"\n" +
-	"HitRequest\x12\x1d\n" +
-	"\n" +
-	"session_id\x18\x01 \x01(\tR\tsessionId\"\x89\x01\n" +
-	"\vHitResponse\x12!\n" +
-	"\fplayer_cards\x18\x01 \x03(\tR\vplayerCards\x12!\n" +
-	"\fplayer_total\x18\x02 \x01(\x05R\vplayerTotal\x12\x1a\n" +
-	"\bfinished\x18\x03 \x01(\bR\bfinished\x12\x18\n" +
-	"\abalance\x18\x04 \x01(\x05R\abalance\"-\n" +
-	"\fStandRequest\x12\x1d\n" +
-	"\n" +
-	"session_id\x18\x01 \x01(\tR\tsessionId\"\x89\x01\n" +
-	"\rStandResponse\x12!\n" +
-	"\fdealer_cards\x18\x01 \x03(\tR\vdealerCards\x12!\n" +
-	"\fdealer_total\x18\x02 \x01(\x05R\vdealerTotal\x12\x18\n" +
-	"\aoutcome\x18\x03 \x01(\tR\aoutcome\x12\x18\n" +
-	"\abalance\x18\x04 \x01(\x05R\abalance2\xa3\x01\n" +
-	"\vGameService\x126\n" +
-	"\aNewGame\x12\x14.game.NewGameRequest\x1a\x15.game.NewGameResponse\x12*\n" +
-	"\x03Hit\x12\x10.game.HitRequest\x1a\x11.game.HitResponse\x120\n" +
-	"\x05Stand\x12\x12.game.StandRequest\x1a\x13.game.StandResponseB1Z/github.com/Arsencchikkk/final/casino/proto/gameb\x06proto3"
+func (x *CrashBetRequest) Reset() {
+	*x = CrashBetRequest{}
+	mi := &file_game_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CrashBetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CrashBetRequest) ProtoMessage() {}
+
+func (x *CrashBetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CrashBetRequest.ProtoReflect.Descriptor instead.
+func (*CrashBetRequest) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CrashBetRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CrashBetRequest) GetAmount() int32 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *CrashBetRequest) GetAutoCashout() float64 {
+	if x != nil {
+		return x.AutoCashout
+	}
+	return 0
+}
+
+func (x *CrashBetRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type CrashBetResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoundId       string                 `protobuf:"bytes,1,opt,name=round_id,json=roundId,proto3" json:"round_id,omitempty"`
+	Amount        int32                  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	AutoCashout   float64                `protobuf:"fixed64,3,opt,name=auto_cashout,json=autoCashout,proto3" json:"auto_cashout,omitempty"`
+	Balance       int32                  `protobuf:"varint,4,opt,name=balance,proto3" json:"balance,omitempty"`
+	Currency      string                 `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CrashBetResponse) Reset() {
+	*x = CrashBetResponse{}
+	mi := &file_game_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CrashBetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CrashBetResponse) ProtoMessage() {}
+
+func (x *CrashBetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CrashBetResponse.ProtoReflect.Descriptor instead.
+func (*CrashBetResponse) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CrashBetResponse) GetRoundId() string {
+	if x != nil {
+		return x.RoundId
+	}
+	return ""
+}
+
+func (x *CrashBetResponse) GetAmount() int32 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *CrashBetResponse) GetAutoCashout() float64 {
+	if x != nil {
+		return x.AutoCashout
+	}
+	return 0
+}
+
+func (x *CrashBetResponse) GetBalance() int32 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+func (x *CrashBetResponse) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type CrashCashoutRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	RoundId       string                 `protobuf:"bytes,2,opt,name=round_id,json=roundId,proto3" json:"round_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CrashCashoutRequest) Reset() {
+	*x = CrashCashoutRequest{}
+	mi := &file_game_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CrashCashoutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CrashCashoutRequest) ProtoMessage() {}
+
+func (x *CrashCashoutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CrashCashoutRequest.ProtoReflect.Descriptor instead.
+func (*CrashCashoutRequest) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CrashCashoutRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CrashCashoutRequest) GetRoundId() string {
+	if x != nil {
+		return x.RoundId
+	}
+	return ""
+}
+
+type CrashCashoutResponse struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	RoundId    string                 `protobuf:"bytes,1,opt,name=round_id,json=roundId,proto3" json:"round_id,omitempty"`
+	Multiplier float64                `protobuf:"fixed64,2,opt,name=multiplier,proto3" json:"multiplier,omitempty"`
+	// выплата, которая будет зачислена при расчёте раунда
+	Payout int32 `protobuf:"varint,3,opt,name=payout,proto3" json:"payout,omitempty"`
+	// валюта ставки, пусто — основная
+	Currency      string `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CrashCashoutResponse) Reset() {
+	*x = CrashCashoutResponse{}
+	mi := &file_game_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CrashCashoutResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CrashCashoutResponse) ProtoMessage() {}
+
+func (x *CrashCashoutResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CrashCashoutResponse.ProtoReflect.Descriptor instead.
+func (*CrashCashoutResponse) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CrashCashoutResponse) GetRoundId() string {
+	if x != nil {
+		return x.RoundId
+	}
+	return ""
+}
+
+func (x *CrashCashoutResponse) GetMultiplier() float64 {
+	if x != nil {
+		return x.Multiplier
+	}
+	return 0
+}
+
+func (x *CrashCashoutResponse) GetPayout() int32 {
+	if x != nil {
+		return x.Payout
+	}
+	return 0
+}
+
+func (x *CrashCashoutResponse) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type WatchCrashRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchCrashRequest) Reset() {
+	*x = WatchCrashRequest{}
+	mi := &file_game_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchCrashRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchCrashRequest) ProtoMessage() {}
+
+func (x *WatchCrashRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchCrashRequest.ProtoReflect.Descriptor instead.
+func (*WatchCrashRequest) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{10}
+}
+
+type CrashState struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	RoundId    string                 `protobuf:"bytes,1,opt,name=round_id,json=roundId,proto3" json:"round_id,omitempty"`
+	Phase      string                 `protobuf:"bytes,2,opt,name=phase,proto3" json:"phase,omitempty"` // "betting", "running" или "crashed"
+	Multiplier float64                `protobuf:"fixed64,3,opt,name=multiplier,proto3" json:"multiplier,omitempty"`
+	// известна только после краша
+	CrashPoint float64 `protobuf:"fixed64,4,opt,name=crash_point,json=crashPoint,proto3" json:"crash_point,omitempty"`
+	// sha256(server_seed), публикуется до начала раунда
+	SeedHash string `protobuf:"bytes,5,opt,name=seed_hash,json=seedHash,proto3" json:"seed_hash,omitempty"`
+	// раскрывается после краша для проверки
+	ServerSeed string `protobuf:"bytes,6,opt,name=server_seed,json=serverSeed,proto3" json:"server_seed,omitempty"`
+	// unix-время (мс) начала полёта
+	StartsAt      int64 `protobuf:"varint,7,opt,name=starts_at,json=startsAt,proto3" json:"starts_at,omitempty"`
+	Players       int32 `protobuf:"varint,8,opt,name=players,proto3" json:"players,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CrashState) Reset() {
+	*x = CrashState{}
+	mi := &file_game_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CrashState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CrashState) ProtoMessage() {}
+
+func (x *CrashState) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CrashState.ProtoReflect.Descriptor instead.
+func (*CrashState) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CrashState) GetRoundId() string {
+	if x != nil {
+		return x.RoundId
+	}
+	return ""
+}
+
+func (x *CrashState) GetPhase() string {
+	if x != nil {
+		return x.Phase
+	}
+	return ""
+}
+
+func (x *CrashState) GetMultiplier() float64 {
+	if x != nil {
+		return x.Multiplier
+	}
+	return 0
+}
+
+func (x *CrashState) GetCrashPoint() float64 {
+	if x != nil {
+		return x.CrashPoint
+	}
+	return 0
+}
+
+func (x *CrashState) GetSeedHash() string {
+	if x != nil {
+		return x.SeedHash
+	}
+	return ""
+}
+
+func (x *CrashState) GetServerSeed() string {
+	if x != nil {
+		return x.ServerSeed
+	}
+	return ""
+}
+
+func (x *CrashState) GetStartsAt() int64 {
+	if x != nil {
+		return x.StartsAt
+	}
+	return 0
+}
+
+func (x *CrashState) GetPlayers() int32 {
+	if x != nil {
+		return x.Players
+	}
+	return 0
+}
+
+// --- Texas Hold'em: столы игрок-против-игрока с рейком ---
+type HoldemTableInfo struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	TableId     string                 `protobuf:"bytes,1,opt,name=table_id,json=tableId,proto3" json:"table_id,omitempty"`
+	SmallBlind  int32                  `protobuf:"varint,2,opt,name=small_blind,json=smallBlind,proto3" json:"small_blind,omitempty"`
+	BigBlind    int32                  `protobuf:"varint,3,opt,name=big_blind,json=bigBlind,proto3" json:"big_blind,omitempty"`
+	MinBuyIn    int32                  `protobuf:"varint,4,opt,name=min_buy_in,json=minBuyIn,proto3" json:"min_buy_in,omitempty"`
+	MaxBuyIn    int32                  `protobuf:"varint,5,opt,name=max_buy_in,json=maxBuyIn,proto3" json:"max_buy_in,omitempty"`
+	Seats       int32                  `protobuf:"varint,6,opt,name=seats,proto3" json:"seats,omitempty"`
+	Players     int32                  `protobuf:"varint,7,opt,name=players,proto3" json:"players,omitempty"`
+	RakePercent float64                `protobuf:"fixed64,8,opt,name=rake_percent,json=rakePercent,proto3" json:"rake_percent,omitempty"`
+	RakeCap     int32                  `protobuf:"varint,9,opt,name=rake_cap,json=rakeCap,proto3" json:"rake_cap,omitempty"`
+	// зрители за столом и их предел
+	Spectators    int32 `protobuf:"varint,10,opt,name=spectators,proto3" json:"spectators,omitempty"`
+	MaxSpectators int32 `protobuf:"varint,11,opt,name=max_spectators,json=maxSpectators,proto3" json:"max_spectators,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HoldemTableInfo) Reset() {
+	*x = HoldemTableInfo{}
+	mi := &file_game_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HoldemTableInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HoldemTableInfo) ProtoMessage() {}
+
+func (x *HoldemTableInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HoldemTableInfo.ProtoReflect.Descriptor instead.
+func (*HoldemTableInfo) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *HoldemTableInfo) GetTableId() string {
+	if x != nil {
+		return x.TableId
+	}
+	return ""
+}
+
+func (x *HoldemTableInfo) GetSmallBlind() int32 {
+	if x != nil {
+		return x.SmallBlind
+	}
+	return 0
+}
+
+func (x *HoldemTableInfo) GetBigBlind() int32 {
+	if x != nil {
+		return x.BigBlind
+	}
+	return 0
+}
+
+func (x *HoldemTableInfo) GetMinBuyIn() int32 {
+	if x != nil {
+		return x.MinBuyIn
+	}
+	return 0
+}
+
+func (x *HoldemTableInfo) GetMaxBuyIn() int32 {
+	if x != nil {
+		return x.MaxBuyIn
+	}
+	return 0
+}
+
+func (x *HoldemTableInfo) GetSeats() int32 {
+	if x != nil {
+		return x.Seats
+	}
+	return 0
+}
+
+func (x *HoldemTableInfo) GetPlayers() int32 {
+	if x != nil {
+		return x.Players
+	}
+	return 0
+}
+
+func (x *HoldemTableInfo) GetRakePercent() float64 {
+	if x != nil {
+		return x.RakePercent
+	}
+	return 0
+}
+
+func (x *HoldemTableInfo) GetRakeCap() int32 {
+	if x != nil {
+		return x.RakeCap
+	}
+	return 0
+}
+
+func (x *HoldemTableInfo) GetSpectators() int32 {
+	if x != nil {
+		return x.Spectators
+	}
+	return 0
+}
+
+func (x *HoldemTableInfo) GetMaxSpectators() int32 {
+	if x != nil {
+		return x.MaxSpectators
+	}
+	return 0
+}
+
+type ListHoldemTablesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListHoldemTablesRequest) Reset() {
+	*x = ListHoldemTablesRequest{}
+	mi := &file_game_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListHoldemTablesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListHoldemTablesRequest) ProtoMessage() {}
+
+func (x *ListHoldemTablesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListHoldemTablesRequest.ProtoReflect.Descriptor instead.
+func (*ListHoldemTablesRequest) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{13}
+}
+
+type ListHoldemTablesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tables        []*HoldemTableInfo     `protobuf:"bytes,1,rep,name=tables,proto3" json:"tables,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListHoldemTablesResponse) Reset() {
+	*x = ListHoldemTablesResponse{}
+	mi := &file_game_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListHoldemTablesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListHoldemTablesResponse) ProtoMessage() {}
+
+func (x *ListHoldemTablesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListHoldemTablesResponse.ProtoReflect.Descriptor instead.
+func (*ListHoldemTablesResponse) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListHoldemTablesResponse) GetTables() []*HoldemTableInfo {
+	if x != nil {
+		return x.Tables
+	}
+	return nil
+}
+
+type HoldemJoinRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	TableId string                 `protobuf:"bytes,1,opt,name=table_id,json=tableId,proto3" json:"table_id,omitempty"`
+	UserId  string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	BuyIn   int32                  `protobuf:"varint,3,opt,name=buy_in,json=buyIn,proto3" json:"buy_in,omitempty"`
+	// 1..seats, 0 — любое свободное место
+	Seat int32 `protobuf:"varint,4,opt,name=seat,proto3" json:"seat,omitempty"`
+	// id посадки от клиента: повтор с тем же id не списывает бай-ин второй раз
+	JoinId        string `protobuf:"bytes,5,opt,name=join_id,json=joinId,proto3" json:"join_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HoldemJoinRequest) Reset() {
+	*x = HoldemJoinRequest{}
+	mi := &file_game_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HoldemJoinRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HoldemJoinRequest) ProtoMessage() {}
+
+func (x *HoldemJoinRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HoldemJoinRequest.ProtoReflect.Descriptor instead.
+func (*HoldemJoinRequest) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *HoldemJoinRequest) GetTableId() string {
+	if x != nil {
+		return x.TableId
+	}
+	return ""
+}
+
+func (x *HoldemJoinRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *HoldemJoinRequest) GetBuyIn() int32 {
+	if x != nil {
+		return x.BuyIn
+	}
+	return 0
+}
+
+func (x *HoldemJoinRequest) GetSeat() int32 {
+	if x != nil {
+		return x.Seat
+	}
+	return 0
+}
+
+func (x *HoldemJoinRequest) GetJoinId() string {
+	if x != nil {
+		return x.JoinId
+	}
+	return ""
+}
+
+type HoldemJoinResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TableId       string                 `protobuf:"bytes,1,opt,name=table_id,json=tableId,proto3" json:"table_id,omitempty"`
+	Seat          int32                  `protobuf:"varint,2,opt,name=seat,proto3" json:"seat,omitempty"`
+	Stack         int32                  `protobuf:"varint,3,opt,name=stack,proto3" json:"stack,omitempty"`
+	Balance       int32                  `protobuf:"varint,4,opt,name=balance,proto3" json:"balance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HoldemJoinResponse) Reset() {
+	*x = HoldemJoinResponse{}
+	mi := &file_game_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HoldemJoinResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HoldemJoinResponse) ProtoMessage() {}
+
+func (x *HoldemJoinResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HoldemJoinResponse.ProtoReflect.Descriptor instead.
+func (*HoldemJoinResponse) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *HoldemJoinResponse) GetTableId() string {
+	if x != nil {
+		return x.TableId
+	}
+	return ""
+}
+
+func (x *HoldemJoinResponse) GetSeat() int32 {
+	if x != nil {
+		return x.Seat
+	}
+	return 0
+}
+
+func (x *HoldemJoinResponse) GetStack() int32 {
+	if x != nil {
+		return x.Stack
+	}
+	return 0
+}
+
+func (x *HoldemJoinResponse) GetBalance() int32 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+type HoldemActRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	TableId string                 `protobuf:"bytes,1,opt,name=table_id,json=tableId,proto3" json:"table_id,omitempty"`
+	UserId  string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Action  string                 `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"` // "fold", "check", "call", "raise", "allin"
+	// для raise — итоговая ставка на улице ("raise to")
+	Amount        int32 `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HoldemActRequest) Reset() {
+	*x = HoldemActRequest{}
+	mi := &file_game_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HoldemActRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HoldemActRequest) ProtoMessage() {}
+
+func (x *HoldemActRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HoldemActRequest.ProtoReflect.Descriptor instead.
+func (*HoldemActRequest) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *HoldemActRequest) GetTableId() string {
+	if x != nil {
+		return x.TableId
+	}
+	return ""
+}
+
+func (x *HoldemActRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *HoldemActRequest) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *HoldemActRequest) GetAmount() int32 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+type HoldemLeaveRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TableId       string                 `protobuf:"bytes,1,opt,name=table_id,json=tableId,proto3" json:"table_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HoldemLeaveRequest) Reset() {
+	*x = HoldemLeaveRequest{}
+	mi := &file_game_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HoldemLeaveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HoldemLeaveRequest) ProtoMessage() {}
+
+func (x *HoldemLeaveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HoldemLeaveRequest.ProtoReflect.Descriptor instead.
+func (*HoldemLeaveRequest) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *HoldemLeaveRequest) GetTableId() string {
+	if x != nil {
+		return x.TableId
+	}
+	return ""
+}
+
+func (x *HoldemLeaveRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type HoldemLeaveResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// фишки, возвращённые в кошелёк
+	CashedOut int32 `protobuf:"varint,1,opt,name=cashed_out,json=cashedOut,proto3" json:"cashed_out,omitempty"`
+	// true — игрок в раздаче, фишки вернутся после её окончания
+	Pending       bool `protobuf:"varint,2,opt,name=pending,proto3" json:"pending,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HoldemLeaveResponse) Reset() {
+	*x = HoldemLeaveResponse{}
+	mi := &file_game_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HoldemLeaveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HoldemLeaveResponse) ProtoMessage() {}
+
+func (x *HoldemLeaveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HoldemLeaveResponse.ProtoReflect.Descriptor instead.
+func (*HoldemLeaveResponse) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *HoldemLeaveResponse) GetCashedOut() int32 {
+	if x != nil {
+		return x.CashedOut
+	}
+	return 0
+}
+
+func (x *HoldemLeaveResponse) GetPending() bool {
+	if x != nil {
+		return x.Pending
+	}
+	return false
+}
+
+type WatchHoldemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TableId       string                 `protobuf:"bytes,1,opt,name=table_id,json=tableId,proto3" json:"table_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchHoldemRequest) Reset() {
+	*x = WatchHoldemRequest{}
+	mi := &file_game_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchHoldemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchHoldemRequest) ProtoMessage() {}
+
+func (x *WatchHoldemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchHoldemRequest.ProtoReflect.Descriptor instead.
+func (*WatchHoldemRequest) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *WatchHoldemRequest) GetTableId() string {
+	if x != nil {
+		return x.TableId
+	}
+	return ""
+}
+
+func (x *WatchHoldemRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// Зритель видит только открытую информацию: борд, стеки, ставки и карты,
+// вскрытые на шоудауне. Карманные карты в игре не приходят никогда.
+type SpectateHoldemRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	TableId string                 `protobuf:"bytes,1,opt,name=table_id,json=tableId,proto3" json:"table_id,omitempty"`
+	// кто смотрит (user_id или адрес клиента), только для логов
+	UserId        string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SpectateHoldemRequest) Reset() {
+	*x = SpectateHoldemRequest{}
+	mi := &file_game_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SpectateHoldemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpectateHoldemRequest) ProtoMessage() {}
+
+func (x *SpectateHoldemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpectateHoldemRequest.ProtoReflect.Descriptor instead.
+func (*SpectateHoldemRequest) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *SpectateHoldemRequest) GetTableId() string {
+	if x != nil {
+		return x.TableId
+	}
+	return ""
+}
+
+func (x *SpectateHoldemRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type HoldemSeat struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Seat   int32                  `protobuf:"varint,1,opt,name=seat,proto3" json:"seat,omitempty"`
+	UserId string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Stack  int32                  `protobuf:"varint,3,opt,name=stack,proto3" json:"stack,omitempty"`
+	// ставка на текущей улице
+	Bet        int32 `protobuf:"varint,4,opt,name=bet,proto3" json:"bet,omitempty"`
+	Folded     bool  `protobuf:"varint,5,opt,name=folded,proto3" json:"folded,omitempty"`
+	AllIn      bool  `protobuf:"varint,6,opt,name=all_in,json=allIn,proto3" json:"all_in,omitempty"`
+	SittingOut bool  `protobuf:"varint,7,opt,name=sitting_out,json=sittingOut,proto3" json:"sitting_out,omitempty"`
+	// карманные карты видны только владельцу и на вскрытии
+	Cards         []string `protobuf:"bytes,8,rep,name=cards,proto3" json:"cards,omitempty"`
+	LastAction    string   `protobuf:"bytes,9,opt,name=last_action,json=lastAction,proto3" json:"last_action,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HoldemSeat) Reset() {
+	*x = HoldemSeat{}
+	mi := &file_game_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HoldemSeat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HoldemSeat) ProtoMessage() {}
+
+func (x *HoldemSeat) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HoldemSeat.ProtoReflect.Descriptor instead.
+func (*HoldemSeat) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *HoldemSeat) GetSeat() int32 {
+	if x != nil {
+		return x.Seat
+	}
+	return 0
+}
+
+func (x *HoldemSeat) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *HoldemSeat) GetStack() int32 {
+	if x != nil {
+		return x.Stack
+	}
+	return 0
+}
+
+func (x *HoldemSeat) GetBet() int32 {
+	if x != nil {
+		return x.Bet
+	}
+	return 0
+}
+
+func (x *HoldemSeat) GetFolded() bool {
+	if x != nil {
+		return x.Folded
+	}
+	return false
+}
+
+func (x *HoldemSeat) GetAllIn() bool {
+	if x != nil {
+		return x.AllIn
+	}
+	return false
+}
+
+func (x *HoldemSeat) GetSittingOut() bool {
+	if x != nil {
+		return x.SittingOut
+	}
+	return false
+}
+
+func (x *HoldemSeat) GetCards() []string {
+	if x != nil {
+		return x.Cards
+	}
+	return nil
+}
+
+func (x *HoldemSeat) GetLastAction() string {
+	if x != nil {
+		return x.LastAction
+	}
+	return ""
+}
+
+type HoldemPot struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Amount        int32                  `protobuf:"varint,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	Seats         []int32                `protobuf:"varint,2,rep,packed,name=seats,proto3" json:"seats,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HoldemPot) Reset() {
+	*x = HoldemPot{}
+	mi := &file_game_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HoldemPot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HoldemPot) ProtoMessage() {}
+
+func (x *HoldemPot) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HoldemPot.ProtoReflect.Descriptor instead.
+func (*HoldemPot) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *HoldemPot) GetAmount() int32 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *HoldemPot) GetSeats() []int32 {
+	if x != nil {
+		return x.Seats
+	}
+	return nil
+}
+
+type HoldemWinner struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Seat          int32                  `protobuf:"varint,1,opt,name=seat,proto3" json:"seat,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Amount        int32                  `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Hand          string                 `protobuf:"bytes,4,opt,name=hand,proto3" json:"hand,omitempty"`
+	Cards         []string               `protobuf:"bytes,5,rep,name=cards,proto3" json:"cards,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HoldemWinner) Reset() {
+	*x = HoldemWinner{}
+	mi := &file_game_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HoldemWinner) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HoldemWinner) ProtoMessage() {}
+
+func (x *HoldemWinner) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HoldemWinner.ProtoReflect.Descriptor instead.
+func (*HoldemWinner) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *HoldemWinner) GetSeat() int32 {
+	if x != nil {
+		return x.Seat
+	}
+	return 0
+}
+
+func (x *HoldemWinner) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *HoldemWinner) GetAmount() int32 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *HoldemWinner) GetHand() string {
+	if x != nil {
+		return x.Hand
+	}
+	return ""
+}
+
+func (x *HoldemWinner) GetCards() []string {
+	if x != nil {
+		return x.Cards
+	}
+	return nil
+}
+
+type HoldemTableState struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	TableId    string                 `protobuf:"bytes,1,opt,name=table_id,json=tableId,proto3" json:"table_id,omitempty"`
+	HandNo     int64                  `protobuf:"varint,2,opt,name=hand_no,json=handNo,proto3" json:"hand_no,omitempty"`
+	Street     string                 `protobuf:"bytes,3,opt,name=street,proto3" json:"street,omitempty"` // "waiting", "preflop", "flop", "turn", "river", "showdown"
+	Board      []string               `protobuf:"bytes,4,rep,name=board,proto3" json:"board,omitempty"`
+	Seats      []*HoldemSeat          `protobuf:"bytes,5,rep,name=seats,proto3" json:"seats,omitempty"`
+	Pots       []*HoldemPot           `protobuf:"bytes,6,rep,name=pots,proto3" json:"pots,omitempty"`
+	Button     int32                  `protobuf:"varint,7,opt,name=button,proto3" json:"button,omitempty"`
+	ToAct      int32                  `protobuf:"varint,8,opt,name=to_act,json=toAct,proto3" json:"to_act,omitempty"`
+	CurrentBet int32                  `protobuf:"varint,9,opt,name=current_bet,json=currentBet,proto3" json:"current_bet,omitempty"`
+	MinRaise   int32                  `protobuf:"varint,10,opt,name=min_raise,json=minRaise,proto3" json:"min_raise,omitempty"`
+	// unix-время (мс), когда ход будет сделан автоматически
+	ActionDeadline int64           `protobuf:"varint,11,opt,name=action_deadline,json=actionDeadline,proto3" json:"action_deadline,omitempty"`
+	Winners        []*HoldemWinner `protobuf:"bytes,12,rep,name=winners,proto3" json:"winners,omitempty"`
+	Rake           int32           `protobuf:"varint,13,opt,name=rake,proto3" json:"rake,omitempty"`
+	Spectators     int32           `protobuf:"varint,14,opt,name=spectators,proto3" json:"spectators,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *HoldemTableState) Reset() {
+	*x = HoldemTableState{}
+	mi := &file_game_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HoldemTableState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HoldemTableState) ProtoMessage() {}
+
+func (x *HoldemTableState) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HoldemTableState.ProtoReflect.Descriptor instead.
+func (*HoldemTableState) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *HoldemTableState) GetTableId() string {
+	if x != nil {
+		return x.TableId
+	}
+	return ""
+}
+
+func (x *HoldemTableState) GetHandNo() int64 {
+	if x != nil {
+		return x.HandNo
+	}
+	return 0
+}
+
+func (x *HoldemTableState) GetStreet() string {
+	if x != nil {
+		return x.Street
+	}
+	return ""
+}
+
+func (x *HoldemTableState) GetBoard() []string {
+	if x != nil {
+		return x.Board
+	}
+	return nil
+}
+
+func (x *HoldemTableState) GetSeats() []*HoldemSeat {
+	if x != nil {
+		return x.Seats
+	}
+	return nil
+}
+
+func (x *HoldemTableState) GetPots() []*HoldemPot {
+	if x != nil {
+		return x.Pots
+	}
+	return nil
+}
+
+func (x *HoldemTableState) GetButton() int32 {
+	if x != nil {
+		return x.Button
+	}
+	return 0
+}
+
+func (x *HoldemTableState) GetToAct() int32 {
+	if x != nil {
+		return x.ToAct
+	}
+	return 0
+}
+
+func (x *HoldemTableState) GetCurrentBet() int32 {
+	if x != nil {
+		return x.CurrentBet
+	}
+	return 0
+}
+
+func (x *HoldemTableState) GetMinRaise() int32 {
+	if x != nil {
+		return x.MinRaise
+	}
+	return 0
+}
+
+func (x *HoldemTableState) GetActionDeadline() int64 {
+	if x != nil {
+		return x.ActionDeadline
+	}
+	return 0
+}
+
+func (x *HoldemTableState) GetWinners() []*HoldemWinner {
+	if x != nil {
+		return x.Winners
+	}
+	return nil
+}
+
+func (x *HoldemTableState) GetRake() int32 {
+	if x != nil {
+		return x.Rake
+	}
+	return 0
+}
+
+func (x *HoldemTableState) GetSpectators() int32 {
+	if x != nil {
+		return x.Spectators
+	}
+	return 0
+}
+
+// --- Mines: открываем клетки, множитель растёт, можно забрать в любой момент ---
+type MinesStartRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// сторона поля: 5 -> 5x5
+	GridSize int32 `protobuf:"varint,2,opt,name=grid_size,json=gridSize,proto3" json:"grid_size,omitempty"`
+	Mines    int32 `protobuf:"varint,3,opt,name=mines,proto3" json:"mines,omitempty"`
+	Stake    int32 `protobuf:"varint,4,opt,name=stake,proto3" json:"stake,omitempty"`
+	// подкошелёк ставки, пусто — основной
+	Currency      string `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MinesStartRequest) Reset() {
+	*x = MinesStartRequest{}
+	mi := &file_game_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MinesStartRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MinesStartRequest) ProtoMessage() {}
+
+func (x *MinesStartRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MinesStartRequest.ProtoReflect.Descriptor instead.
+func (*MinesStartRequest) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *MinesStartRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *MinesStartRequest) GetGridSize() int32 {
+	if x != nil {
+		return x.GridSize
+	}
+	return 0
+}
+
+func (x *MinesStartRequest) GetMines() int32 {
+	if x != nil {
+		return x.Mines
+	}
+	return 0
+}
+
+func (x *MinesStartRequest) GetStake() int32 {
+	if x != nil {
+		return x.Stake
+	}
+	return 0
+}
+
+func (x *MinesStartRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type MinesRevealRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	UserId    string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	SessionId string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// номер клетки 0..grid_size*grid_size-1
+	Tile          int32 `protobuf:"varint,3,opt,name=tile,proto3" json:"tile,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MinesRevealRequest) Reset() {
+	*x = MinesRevealRequest{}
+	mi := &file_game_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MinesRevealRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MinesRevealRequest) ProtoMessage() {}
+
+func (x *MinesRevealRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MinesRevealRequest.ProtoReflect.Descriptor instead.
+func (*MinesRevealRequest) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *MinesRevealRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *MinesRevealRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *MinesRevealRequest) GetTile() int32 {
+	if x != nil {
+		return x.Tile
+	}
+	return 0
+}
+
+type MinesCashoutRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	SessionId     string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MinesCashoutRequest) Reset() {
+	*x = MinesCashoutRequest{}
+	mi := &file_game_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MinesCashoutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MinesCashoutRequest) ProtoMessage() {}
+
+func (x *MinesCashoutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MinesCashoutRequest.ProtoReflect.Descriptor instead.
+func (*MinesCashoutRequest) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *MinesCashoutRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *MinesCashoutRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+type MinesGetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	SessionId     string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MinesGetRequest) Reset() {
+	*x = MinesGetRequest{}
+	mi := &file_game_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MinesGetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MinesGetRequest) ProtoMessage() {}
+
+func (x *MinesGetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MinesGetRequest.ProtoReflect.Descriptor instead.
+func (*MinesGetRequest) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *MinesGetRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *MinesGetRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+type MinesState struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	SessionId string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	GridSize  int32                  `protobuf:"varint,2,opt,name=grid_size,json=gridSize,proto3" json:"grid_size,omitempty"`
+	Mines     int32                  `protobuf:"varint,3,opt,name=mines,proto3" json:"mines,omitempty"`
+	Stake     int32                  `protobuf:"varint,4,opt,name=stake,proto3" json:"stake,omitempty"`
+	Revealed  []int32                `protobuf:"varint,5,rep,packed,name=revealed,proto3" json:"revealed,omitempty"`
+	// расположение мин — только после окончания игры
+	MineTiles      []int32 `protobuf:"varint,6,rep,packed,name=mine_tiles,json=mineTiles,proto3" json:"mine_tiles,omitempty"`
+	Status         string  `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"` // "active", "lost", "cashing", "cashed"
+	Multiplier     float64 `protobuf:"fixed64,8,opt,name=multiplier,proto3" json:"multiplier,omitempty"`
+	NextMultiplier float64 `protobuf:"fixed64,9,opt,name=next_multiplier,json=nextMultiplier,proto3" json:"next_multiplier,omitempty"`
+	Payout         int32   `protobuf:"varint,10,opt,name=payout,proto3" json:"payout,omitempty"`
+	Balance        int32   `protobuf:"varint,11,opt,name=balance,proto3" json:"balance,omitempty"`
+	// валюта ставки, пусто — основная
+	Currency      string `protobuf:"bytes,12,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MinesState) Reset() {
+	*x = MinesState{}
+	mi := &file_game_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MinesState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MinesState) ProtoMessage() {}
+
+func (x *MinesState) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MinesState.ProtoReflect.Descriptor instead.
+func (*MinesState) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *MinesState) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *MinesState) GetGridSize() int32 {
+	if x != nil {
+		return x.GridSize
+	}
+	return 0
+}
+
+func (x *MinesState) GetMines() int32 {
+	if x != nil {
+		return x.Mines
+	}
+	return 0
+}
+
+func (x *MinesState) GetStake() int32 {
+	if x != nil {
+		return x.Stake
+	}
+	return 0
+}
+
+func (x *MinesState) GetRevealed() []int32 {
+	if x != nil {
+		return x.Revealed
+	}
+	return nil
+}
+
+func (x *MinesState) GetMineTiles() []int32 {
+	if x != nil {
+		return x.MineTiles
+	}
+	return nil
+}
+
+func (x *MinesState) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *MinesState) GetMultiplier() float64 {
+	if x != nil {
+		return x.Multiplier
+	}
+	return 0
+}
+
+func (x *MinesState) GetNextMultiplier() float64 {
+	if x != nil {
+		return x.NextMultiplier
+	}
+	return 0
+}
+
+func (x *MinesState) GetPayout() int32 {
+	if x != nil {
+		return x.Payout
+	}
+	return 0
+}
+
+func (x *MinesState) GetBalance() int32 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+func (x *MinesState) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+// --- Турниры: отдельный стек фишек, таблица лидеров, призовой фонд ---
+type Tournament struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	TournamentId string                 `protobuf:"bytes,1,opt,name=tournament_id,json=tournamentId,proto3" json:"tournament_id,omitempty"`
+	Name         string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Game         string                 `protobuf:"bytes,3,opt,name=game,proto3" json:"game,omitempty"` // "blackjack", "slots"
+	// 0 — фриролл
+	BuyIn         int32 `protobuf:"varint,4,opt,name=buy_in,json=buyIn,proto3" json:"buy_in,omitempty"`
+	StartingChips int32 `protobuf:"varint,5,opt,name=starting_chips,json=startingChips,proto3" json:"starting_chips,omitempty"`
+	// сколько раздач/спинов у игрока; 0 — без лимита, играют до ends_at
+	MaxHands int32 `protobuf:"varint,6,opt,name=max_hands,json=maxHands,proto3" json:"max_hands,omitempty"`
+	// unix-время (сек)
+	StartsAt int64 `protobuf:"varint,7,opt,name=starts_at,json=startsAt,proto3" json:"starts_at,omitempty"`
+	EndsAt   int64 `protobuf:"varint,8,opt,name=ends_at,json=endsAt,proto3" json:"ends_at,omitempty"`
+	// доли фонда по местам в процентах: [50, 30, 20]
+	Payouts []int32 `protobuf:"varint,9,rep,packed,name=payouts,proto3" json:"payouts,omitempty"`
+	// гарантия от казино, добавляется к бай-инам
+	Guarantee     int32  `protobuf:"varint,10,opt,name=guarantee,proto3" json:"guarantee,omitempty"`
+	Status        string `protobuf:"bytes,11,opt,name=status,proto3" json:"status,omitempty"` // "scheduled", "running", "finishing", "finished", "cancelled"
+	PrizePool     int32  `protobuf:"varint,12,opt,name=prize_pool,json=prizePool,proto3" json:"prize_pool,omitempty"`
+	Entries       int32  `protobuf:"varint,13,opt,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Tournament) Reset() {
+	*x = Tournament{}
+	mi := &file_game_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Tournament) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tournament) ProtoMessage() {}
+
+func (x *Tournament) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tournament.ProtoReflect.Descriptor instead.
+func (*Tournament) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *Tournament) GetTournamentId() string {
+	if x != nil {
+		return x.TournamentId
+	}
+	return ""
+}
+
+func (x *Tournament) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Tournament) GetGame() string {
+	if x != nil {
+		return x.Game
+	}
+	return ""
+}
+
+func (x *Tournament) GetBuyIn() int32 {
+	if x != nil {
+		return x.BuyIn
+	}
+	return 0
+}
+
+func (x *Tournament) GetStartingChips() int32 {
+	if x != nil {
+		return x.StartingChips
+	}
+	return 0
+}
+
+func (x *Tournament) GetMaxHands() int32 {
+	if x != nil {
+		return x.MaxHands
+	}
+	return 0
+}
+
+func (x *Tournament) GetStartsAt() int64 {
+	if x != nil {
+		return x.StartsAt
+	}
+	return 0
+}
+
+func (x *Tournament) GetEndsAt() int64 {
+	if x != nil {
+		return x.EndsAt
+	}
+	return 0
+}
+
+func (x *Tournament) GetPayouts() []int32 {
+	if x != nil {
+		return x.Payouts
+	}
+	return nil
+}
+
+func (x *Tournament) GetGuarantee() int32 {
+	if x != nil {
+		return x.Guarantee
+	}
+	return 0
+}
+
+func (x *Tournament) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Tournament) GetPrizePool() int32 {
+	if x != nil {
+		return x.PrizePool
+	}
+	return 0
+}
+
+func (x *Tournament) GetEntries() int32 {
+	if x != nil {
+		return x.Entries
+	}
+	return 0
+}
+
+type CreateTournamentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Game          string                 `protobuf:"bytes,2,opt,name=game,proto3" json:"game,omitempty"`
+	BuyIn         int32                  `protobuf:"varint,3,opt,name=buy_in,json=buyIn,proto3" json:"buy_in,omitempty"`
+	StartingChips int32                  `protobuf:"varint,4,opt,name=starting_chips,json=startingChips,proto3" json:"starting_chips,omitempty"`
+	MaxHands      int32                  `protobuf:"varint,5,opt,name=max_hands,json=maxHands,proto3" json:"max_hands,omitempty"`
+	StartsAt      int64                  `protobuf:"varint,6,opt,name=starts_at,json=startsAt,proto3" json:"starts_at,omitempty"`
+	EndsAt        int64                  `protobuf:"varint,7,opt,name=ends_at,json=endsAt,proto3" json:"ends_at,omitempty"`
+	Payouts       []int32                `protobuf:"varint,8,rep,packed,name=payouts,proto3" json:"payouts,omitempty"`
+	Guarantee     int32                  `protobuf:"varint,9,opt,name=guarantee,proto3" json:"guarantee,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTournamentRequest) Reset() {
+	*x = CreateTournamentRequest{}
+	mi := &file_game_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTournamentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTournamentRequest) ProtoMessage() {}
+
+func (x *CreateTournamentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTournamentRequest.ProtoReflect.Descriptor instead.
+func (*CreateTournamentRequest) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *CreateTournamentRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateTournamentRequest) GetGame() string {
+	if x != nil {
+		return x.Game
+	}
+	return ""
+}
+
+func (x *CreateTournamentRequest) GetBuyIn() int32 {
+	if x != nil {
+		return x.BuyIn
+	}
+	return 0
+}
+
+func (x *CreateTournamentRequest) GetStartingChips() int32 {
+	if x != nil {
+		return x.StartingChips
+	}
+	return 0
+}
+
+func (x *CreateTournamentRequest) GetMaxHands() int32 {
+	if x != nil {
+		return x.MaxHands
+	}
+	return 0
+}
+
+func (x *CreateTournamentRequest) GetStartsAt() int64 {
+	if x != nil {
+		return x.StartsAt
+	}
+	return 0
+}
+
+func (x *CreateTournamentRequest) GetEndsAt() int64 {
+	if x != nil {
+		return x.EndsAt
+	}
+	return 0
+}
+
+func (x *CreateTournamentRequest) GetPayouts() []int32 {
+	if x != nil {
+		return x.Payouts
+	}
+	return nil
+}
+
+func (x *CreateTournamentRequest) GetGuarantee() int32 {
+	if x != nil {
+		return x.Guarantee
+	}
+	return 0
+}
+
+// перенос ещё не начавшегося турнира
+type ScheduleTournamentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TournamentId  string                 `protobuf:"bytes,1,opt,name=tournament_id,json=tournamentId,proto3" json:"tournament_id,omitempty"`
+	StartsAt      int64                  `protobuf:"varint,2,opt,name=starts_at,json=startsAt,proto3" json:"starts_at,omitempty"`
+	EndsAt        int64                  `protobuf:"varint,3,opt,name=ends_at,json=endsAt,proto3" json:"ends_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduleTournamentRequest) Reset() {
+	*x = ScheduleTournamentRequest{}
+	mi := &file_game_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduleTournamentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleTournamentRequest) ProtoMessage() {}
+
+func (x *ScheduleTournamentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleTournamentRequest.ProtoReflect.Descriptor instead.
+func (*ScheduleTournamentRequest) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ScheduleTournamentRequest) GetTournamentId() string {
+	if x != nil {
+		return x.TournamentId
+	}
+	return ""
+}
+
+func (x *ScheduleTournamentRequest) GetStartsAt() int64 {
+	if x != nil {
+		return x.StartsAt
+	}
+	return 0
+}
+
+func (x *ScheduleTournamentRequest) GetEndsAt() int64 {
+	if x != nil {
+		return x.EndsAt
+	}
+	return 0
+}
+
+type CancelTournamentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TournamentId  string                 `protobuf:"bytes,1,opt,name=tournament_id,json=tournamentId,proto3" json:"tournament_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelTournamentRequest) Reset() {
+	*x = CancelTournamentRequest{}
+	mi := &file_game_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelTournamentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelTournamentRequest) ProtoMessage() {}
+
+func (x *CancelTournamentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelTournamentRequest.ProtoReflect.Descriptor instead.
+func (*CancelTournamentRequest) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *CancelTournamentRequest) GetTournamentId() string {
+	if x != nil {
+		return x.TournamentId
+	}
+	return ""
+}
+
+type ListTournamentsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// пусто — все
+	Status        string `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Limit         int32  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTournamentsRequest) Reset() {
+	*x = ListTournamentsRequest{}
+	mi := &file_game_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTournamentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTournamentsRequest) ProtoMessage() {}
+
+func (x *ListTournamentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTournamentsRequest.ProtoReflect.Descriptor instead.
+func (*ListTournamentsRequest) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ListTournamentsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListTournamentsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListTournamentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tournaments   []*Tournament          `protobuf:"bytes,1,rep,name=tournaments,proto3" json:"tournaments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTournamentsResponse) Reset() {
+	*x = ListTournamentsResponse{}
+	mi := &file_game_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTournamentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTournamentsResponse) ProtoMessage() {}
+
+func (x *ListTournamentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTournamentsResponse.ProtoReflect.Descriptor instead.
+func (*ListTournamentsResponse) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ListTournamentsResponse) GetTournaments() []*Tournament {
+	if x != nil {
+		return x.Tournaments
+	}
+	return nil
+}
+
+type GetTournamentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TournamentId  string                 `protobuf:"bytes,1,opt,name=tournament_id,json=tournamentId,proto3" json:"tournament_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTournamentRequest) Reset() {
+	*x = GetTournamentRequest{}
+	mi := &file_game_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTournamentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTournamentRequest) ProtoMessage() {}
+
+func (x *GetTournamentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTournamentRequest.ProtoReflect.Descriptor instead.
+func (*GetTournamentRequest) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *GetTournamentRequest) GetTournamentId() string {
+	if x != nil {
+		return x.TournamentId
+	}
+	return ""
+}
+
+type TournamentEntry struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	TournamentId string                 `protobuf:"bytes,1,opt,name=tournament_id,json=tournamentId,proto3" json:"tournament_id,omitempty"`
+	UserId       string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Chips        int32                  `protobuf:"varint,3,opt,name=chips,proto3" json:"chips,omitempty"`
+	HandsPlayed  int32                  `protobuf:"varint,4,opt,name=hands_played,json=handsPlayed,proto3" json:"hands_played,omitempty"`
+	// -1 — без лимита
+	HandsLeft int32 `protobuf:"varint,5,opt,name=hands_left,json=handsLeft,proto3" json:"hands_left,omitempty"`
+	// место в таблице, 0 — ещё не считали
+	Rank  int32 `protobuf:"varint,6,opt,name=rank,proto3" json:"rank,omitempty"`
+	Prize int32 `protobuf:"varint,7,opt,name=prize,proto3" json:"prize,omitempty"`
+	// раздача блэкджека не доиграна
+	InHand        bool `protobuf:"varint,8,opt,name=in_hand,json=inHand,proto3" json:"in_hand,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TournamentEntry) Reset() {
+	*x = TournamentEntry{}
+	mi := &file_game_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TournamentEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TournamentEntry) ProtoMessage() {}
+
+func (x *TournamentEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TournamentEntry.ProtoReflect.Descriptor instead.
+func (*TournamentEntry) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *TournamentEntry) GetTournamentId() string {
+	if x != nil {
+		return x.TournamentId
+	}
+	return ""
+}
+
+func (x *TournamentEntry) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *TournamentEntry) GetChips() int32 {
+	if x != nil {
+		return x.Chips
+	}
+	return 0
+}
+
+func (x *TournamentEntry) GetHandsPlayed() int32 {
+	if x != nil {
+		return x.HandsPlayed
+	}
+	return 0
+}
+
+func (x *TournamentEntry) GetHandsLeft() int32 {
+	if x != nil {
+		return x.HandsLeft
+	}
+	return 0
+}
+
+func (x *TournamentEntry) GetRank() int32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *TournamentEntry) GetPrize() int32 {
+	if x != nil {
+		return x.Prize
+	}
+	return 0
+}
+
+func (x *TournamentEntry) GetInHand() bool {
+	if x != nil {
+		return x.InHand
+	}
+	return false
+}
+
+type JoinTournamentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TournamentId  string                 `protobuf:"bytes,1,opt,name=tournament_id,json=tournamentId,proto3" json:"tournament_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JoinTournamentRequest) Reset() {
+	*x = JoinTournamentRequest{}
+	mi := &file_game_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinTournamentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinTournamentRequest) ProtoMessage() {}
+
+func (x *JoinTournamentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinTournamentRequest.ProtoReflect.Descriptor instead.
+func (*JoinTournamentRequest) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *JoinTournamentRequest) GetTournamentId() string {
+	if x != nil {
+		return x.TournamentId
+	}
+	return ""
+}
+
+func (x *JoinTournamentRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type JoinTournamentResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Entry *TournamentEntry       `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
+	// баланс кошелька после бай-ина
+	Balance       int32 `protobuf:"varint,2,opt,name=balance,proto3" json:"balance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JoinTournamentResponse) Reset() {
+	*x = JoinTournamentResponse{}
+	mi := &file_game_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinTournamentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinTournamentResponse) ProtoMessage() {}
+
+func (x *JoinTournamentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinTournamentResponse.ProtoReflect.Descriptor instead.
+func (*JoinTournamentResponse) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *JoinTournamentResponse) GetEntry() *TournamentEntry {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+func (x *JoinTournamentResponse) GetBalance() int32 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+type TournamentPlayRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	TournamentId string                 `protobuf:"bytes,1,opt,name=tournament_id,json=tournamentId,proto3" json:"tournament_id,omitempty"`
+	UserId       string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// блэкджек: "deal", "hit", "stand"; слоты: "spin"
+	Action string `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`
+	// ставка в турнирных фишках (для "deal" и "spin")
+	Bet           int32 `protobuf:"varint,4,opt,name=bet,proto3" json:"bet,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TournamentPlayRequest) Reset() {
+	*x = TournamentPlayRequest{}
+	mi := &file_game_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TournamentPlayRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TournamentPlayRequest) ProtoMessage() {}
+
+func (x *TournamentPlayRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TournamentPlayRequest.ProtoReflect.Descriptor instead.
+func (*TournamentPlayRequest) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *TournamentPlayRequest) GetTournamentId() string {
+	if x != nil {
+		return x.TournamentId
+	}
+	return ""
+}
+
+func (x *TournamentPlayRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *TournamentPlayRequest) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *TournamentPlayRequest) GetBet() int32 {
+	if x != nil {
+		return x.Bet
+	}
+	return 0
+}
+
+type TournamentPlayResponse struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Entry       *TournamentEntry       `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
+	PlayerCards []string               `protobuf:"bytes,2,rep,name=player_cards,json=playerCards,proto3" json:"player_cards,omitempty"`
+	// пока рука не доиграна — только открытая карта
+	DealerCards []string `protobuf:"bytes,3,rep,name=dealer_cards,json=dealerCards,proto3" json:"dealer_cards,omitempty"`
+	PlayerTotal int32    `protobuf:"varint,4,opt,name=player_total,json=playerTotal,proto3" json:"player_total,omitempty"`
+	DealerTotal int32    `protobuf:"varint,5,opt,name=dealer_total,json=dealerTotal,proto3" json:"dealer_total,omitempty"`
+	Outcome     string   `protobuf:"bytes,6,opt,name=outcome,proto3" json:"outcome,omitempty"` // "win", "lose", "push"
+	Reels       []string `protobuf:"bytes,7,rep,name=reels,proto3" json:"reels,omitempty"`
+	// выигрыш в фишках (вместе со ставкой)
+	Win           int32 `protobuf:"varint,8,opt,name=win,proto3" json:"win,omitempty"`
+	Finished      bool  `protobuf:"varint,9,opt,name=finished,proto3" json:"finished,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TournamentPlayResponse) Reset() {
+	*x = TournamentPlayResponse{}
+	mi := &file_game_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TournamentPlayResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TournamentPlayResponse) ProtoMessage() {}
+
+func (x *TournamentPlayResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TournamentPlayResponse.ProtoReflect.Descriptor instead.
+func (*TournamentPlayResponse) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *TournamentPlayResponse) GetEntry() *TournamentEntry {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+func (x *TournamentPlayResponse) GetPlayerCards() []string {
+	if x != nil {
+		return x.PlayerCards
+	}
+	return nil
+}
+
+func (x *TournamentPlayResponse) GetDealerCards() []string {
+	if x != nil {
+		return x.DealerCards
+	}
+	return nil
+}
+
+func (x *TournamentPlayResponse) GetPlayerTotal() int32 {
+	if x != nil {
+		return x.PlayerTotal
+	}
+	return 0
+}
+
+func (x *TournamentPlayResponse) GetDealerTotal() int32 {
+	if x != nil {
+		return x.DealerTotal
+	}
+	return 0
+}
+
+func (x *TournamentPlayResponse) GetOutcome() string {
+	if x != nil {
+		return x.Outcome
+	}
+	return ""
+}
+
+func (x *TournamentPlayResponse) GetReels() []string {
+	if x != nil {
+		return x.Reels
+	}
+	return nil
+}
+
+func (x *TournamentPlayResponse) GetWin() int32 {
+	if x != nil {
+		return x.Win
+	}
+	return 0
+}
+
+func (x *TournamentPlayResponse) GetFinished() bool {
+	if x != nil {
+		return x.Finished
+	}
+	return false
+}
+
+type TournamentLeaderboardRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TournamentId  string                 `protobuf:"bytes,1,opt,name=tournament_id,json=tournamentId,proto3" json:"tournament_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TournamentLeaderboardRequest) Reset() {
+	*x = TournamentLeaderboardRequest{}
+	mi := &file_game_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TournamentLeaderboardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TournamentLeaderboardRequest) ProtoMessage() {}
+
+func (x *TournamentLeaderboardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TournamentLeaderboardRequest.ProtoReflect.Descriptor instead.
+func (*TournamentLeaderboardRequest) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *TournamentLeaderboardRequest) GetTournamentId() string {
+	if x != nil {
+		return x.TournamentId
+	}
+	return ""
+}
+
+func (x *TournamentLeaderboardRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *TournamentLeaderboardRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type TournamentLeaderboardResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Top   []*TournamentEntry     `protobuf:"bytes,1,rep,name=top,proto3" json:"top,omitempty"`
+	// позиция запросившего, если он участвует
+	Me            *TournamentEntry `protobuf:"bytes,2,opt,name=me,proto3" json:"me,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TournamentLeaderboardResponse) Reset() {
+	*x = TournamentLeaderboardResponse{}
+	mi := &file_game_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TournamentLeaderboardResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TournamentLeaderboardResponse) ProtoMessage() {}
+
+func (x *TournamentLeaderboardResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_game_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TournamentLeaderboardResponse.ProtoReflect.Descriptor instead.
+func (*TournamentLeaderboardResponse) Descriptor() ([]byte, []int) {
+	return file_game_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *TournamentLeaderboardResponse) GetTop() []*TournamentEntry {
+	if x != nil {
+		return x.Top
+	}
+	return nil
+}
+
+func (x *TournamentLeaderboardResponse) GetMe() *TournamentEntry {
+	if x != nil {
+		return x.Me
+	}
+	return nil
+}
+
+var File_game_proto protoreflect.FileDescriptor
+
+const file_game_proto_rawDesc = "" +
+	"\n" +
+	"\n" +
+	"game.proto\x12\x04game\")\n" +
+	"\x0eNewGameRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\xb3\x01\n" +
+	"\x0fNewGameResponse\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12!\n" +
+	"\fplayer_cards\x18\x02 \x03(\tR\vplayerCards\x12!\n" +
+	"\fdealer_cards\x18\x03 \x03(\tR\vdealerCards\x12!\n" +
+	"\fplayer_total\x18\x04 \x01(\x05R\vplayerTotal\x12\x18\n" +
+	"\abalance\x18\x05 \x01(\x05R\abalance\"D\n" +
+	"\n" +
+	"HitRequest\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\x89\x01\n" +
+	"\vHitResponse\x12!\n" +
+	"\fplayer_cards\x18\x01 \x03(\tR\vplayerCards\x12!\n" +
+	"\fplayer_total\x18\x02 \x01(\x05R\vplayerTotal\x12\x1a\n" +
+	"\bfinished\x18\x03 \x01(\bR\bfinished\x12\x18\n" +
+	"\abalance\x18\x04 \x01(\x05R\abalance\"F\n" +
+	"\fStandRequest\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\x89\x01\n" +
+	"\rStandResponse\x12!\n" +
+	"\fdealer_cards\x18\x01 \x03(\tR\vdealerCards\x12!\n" +
+	"\fdealer_total\x18\x02 \x01(\x05R\vdealerTotal\x12\x18\n" +
+	"\aoutcome\x18\x03 \x01(\tR\aoutcome\x12\x18\n" +
+	"\abalance\x18\x04 \x01(\x05R\abalance\"\x81\x01\n" +
+	"\x0fCrashBetRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x05R\x06amount\x12!\n" +
+	"\fauto_cashout\x18\x03 \x01(\x01R\vautoCashout\x12\x1a\n" +
+	"\bcurrency\x18\x04 \x01(\tR\bcurrency\"\x9e\x01\n" +
+	"\x10CrashBetResponse\x12\x19\n" +
+	"\bround_id\x18\x01 \x01(\tR\aroundId\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x05R\x06amount\x12!\n" +
+	"\fauto_cashout\x18\x03 \x01(\x01R\vautoCashout\x12\x18\n" +
+	"\abalance\x18\x04 \x01(\x05R\abalance\x12\x1a\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrency\"I\n" +
+	"\x13CrashCashoutRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x19\n" +
+	"\bround_id\x18\x02 \x01(\tR\aroundId\"\x85\x01\n" +
+	"\x14CrashCashoutResponse\x12\x19\n" +
+	"\bround_id\x18\x01 \x01(\tR\aroundId\x12\x1e\n" +
+	"\n" +
+	"multiplier\x18\x02 \x01(\x01R\n" +
+	"multiplier\x12\x16\n" +
+	"\x06payout\x18\x03 \x01(\x05R\x06payout\x12\x1a\n" +
+	"\bcurrency\x18\x04 \x01(\tR\bcurrency\"\x13\n" +
+	"\x11WatchCrashRequest\"\xf3\x01\n" +
+	"\n" +
+	"CrashState\x12\x19\n" +
+	"\bround_id\x18\x01 \x01(\tR\aroundId\x12\x14\n" +
+	"\x05phase\x18\x02 \x01(\tR\x05phase\x12\x1e\n" +
+	"\n" +
+	"multiplier\x18\x03 \x01(\x01R\n" +
+	"multiplier\x12\x1f\n" +
+	"\vcrash_point\x18\x04 \x01(\x01R\n" +
+	"crashPoint\x12\x1b\n" +
+	"\tseed_hash\x18\x05 \x01(\tR\bseedHash\x12\x1f\n" +
+	"\vserver_seed\x18\x06 \x01(\tR\n" +
+	"serverSeed\x12\x1b\n" +
+	"\tstarts_at\x18\a \x01(\x03R\bstartsAt\x12\x18\n" +
+	"\aplayers\x18\b \x01(\x05R\aplayers\"\xdb\x02\n" +
+	"\x0fHoldemTableInfo\x12\x19\n" +
+	"\btable_id\x18\x01 \x01(\tR\atableId\x12\x1f\n" +
+	"\vsmall_blind\x18\x02 \x01(\x05R\n" +
+	"smallBlind\x12\x1b\n" +
+	"\tbig_blind\x18\x03 \x01(\x05R\bbigBlind\x12\x1c\n" +
+	"\n" +
+	"min_buy_in\x18\x04 \x01(\x05R\bminBuyIn\x12\x1c\n" +
+	"\n" +
+	"max_buy_in\x18\x05 \x01(\x05R\bmaxBuyIn\x12\x14\n" +
+	"\x05seats\x18\x06 \x01(\x05R\x05seats\x12\x18\n" +
+	"\aplayers\x18\a \x01(\x05R\aplayers\x12!\n" +
+	"\frake_percent\x18\b \x01(\x01R\vrakePercent\x12\x19\n" +
+	"\brake_cap\x18\t \x01(\x05R\arakeCap\x12\x1e\n" +
+	"\n" +
+	"spectators\x18\n" +
+	" \x01(\x05R\n" +
+	"spectators\x12%\n" +
+	"\x0emax_spectators\x18\v \x01(\x05R\rmaxSpectators\"\x19\n" +
+	"\x17ListHoldemTablesRequest\"I\n" +
+	"\x18ListHoldemTablesResponse\x12-\n" +
+	"\x06tables\x18\x01 \x03(\v2\x15.game.HoldemTableInfoR\x06tables\"\x8b\x01\n" +
+	"\x11HoldemJoinRequest\x12\x19\n" +
+	"\btable_id\x18\x01 \x01(\tR\atableId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x15\n" +
+	"\x06buy_in\x18\x03 \x01(\x05R\x05buyIn\x12\x12\n" +
+	"\x04seat\x18\x04 \x01(\x05R\x04seat\x12\x17\n" +
+	"\ajoin_id\x18\x05 \x01(\tR\x06joinId\"s\n" +
+	"\x12HoldemJoinResponse\x12\x19\n" +
+	"\btable_id\x18\x01 \x01(\tR\atableId\x12\x12\n" +
+	"\x04seat\x18\x02 \x01(\x05R\x04seat\x12\x14\n" +
+	"\x05stack\x18\x03 \x01(\x05R\x05stack\x12\x18\n" +
+	"\abalance\x18\x04 \x01(\x05R\abalance\"v\n" +
+	"\x10HoldemActRequest\x12\x19\n" +
+	"\btable_id\x18\x01 \x01(\tR\atableId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06action\x18\x03 \x01(\tR\x06action\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x05R\x06amount\"H\n" +
+	"\x12HoldemLeaveRequest\x12\x19\n" +
+	"\btable_id\x18\x01 \x01(\tR\atableId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"N\n" +
+	"\x13HoldemLeaveResponse\x12\x1d\n" +
+	"\n" +
+	"cashed_out\x18\x01 \x01(\x05R\tcashedOut\x12\x18\n" +
+	"\apending\x18\x02 \x01(\bR\apending\"H\n" +
+	"\x12WatchHoldemRequest\x12\x19\n" +
+	"\btable_id\x18\x01 \x01(\tR\atableId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"K\n" +
+	"\x15SpectateHoldemRequest\x12\x19\n" +
+	"\btable_id\x18\x01 \x01(\tR\atableId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\xe8\x01\n" +
+	"\n" +
+	"HoldemSeat\x12\x12\n" +
+	"\x04seat\x18\x01 \x01(\x05R\x04seat\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05stack\x18\x03 \x01(\x05R\x05stack\x12\x10\n" +
+	"\x03bet\x18\x04 \x01(\x05R\x03bet\x12\x16\n" +
+	"\x06folded\x18\x05 \x01(\bR\x06folded\x12\x15\n" +
+	"\x06all_in\x18\x06 \x01(\bR\x05allIn\x12\x1f\n" +
+	"\vsitting_out\x18\a \x01(\bR\n" +
+	"sittingOut\x12\x14\n" +
+	"\x05cards\x18\b \x03(\tR\x05cards\x12\x1f\n" +
+	"\vlast_action\x18\t \x01(\tR\n" +
+	"lastAction\"9\n" +
+	"\tHoldemPot\x12\x16\n" +
+	"\x06amount\x18\x01 \x01(\x05R\x06amount\x12\x14\n" +
+	"\x05seats\x18\x02 \x03(\x05R\x05seats\"}\n" +
+	"\fHoldemWinner\x12\x12\n" +
+	"\x04seat\x18\x01 \x01(\x05R\x04seat\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x05R\x06amount\x12\x12\n" +
+	"\x04hand\x18\x04 \x01(\tR\x04hand\x12\x14\n" +
+	"\x05cards\x18\x05 \x03(\tR\x05cards\"\xb9\x03\n" +
+	"\x10HoldemTableState\x12\x19\n" +
+	"\btable_id\x18\x01 \x01(\tR\atableId\x12\x17\n" +
+	"\ahand_no\x18\x02 \x01(\x03R\x06handNo\x12\x16\n" +
+	"\x06street\x18\x03 \x01(\tR\x06street\x12\x14\n" +
+	"\x05board\x18\x04 \x03(\tR\x05board\x12&\n" +
+	"\x05seats\x18\x05 \x03(\v2\x10.game.HoldemSeatR\x05seats\x12#\n" +
+	"\x04pots\x18\x06 \x03(\v2\x0f.game.HoldemPotR\x04pots\x12\x16\n" +
+	"\x06button\x18\a \x01(\x05R\x06button\x12\x15\n" +
+	"\x06to_act\x18\b \x01(\x05R\x05toAct\x12\x1f\n" +
+	"\vcurrent_bet\x18\t \x01(\x05R\n" +
+	"currentBet\x12\x1b\n" +
+	"\tmin_raise\x18\n" +
+	" \x01(\x05R\bminRaise\x12'\n" +
+	"\x0faction_deadline\x18\v \x01(\x03R\x0eactionDeadline\x12,\n" +
+	"\awinners\x18\f \x03(\v2\x12.game.HoldemWinnerR\awinners\x12\x12\n" +
+	"\x04rake\x18\r \x01(\x05R\x04rake\x12\x1e\n" +
+	"\n" +
+	"spectators\x18\x0e \x01(\x05R\n" +
+	"spectators\"\x91\x01\n" +
+	"\x11MinesStartRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tgrid_size\x18\x02 \x01(\x05R\bgridSize\x12\x14\n" +
+	"\x05mines\x18\x03 \x01(\x05R\x05mines\x12\x14\n" +
+	"\x05stake\x18\x04 \x01(\x05R\x05stake\x12\x1a\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrency\"`\n" +
+	"\x12MinesRevealRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\tR\tsessionId\x12\x12\n" +
+	"\x04tile\x18\x03 \x01(\x05R\x04tile\"M\n" +
+	"\x13MinesCashoutRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\tR\tsessionId\"I\n" +
+	"\x0fMinesGetRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\tR\tsessionId\"\xde\x02\n" +
+	"\n" +
+	"MinesState\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x1b\n" +
+	"\tgrid_size\x18\x02 \x01(\x05R\bgridSize\x12\x14\n" +
+	"\x05mines\x18\x03 \x01(\x05R\x05mines\x12\x14\n" +
+	"\x05stake\x18\x04 \x01(\x05R\x05stake\x12\x1a\n" +
+	"\brevealed\x18\x05 \x03(\x05R\brevealed\x12\x1d\n" +
+	"\n" +
+	"mine_tiles\x18\x06 \x03(\x05R\tmineTiles\x12\x16\n" +
+	"\x06status\x18\a \x01(\tR\x06status\x12\x1e\n" +
+	"\n" +
+	"multiplier\x18\b \x01(\x01R\n" +
+	"multiplier\x12'\n" +
+	"\x0fnext_multiplier\x18\t \x01(\x01R\x0enextMultiplier\x12\x16\n" +
+	"\x06payout\x18\n" +
+	" \x01(\x05R\x06payout\x12\x18\n" +
+	"\abalance\x18\v \x01(\x05R\abalance\x12\x1a\n" +
+	"\bcurrency\x18\f \x01(\tR\bcurrency\"\xf3\x02\n" +
+	"\n" +
+	"Tournament\x12#\n" +
+	"\rtournament_id\x18\x01 \x01(\tR\ftournamentId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04game\x18\x03 \x01(\tR\x04game\x12\x15\n" +
+	"\x06buy_in\x18\x04 \x01(\x05R\x05buyIn\x12%\n" +
+	"\x0estarting_chips\x18\x05 \x01(\x05R\rstartingChips\x12\x1b\n" +
+	"\tmax_hands\x18\x06 \x01(\x05R\bmaxHands\x12\x1b\n" +
+	"\tstarts_at\x18\a \x01(\x03R\bstartsAt\x12\x17\n" +
+	"\aends_at\x18\b \x01(\x03R\x06endsAt\x12\x18\n" +
+	"\apayouts\x18\t \x03(\x05R\apayouts\x12\x1c\n" +
+	"\tguarantee\x18\n" +
+	" \x01(\x05R\tguarantee\x12\x16\n" +
+	"\x06status\x18\v \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"prize_pool\x18\f \x01(\x05R\tprizePool\x12\x18\n" +
+	"\aentries\x18\r \x01(\x05R\aentries\"\x8a\x02\n" +
+	"\x17CreateTournamentRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04game\x18\x02 \x01(\tR\x04game\x12\x15\n" +
+	"\x06buy_in\x18\x03 \x01(\x05R\x05buyIn\x12%\n" +
+	"\x0estarting_chips\x18\x04 \x01(\x05R\rstartingChips\x12\x1b\n" +
+	"\tmax_hands\x18\x05 \x01(\x05R\bmaxHands\x12\x1b\n" +
+	"\tstarts_at\x18\x06 \x01(\x03R\bstartsAt\x12\x17\n" +
+	"\aends_at\x18\a \x01(\x03R\x06endsAt\x12\x18\n" +
+	"\apayouts\x18\b \x03(\x05R\apayouts\x12\x1c\n" +
+	"\tguarantee\x18\t \x01(\x05R\tguarantee\"v\n" +
+	"\x19ScheduleTournamentRequest\x12#\n" +
+	"\rtournament_id\x18\x01 \x01(\tR\ftournamentId\x12\x1b\n" +
+	"\tstarts_at\x18\x02 \x01(\x03R\bstartsAt\x12\x17\n" +
+	"\aends_at\x18\x03 \x01(\x03R\x06endsAt\">\n" +
+	"\x17CancelTournamentRequest\x12#\n" +
+	"\rtournament_id\x18\x01 \x01(\tR\ftournamentId\"F\n" +
+	"\x16ListTournamentsRequest\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"M\n" +
+	"\x17ListTournamentsResponse\x122\n" +
+	"\vtournaments\x18\x01 \x03(\v2\x10.game.TournamentR\vtournaments\";\n" +
+	"\x14GetTournamentRequest\x12#\n" +
+	"\rtournament_id\x18\x01 \x01(\tR\ftournamentId\"\xea\x01\n" +
+	"\x0fTournamentEntry\x12#\n" +
+	"\rtournament_id\x18\x01 \x01(\tR\ftournamentId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05chips\x18\x03 \x01(\x05R\x05chips\x12!\n" +
+	"\fhands_played\x18\x04 \x01(\x05R\vhandsPlayed\x12\x1d\n" +
+	"\n" +
+	"hands_left\x18\x05 \x01(\x05R\thandsLeft\x12\x12\n" +
+	"\x04rank\x18\x06 \x01(\x05R\x04rank\x12\x14\n" +
+	"\x05prize\x18\a \x01(\x05R\x05prize\x12\x17\n" +
+	"\ain_hand\x18\b \x01(\bR\x06inHand\"U\n" +
+	"\x15JoinTournamentRequest\x12#\n" +
+	"\rtournament_id\x18\x01 \x01(\tR\ftournamentId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"_\n" +
+	"\x16JoinTournamentResponse\x12+\n" +
+	"\x05entry\x18\x01 \x01(\v2\x15.game.TournamentEntryR\x05entry\x12\x18\n" +
+	"\abalance\x18\x02 \x01(\x05R\abalance\"\x7f\n" +
+	"\x15TournamentPlayRequest\x12#\n" +
+	"\rtournament_id\x18\x01 \x01(\tR\ftournamentId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06action\x18\x03 \x01(\tR\x06action\x12\x10\n" +
+	"\x03bet\x18\x04 \x01(\x05R\x03bet\"\xaf\x02\n" +
+	"\x16TournamentPlayResponse\x12+\n" +
+	"\x05entry\x18\x01 \x01(\v2\x15.game.TournamentEntryR\x05entry\x12!\n" +
+	"\fplayer_cards\x18\x02 \x03(\tR\vplayerCards\x12!\n" +
+	"\fdealer_cards\x18\x03 \x03(\tR\vdealerCards\x12!\n" +
+	"\fplayer_total\x18\x04 \x01(\x05R\vplayerTotal\x12!\n" +
+	"\fdealer_total\x18\x05 \x01(\x05R\vdealerTotal\x12\x18\n" +
+	"\aoutcome\x18\x06 \x01(\tR\aoutcome\x12\x14\n" +
+	"\x05reels\x18\a \x03(\tR\x05reels\x12\x10\n" +
+	"\x03win\x18\b \x01(\x05R\x03win\x12\x1a\n" +
+	"\bfinished\x18\t \x01(\bR\bfinished\"r\n" +
+	"\x1cTournamentLeaderboardRequest\x12#\n" +
+	"\rtournament_id\x18\x01 \x01(\tR\ftournamentId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"o\n" +
+	"\x1dTournamentLeaderboardResponse\x12'\n" +
+	"\x03top\x18\x01 \x03(\v2\x15.game.TournamentEntryR\x03top\x12%\n" +
+	"\x02me\x18\x02 \x01(\v2\x15.game.TournamentEntryR\x02me2\xca\f\n" +
+	"\vGameService\x126\n" +
+	"\aNewGame\x12\x14.game.NewGameRequest\x1a\x15.game.NewGameResponse\x12*\n" +
+	"\x03Hit\x12\x10.game.HitRequest\x1a\x11.game.HitResponse\x120\n" +
+	"\x05Stand\x12\x12.game.StandRequest\x1a\x13.game.StandResponse\x12>\n" +
+	"\rPlaceCrashBet\x12\x15.game.CrashBetRequest\x1a\x16.game.CrashBetResponse\x12E\n" +
+	"\fCrashCashout\x12\x19.game.CrashCashoutRequest\x1a\x1a.game.CrashCashoutResponse\x129\n" +
+	"\n" +
+	"WatchCrash\x12\x17.game.WatchCrashRequest\x1a\x10.game.CrashState0\x01\x12Q\n" +
+	"\x10ListHoldemTables\x12\x1d.game.ListHoldemTablesRequest\x1a\x1e.game.ListHoldemTablesResponse\x12?\n" +
+	"\n" +
+	"HoldemJoin\x12\x17.game.HoldemJoinRequest\x1a\x18.game.HoldemJoinResponse\x12;\n" +
+	"\tHoldemAct\x12\x16.game.HoldemActRequest\x1a\x16.game.HoldemTableState\x12B\n" +
+	"\vHoldemLeave\x12\x18.game.HoldemLeaveRequest\x1a\x19.game.HoldemLeaveResponse\x12A\n" +
+	"\vWatchHoldem\x12\x18.game.WatchHoldemRequest\x1a\x16.game.HoldemTableState0\x01\x12G\n" +
+	"\x0eSpectateHoldem\x12\x1b.game.SpectateHoldemRequest\x1a\x16.game.HoldemTableState0\x01\x127\n" +
+	"\n" +
+	"MinesStart\x12\x17.game.MinesStartRequest\x1a\x10.game.MinesState\x129\n" +
+	"\vMinesReveal\x12\x18.game.MinesRevealRequest\x1a\x10.game.MinesState\x12;\n" +
+	"\fMinesCashout\x12\x19.game.MinesCashoutRequest\x1a\x10.game.MinesState\x123\n" +
+	"\bMinesGet\x12\x15.game.MinesGetRequest\x1a\x10.game.MinesState\x12C\n" +
+	"\x10CreateTournament\x12\x1d.game.CreateTournamentRequest\x1a\x10.game.Tournament\x12G\n" +
+	"\x12ScheduleTournament\x12\x1f.game.ScheduleTournamentRequest\x1a\x10.game.Tournament\x12C\n" +
+	"\x10CancelTournament\x12\x1d.game.CancelTournamentRequest\x1a\x10.game.Tournament\x12N\n" +
+	"\x0fListTournaments\x12\x1c.game.ListTournamentsRequest\x1a\x1d.game.ListTournamentsResponse\x12=\n" +
+	"\rGetTournament\x12\x1a.game.GetTournamentRequest\x1a\x10.game.Tournament\x12K\n" +
+	"\x0eJoinTournament\x12\x1b.game.JoinTournamentRequest\x1a\x1c.game.JoinTournamentResponse\x12K\n" +
+	"\x0eTournamentPlay\x12\x1b.game.TournamentPlayRequest\x1a\x1c.game.TournamentPlayResponse\x12`\n" +
+	"\x15TournamentLeaderboard\x12\".game.TournamentLeaderboardRequest\x1a#.game.TournamentLeaderboardResponseB1Z/github.com/Arsencchikkk/final/casino/proto/gameb\x06proto3"
 
 var (
 	file_game_proto_rawDescOnce sync.Once
@@ -413,27 +3517,117 @@ func file_game_proto_rawDescGZIP() []byte {
 	return file_game_proto_rawDescData
 }
 
-var file_game_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_game_proto_msgTypes = make([]protoimpl.MessageInfo, 45)
 var file_game_proto_goTypes = []any{
-	(*NewGameRequest)(nil),  // 0: game.NewGameRequest
-	(*NewGameResponse)(nil), // 1: game.NewGameResponse
-	(*HitRequest)(nil),      // 2: game.HitRequest
-	(*HitResponse)(nil),     // 3: game.HitResponse
-	(*StandRequest)(nil),    // 4: game.StandRequest
-	(*StandResponse)(nil),   // 5: game.StandResponse
+	(*NewGameRequest)(nil),                // 0: game.NewGameRequest
+	(*NewGameResponse)(nil),               // 1: game.NewGameResponse
+	(*HitRequest)(nil),                    // 2: game.HitRequest
+	(*HitResponse)(nil),                   // 3: game.HitResponse
+	(*StandRequest)(nil),                  // 4: game.StandRequest
+	(*StandResponse)(nil),                 // 5: game.StandResponse
+	(*CrashBetRequest)(nil),               // 6: game.CrashBetRequest
+	(*CrashBetResponse)(nil),              // 7: game.CrashBetResponse
+	(*CrashCashoutRequest)(nil),           // 8: game.CrashCashoutRequest
+	(*CrashCashoutResponse)(nil),          // 9: game.CrashCashoutResponse
+	(*WatchCrashRequest)(nil),             // 10: game.WatchCrashRequest
+	(*CrashState)(nil),                    // 11: game.CrashState
+	(*HoldemTableInfo)(nil),               // 12: game.HoldemTableInfo
+	(*ListHoldemTablesRequest)(nil),       // 13: game.ListHoldemTablesRequest
+	(*ListHoldemTablesResponse)(nil),      // 14: game.ListHoldemTablesResponse
+	(*HoldemJoinRequest)(nil),             // 15: game.HoldemJoinRequest
+	(*HoldemJoinResponse)(nil),            // 16: game.HoldemJoinResponse
+	(*HoldemActRequest)(nil),              // 17: game.HoldemActRequest
+	(*HoldemLeaveRequest)(nil),            // 18: game.HoldemLeaveRequest
+	(*HoldemLeaveResponse)(nil),           // 19: game.HoldemLeaveResponse
+	(*WatchHoldemRequest)(nil),            // 20: game.WatchHoldemRequest
+	(*SpectateHoldemRequest)(nil),         // 21: game.SpectateHoldemRequest
+	(*HoldemSeat)(nil),                    // 22: game.HoldemSeat
+	(*HoldemPot)(nil),                     // 23: game.HoldemPot
+	(*HoldemWinner)(nil),                  // 24: game.HoldemWinner
+	(*HoldemTableState)(nil),              // 25: game.HoldemTableState
+	(*MinesStartRequest)(nil),             // 26: game.MinesStartRequest
+	(*MinesRevealRequest)(nil),            // 27: game.MinesRevealRequest
+	(*MinesCashoutRequest)(nil),           // 28: game.MinesCashoutRequest
+	(*MinesGetRequest)(nil),               // 29: game.MinesGetRequest
+	(*MinesState)(nil),                    // 30: game.MinesState
+	(*Tournament)(nil),                    // 31: game.Tournament
+	(*CreateTournamentRequest)(nil),       // 32: game.CreateTournamentRequest
+	(*ScheduleTournamentRequest)(nil),     // 33: game.ScheduleTournamentRequest
+	(*CancelTournamentRequest)(nil),       // 34: game.CancelTournamentRequest
+	(*ListTournamentsRequest)(nil),        // 35: game.ListTournamentsRequest
+	(*ListTournamentsResponse)(nil),       // 36: game.ListTournamentsResponse
+	(*GetTournamentRequest)(nil),          // 37: game.GetTournamentRequest
+	(*TournamentEntry)(nil),               // 38: game.TournamentEntry
+	(*JoinTournamentRequest)(nil),         // 39: game.JoinTournamentRequest
+	(*JoinTournamentResponse)(nil),        // 40: game.JoinTournamentResponse
+	(*TournamentPlayRequest)(nil),         // 41: game.TournamentPlayRequest
+	(*TournamentPlayResponse)(nil),        // 42: game.TournamentPlayResponse
+	(*TournamentLeaderboardRequest)(nil),  // 43: game.TournamentLeaderboardRequest
+	(*TournamentLeaderboardResponse)(nil), // 44: game.TournamentLeaderboardResponse
 }
 var file_game_proto_depIdxs = []int32{
-	0, // 0: game.GameService.NewGame:input_type -> game.NewGameRequest
-	2, // 1: game.GameService.Hit:input_type -> game.HitRequest
-	4, // 2: game.GameService.Stand:input_type -> game.StandRequest
-	1, // 3: game.GameService.NewGame:output_type -> game.NewGameResponse
-	3, // 4: game.GameService.Hit:output_type -> game.HitResponse
-	5, // 5: game.GameService.Stand:output_type -> game.StandResponse
-	3, // [3:6] is the sub-list for method output_type
-	0, // [0:3] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	12, // 0: game.ListHoldemTablesResponse.tables:type_name -> game.HoldemTableInfo
+	22, // 1: game.HoldemTableState.seats:type_name -> game.HoldemSeat
+	23, // 2: game.HoldemTableState.pots:type_name -> game.HoldemPot
+	24, // 3: game.HoldemTableState.winners:type_name -> game.HoldemWinner
+	31, // 4: game.ListTournamentsResponse.tournaments:type_name -> game.Tournament
+	38, // 5: game.JoinTournamentResponse.entry:type_name -> game.TournamentEntry
+	38, // 6: game.TournamentPlayResponse.entry:type_name -> game.TournamentEntry
+	38, // 7: game.TournamentLeaderboardResponse.top:type_name -> game.TournamentEntry
+	38, // 8: game.TournamentLeaderboardResponse.me:type_name -> game.TournamentEntry
+	0,  // 9: game.GameService.NewGame:input_type -> game.NewGameRequest
+	2,  // 10: game.GameService.Hit:input_type -> game.HitRequest
+	4,  // 11: game.GameService.Stand:input_type -> game.StandRequest
+	6,  // 12: game.GameService.PlaceCrashBet:input_type -> game.CrashBetRequest
+	8,  // 13: game.GameService.CrashCashout:input_type -> game.CrashCashoutRequest
+	10, // 14: game.GameService.WatchCrash:input_type -> game.WatchCrashRequest
+	13, // 15: game.GameService.ListHoldemTables:input_type -> game.ListHoldemTablesRequest
+	15, // 16: game.GameService.HoldemJoin:input_type -> game.HoldemJoinRequest
+	17, // 17: game.GameService.HoldemAct:input_type -> game.HoldemActRequest
+	18, // 18: game.GameService.HoldemLeave:input_type -> game.HoldemLeaveRequest
+	20, // 19: game.GameService.WatchHoldem:input_type -> game.WatchHoldemRequest
+	21, // 20: game.GameService.SpectateHoldem:input_type -> game.SpectateHoldemRequest
+	26, // 21: game.GameService.MinesStart:input_type -> game.MinesStartRequest
+	27, // 22: game.GameService.MinesReveal:input_type -> game.MinesRevealRequest
+	28, // 23: game.GameService.MinesCashout:input_type -> game.MinesCashoutRequest
+	29, // 24: game.GameService.MinesGet:input_type -> game.MinesGetRequest
+	32, // 25: game.GameService.CreateTournament:input_type -> game.CreateTournamentRequest
+	33, // 26: game.GameService.ScheduleTournament:input_type -> game.ScheduleTournamentRequest
+	34, // 27: game.GameService.CancelTournament:input_type -> game.CancelTournamentRequest
+	35, // 28: game.GameService.ListTournaments:input_type -> game.ListTournamentsRequest
+	37, // 29: game.GameService.GetTournament:input_type -> game.GetTournamentRequest
+	39, // 30: game.GameService.JoinTournament:input_type -> game.JoinTournamentRequest
+	41, // 31: game.GameService.TournamentPlay:input_type -> game.TournamentPlayRequest
+	43, // 32: game.GameService.TournamentLeaderboard:input_type -> game.TournamentLeaderboardRequest
+	1,  // 33: game.GameService.NewGame:output_type -> game.NewGameResponse
+	3,  // 34: game.GameService.Hit:output_type -> game.HitResponse
+	5,  // 35: game.GameService.Stand:output_type -> game.StandResponse
+	7,  // 36: game.GameService.PlaceCrashBet:output_type -> game.CrashBetResponse
+	9,  // 37: game.GameService.CrashCashout:output_type -> game.CrashCashoutResponse
+	11, // 38: game.GameService.WatchCrash:output_type -> game.CrashState
+	14, // 39: game.GameService.ListHoldemTables:output_type -> game.ListHoldemTablesResponse
+	16, // 40: game.GameService.HoldemJoin:output_type -> game.HoldemJoinResponse
+	25, // 41: game.GameService.HoldemAct:output_type -> game.HoldemTableState
+	19, // 42: game.GameService.HoldemLeave:output_type -> game.HoldemLeaveResponse
+	25, // 43: game.GameService.WatchHoldem:output_type -> game.HoldemTableState
+	25, // 44: game.GameService.SpectateHoldem:output_type -> game.HoldemTableState
+	30, // 45: game.GameService.MinesStart:output_type -> game.MinesState
+	30, // 46: game.GameService.MinesReveal:output_type -> game.MinesState
+	30, // 47: game.GameService.MinesCashout:output_type -> game.MinesState
+	30, // 48: game.GameService.MinesGet:output_type -> game.MinesState
+	31, // 49: game.GameService.CreateTournament:output_type -> game.Tournament
+	31, // 50: game.GameService.ScheduleTournament:output_type -> game.Tournament
+	31, // 51: game.GameService.CancelTournament:output_type -> game.Tournament
+	36, // 52: game.GameService.ListTournaments:output_type -> game.ListTournamentsResponse
+	31, // 53: game.GameService.GetTournament:output_type -> game.Tournament
+	40, // 54: game.GameService.JoinTournament:output_type -> game.JoinTournamentResponse
+	42, // 55: game.GameService.TournamentPlay:output_type -> game.TournamentPlayResponse
+	44, // 56: game.GameService.TournamentLeaderboard:output_type -> game.TournamentLeaderboardResponse
+	33, // [33:57] is the sub-list for method output_type
+	9,  // [9:33] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
 }
 
 func init() { file_game_proto_init() }
@@ -447,7 +3641,7 @@ func file_game_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_game_proto_rawDesc), len(file_game_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   45,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
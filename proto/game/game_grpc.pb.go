@@ -19,9 +19,30 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	GameService_NewGame_FullMethodName = "/game.GameService/NewGame"
-	GameService_Hit_FullMethodName     = "/game.GameService/Hit"
-	GameService_Stand_FullMethodName   = "/game.GameService/Stand"
+	GameService_NewGame_FullMethodName               = "/game.GameService/NewGame"
+	GameService_Hit_FullMethodName                   = "/game.GameService/Hit"
+	GameService_Stand_FullMethodName                 = "/game.GameService/Stand"
+	GameService_PlaceCrashBet_FullMethodName         = "/game.GameService/PlaceCrashBet"
+	GameService_CrashCashout_FullMethodName          = "/game.GameService/CrashCashout"
+	GameService_WatchCrash_FullMethodName            = "/game.GameService/WatchCrash"
+	GameService_ListHoldemTables_FullMethodName      = "/game.GameService/ListHoldemTables"
+	GameService_HoldemJoin_FullMethodName            = "/game.GameService/HoldemJoin"
+	GameService_HoldemAct_FullMethodName             = "/game.GameService/HoldemAct"
+	GameService_HoldemLeave_FullMethodName           = "/game.GameService/HoldemLeave"
+	GameService_WatchHoldem_FullMethodName           = "/game.GameService/WatchHoldem"
+	GameService_SpectateHoldem_FullMethodName        = "/game.GameService/SpectateHoldem"
+	GameService_MinesStart_FullMethodName            = "/game.GameService/MinesStart"
+	GameService_MinesReveal_FullMethodName           = "/game.GameService/MinesReveal"
+	GameService_MinesCashout_FullMethodName          = "/game.GameService/MinesCashout"
+	GameService_MinesGet_FullMethodName              = "/game.GameService/MinesGet"
+	GameService_CreateTournament_FullMethodName      = "/game.GameService/CreateTournament"
+	GameService_ScheduleTournament_FullMethodName    = "/game.GameService/ScheduleTournament"
+	GameService_CancelTournament_FullMethodName      = "/game.GameService/CancelTournament"
+	GameService_ListTournaments_FullMethodName       = "/game.GameService/ListTournaments"
+	GameService_GetTournament_FullMethodName         = "/game.GameService/GetTournament"
+	GameService_JoinTournament_FullMethodName        = "/game.GameService/JoinTournament"
+	GameService_TournamentPlay_FullMethodName        = "/game.GameService/TournamentPlay"
+	GameService_TournamentLeaderboard_FullMethodName = "/game.GameService/TournamentLeaderboard"
 )
 
 // GameServiceClient is the client API for GameService service.
@@ -31,6 +52,27 @@ type GameServiceClient interface {
 	NewGame(ctx context.Context, in *NewGameRequest, opts ...grpc.CallOption) (*NewGameResponse, error)
 	Hit(ctx context.Context, in *HitRequest, opts ...grpc.CallOption) (*HitResponse, error)
 	Stand(ctx context.Context, in *StandRequest, opts ...grpc.CallOption) (*StandResponse, error)
+	PlaceCrashBet(ctx context.Context, in *CrashBetRequest, opts ...grpc.CallOption) (*CrashBetResponse, error)
+	CrashCashout(ctx context.Context, in *CrashCashoutRequest, opts ...grpc.CallOption) (*CrashCashoutResponse, error)
+	WatchCrash(ctx context.Context, in *WatchCrashRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CrashState], error)
+	ListHoldemTables(ctx context.Context, in *ListHoldemTablesRequest, opts ...grpc.CallOption) (*ListHoldemTablesResponse, error)
+	HoldemJoin(ctx context.Context, in *HoldemJoinRequest, opts ...grpc.CallOption) (*HoldemJoinResponse, error)
+	HoldemAct(ctx context.Context, in *HoldemActRequest, opts ...grpc.CallOption) (*HoldemTableState, error)
+	HoldemLeave(ctx context.Context, in *HoldemLeaveRequest, opts ...grpc.CallOption) (*HoldemLeaveResponse, error)
+	WatchHoldem(ctx context.Context, in *WatchHoldemRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[HoldemTableState], error)
+	SpectateHoldem(ctx context.Context, in *SpectateHoldemRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[HoldemTableState], error)
+	MinesStart(ctx context.Context, in *MinesStartRequest, opts ...grpc.CallOption) (*MinesState, error)
+	MinesReveal(ctx context.Context, in *MinesRevealRequest, opts ...grpc.CallOption) (*MinesState, error)
+	MinesCashout(ctx context.Context, in *MinesCashoutRequest, opts ...grpc.CallOption) (*MinesState, error)
+	MinesGet(ctx context.Context, in *MinesGetRequest, opts ...grpc.CallOption) (*MinesState, error)
+	CreateTournament(ctx context.Context, in *CreateTournamentRequest, opts ...grpc.CallOption) (*Tournament, error)
+	ScheduleTournament(ctx context.Context, in *ScheduleTournamentRequest, opts ...grpc.CallOption) (*Tournament, error)
+	CancelTournament(ctx context.Context, in *CancelTournamentRequest, opts ...grpc.CallOption) (*Tournament, error)
+	ListTournaments(ctx context.Context, in *ListTournamentsRequest, opts ...grpc.CallOption) (*ListTournamentsResponse, error)
+	GetTournament(ctx context.Context, in *GetTournamentRequest, opts ...grpc.CallOption) (*Tournament, error)
+	JoinTournament(ctx context.Context, in *JoinTournamentRequest, opts ...grpc.CallOption) (*JoinTournamentResponse, error)
+	TournamentPlay(ctx context.Context, in *TournamentPlayRequest, opts ...grpc.CallOption) (*TournamentPlayResponse, error)
+	TournamentLeaderboard(ctx context.Context, in *TournamentLeaderboardRequest, opts ...grpc.CallOption) (*TournamentLeaderboardResponse, error)
 }
 
 type gameServiceClient struct {
@@ -71,6 +113,243 @@ func (c *gameServiceClient) Stand(ctx context.Context, in *StandRequest, opts ..
 	return out, nil
 }
 
+func (c *gameServiceClient) PlaceCrashBet(ctx context.Context, in *CrashBetRequest, opts ...grpc.CallOption) (*CrashBetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CrashBetResponse)
+	err := c.cc.Invoke(ctx, GameService_PlaceCrashBet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameServiceClient) CrashCashout(ctx context.Context, in *CrashCashoutRequest, opts ...grpc.CallOption) (*CrashCashoutResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CrashCashoutResponse)
+	err := c.cc.Invoke(ctx, GameService_CrashCashout_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameServiceClient) WatchCrash(ctx context.Context, in *WatchCrashRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[CrashState], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &GameService_ServiceDesc.Streams[0], GameService_WatchCrash_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchCrashRequest, CrashState]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GameService_WatchCrashClient = grpc.ServerStreamingClient[CrashState]
+
+func (c *gameServiceClient) ListHoldemTables(ctx context.Context, in *ListHoldemTablesRequest, opts ...grpc.CallOption) (*ListHoldemTablesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListHoldemTablesResponse)
+	err := c.cc.Invoke(ctx, GameService_ListHoldemTables_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameServiceClient) HoldemJoin(ctx context.Context, in *HoldemJoinRequest, opts ...grpc.CallOption) (*HoldemJoinResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HoldemJoinResponse)
+	err := c.cc.Invoke(ctx, GameService_HoldemJoin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameServiceClient) HoldemAct(ctx context.Context, in *HoldemActRequest, opts ...grpc.CallOption) (*HoldemTableState, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HoldemTableState)
+	err := c.cc.Invoke(ctx, GameService_HoldemAct_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameServiceClient) HoldemLeave(ctx context.Context, in *HoldemLeaveRequest, opts ...grpc.CallOption) (*HoldemLeaveResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HoldemLeaveResponse)
+	err := c.cc.Invoke(ctx, GameService_HoldemLeave_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameServiceClient) WatchHoldem(ctx context.Context, in *WatchHoldemRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[HoldemTableState], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &GameService_ServiceDesc.Streams[1], GameService_WatchHoldem_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchHoldemRequest, HoldemTableState]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GameService_WatchHoldemClient = grpc.ServerStreamingClient[HoldemTableState]
+
+func (c *gameServiceClient) SpectateHoldem(ctx context.Context, in *SpectateHoldemRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[HoldemTableState], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &GameService_ServiceDesc.Streams[2], GameService_SpectateHoldem_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SpectateHoldemRequest, HoldemTableState]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GameService_SpectateHoldemClient = grpc.ServerStreamingClient[HoldemTableState]
+
+func (c *gameServiceClient) MinesStart(ctx context.Context, in *MinesStartRequest, opts ...grpc.CallOption) (*MinesState, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MinesState)
+	err := c.cc.Invoke(ctx, GameService_MinesStart_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameServiceClient) MinesReveal(ctx context.Context, in *MinesRevealRequest, opts ...grpc.CallOption) (*MinesState, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MinesState)
+	err := c.cc.Invoke(ctx, GameService_MinesReveal_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameServiceClient) MinesCashout(ctx context.Context, in *MinesCashoutRequest, opts ...grpc.CallOption) (*MinesState, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MinesState)
+	err := c.cc.Invoke(ctx, GameService_MinesCashout_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameServiceClient) MinesGet(ctx context.Context, in *MinesGetRequest, opts ...grpc.CallOption) (*MinesState, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MinesState)
+	err := c.cc.Invoke(ctx, GameService_MinesGet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameServiceClient) CreateTournament(ctx context.Context, in *CreateTournamentRequest, opts ...grpc.CallOption) (*Tournament, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Tournament)
+	err := c.cc.Invoke(ctx, GameService_CreateTournament_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameServiceClient) ScheduleTournament(ctx context.Context, in *ScheduleTournamentRequest, opts ...grpc.CallOption) (*Tournament, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Tournament)
+	err := c.cc.Invoke(ctx, GameService_ScheduleTournament_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameServiceClient) CancelTournament(ctx context.Context, in *CancelTournamentRequest, opts ...grpc.CallOption) (*Tournament, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Tournament)
+	err := c.cc.Invoke(ctx, GameService_CancelTournament_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameServiceClient) ListTournaments(ctx context.Context, in *ListTournamentsRequest, opts ...grpc.CallOption) (*ListTournamentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTournamentsResponse)
+	err := c.cc.Invoke(ctx, GameService_ListTournaments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameServiceClient) GetTournament(ctx context.Context, in *GetTournamentRequest, opts ...grpc.CallOption) (*Tournament, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Tournament)
+	err := c.cc.Invoke(ctx, GameService_GetTournament_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameServiceClient) JoinTournament(ctx context.Context, in *JoinTournamentRequest, opts ...grpc.CallOption) (*JoinTournamentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(JoinTournamentResponse)
+	err := c.cc.Invoke(ctx, GameService_JoinTournament_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameServiceClient) TournamentPlay(ctx context.Context, in *TournamentPlayRequest, opts ...grpc.CallOption) (*TournamentPlayResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TournamentPlayResponse)
+	err := c.cc.Invoke(ctx, GameService_TournamentPlay_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameServiceClient) TournamentLeaderboard(ctx context.Context, in *TournamentLeaderboardRequest, opts ...grpc.CallOption) (*TournamentLeaderboardResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TournamentLeaderboardResponse)
+	err := c.cc.Invoke(ctx, GameService_TournamentLeaderboard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // GameServiceServer is the server API for GameService service.
 // All implementations must embed UnimplementedGameServiceServer
 // for forward compatibility.
@@ -78,6 +357,27 @@ type GameServiceServer interface {
 	NewGame(context.Context, *NewGameRequest) (*NewGameResponse, error)
 	Hit(context.Context, *HitRequest) (*HitResponse, error)
 	Stand(context.Context, *StandRequest) (*StandResponse, error)
+	PlaceCrashBet(context.Context, *CrashBetRequest) (*CrashBetResponse, error)
+	CrashCashout(context.Context, *CrashCashoutRequest) (*CrashCashoutResponse, error)
+	WatchCrash(*WatchCrashRequest, grpc.ServerStreamingServer[CrashState]) error
+	ListHoldemTables(context.Context, *ListHoldemTablesRequest) (*ListHoldemTablesResponse, error)
+	HoldemJoin(context.Context, *HoldemJoinRequest) (*HoldemJoinResponse, error)
+	HoldemAct(context.Context, *HoldemActRequest) (*HoldemTableState, error)
+	HoldemLeave(context.Context, *HoldemLeaveRequest) (*HoldemLeaveResponse, error)
+	WatchHoldem(*WatchHoldemRequest, grpc.ServerStreamingServer[HoldemTableState]) error
+	SpectateHoldem(*SpectateHoldemRequest, grpc.ServerStreamingServer[HoldemTableState]) error
+	MinesStart(context.Context, *MinesStartRequest) (*MinesState, error)
+	MinesReveal(context.Context, *MinesRevealRequest) (*MinesState, error)
+	MinesCashout(context.Context, *MinesCashoutRequest) (*MinesState, error)
+	MinesGet(context.Context, *MinesGetRequest) (*MinesState, error)
+	CreateTournament(context.Context, *CreateTournamentRequest) (*Tournament, error)
+	ScheduleTournament(context.Context, *ScheduleTournamentRequest) (*Tournament, error)
+	CancelTournament(context.Context, *CancelTournamentRequest) (*Tournament, error)
+	ListTournaments(context.Context, *ListTournamentsRequest) (*ListTournamentsResponse, error)
+	GetTournament(context.Context, *GetTournamentRequest) (*Tournament, error)
+	JoinTournament(context.Context, *JoinTournamentRequest) (*JoinTournamentResponse, error)
+	TournamentPlay(context.Context, *TournamentPlayRequest) (*TournamentPlayResponse, error)
+	TournamentLeaderboard(context.Context, *TournamentLeaderboardRequest) (*TournamentLeaderboardResponse, error)
 	mustEmbedUnimplementedGameServiceServer()
 }
 
@@ -97,6 +397,69 @@ func (UnimplementedGameServiceServer) Hit(context.Context, *HitRequest) (*HitRes
 func (UnimplementedGameServiceServer) Stand(context.Context, *StandRequest) (*StandResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Stand not implemented")
 }
+func (UnimplementedGameServiceServer) PlaceCrashBet(context.Context, *CrashBetRequest) (*CrashBetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PlaceCrashBet not implemented")
+}
+func (UnimplementedGameServiceServer) CrashCashout(context.Context, *CrashCashoutRequest) (*CrashCashoutResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CrashCashout not implemented")
+}
+func (UnimplementedGameServiceServer) WatchCrash(*WatchCrashRequest, grpc.ServerStreamingServer[CrashState]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchCrash not implemented")
+}
+func (UnimplementedGameServiceServer) ListHoldemTables(context.Context, *ListHoldemTablesRequest) (*ListHoldemTablesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListHoldemTables not implemented")
+}
+func (UnimplementedGameServiceServer) HoldemJoin(context.Context, *HoldemJoinRequest) (*HoldemJoinResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HoldemJoin not implemented")
+}
+func (UnimplementedGameServiceServer) HoldemAct(context.Context, *HoldemActRequest) (*HoldemTableState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HoldemAct not implemented")
+}
+func (UnimplementedGameServiceServer) HoldemLeave(context.Context, *HoldemLeaveRequest) (*HoldemLeaveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HoldemLeave not implemented")
+}
+func (UnimplementedGameServiceServer) WatchHoldem(*WatchHoldemRequest, grpc.ServerStreamingServer[HoldemTableState]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchHoldem not implemented")
+}
+func (UnimplementedGameServiceServer) SpectateHoldem(*SpectateHoldemRequest, grpc.ServerStreamingServer[HoldemTableState]) error {
+	return status.Errorf(codes.Unimplemented, "method SpectateHoldem not implemented")
+}
+func (UnimplementedGameServiceServer) MinesStart(context.Context, *MinesStartRequest) (*MinesState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MinesStart not implemented")
+}
+func (UnimplementedGameServiceServer) MinesReveal(context.Context, *MinesRevealRequest) (*MinesState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MinesReveal not implemented")
+}
+func (UnimplementedGameServiceServer) MinesCashout(context.Context, *MinesCashoutRequest) (*MinesState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MinesCashout not implemented")
+}
+func (UnimplementedGameServiceServer) MinesGet(context.Context, *MinesGetRequest) (*MinesState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MinesGet not implemented")
+}
+func (UnimplementedGameServiceServer) CreateTournament(context.Context, *CreateTournamentRequest) (*Tournament, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTournament not implemented")
+}
+func (UnimplementedGameServiceServer) ScheduleTournament(context.Context, *ScheduleTournamentRequest) (*Tournament, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ScheduleTournament not implemented")
+}
+func (UnimplementedGameServiceServer) CancelTournament(context.Context, *CancelTournamentRequest) (*Tournament, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelTournament not implemented")
+}
+func (UnimplementedGameServiceServer) ListTournaments(context.Context, *ListTournamentsRequest) (*ListTournamentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTournaments not implemented")
+}
+func (UnimplementedGameServiceServer) GetTournament(context.Context, *GetTournamentRequest) (*Tournament, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTournament not implemented")
+}
+func (UnimplementedGameServiceServer) JoinTournament(context.Context, *JoinTournamentRequest) (*JoinTournamentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method JoinTournament not implemented")
+}
+func (UnimplementedGameServiceServer) TournamentPlay(context.Context, *TournamentPlayRequest) (*TournamentPlayResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TournamentPlay not implemented")
+}
+func (UnimplementedGameServiceServer) TournamentLeaderboard(context.Context, *TournamentLeaderboardRequest) (*TournamentLeaderboardResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TournamentLeaderboard not implemented")
+}
 func (UnimplementedGameServiceServer) mustEmbedUnimplementedGameServiceServer() {}
 func (UnimplementedGameServiceServer) testEmbeddedByValue()                     {}
 
@@ -172,6 +535,363 @@ func _GameService_Stand_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GameService_PlaceCrashBet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CrashBetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).PlaceCrashBet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameService_PlaceCrashBet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).PlaceCrashBet(ctx, req.(*CrashBetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameService_CrashCashout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CrashCashoutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).CrashCashout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameService_CrashCashout_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).CrashCashout(ctx, req.(*CrashCashoutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameService_WatchCrash_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchCrashRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GameServiceServer).WatchCrash(m, &grpc.GenericServerStream[WatchCrashRequest, CrashState]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GameService_WatchCrashServer = grpc.ServerStreamingServer[CrashState]
+
+func _GameService_ListHoldemTables_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListHoldemTablesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).ListHoldemTables(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameService_ListHoldemTables_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).ListHoldemTables(ctx, req.(*ListHoldemTablesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameService_HoldemJoin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HoldemJoinRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).HoldemJoin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameService_HoldemJoin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).HoldemJoin(ctx, req.(*HoldemJoinRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameService_HoldemAct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HoldemActRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).HoldemAct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameService_HoldemAct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).HoldemAct(ctx, req.(*HoldemActRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameService_HoldemLeave_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HoldemLeaveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).HoldemLeave(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameService_HoldemLeave_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).HoldemLeave(ctx, req.(*HoldemLeaveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameService_WatchHoldem_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchHoldemRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GameServiceServer).WatchHoldem(m, &grpc.GenericServerStream[WatchHoldemRequest, HoldemTableState]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GameService_WatchHoldemServer = grpc.ServerStreamingServer[HoldemTableState]
+
+func _GameService_SpectateHoldem_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SpectateHoldemRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GameServiceServer).SpectateHoldem(m, &grpc.GenericServerStream[SpectateHoldemRequest, HoldemTableState]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GameService_SpectateHoldemServer = grpc.ServerStreamingServer[HoldemTableState]
+
+func _GameService_MinesStart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MinesStartRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).MinesStart(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameService_MinesStart_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).MinesStart(ctx, req.(*MinesStartRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameService_MinesReveal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MinesRevealRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).MinesReveal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameService_MinesReveal_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).MinesReveal(ctx, req.(*MinesRevealRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameService_MinesCashout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MinesCashoutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).MinesCashout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameService_MinesCashout_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).MinesCashout(ctx, req.(*MinesCashoutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameService_MinesGet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MinesGetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).MinesGet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameService_MinesGet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).MinesGet(ctx, req.(*MinesGetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameService_CreateTournament_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTournamentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).CreateTournament(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameService_CreateTournament_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).CreateTournament(ctx, req.(*CreateTournamentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameService_ScheduleTournament_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScheduleTournamentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).ScheduleTournament(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameService_ScheduleTournament_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).ScheduleTournament(ctx, req.(*ScheduleTournamentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameService_CancelTournament_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelTournamentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).CancelTournament(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameService_CancelTournament_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).CancelTournament(ctx, req.(*CancelTournamentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameService_ListTournaments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTournamentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).ListTournaments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameService_ListTournaments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).ListTournaments(ctx, req.(*ListTournamentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameService_GetTournament_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTournamentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).GetTournament(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameService_GetTournament_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).GetTournament(ctx, req.(*GetTournamentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameService_JoinTournament_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JoinTournamentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).JoinTournament(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameService_JoinTournament_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).JoinTournament(ctx, req.(*JoinTournamentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameService_TournamentPlay_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TournamentPlayRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).TournamentPlay(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameService_TournamentPlay_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).TournamentPlay(ctx, req.(*TournamentPlayRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameService_TournamentLeaderboard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TournamentLeaderboardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).TournamentLeaderboard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameService_TournamentLeaderboard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).TournamentLeaderboard(ctx, req.(*TournamentLeaderboardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // GameService_ServiceDesc is the grpc.ServiceDesc for GameService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -191,7 +911,95 @@ var GameService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Stand",
 			Handler:    _GameService_Stand_Handler,
 		},
+		{
+			MethodName: "PlaceCrashBet",
+			Handler:    _GameService_PlaceCrashBet_Handler,
+		},
+		{
+			MethodName: "CrashCashout",
+			Handler:    _GameService_CrashCashout_Handler,
+		},
+		{
+			MethodName: "ListHoldemTables",
+			Handler:    _GameService_ListHoldemTables_Handler,
+		},
+		{
+			MethodName: "HoldemJoin",
+			Handler:    _GameService_HoldemJoin_Handler,
+		},
+		{
+			MethodName: "HoldemAct",
+			Handler:    _GameService_HoldemAct_Handler,
+		},
+		{
+			MethodName: "HoldemLeave",
+			Handler:    _GameService_HoldemLeave_Handler,
+		},
+		{
+			MethodName: "MinesStart",
+			Handler:    _GameService_MinesStart_Handler,
+		},
+		{
+			MethodName: "MinesReveal",
+			Handler:    _GameService_MinesReveal_Handler,
+		},
+		{
+			MethodName: "MinesCashout",
+			Handler:    _GameService_MinesCashout_Handler,
+		},
+		{
+			MethodName: "MinesGet",
+			Handler:    _GameService_MinesGet_Handler,
+		},
+		{
+			MethodName: "CreateTournament",
+			Handler:    _GameService_CreateTournament_Handler,
+		},
+		{
+			MethodName: "ScheduleTournament",
+			Handler:    _GameService_ScheduleTournament_Handler,
+		},
+		{
+			MethodName: "CancelTournament",
+			Handler:    _GameService_CancelTournament_Handler,
+		},
+		{
+			MethodName: "ListTournaments",
+			Handler:    _GameService_ListTournaments_Handler,
+		},
+		{
+			MethodName: "GetTournament",
+			Handler:    _GameService_GetTournament_Handler,
+		},
+		{
+			MethodName: "JoinTournament",
+			Handler:    _GameService_JoinTournament_Handler,
+		},
+		{
+			MethodName: "TournamentPlay",
+			Handler:    _GameService_TournamentPlay_Handler,
+		},
+		{
+			MethodName: "TournamentLeaderboard",
+			Handler:    _GameService_TournamentLeaderboard_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchCrash",
+			Handler:       _GameService_WatchCrash_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchHoldem",
+			Handler:       _GameService_WatchHoldem_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SpectateHoldem",
+			Handler:       _GameService_SpectateHoldem_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "game.proto",
 }
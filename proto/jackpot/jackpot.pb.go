@@ -0,0 +1,758 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.30.2
+// source: jackpot.proto
+
+package jackpot
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// --- Взнос: процент с каждой подходящей ставки уходит в общий фонд ---
+type ContributeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Game          string                 `protobuf:"bytes,1,opt,name=game,proto3" json:"game,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	RoundId       string                 `protobuf:"bytes,3,opt,name=round_id,json=roundId,proto3" json:"round_id,omitempty"`
+	Stake         int32                  `protobuf:"varint,4,opt,name=stake,proto3" json:"stake,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ContributeRequest) Reset() {
+	*x = ContributeRequest{}
+	mi := &file_jackpot_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContributeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContributeRequest) ProtoMessage() {}
+
+func (x *ContributeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_jackpot_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContributeRequest.ProtoReflect.Descriptor instead.
+func (*ContributeRequest) Descriptor() ([]byte, []int) {
+	return file_jackpot_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ContributeRequest) GetGame() string {
+	if x != nil {
+		return x.Game
+	}
+	return ""
+}
+
+func (x *ContributeRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ContributeRequest) GetRoundId() string {
+	if x != nil {
+		return x.RoundId
+	}
+	return ""
+}
+
+func (x *ContributeRequest) GetStake() int32 {
+	if x != nil {
+		return x.Stake
+	}
+	return 0
+}
+
+type ContributeResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// сколько ушло в фонд, в сотых долях кредита
+	ContributedCents int64 `protobuf:"varint,1,opt,name=contributed_cents,json=contributedCents,proto3" json:"contributed_cents,omitempty"`
+	Amount           int64 `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ContributeResponse) Reset() {
+	*x = ContributeResponse{}
+	mi := &file_jackpot_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContributeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContributeResponse) ProtoMessage() {}
+
+func (x *ContributeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_jackpot_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContributeResponse.ProtoReflect.Descriptor instead.
+func (*ContributeResponse) Descriptor() ([]byte, []int) {
+	return file_jackpot_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ContributeResponse) GetContributedCents() int64 {
+	if x != nil {
+		return x.ContributedCents
+	}
+	return 0
+}
+
+func (x *ContributeResponse) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+// --- Выигрыш: игра сообщает о редком исходе, фонд выплачивается один раз ---
+type ClaimRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Game    string                 `protobuf:"bytes,1,opt,name=game,proto3" json:"game,omitempty"`
+	UserId  string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	RoundId string                 `protobuf:"bytes,3,opt,name=round_id,json=roundId,proto3" json:"round_id,omitempty"`
+	// "777" и т.п., должен быть разрешён для этой игры
+	Trigger string `protobuf:"bytes,4,opt,name=trigger,proto3" json:"trigger,omitempty"`
+	// ставка раунда в кредитах: фонд выигрывает только ставка не меньше
+	// JACKPOT_MIN_STAKE, как и пополняет его
+	Stake         int32 `protobuf:"varint,5,opt,name=stake,proto3" json:"stake,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClaimRequest) Reset() {
+	*x = ClaimRequest{}
+	mi := &file_jackpot_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClaimRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClaimRequest) ProtoMessage() {}
+
+func (x *ClaimRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_jackpot_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClaimRequest.ProtoReflect.Descriptor instead.
+func (*ClaimRequest) Descriptor() ([]byte, []int) {
+	return file_jackpot_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ClaimRequest) GetGame() string {
+	if x != nil {
+		return x.Game
+	}
+	return ""
+}
+
+func (x *ClaimRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ClaimRequest) GetRoundId() string {
+	if x != nil {
+		return x.RoundId
+	}
+	return ""
+}
+
+func (x *ClaimRequest) GetTrigger() string {
+	if x != nil {
+		return x.Trigger
+	}
+	return ""
+}
+
+func (x *ClaimRequest) GetStake() int32 {
+	if x != nil {
+		return x.Stake
+	}
+	return 0
+}
+
+type JackpotWin struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WinId         string                 `protobuf:"bytes,1,opt,name=win_id,json=winId,proto3" json:"win_id,omitempty"` // game:round_id
+	PoolId        string                 `protobuf:"bytes,2,opt,name=pool_id,json=poolId,proto3" json:"pool_id,omitempty"`
+	Game          string                 `protobuf:"bytes,3,opt,name=game,proto3" json:"game,omitempty"`
+	UserId        string                 `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	RoundId       string                 `protobuf:"bytes,5,opt,name=round_id,json=roundId,proto3" json:"round_id,omitempty"`
+	Trigger       string                 `protobuf:"bytes,6,opt,name=trigger,proto3" json:"trigger,omitempty"`
+	Amount        int32                  `protobuf:"varint,7,opt,name=amount,proto3" json:"amount,omitempty"`
+	Status        string                 `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"` // "pending", "paying", "paid"
+	CreatedAt     int64                  `protobuf:"varint,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JackpotWin) Reset() {
+	*x = JackpotWin{}
+	mi := &file_jackpot_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JackpotWin) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JackpotWin) ProtoMessage() {}
+
+func (x *JackpotWin) ProtoReflect() protoreflect.Message {
+	mi := &file_jackpot_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JackpotWin.ProtoReflect.Descriptor instead.
+func (*JackpotWin) Descriptor() ([]byte, []int) {
+	return file_jackpot_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *JackpotWin) GetWinId() string {
+	if x != nil {
+		return x.WinId
+	}
+	return ""
+}
+
+func (x *JackpotWin) GetPoolId() string {
+	if x != nil {
+		return x.PoolId
+	}
+	return ""
+}
+
+func (x *JackpotWin) GetGame() string {
+	if x != nil {
+		return x.Game
+	}
+	return ""
+}
+
+func (x *JackpotWin) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *JackpotWin) GetRoundId() string {
+	if x != nil {
+		return x.RoundId
+	}
+	return ""
+}
+
+func (x *JackpotWin) GetTrigger() string {
+	if x != nil {
+		return x.Trigger
+	}
+	return ""
+}
+
+func (x *JackpotWin) GetAmount() int32 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *JackpotWin) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *JackpotWin) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+// --- Текущие суммы ---
+type Jackpot struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	PoolId string                 `protobuf:"bytes,1,opt,name=pool_id,json=poolId,proto3" json:"pool_id,omitempty"`
+	Amount int64                  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	// с этой суммы фонд начинается заново после выигрыша
+	Seed int64 `protobuf:"varint,3,opt,name=seed,proto3" json:"seed,omitempty"`
+	// процент от ставки в базисных пунктах (100 = 1%)
+	RateBp        int32  `protobuf:"varint,4,opt,name=rate_bp,json=rateBp,proto3" json:"rate_bp,omitempty"`
+	LastWinner    string `protobuf:"bytes,5,opt,name=last_winner,json=lastWinner,proto3" json:"last_winner,omitempty"`
+	LastAmount    int64  `protobuf:"varint,6,opt,name=last_amount,json=lastAmount,proto3" json:"last_amount,omitempty"`
+	UpdatedAt     int64  `protobuf:"varint,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Jackpot) Reset() {
+	*x = Jackpot{}
+	mi := &file_jackpot_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Jackpot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Jackpot) ProtoMessage() {}
+
+func (x *Jackpot) ProtoReflect() protoreflect.Message {
+	mi := &file_jackpot_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Jackpot.ProtoReflect.Descriptor instead.
+func (*Jackpot) Descriptor() ([]byte, []int) {
+	return file_jackpot_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Jackpot) GetPoolId() string {
+	if x != nil {
+		return x.PoolId
+	}
+	return ""
+}
+
+func (x *Jackpot) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Jackpot) GetSeed() int64 {
+	if x != nil {
+		return x.Seed
+	}
+	return 0
+}
+
+func (x *Jackpot) GetRateBp() int32 {
+	if x != nil {
+		return x.RateBp
+	}
+	return 0
+}
+
+func (x *Jackpot) GetLastWinner() string {
+	if x != nil {
+		return x.LastWinner
+	}
+	return ""
+}
+
+func (x *Jackpot) GetLastAmount() int64 {
+	if x != nil {
+		return x.LastAmount
+	}
+	return 0
+}
+
+func (x *Jackpot) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+type GetJackpotsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetJackpotsRequest) Reset() {
+	*x = GetJackpotsRequest{}
+	mi := &file_jackpot_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetJackpotsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJackpotsRequest) ProtoMessage() {}
+
+func (x *GetJackpotsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_jackpot_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJackpotsRequest.ProtoReflect.Descriptor instead.
+func (*GetJackpotsRequest) Descriptor() ([]byte, []int) {
+	return file_jackpot_proto_rawDescGZIP(), []int{5}
+}
+
+type GetJackpotsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Jackpots      []*Jackpot             `protobuf:"bytes,1,rep,name=jackpots,proto3" json:"jackpots,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetJackpotsResponse) Reset() {
+	*x = GetJackpotsResponse{}
+	mi := &file_jackpot_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetJackpotsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJackpotsResponse) ProtoMessage() {}
+
+func (x *GetJackpotsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_jackpot_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJackpotsResponse.ProtoReflect.Descriptor instead.
+func (*GetJackpotsResponse) Descriptor() ([]byte, []int) {
+	return file_jackpot_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetJackpotsResponse) GetJackpots() []*Jackpot {
+	if x != nil {
+		return x.Jackpots
+	}
+	return nil
+}
+
+type WatchJackpotsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchJackpotsRequest) Reset() {
+	*x = WatchJackpotsRequest{}
+	mi := &file_jackpot_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchJackpotsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchJackpotsRequest) ProtoMessage() {}
+
+func (x *WatchJackpotsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_jackpot_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchJackpotsRequest.ProtoReflect.Descriptor instead.
+func (*WatchJackpotsRequest) Descriptor() ([]byte, []int) {
+	return file_jackpot_proto_rawDescGZIP(), []int{7}
+}
+
+type ListWinsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWinsRequest) Reset() {
+	*x = ListWinsRequest{}
+	mi := &file_jackpot_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWinsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWinsRequest) ProtoMessage() {}
+
+func (x *ListWinsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_jackpot_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWinsRequest.ProtoReflect.Descriptor instead.
+func (*ListWinsRequest) Descriptor() ([]byte, []int) {
+	return file_jackpot_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListWinsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListWinsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Wins          []*JackpotWin          `protobuf:"bytes,1,rep,name=wins,proto3" json:"wins,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWinsResponse) Reset() {
+	*x = ListWinsResponse{}
+	mi := &file_jackpot_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWinsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWinsResponse) ProtoMessage() {}
+
+func (x *ListWinsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_jackpot_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWinsResponse.ProtoReflect.Descriptor instead.
+func (*ListWinsResponse) Descriptor() ([]byte, []int) {
+	return file_jackpot_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListWinsResponse) GetWins() []*JackpotWin {
+	if x != nil {
+		return x.Wins
+	}
+	return nil
+}
+
+var File_jackpot_proto protoreflect.FileDescriptor
+
+const file_jackpot_proto_rawDesc = "" +
+	"\n" +
+	"\rjackpot.proto\x12\ajackpot\"q\n" +
+	"\x11ContributeRequest\x12\x12\n" +
+	"\x04game\x18\x01 \x01(\tR\x04game\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x19\n" +
+	"\bround_id\x18\x03 \x01(\tR\aroundId\x12\x14\n" +
+	"\x05stake\x18\x04 \x01(\x05R\x05stake\"Y\n" +
+	"\x12ContributeResponse\x12+\n" +
+	"\x11contributed_cents\x18\x01 \x01(\x03R\x10contributedCents\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x03R\x06amount\"\x86\x01\n" +
+	"\fClaimRequest\x12\x12\n" +
+	"\x04game\x18\x01 \x01(\tR\x04game\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x19\n" +
+	"\bround_id\x18\x03 \x01(\tR\aroundId\x12\x18\n" +
+	"\atrigger\x18\x04 \x01(\tR\atrigger\x12\x14\n" +
+	"\x05stake\x18\x05 \x01(\x05R\x05stake\"\xed\x01\n" +
+	"\n" +
+	"JackpotWin\x12\x15\n" +
+	"\x06win_id\x18\x01 \x01(\tR\x05winId\x12\x17\n" +
+	"\apool_id\x18\x02 \x01(\tR\x06poolId\x12\x12\n" +
+	"\x04game\x18\x03 \x01(\tR\x04game\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\tR\x06userId\x12\x19\n" +
+	"\bround_id\x18\x05 \x01(\tR\aroundId\x12\x18\n" +
+	"\atrigger\x18\x06 \x01(\tR\atrigger\x12\x16\n" +
+	"\x06amount\x18\a \x01(\x05R\x06amount\x12\x16\n" +
+	"\x06status\x18\b \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\t \x01(\x03R\tcreatedAt\"\xc8\x01\n" +
+	"\aJackpot\x12\x17\n" +
+	"\apool_id\x18\x01 \x01(\tR\x06poolId\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x03R\x06amount\x12\x12\n" +
+	"\x04seed\x18\x03 \x01(\x03R\x04seed\x12\x17\n" +
+	"\arate_bp\x18\x04 \x01(\x05R\x06rateBp\x12\x1f\n" +
+	"\vlast_winner\x18\x05 \x01(\tR\n" +
+	"lastWinner\x12\x1f\n" +
+	"\vlast_amount\x18\x06 \x01(\x03R\n" +
+	"lastAmount\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\x03R\tupdatedAt\"\x14\n" +
+	"\x12GetJackpotsRequest\"C\n" +
+	"\x13GetJackpotsResponse\x12,\n" +
+	"\bjackpots\x18\x01 \x03(\v2\x10.jackpot.JackpotR\bjackpots\"\x16\n" +
+	"\x14WatchJackpotsRequest\"'\n" +
+	"\x0fListWinsRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\";\n" +
+	"\x10ListWinsResponse\x12'\n" +
+	"\x04wins\x18\x01 \x03(\v2\x13.jackpot.JackpotWinR\x04wins2\xe7\x02\n" +
+	"\x0eJackpotService\x12E\n" +
+	"\n" +
+	"Contribute\x12\x1a.jackpot.ContributeRequest\x1a\x1b.jackpot.ContributeResponse\x123\n" +
+	"\x05Claim\x12\x15.jackpot.ClaimRequest\x1a\x13.jackpot.JackpotWin\x12H\n" +
+	"\vGetJackpots\x12\x1b.jackpot.GetJackpotsRequest\x1a\x1c.jackpot.GetJackpotsResponse\x12N\n" +
+	"\rWatchJackpots\x12\x1d.jackpot.WatchJackpotsRequest\x1a\x1c.jackpot.GetJackpotsResponse0\x01\x12?\n" +
+	"\bListWins\x12\x18.jackpot.ListWinsRequest\x1a\x19.jackpot.ListWinsResponseB4Z2github.com/Arsencchikkk/final/casino/proto/jackpotb\x06proto3"
+
+var (
+	file_jackpot_proto_rawDescOnce sync.Once
+	file_jackpot_proto_rawDescData []byte
+)
+
+func file_jackpot_proto_rawDescGZIP() []byte {
+	file_jackpot_proto_rawDescOnce.Do(func() {
+		file_jackpot_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_jackpot_proto_rawDesc), len(file_jackpot_proto_rawDesc)))
+	})
+	return file_jackpot_proto_rawDescData
+}
+
+var file_jackpot_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_jackpot_proto_goTypes = []any{
+	(*ContributeRequest)(nil),    // 0: jackpot.ContributeRequest
+	(*ContributeResponse)(nil),   // 1: jackpot.ContributeResponse
+	(*ClaimRequest)(nil),         // 2: jackpot.ClaimRequest
+	(*JackpotWin)(nil),           // 3: jackpot.JackpotWin
+	(*Jackpot)(nil),              // 4: jackpot.Jackpot
+	(*GetJackpotsRequest)(nil),   // 5: jackpot.GetJackpotsRequest
+	(*GetJackpotsResponse)(nil),  // 6: jackpot.GetJackpotsResponse
+	(*WatchJackpotsRequest)(nil), // 7: jackpot.WatchJackpotsRequest
+	(*ListWinsRequest)(nil),      // 8: jackpot.ListWinsRequest
+	(*ListWinsResponse)(nil),     // 9: jackpot.ListWinsResponse
+}
+var file_jackpot_proto_depIdxs = []int32{
+	4, // 0: jackpot.GetJackpotsResponse.jackpots:type_name -> jackpot.Jackpot
+	3, // 1: jackpot.ListWinsResponse.wins:type_name -> jackpot.JackpotWin
+	0, // 2: jackpot.JackpotService.Contribute:input_type -> jackpot.ContributeRequest
+	2, // 3: jackpot.JackpotService.Claim:input_type -> jackpot.ClaimRequest
+	5, // 4: jackpot.JackpotService.GetJackpots:input_type -> jackpot.GetJackpotsRequest
+	7, // 5: jackpot.JackpotService.WatchJackpots:input_type -> jackpot.WatchJackpotsRequest
+	8, // 6: jackpot.JackpotService.ListWins:input_type -> jackpot.ListWinsRequest
+	1, // 7: jackpot.JackpotService.Contribute:output_type -> jackpot.ContributeResponse
+	3, // 8: jackpot.JackpotService.Claim:output_type -> jackpot.JackpotWin
+	6, // 9: jackpot.JackpotService.GetJackpots:output_type -> jackpot.GetJackpotsResponse
+	6, // 10: jackpot.JackpotService.WatchJackpots:output_type -> jackpot.GetJackpotsResponse
+	9, // 11: jackpot.JackpotService.ListWins:output_type -> jackpot.ListWinsResponse
+	7, // [7:12] is the sub-list for method output_type
+	2, // [2:7] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_jackpot_proto_init() }
+func file_jackpot_proto_init() {
+	if File_jackpot_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_jackpot_proto_rawDesc), len(file_jackpot_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_jackpot_proto_goTypes,
+		DependencyIndexes: file_jackpot_proto_depIdxs,
+		MessageInfos:      file_jackpot_proto_msgTypes,
+	}.Build()
+	File_jackpot_proto = out.File
+	file_jackpot_proto_goTypes = nil
+	file_jackpot_proto_depIdxs = nil
+}
@@ -0,0 +1,279 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v6.30.2
+// source: jackpot.proto
+
+package jackpot
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	JackpotService_Contribute_FullMethodName    = "/jackpot.JackpotService/Contribute"
+	JackpotService_Claim_FullMethodName         = "/jackpot.JackpotService/Claim"
+	JackpotService_GetJackpots_FullMethodName   = "/jackpot.JackpotService/GetJackpots"
+	JackpotService_WatchJackpots_FullMethodName = "/jackpot.JackpotService/WatchJackpots"
+	JackpotService_ListWins_FullMethodName      = "/jackpot.JackpotService/ListWins"
+)
+
+// JackpotServiceClient is the client API for JackpotService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type JackpotServiceClient interface {
+	Contribute(ctx context.Context, in *ContributeRequest, opts ...grpc.CallOption) (*ContributeResponse, error)
+	Claim(ctx context.Context, in *ClaimRequest, opts ...grpc.CallOption) (*JackpotWin, error)
+	GetJackpots(ctx context.Context, in *GetJackpotsRequest, opts ...grpc.CallOption) (*GetJackpotsResponse, error)
+	// новое состояние при каждом изменении суммы
+	WatchJackpots(ctx context.Context, in *WatchJackpotsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GetJackpotsResponse], error)
+	ListWins(ctx context.Context, in *ListWinsRequest, opts ...grpc.CallOption) (*ListWinsResponse, error)
+}
+
+type jackpotServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewJackpotServiceClient(cc grpc.ClientConnInterface) JackpotServiceClient {
+	return &jackpotServiceClient{cc}
+}
+
+func (c *jackpotServiceClient) Contribute(ctx context.Context, in *ContributeRequest, opts ...grpc.CallOption) (*ContributeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ContributeResponse)
+	err := c.cc.Invoke(ctx, JackpotService_Contribute_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jackpotServiceClient) Claim(ctx context.Context, in *ClaimRequest, opts ...grpc.CallOption) (*JackpotWin, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(JackpotWin)
+	err := c.cc.Invoke(ctx, JackpotService_Claim_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jackpotServiceClient) GetJackpots(ctx context.Context, in *GetJackpotsRequest, opts ...grpc.CallOption) (*GetJackpotsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetJackpotsResponse)
+	err := c.cc.Invoke(ctx, JackpotService_GetJackpots_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jackpotServiceClient) WatchJackpots(ctx context.Context, in *WatchJackpotsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GetJackpotsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &JackpotService_ServiceDesc.Streams[0], JackpotService_WatchJackpots_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchJackpotsRequest, GetJackpotsResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type JackpotService_WatchJackpotsClient = grpc.ServerStreamingClient[GetJackpotsResponse]
+
+func (c *jackpotServiceClient) ListWins(ctx context.Context, in *ListWinsRequest, opts ...grpc.CallOption) (*ListWinsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWinsResponse)
+	err := c.cc.Invoke(ctx, JackpotService_ListWins_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// JackpotServiceServer is the server API for JackpotService service.
+// All implementations must embed UnimplementedJackpotServiceServer
+// for forward compatibility.
+type JackpotServiceServer interface {
+	Contribute(context.Context, *ContributeRequest) (*ContributeResponse, error)
+	Claim(context.Context, *ClaimRequest) (*JackpotWin, error)
+	GetJackpots(context.Context, *GetJackpotsRequest) (*GetJackpotsResponse, error)
+	// новое состояние при каждом изменении суммы
+	WatchJackpots(*WatchJackpotsRequest, grpc.ServerStreamingServer[GetJackpotsResponse]) error
+	ListWins(context.Context, *ListWinsRequest) (*ListWinsResponse, error)
+	mustEmbedUnimplementedJackpotServiceServer()
+}
+
+// UnimplementedJackpotServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedJackpotServiceServer struct{}
+
+func (UnimplementedJackpotServiceServer) Contribute(context.Context, *ContributeRequest) (*ContributeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Contribute not implemented")
+}
+func (UnimplementedJackpotServiceServer) Claim(context.Context, *ClaimRequest) (*JackpotWin, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Claim not implemented")
+}
+func (UnimplementedJackpotServiceServer) GetJackpots(context.Context, *GetJackpotsRequest) (*GetJackpotsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJackpots not implemented")
+}
+func (UnimplementedJackpotServiceServer) WatchJackpots(*WatchJackpotsRequest, grpc.ServerStreamingServer[GetJackpotsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchJackpots not implemented")
+}
+func (UnimplementedJackpotServiceServer) ListWins(context.Context, *ListWinsRequest) (*ListWinsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWins not implemented")
+}
+func (UnimplementedJackpotServiceServer) mustEmbedUnimplementedJackpotServiceServer() {}
+func (UnimplementedJackpotServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeJackpotServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to JackpotServiceServer will
+// result in compilation errors.
+type UnsafeJackpotServiceServer interface {
+	mustEmbedUnimplementedJackpotServiceServer()
+}
+
+func RegisterJackpotServiceServer(s grpc.ServiceRegistrar, srv JackpotServiceServer) {
+	// If the following call pancis, it indicates UnimplementedJackpotServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&JackpotService_ServiceDesc, srv)
+}
+
+func _JackpotService_Contribute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ContributeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JackpotServiceServer).Contribute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JackpotService_Contribute_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JackpotServiceServer).Contribute(ctx, req.(*ContributeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JackpotService_Claim_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClaimRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JackpotServiceServer).Claim(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JackpotService_Claim_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JackpotServiceServer).Claim(ctx, req.(*ClaimRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JackpotService_GetJackpots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetJackpotsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JackpotServiceServer).GetJackpots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JackpotService_GetJackpots_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JackpotServiceServer).GetJackpots(ctx, req.(*GetJackpotsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JackpotService_WatchJackpots_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchJackpotsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(JackpotServiceServer).WatchJackpots(m, &grpc.GenericServerStream[WatchJackpotsRequest, GetJackpotsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type JackpotService_WatchJackpotsServer = grpc.ServerStreamingServer[GetJackpotsResponse]
+
+func _JackpotService_ListWins_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWinsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JackpotServiceServer).ListWins(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JackpotService_ListWins_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JackpotServiceServer).ListWins(ctx, req.(*ListWinsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// JackpotService_ServiceDesc is the grpc.ServiceDesc for JackpotService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var JackpotService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "jackpot.JackpotService",
+	HandlerType: (*JackpotServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Contribute",
+			Handler:    _JackpotService_Contribute_Handler,
+		},
+		{
+			MethodName: "Claim",
+			Handler:    _JackpotService_Claim_Handler,
+		},
+		{
+			MethodName: "GetJackpots",
+			Handler:    _JackpotService_GetJackpots_Handler,
+		},
+		{
+			MethodName: "ListWins",
+			Handler:    _JackpotService_ListWins_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchJackpots",
+			Handler:       _JackpotService_WatchJackpots_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "jackpot.proto",
+}
@@ -0,0 +1,973 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.30.2
+// source: keno.proto
+
+package keno
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// --- Покупка билета: 1–10 чисел из 80 на ближайшие тиражи ---
+type BuyTicketRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Picks  []int32                `protobuf:"varint,2,rep,packed,name=picks,proto3" json:"picks,omitempty"`
+	Stake  int32                  `protobuf:"varint,3,opt,name=stake,proto3" json:"stake,omitempty"`
+	// на сколько тиражей подряд (по умолчанию 1)
+	Draws int32 `protobuf:"varint,4,opt,name=draws,proto3" json:"draws,omitempty"`
+	// подкошелёк ставки, пусто — основной
+	Currency      string `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BuyTicketRequest) Reset() {
+	*x = BuyTicketRequest{}
+	mi := &file_keno_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BuyTicketRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuyTicketRequest) ProtoMessage() {}
+
+func (x *BuyTicketRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_keno_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BuyTicketRequest.ProtoReflect.Descriptor instead.
+func (*BuyTicketRequest) Descriptor() ([]byte, []int) {
+	return file_keno_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *BuyTicketRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *BuyTicketRequest) GetPicks() []int32 {
+	if x != nil {
+		return x.Picks
+	}
+	return nil
+}
+
+func (x *BuyTicketRequest) GetStake() int32 {
+	if x != nil {
+		return x.Stake
+	}
+	return 0
+}
+
+func (x *BuyTicketRequest) GetDraws() int32 {
+	if x != nil {
+		return x.Draws
+	}
+	return 0
+}
+
+func (x *BuyTicketRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type Ticket struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	TicketId  string                 `protobuf:"bytes,1,opt,name=ticket_id,json=ticketId,proto3" json:"ticket_id,omitempty"`
+	UserId    string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DrawNo    int64                  `protobuf:"varint,3,opt,name=draw_no,json=drawNo,proto3" json:"draw_no,omitempty"`
+	Picks     []int32                `protobuf:"varint,4,rep,packed,name=picks,proto3" json:"picks,omitempty"`
+	Stake     int32                  `protobuf:"varint,5,opt,name=stake,proto3" json:"stake,omitempty"`
+	Status    string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"` // "pending", "won", "lost", "paid"
+	Hits      int32                  `protobuf:"varint,7,opt,name=hits,proto3" json:"hits,omitempty"`
+	Payout    int32                  `protobuf:"varint,8,opt,name=payout,proto3" json:"payout,omitempty"`
+	CreatedAt int64                  `protobuf:"varint,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// валюта ставки и выплаты, пусто — основная
+	Currency      string `protobuf:"bytes,10,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Ticket) Reset() {
+	*x = Ticket{}
+	mi := &file_keno_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Ticket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Ticket) ProtoMessage() {}
+
+func (x *Ticket) ProtoReflect() protoreflect.Message {
+	mi := &file_keno_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Ticket.ProtoReflect.Descriptor instead.
+func (*Ticket) Descriptor() ([]byte, []int) {
+	return file_keno_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Ticket) GetTicketId() string {
+	if x != nil {
+		return x.TicketId
+	}
+	return ""
+}
+
+func (x *Ticket) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Ticket) GetDrawNo() int64 {
+	if x != nil {
+		return x.DrawNo
+	}
+	return 0
+}
+
+func (x *Ticket) GetPicks() []int32 {
+	if x != nil {
+		return x.Picks
+	}
+	return nil
+}
+
+func (x *Ticket) GetStake() int32 {
+	if x != nil {
+		return x.Stake
+	}
+	return 0
+}
+
+func (x *Ticket) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Ticket) GetHits() int32 {
+	if x != nil {
+		return x.Hits
+	}
+	return 0
+}
+
+func (x *Ticket) GetPayout() int32 {
+	if x != nil {
+		return x.Payout
+	}
+	return 0
+}
+
+func (x *Ticket) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Ticket) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type BuyTicketResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tickets       []*Ticket              `protobuf:"bytes,1,rep,name=tickets,proto3" json:"tickets,omitempty"`
+	Balance       int32                  `protobuf:"varint,2,opt,name=balance,proto3" json:"balance,omitempty"`
+	Currency      string                 `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BuyTicketResponse) Reset() {
+	*x = BuyTicketResponse{}
+	mi := &file_keno_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BuyTicketResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuyTicketResponse) ProtoMessage() {}
+
+func (x *BuyTicketResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_keno_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BuyTicketResponse.ProtoReflect.Descriptor instead.
+func (*BuyTicketResponse) Descriptor() ([]byte, []int) {
+	return file_keno_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BuyTicketResponse) GetTickets() []*Ticket {
+	if x != nil {
+		return x.Tickets
+	}
+	return nil
+}
+
+func (x *BuyTicketResponse) GetBalance() int32 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+func (x *BuyTicketResponse) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+// --- Тиражи ---
+type Draw struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	DrawNo int64                  `protobuf:"varint,1,opt,name=draw_no,json=drawNo,proto3" json:"draw_no,omitempty"`
+	// unix-время тиража (сек)
+	DrawAt        int64   `protobuf:"varint,2,opt,name=draw_at,json=drawAt,proto3" json:"draw_at,omitempty"`
+	Numbers       []int32 `protobuf:"varint,3,rep,packed,name=numbers,proto3" json:"numbers,omitempty"`
+	Status        string  `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"` // "drawn", "settled"
+	Tickets       int32   `protobuf:"varint,5,opt,name=tickets,proto3" json:"tickets,omitempty"`
+	Paid          int32   `protobuf:"varint,6,opt,name=paid,proto3" json:"paid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Draw) Reset() {
+	*x = Draw{}
+	mi := &file_keno_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Draw) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Draw) ProtoMessage() {}
+
+func (x *Draw) ProtoReflect() protoreflect.Message {
+	mi := &file_keno_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Draw.ProtoReflect.Descriptor instead.
+func (*Draw) Descriptor() ([]byte, []int) {
+	return file_keno_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Draw) GetDrawNo() int64 {
+	if x != nil {
+		return x.DrawNo
+	}
+	return 0
+}
+
+func (x *Draw) GetDrawAt() int64 {
+	if x != nil {
+		return x.DrawAt
+	}
+	return 0
+}
+
+func (x *Draw) GetNumbers() []int32 {
+	if x != nil {
+		return x.Numbers
+	}
+	return nil
+}
+
+func (x *Draw) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Draw) GetTickets() int32 {
+	if x != nil {
+		return x.Tickets
+	}
+	return 0
+}
+
+func (x *Draw) GetPaid() int32 {
+	if x != nil {
+		return x.Paid
+	}
+	return 0
+}
+
+type PayTableEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hits          int32                  `protobuf:"varint,1,opt,name=hits,proto3" json:"hits,omitempty"`
+	Multiplier    float64                `protobuf:"fixed64,2,opt,name=multiplier,proto3" json:"multiplier,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PayTableEntry) Reset() {
+	*x = PayTableEntry{}
+	mi := &file_keno_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PayTableEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PayTableEntry) ProtoMessage() {}
+
+func (x *PayTableEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_keno_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PayTableEntry.ProtoReflect.Descriptor instead.
+func (*PayTableEntry) Descriptor() ([]byte, []int) {
+	return file_keno_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PayTableEntry) GetHits() int32 {
+	if x != nil {
+		return x.Hits
+	}
+	return 0
+}
+
+func (x *PayTableEntry) GetMultiplier() float64 {
+	if x != nil {
+		return x.Multiplier
+	}
+	return 0
+}
+
+type PayTableRow struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Picks         int32                  `protobuf:"varint,1,opt,name=picks,proto3" json:"picks,omitempty"`
+	Payouts       []*PayTableEntry       `protobuf:"bytes,2,rep,name=payouts,proto3" json:"payouts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PayTableRow) Reset() {
+	*x = PayTableRow{}
+	mi := &file_keno_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PayTableRow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PayTableRow) ProtoMessage() {}
+
+func (x *PayTableRow) ProtoReflect() protoreflect.Message {
+	mi := &file_keno_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PayTableRow.ProtoReflect.Descriptor instead.
+func (*PayTableRow) Descriptor() ([]byte, []int) {
+	return file_keno_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *PayTableRow) GetPicks() int32 {
+	if x != nil {
+		return x.Picks
+	}
+	return 0
+}
+
+func (x *PayTableRow) GetPayouts() []*PayTableEntry {
+	if x != nil {
+		return x.Payouts
+	}
+	return nil
+}
+
+type NextDrawRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NextDrawRequest) Reset() {
+	*x = NextDrawRequest{}
+	mi := &file_keno_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NextDrawRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NextDrawRequest) ProtoMessage() {}
+
+func (x *NextDrawRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_keno_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NextDrawRequest.ProtoReflect.Descriptor instead.
+func (*NextDrawRequest) Descriptor() ([]byte, []int) {
+	return file_keno_proto_rawDescGZIP(), []int{6}
+}
+
+type NextDrawResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DrawNo        int64                  `protobuf:"varint,1,opt,name=draw_no,json=drawNo,proto3" json:"draw_no,omitempty"`
+	DrawAt        int64                  `protobuf:"varint,2,opt,name=draw_at,json=drawAt,proto3" json:"draw_at,omitempty"`
+	IntervalSec   int32                  `protobuf:"varint,3,opt,name=interval_sec,json=intervalSec,proto3" json:"interval_sec,omitempty"`
+	PayTable      []*PayTableRow         `protobuf:"bytes,4,rep,name=pay_table,json=payTable,proto3" json:"pay_table,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NextDrawResponse) Reset() {
+	*x = NextDrawResponse{}
+	mi := &file_keno_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NextDrawResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NextDrawResponse) ProtoMessage() {}
+
+func (x *NextDrawResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_keno_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NextDrawResponse.ProtoReflect.Descriptor instead.
+func (*NextDrawResponse) Descriptor() ([]byte, []int) {
+	return file_keno_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *NextDrawResponse) GetDrawNo() int64 {
+	if x != nil {
+		return x.DrawNo
+	}
+	return 0
+}
+
+func (x *NextDrawResponse) GetDrawAt() int64 {
+	if x != nil {
+		return x.DrawAt
+	}
+	return 0
+}
+
+func (x *NextDrawResponse) GetIntervalSec() int32 {
+	if x != nil {
+		return x.IntervalSec
+	}
+	return 0
+}
+
+func (x *NextDrawResponse) GetPayTable() []*PayTableRow {
+	if x != nil {
+		return x.PayTable
+	}
+	return nil
+}
+
+type ListDrawsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Limit int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	// только тиражи с номером меньше указанного (пагинация)
+	Before        int64 `protobuf:"varint,2,opt,name=before,proto3" json:"before,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDrawsRequest) Reset() {
+	*x = ListDrawsRequest{}
+	mi := &file_keno_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDrawsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDrawsRequest) ProtoMessage() {}
+
+func (x *ListDrawsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_keno_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDrawsRequest.ProtoReflect.Descriptor instead.
+func (*ListDrawsRequest) Descriptor() ([]byte, []int) {
+	return file_keno_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListDrawsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListDrawsRequest) GetBefore() int64 {
+	if x != nil {
+		return x.Before
+	}
+	return 0
+}
+
+type ListDrawsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Draws         []*Draw                `protobuf:"bytes,1,rep,name=draws,proto3" json:"draws,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDrawsResponse) Reset() {
+	*x = ListDrawsResponse{}
+	mi := &file_keno_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDrawsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDrawsResponse) ProtoMessage() {}
+
+func (x *ListDrawsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_keno_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDrawsResponse.ProtoReflect.Descriptor instead.
+func (*ListDrawsResponse) Descriptor() ([]byte, []int) {
+	return file_keno_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListDrawsResponse) GetDraws() []*Draw {
+	if x != nil {
+		return x.Draws
+	}
+	return nil
+}
+
+type GetDrawRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DrawNo        int64                  `protobuf:"varint,1,opt,name=draw_no,json=drawNo,proto3" json:"draw_no,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDrawRequest) Reset() {
+	*x = GetDrawRequest{}
+	mi := &file_keno_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDrawRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDrawRequest) ProtoMessage() {}
+
+func (x *GetDrawRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_keno_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDrawRequest.ProtoReflect.Descriptor instead.
+func (*GetDrawRequest) Descriptor() ([]byte, []int) {
+	return file_keno_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetDrawRequest) GetDrawNo() int64 {
+	if x != nil {
+		return x.DrawNo
+	}
+	return 0
+}
+
+type ListTicketsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	DrawNo        int64                  `protobuf:"varint,3,opt,name=draw_no,json=drawNo,proto3" json:"draw_no,omitempty"`
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTicketsRequest) Reset() {
+	*x = ListTicketsRequest{}
+	mi := &file_keno_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTicketsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTicketsRequest) ProtoMessage() {}
+
+func (x *ListTicketsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_keno_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTicketsRequest.ProtoReflect.Descriptor instead.
+func (*ListTicketsRequest) Descriptor() ([]byte, []int) {
+	return file_keno_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListTicketsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListTicketsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListTicketsRequest) GetDrawNo() int64 {
+	if x != nil {
+		return x.DrawNo
+	}
+	return 0
+}
+
+func (x *ListTicketsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type ListTicketsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tickets       []*Ticket              `protobuf:"bytes,1,rep,name=tickets,proto3" json:"tickets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTicketsResponse) Reset() {
+	*x = ListTicketsResponse{}
+	mi := &file_keno_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTicketsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTicketsResponse) ProtoMessage() {}
+
+func (x *ListTicketsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_keno_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTicketsResponse.ProtoReflect.Descriptor instead.
+func (*ListTicketsResponse) Descriptor() ([]byte, []int) {
+	return file_keno_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListTicketsResponse) GetTickets() []*Ticket {
+	if x != nil {
+		return x.Tickets
+	}
+	return nil
+}
+
+var File_keno_proto protoreflect.FileDescriptor
+
+const file_keno_proto_rawDesc = "" +
+	"\n" +
+	"\n" +
+	"keno.proto\x12\x04keno\"\x89\x01\n" +
+	"\x10BuyTicketRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05picks\x18\x02 \x03(\x05R\x05picks\x12\x14\n" +
+	"\x05stake\x18\x03 \x01(\x05R\x05stake\x12\x14\n" +
+	"\x05draws\x18\x04 \x01(\x05R\x05draws\x12\x1a\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrency\"\x82\x02\n" +
+	"\x06Ticket\x12\x1b\n" +
+	"\tticket_id\x18\x01 \x01(\tR\bticketId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x17\n" +
+	"\adraw_no\x18\x03 \x01(\x03R\x06drawNo\x12\x14\n" +
+	"\x05picks\x18\x04 \x03(\x05R\x05picks\x12\x14\n" +
+	"\x05stake\x18\x05 \x01(\x05R\x05stake\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12\x12\n" +
+	"\x04hits\x18\a \x01(\x05R\x04hits\x12\x16\n" +
+	"\x06payout\x18\b \x01(\x05R\x06payout\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\t \x01(\x03R\tcreatedAt\x12\x1a\n" +
+	"\bcurrency\x18\n" +
+	" \x01(\tR\bcurrency\"q\n" +
+	"\x11BuyTicketResponse\x12&\n" +
+	"\atickets\x18\x01 \x03(\v2\f.keno.TicketR\atickets\x12\x18\n" +
+	"\abalance\x18\x02 \x01(\x05R\abalance\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\"\x98\x01\n" +
+	"\x04Draw\x12\x17\n" +
+	"\adraw_no\x18\x01 \x01(\x03R\x06drawNo\x12\x17\n" +
+	"\adraw_at\x18\x02 \x01(\x03R\x06drawAt\x12\x18\n" +
+	"\anumbers\x18\x03 \x03(\x05R\anumbers\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x18\n" +
+	"\atickets\x18\x05 \x01(\x05R\atickets\x12\x12\n" +
+	"\x04paid\x18\x06 \x01(\x05R\x04paid\"C\n" +
+	"\rPayTableEntry\x12\x12\n" +
+	"\x04hits\x18\x01 \x01(\x05R\x04hits\x12\x1e\n" +
+	"\n" +
+	"multiplier\x18\x02 \x01(\x01R\n" +
+	"multiplier\"R\n" +
+	"\vPayTableRow\x12\x14\n" +
+	"\x05picks\x18\x01 \x01(\x05R\x05picks\x12-\n" +
+	"\apayouts\x18\x02 \x03(\v2\x13.keno.PayTableEntryR\apayouts\"\x11\n" +
+	"\x0fNextDrawRequest\"\x97\x01\n" +
+	"\x10NextDrawResponse\x12\x17\n" +
+	"\adraw_no\x18\x01 \x01(\x03R\x06drawNo\x12\x17\n" +
+	"\adraw_at\x18\x02 \x01(\x03R\x06drawAt\x12!\n" +
+	"\finterval_sec\x18\x03 \x01(\x05R\vintervalSec\x12.\n" +
+	"\tpay_table\x18\x04 \x03(\v2\x11.keno.PayTableRowR\bpayTable\"@\n" +
+	"\x10ListDrawsRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06before\x18\x02 \x01(\x03R\x06before\"5\n" +
+	"\x11ListDrawsResponse\x12 \n" +
+	"\x05draws\x18\x01 \x03(\v2\n" +
+	".keno.DrawR\x05draws\")\n" +
+	"\x0eGetDrawRequest\x12\x17\n" +
+	"\adraw_no\x18\x01 \x01(\x03R\x06drawNo\"t\n" +
+	"\x12ListTicketsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x17\n" +
+	"\adraw_no\x18\x03 \x01(\x03R\x06drawNo\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\"=\n" +
+	"\x13ListTicketsResponse\x12&\n" +
+	"\atickets\x18\x01 \x03(\v2\f.keno.TicketR\atickets2\xb5\x02\n" +
+	"\vKenoService\x12<\n" +
+	"\tBuyTicket\x12\x16.keno.BuyTicketRequest\x1a\x17.keno.BuyTicketResponse\x129\n" +
+	"\bNextDraw\x12\x15.keno.NextDrawRequest\x1a\x16.keno.NextDrawResponse\x12<\n" +
+	"\tListDraws\x12\x16.keno.ListDrawsRequest\x1a\x17.keno.ListDrawsResponse\x12+\n" +
+	"\aGetDraw\x12\x14.keno.GetDrawRequest\x1a\n" +
+	".keno.Draw\x12B\n" +
+	"\vListTickets\x12\x18.keno.ListTicketsRequest\x1a\x19.keno.ListTicketsResponseB1Z/github.com/Arsencchikkk/final/casino/proto/kenob\x06proto3"
+
+var (
+	file_keno_proto_rawDescOnce sync.Once
+	file_keno_proto_rawDescData []byte
+)
+
+func file_keno_proto_rawDescGZIP() []byte {
+	file_keno_proto_rawDescOnce.Do(func() {
+		file_keno_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_keno_proto_rawDesc), len(file_keno_proto_rawDesc)))
+	})
+	return file_keno_proto_rawDescData
+}
+
+var file_keno_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_keno_proto_goTypes = []any{
+	(*BuyTicketRequest)(nil),    // 0: keno.BuyTicketRequest
+	(*Ticket)(nil),              // 1: keno.Ticket
+	(*BuyTicketResponse)(nil),   // 2: keno.BuyTicketResponse
+	(*Draw)(nil),                // 3: keno.Draw
+	(*PayTableEntry)(nil),       // 4: keno.PayTableEntry
+	(*PayTableRow)(nil),         // 5: keno.PayTableRow
+	(*NextDrawRequest)(nil),     // 6: keno.NextDrawRequest
+	(*NextDrawResponse)(nil),    // 7: keno.NextDrawResponse
+	(*ListDrawsRequest)(nil),    // 8: keno.ListDrawsRequest
+	(*ListDrawsResponse)(nil),   // 9: keno.ListDrawsResponse
+	(*GetDrawRequest)(nil),      // 10: keno.GetDrawRequest
+	(*ListTicketsRequest)(nil),  // 11: keno.ListTicketsRequest
+	(*ListTicketsResponse)(nil), // 12: keno.ListTicketsResponse
+}
+var file_keno_proto_depIdxs = []int32{
+	1,  // 0: keno.BuyTicketResponse.tickets:type_name -> keno.Ticket
+	4,  // 1: keno.PayTableRow.payouts:type_name -> keno.PayTableEntry
+	5,  // 2: keno.NextDrawResponse.pay_table:type_name -> keno.PayTableRow
+	3,  // 3: keno.ListDrawsResponse.draws:type_name -> keno.Draw
+	1,  // 4: keno.ListTicketsResponse.tickets:type_name -> keno.Ticket
+	0,  // 5: keno.KenoService.BuyTicket:input_type -> keno.BuyTicketRequest
+	6,  // 6: keno.KenoService.NextDraw:input_type -> keno.NextDrawRequest
+	8,  // 7: keno.KenoService.ListDraws:input_type -> keno.ListDrawsRequest
+	10, // 8: keno.KenoService.GetDraw:input_type -> keno.GetDrawRequest
+	11, // 9: keno.KenoService.ListTickets:input_type -> keno.ListTicketsRequest
+	2,  // 10: keno.KenoService.BuyTicket:output_type -> keno.BuyTicketResponse
+	7,  // 11: keno.KenoService.NextDraw:output_type -> keno.NextDrawResponse
+	9,  // 12: keno.KenoService.ListDraws:output_type -> keno.ListDrawsResponse
+	3,  // 13: keno.KenoService.GetDraw:output_type -> keno.Draw
+	12, // 14: keno.KenoService.ListTickets:output_type -> keno.ListTicketsResponse
+	10, // [10:15] is the sub-list for method output_type
+	5,  // [5:10] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_keno_proto_init() }
+func file_keno_proto_init() {
+	if File_keno_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_keno_proto_rawDesc), len(file_keno_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   13,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_keno_proto_goTypes,
+		DependencyIndexes: file_keno_proto_depIdxs,
+		MessageInfos:      file_keno_proto_msgTypes,
+	}.Build()
+	File_keno_proto = out.File
+	file_keno_proto_goTypes = nil
+	file_keno_proto_depIdxs = nil
+}
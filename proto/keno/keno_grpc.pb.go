@@ -0,0 +1,273 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v6.30.2
+// source: keno.proto
+
+package keno
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	KenoService_BuyTicket_FullMethodName   = "/keno.KenoService/BuyTicket"
+	KenoService_NextDraw_FullMethodName    = "/keno.KenoService/NextDraw"
+	KenoService_ListDraws_FullMethodName   = "/keno.KenoService/ListDraws"
+	KenoService_GetDraw_FullMethodName     = "/keno.KenoService/GetDraw"
+	KenoService_ListTickets_FullMethodName = "/keno.KenoService/ListTickets"
+)
+
+// KenoServiceClient is the client API for KenoService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type KenoServiceClient interface {
+	BuyTicket(ctx context.Context, in *BuyTicketRequest, opts ...grpc.CallOption) (*BuyTicketResponse, error)
+	NextDraw(ctx context.Context, in *NextDrawRequest, opts ...grpc.CallOption) (*NextDrawResponse, error)
+	ListDraws(ctx context.Context, in *ListDrawsRequest, opts ...grpc.CallOption) (*ListDrawsResponse, error)
+	GetDraw(ctx context.Context, in *GetDrawRequest, opts ...grpc.CallOption) (*Draw, error)
+	ListTickets(ctx context.Context, in *ListTicketsRequest, opts ...grpc.CallOption) (*ListTicketsResponse, error)
+}
+
+type kenoServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewKenoServiceClient(cc grpc.ClientConnInterface) KenoServiceClient {
+	return &kenoServiceClient{cc}
+}
+
+func (c *kenoServiceClient) BuyTicket(ctx context.Context, in *BuyTicketRequest, opts ...grpc.CallOption) (*BuyTicketResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BuyTicketResponse)
+	err := c.cc.Invoke(ctx, KenoService_BuyTicket_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kenoServiceClient) NextDraw(ctx context.Context, in *NextDrawRequest, opts ...grpc.CallOption) (*NextDrawResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NextDrawResponse)
+	err := c.cc.Invoke(ctx, KenoService_NextDraw_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kenoServiceClient) ListDraws(ctx context.Context, in *ListDrawsRequest, opts ...grpc.CallOption) (*ListDrawsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDrawsResponse)
+	err := c.cc.Invoke(ctx, KenoService_ListDraws_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kenoServiceClient) GetDraw(ctx context.Context, in *GetDrawRequest, opts ...grpc.CallOption) (*Draw, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Draw)
+	err := c.cc.Invoke(ctx, KenoService_GetDraw_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kenoServiceClient) ListTickets(ctx context.Context, in *ListTicketsRequest, opts ...grpc.CallOption) (*ListTicketsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTicketsResponse)
+	err := c.cc.Invoke(ctx, KenoService_ListTickets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// KenoServiceServer is the server API for KenoService service.
+// All implementations must embed UnimplementedKenoServiceServer
+// for forward compatibility.
+type KenoServiceServer interface {
+	BuyTicket(context.Context, *BuyTicketRequest) (*BuyTicketResponse, error)
+	NextDraw(context.Context, *NextDrawRequest) (*NextDrawResponse, error)
+	ListDraws(context.Context, *ListDrawsRequest) (*ListDrawsResponse, error)
+	GetDraw(context.Context, *GetDrawRequest) (*Draw, error)
+	ListTickets(context.Context, *ListTicketsRequest) (*ListTicketsResponse, error)
+	mustEmbedUnimplementedKenoServiceServer()
+}
+
+// UnimplementedKenoServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedKenoServiceServer struct{}
+
+func (UnimplementedKenoServiceServer) BuyTicket(context.Context, *BuyTicketRequest) (*BuyTicketResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BuyTicket not implemented")
+}
+func (UnimplementedKenoServiceServer) NextDraw(context.Context, *NextDrawRequest) (*NextDrawResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NextDraw not implemented")
+}
+func (UnimplementedKenoServiceServer) ListDraws(context.Context, *ListDrawsRequest) (*ListDrawsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDraws not implemented")
+}
+func (UnimplementedKenoServiceServer) GetDraw(context.Context, *GetDrawRequest) (*Draw, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDraw not implemented")
+}
+func (UnimplementedKenoServiceServer) ListTickets(context.Context, *ListTicketsRequest) (*ListTicketsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTickets not implemented")
+}
+func (UnimplementedKenoServiceServer) mustEmbedUnimplementedKenoServiceServer() {}
+func (UnimplementedKenoServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeKenoServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to KenoServiceServer will
+// result in compilation errors.
+type UnsafeKenoServiceServer interface {
+	mustEmbedUnimplementedKenoServiceServer()
+}
+
+func RegisterKenoServiceServer(s grpc.ServiceRegistrar, srv KenoServiceServer) {
+	// If the following call pancis, it indicates UnimplementedKenoServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&KenoService_ServiceDesc, srv)
+}
+
+func _KenoService_BuyTicket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BuyTicketRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KenoServiceServer).BuyTicket(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KenoService_BuyTicket_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KenoServiceServer).BuyTicket(ctx, req.(*BuyTicketRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KenoService_NextDraw_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NextDrawRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KenoServiceServer).NextDraw(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KenoService_NextDraw_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KenoServiceServer).NextDraw(ctx, req.(*NextDrawRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KenoService_ListDraws_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDrawsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KenoServiceServer).ListDraws(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KenoService_ListDraws_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KenoServiceServer).ListDraws(ctx, req.(*ListDrawsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KenoService_GetDraw_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDrawRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KenoServiceServer).GetDraw(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KenoService_GetDraw_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KenoServiceServer).GetDraw(ctx, req.(*GetDrawRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KenoService_ListTickets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTicketsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KenoServiceServer).ListTickets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KenoService_ListTickets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KenoServiceServer).ListTickets(ctx, req.(*ListTicketsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// KenoService_ServiceDesc is the grpc.ServiceDesc for KenoService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var KenoService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "keno.KenoService",
+	HandlerType: (*KenoServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "BuyTicket",
+			Handler:    _KenoService_BuyTicket_Handler,
+		},
+		{
+			MethodName: "NextDraw",
+			Handler:    _KenoService_NextDraw_Handler,
+		},
+		{
+			MethodName: "ListDraws",
+			Handler:    _KenoService_ListDraws_Handler,
+		},
+		{
+			MethodName: "GetDraw",
+			Handler:    _KenoService_GetDraw_Handler,
+		},
+		{
+			MethodName: "ListTickets",
+			Handler:    _KenoService_ListTickets_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "keno.proto",
+}
@@ -21,16 +21,73 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
-type WalletRequest struct {
+// Сумма денег: целое число минимальных единиц валюты (центов и т.п.) и код
+// валюты ISO 4217 (CRD — внутренние игровые кредиты). У игрока по
+// подкошельку на валюту; в запросах пустая валюта — основная (WALLET_CURRENCY).
+type Money struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Amount        int64                  `protobuf:"varint,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Money) Reset() {
+	*x = Money{}
+	mi := &file_wallet_wallet_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Money) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Money) ProtoMessage() {}
+
+func (x *Money) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_wallet_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Money.ProtoReflect.Descriptor instead.
+func (*Money) Descriptor() ([]byte, []int) {
+	return file_wallet_wallet_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Money) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Money) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type WalletRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// подкошелёк, пусто — основной
+	Currency      string `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *WalletRequest) Reset() {
 	*x = WalletRequest{}
-	mi := &file_wallet_wallet_proto_msgTypes[0]
+	mi := &file_wallet_wallet_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -42,7 +99,7 @@ func (x *WalletRequest) String() string {
 func (*WalletRequest) ProtoMessage() {}
 
 func (x *WalletRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_wallet_wallet_proto_msgTypes[0]
+	mi := &file_wallet_wallet_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -55,7 +112,7 @@ func (x *WalletRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WalletRequest.ProtoReflect.Descriptor instead.
 func (*WalletRequest) Descriptor() ([]byte, []int) {
-	return file_wallet_wallet_proto_rawDescGZIP(), []int{0}
+	return file_wallet_wallet_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *WalletRequest) GetUserId() string {
@@ -65,16 +122,26 @@ func (x *WalletRequest) GetUserId() string {
 	return ""
 }
 
+func (x *WalletRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
 type WalletResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Balance       int32                  `protobuf:"varint,1,opt,name=balance,proto3" json:"balance,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// доступно для ставок
+	Balance *Money `protobuf:"bytes,3,opt,name=balance,proto3" json:"balance,omitempty"`
+	// удержано под незавершённые раунды
+	Held          *Money `protobuf:"bytes,4,opt,name=held,proto3" json:"held,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *WalletResponse) Reset() {
 	*x = WalletResponse{}
-	mi := &file_wallet_wallet_proto_msgTypes[1]
+	mi := &file_wallet_wallet_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -86,7 +153,7 @@ func (x *WalletResponse) String() string {
 func (*WalletResponse) ProtoMessage() {}
 
 func (x *WalletResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_wallet_wallet_proto_msgTypes[1]
+	mi := &file_wallet_wallet_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -99,27 +166,48 @@ func (x *WalletResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WalletResponse.ProtoReflect.Descriptor instead.
 func (*WalletResponse) Descriptor() ([]byte, []int) {
-	return file_wallet_wallet_proto_rawDescGZIP(), []int{1}
+	return file_wallet_wallet_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *WalletResponse) GetBalance() int32 {
+func (x *WalletResponse) GetBalance() *Money {
 	if x != nil {
 		return x.Balance
 	}
-	return 0
+	return nil
+}
+
+func (x *WalletResponse) GetHeld() *Money {
+	if x != nil {
+		return x.Held
+	}
+	return nil
 }
 
 type WalletUpdateRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Amount        int32                  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Amount *Money                 `protobuf:"bytes,6,opt,name=amount,proto3" json:"amount,omitempty"`
+	// зачем двигаются деньги: deposit, bet, win, refund, bonus, adjustment
+	// (пусто — adjustment); conversion проводит только Convert, withdrawal —
+	// только вывод через провайдера, transfer — только Transfer
+	Type string `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	// id раунда, билета, турнира… к которому относится проводка
+	Ref string `protobuf:"bytes,4,opt,name=ref,proto3" json:"ref,omitempty"`
+	// обязателен: повтор запроса с тем же ключом вернёт первый ответ,
+	// а не спишет/начислит ещё раз. Ключ лучше строить из сути операции
+	// ("mines:<session>:bet"), тогда он переживёт и рестарт клиента
+	IdempotencyKey string `protobuf:"bytes,5,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	// только деньги: ставка не добирается из бонуса и не идёт в его отыгрыш.
+	// Для бай-инов игр против других игроков (hold'em, турниры): фишки, купленные
+	// на бонус и проигранные другому игроку, ушли бы ему деньгами
+	CashOnly      bool `protobuf:"varint,7,opt,name=cash_only,json=cashOnly,proto3" json:"cash_only,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *WalletUpdateRequest) Reset() {
 	*x = WalletUpdateRequest{}
-	mi := &file_wallet_wallet_proto_msgTypes[2]
+	mi := &file_wallet_wallet_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -131,7 +219,7 @@ func (x *WalletUpdateRequest) String() string {
 func (*WalletUpdateRequest) ProtoMessage() {}
 
 func (x *WalletUpdateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_wallet_wallet_proto_msgTypes[2]
+	mi := &file_wallet_wallet_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -144,7 +232,7 @@ func (x *WalletUpdateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WalletUpdateRequest.ProtoReflect.Descriptor instead.
 func (*WalletUpdateRequest) Descriptor() ([]byte, []int) {
-	return file_wallet_wallet_proto_rawDescGZIP(), []int{2}
+	return file_wallet_wallet_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *WalletUpdateRequest) GetUserId() string {
@@ -154,23 +242,53 @@ func (x *WalletUpdateRequest) GetUserId() string {
 	return ""
 }
 
-func (x *WalletUpdateRequest) GetAmount() int32 {
+func (x *WalletUpdateRequest) GetAmount() *Money {
 	if x != nil {
 		return x.Amount
 	}
-	return 0
+	return nil
+}
+
+func (x *WalletUpdateRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *WalletUpdateRequest) GetRef() string {
+	if x != nil {
+		return x.Ref
+	}
+	return ""
+}
+
+func (x *WalletUpdateRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *WalletUpdateRequest) GetCashOnly() bool {
+	if x != nil {
+		return x.CashOnly
+	}
+	return false
 }
 
 type WalletUpdateResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	NewBalance    int32                  `protobuf:"varint,1,opt,name=new_balance,json=newBalance,proto3" json:"new_balance,omitempty"`
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	NewBalance *Money                 `protobuf:"bytes,3,opt,name=new_balance,json=newBalance,proto3" json:"new_balance,omitempty"`
+	// id проводки в журнале (пусто для демо-кошельков и нулевой суммы)
+	TxId          string `protobuf:"bytes,2,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *WalletUpdateResponse) Reset() {
 	*x = WalletUpdateResponse{}
-	mi := &file_wallet_wallet_proto_msgTypes[3]
+	mi := &file_wallet_wallet_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -182,7 +300,7 @@ func (x *WalletUpdateResponse) String() string {
 func (*WalletUpdateResponse) ProtoMessage() {}
 
 func (x *WalletUpdateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_wallet_wallet_proto_msgTypes[3]
+	mi := &file_wallet_wallet_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -195,35 +313,3726 @@ func (x *WalletUpdateResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WalletUpdateResponse.ProtoReflect.Descriptor instead.
 func (*WalletUpdateResponse) Descriptor() ([]byte, []int) {
-	return file_wallet_wallet_proto_rawDescGZIP(), []int{3}
+	return file_wallet_wallet_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *WalletUpdateResponse) GetNewBalance() int32 {
+func (x *WalletUpdateResponse) GetNewBalance() *Money {
 	if x != nil {
 		return x.NewBalance
 	}
+	return nil
+}
+
+func (x *WalletUpdateResponse) GetTxId() string {
+	if x != nil {
+		return x.TxId
+	}
+	return ""
+}
+
+type BalanceDelta struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Amount        *Money                 `protobuf:"bytes,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Ref           string                 `protobuf:"bytes,4,opt,name=ref,proto3" json:"ref,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BalanceDelta) Reset() {
+	*x = BalanceDelta{}
+	mi := &file_wallet_wallet_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BalanceDelta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BalanceDelta) ProtoMessage() {}
+
+func (x *BalanceDelta) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_wallet_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BalanceDelta.ProtoReflect.Descriptor instead.
+func (*BalanceDelta) Descriptor() ([]byte, []int) {
+	return file_wallet_wallet_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *BalanceDelta) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *BalanceDelta) GetAmount() *Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *BalanceDelta) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *BalanceDelta) GetRef() string {
+	if x != nil {
+		return x.Ref
+	}
+	return ""
+}
+
+type BatchUpdateRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Updates []*BalanceDelta        `protobuf:"bytes,1,rep,name=updates,proto3" json:"updates,omitempty"`
+	// обязателен, действует на весь пакет
+	IdempotencyKey string `protobuf:"bytes,2,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *BatchUpdateRequest) Reset() {
+	*x = BatchUpdateRequest{}
+	mi := &file_wallet_wallet_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchUpdateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchUpdateRequest) ProtoMessage() {}
+
+func (x *BatchUpdateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_wallet_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchUpdateRequest.ProtoReflect.Descriptor instead.
+func (*BatchUpdateRequest) Descriptor() ([]byte, []int) {
+	return file_wallet_wallet_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *BatchUpdateRequest) GetUpdates() []*BalanceDelta {
+	if x != nil {
+		return x.Updates
+	}
+	return nil
+}
+
+func (x *BatchUpdateRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+type BatchUpdateResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// сколько кошельков изменено
+	Updated int32 `protobuf:"varint,1,opt,name=updated,proto3" json:"updated,omitempty"`
+	// демо-списания, отклонённые при записи (баланс успел уменьшиться после
+	// проверки); остальные дельты пакета применены, повтор с тем же ключом
+	// вернёт этот же ответ
+	Rejected      int32 `protobuf:"varint,2,opt,name=rejected,proto3" json:"rejected,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchUpdateResponse) Reset() {
+	*x = BatchUpdateResponse{}
+	mi := &file_wallet_wallet_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchUpdateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchUpdateResponse) ProtoMessage() {}
+
+func (x *BatchUpdateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_wallet_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchUpdateResponse.ProtoReflect.Descriptor instead.
+func (*BatchUpdateResponse) Descriptor() ([]byte, []int) {
+	return file_wallet_wallet_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *BatchUpdateResponse) GetUpdated() int32 {
+	if x != nil {
+		return x.Updated
+	}
 	return 0
 }
 
-var File_wallet_wallet_proto protoreflect.FileDescriptor
+func (x *BatchUpdateResponse) GetRejected() int32 {
+	if x != nil {
+		return x.Rejected
+	}
+	return 0
+}
 
-const file_wallet_wallet_proto_rawDesc = "" +
-	"\n" +
-	"\x13wallet/wallet.proto\x12\x06wallet\"(\n" +
-	"\rWalletRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\"*\n" +
-	"\x0eWalletResponse\x12\x18\n" +
-	"\abalance\x18\x01 \x01(\x05R\abalance\"F\n" +
-	"\x13WalletUpdateRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
-	"\x06amount\x18\x02 \x01(\x05R\x06amount\"7\n" +
-	"\x14WalletUpdateResponse\x12\x1f\n" +
-	"\vnew_balance\x18\x01 \x01(\x05R\n" +
-	"newBalance2\x98\x01\n" +
-	"\rWalletService\x12;\n" +
-	"\n" +
-	"GetBalance\x12\x15.wallet.WalletRequest\x1a\x16.wallet.WalletResponse\x12J\n" +
-	"\rUpdateBalance\x12\x1b.wallet.WalletUpdateRequest\x1a\x1c.wallet.WalletUpdateResponseB8Z6github.com/Arsencchikkk/projectt/Handbook/proto/walletb\x06proto3"
+// итог одного раунда для одного игрока
+type GameResult struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Game   string                 `protobuf:"bytes,2,opt,name=game,proto3" json:"game,omitempty"`
+	// повторная отправка того же раунда не учитывается
+	RoundId string `protobuf:"bytes,3,opt,name=round_id,json=roundId,proto3" json:"round_id,omitempty"`
+	Stake   *Money `protobuf:"bytes,11,opt,name=stake,proto3" json:"stake,omitempty"`
+	Payout  *Money `protobuf:"bytes,12,opt,name=payout,proto3" json:"payout,omitempty"`
+	// факты о раунде для достижений: "natural", "five_card_21", "x10", "hits_8"…
+	// пороговые теги ставятся все до достигнутого: при 12x — и "x2", и "x10"
+	Tags []string `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty"`
+	// для мониторинга RTP: стол, вариант правил ("mines_3", "spots_6"…),
+	// теоретический RTP игры (0 — игра против игроков, не сравниваем) и
+	// максимальная выплата, которую раунд мог потребовать
+	Table         string  `protobuf:"bytes,7,opt,name=table,proto3" json:"table,omitempty"`
+	RuleSet       string  `protobuf:"bytes,8,opt,name=rule_set,json=ruleSet,proto3" json:"rule_set,omitempty"`
+	Rtp           float64 `protobuf:"fixed64,9,opt,name=rtp,proto3" json:"rtp,omitempty"`
+	Liability     *Money  `protobuf:"bytes,13,opt,name=liability,proto3" json:"liability,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GameResult) Reset() {
+	*x = GameResult{}
+	mi := &file_wallet_wallet_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameResult) ProtoMessage() {}
+
+func (x *GameResult) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_wallet_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameResult.ProtoReflect.Descriptor instead.
+func (*GameResult) Descriptor() ([]byte, []int) {
+	return file_wallet_wallet_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GameResult) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GameResult) GetGame() string {
+	if x != nil {
+		return x.Game
+	}
+	return ""
+}
+
+func (x *GameResult) GetRoundId() string {
+	if x != nil {
+		return x.RoundId
+	}
+	return ""
+}
+
+func (x *GameResult) GetStake() *Money {
+	if x != nil {
+		return x.Stake
+	}
+	return nil
+}
+
+func (x *GameResult) GetPayout() *Money {
+	if x != nil {
+		return x.Payout
+	}
+	return nil
+}
+
+func (x *GameResult) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *GameResult) GetTable() string {
+	if x != nil {
+		return x.Table
+	}
+	return ""
+}
+
+func (x *GameResult) GetRuleSet() string {
+	if x != nil {
+		return x.RuleSet
+	}
+	return ""
+}
+
+func (x *GameResult) GetRtp() float64 {
+	if x != nil {
+		return x.Rtp
+	}
+	return 0
+}
+
+func (x *GameResult) GetLiability() *Money {
+	if x != nil {
+		return x.Liability
+	}
+	return nil
+}
+
+type RecordResultsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*GameResult          `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordResultsRequest) Reset() {
+	*x = RecordResultsRequest{}
+	mi := &file_wallet_wallet_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordResultsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordResultsRequest) ProtoMessage() {}
+
+func (x *RecordResultsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_wallet_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordResultsRequest.ProtoReflect.Descriptor instead.
+func (*RecordResultsRequest) Descriptor() ([]byte, []int) {
+	return file_wallet_wallet_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RecordResultsRequest) GetResults() []*GameResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type RecordResultsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// сколько результатов учтено (без дублей)
+	Recorded      int32 `protobuf:"varint,1,opt,name=recorded,proto3" json:"recorded,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordResultsResponse) Reset() {
+	*x = RecordResultsResponse{}
+	mi := &file_wallet_wallet_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordResultsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordResultsResponse) ProtoMessage() {}
+
+func (x *RecordResultsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_wallet_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordResultsResponse.ProtoReflect.Descriptor instead.
+func (*RecordResultsResponse) Descriptor() ([]byte, []int) {
+	return file_wallet_wallet_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RecordResultsResponse) GetRecorded() int32 {
+	if x != nil {
+		return x.Recorded
+	}
+	return 0
+}
+
+type LeaderboardRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// "biggest_win", "profit", "streak"
+	Board string `protobuf:"bytes,1,opt,name=board,proto3" json:"board,omitempty"`
+	// "day", "week", "all"
+	Period string `protobuf:"bytes,2,opt,name=period,proto3" json:"period,omitempty"`
+	// для позиции запросившего
+	UserId        string `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Limit         int32  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaderboardRequest) Reset() {
+	*x = LeaderboardRequest{}
+	mi := &file_wallet_wallet_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaderboardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaderboardRequest) ProtoMessage() {}
+
+func (x *LeaderboardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_wallet_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaderboardRequest.ProtoReflect.Descriptor instead.
+func (*LeaderboardRequest) Descriptor() ([]byte, []int) {
+	return file_wallet_wallet_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *LeaderboardRequest) GetBoard() string {
+	if x != nil {
+		return x.Board
+	}
+	return ""
+}
+
+func (x *LeaderboardRequest) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+func (x *LeaderboardRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *LeaderboardRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type LeaderboardEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rank          int32                  `protobuf:"varint,1,opt,name=rank,proto3" json:"rank,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Score         int64                  `protobuf:"varint,3,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaderboardEntry) Reset() {
+	*x = LeaderboardEntry{}
+	mi := &file_wallet_wallet_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaderboardEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaderboardEntry) ProtoMessage() {}
+
+func (x *LeaderboardEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_wallet_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaderboardEntry.ProtoReflect.Descriptor instead.
+func (*LeaderboardEntry) Descriptor() ([]byte, []int) {
+	return file_wallet_wallet_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *LeaderboardEntry) GetRank() int32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *LeaderboardEntry) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *LeaderboardEntry) GetScore() int64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type LeaderboardResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Board  string                 `protobuf:"bytes,1,opt,name=board,proto3" json:"board,omitempty"`
+	Period string                 `protobuf:"bytes,2,opt,name=period,proto3" json:"period,omitempty"`
+	Top    []*LeaderboardEntry    `protobuf:"bytes,3,rep,name=top,proto3" json:"top,omitempty"`
+	// нет, если игрок ещё не попал в таблицу
+	Me *LeaderboardEntry `protobuf:"bytes,4,opt,name=me,proto3" json:"me,omitempty"`
+	// unix-время (сек) следующего сброса, 0 для "all"
+	ResetsAt      int64 `protobuf:"varint,5,opt,name=resets_at,json=resetsAt,proto3" json:"resets_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaderboardResponse) Reset() {
+	*x = LeaderboardResponse{}
+	mi := &file_wallet_wallet_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaderboardResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaderboardResponse) ProtoMessage() {}
+
+func (x *LeaderboardResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_wallet_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaderboardResponse.ProtoReflect.Descriptor instead.
+func (*LeaderboardResponse) Descriptor() ([]byte, []int) {
+	return file_wallet_wallet_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *LeaderboardResponse) GetBoard() string {
+	if x != nil {
+		return x.Board
+	}
+	return ""
+}
+
+func (x *LeaderboardResponse) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+func (x *LeaderboardResponse) GetTop() []*LeaderboardEntry {
+	if x != nil {
+		return x.Top
+	}
+	return nil
+}
+
+func (x *LeaderboardResponse) GetMe() *LeaderboardEntry {
+	if x != nil {
+		return x.Me
+	}
+	return nil
+}
+
+func (x *LeaderboardResponse) GetResetsAt() int64 {
+	if x != nil {
+		return x.ResetsAt
+	}
+	return 0
+}
+
+type AchievementsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AchievementsRequest) Reset() {
+	*x = AchievementsRequest{}
+	mi := &file_wallet_wallet_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AchievementsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AchievementsRequest) ProtoMessage() {}
+
+func (x *AchievementsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_wallet_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AchievementsRequest.ProtoReflect.Descriptor instead.
+func (*AchievementsRequest) Descriptor() ([]byte, []int) {
+	return file_wallet_wallet_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *AchievementsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type Achievement struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Target      int64                  `protobuf:"varint,4,opt,name=target,proto3" json:"target,omitempty"`
+	Progress    int64                  `protobuf:"varint,5,opt,name=progress,proto3" json:"progress,omitempty"`
+	Unlocked    bool                   `protobuf:"varint,6,opt,name=unlocked,proto3" json:"unlocked,omitempty"`
+	// unix-время (сек), 0 — ещё не получено
+	UnlockedAt int64 `protobuf:"varint,7,opt,name=unlocked_at,json=unlockedAt,proto3" json:"unlocked_at,omitempty"`
+	// бонус на кошелёк за получение
+	Bonus         *Money `protobuf:"bytes,9,opt,name=bonus,proto3" json:"bonus,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Achievement) Reset() {
+	*x = Achievement{}
+	mi := &file_wallet_wallet_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Achievement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Achievement) ProtoMessage() {}
+
+func (x *Achievement) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_wallet_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Achievement.ProtoReflect.Descriptor instead.
+func (*Achievement) Descriptor() ([]byte, []int) {
+	return file_wallet_wallet_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Achievement) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Achievement) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Achievement) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Achievement) GetTarget() int64 {
+	if x != nil {
+		return x.Target
+	}
+	return 0
+}
+
+func (x *Achievement) GetProgress() int64 {
+	if x != nil {
+		return x.Progress
+	}
+	return 0
+}
+
+func (x *Achievement) GetUnlocked() bool {
+	if x != nil {
+		return x.Unlocked
+	}
+	return false
+}
+
+func (x *Achievement) GetUnlockedAt() int64 {
+	if x != nil {
+		return x.UnlockedAt
+	}
+	return 0
+}
+
+func (x *Achievement) GetBonus() *Money {
+	if x != nil {
+		return x.Bonus
+	}
+	return nil
+}
+
+type AchievementsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Achievements  []*Achievement         `protobuf:"bytes,1,rep,name=achievements,proto3" json:"achievements,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AchievementsResponse) Reset() {
+	*x = AchievementsResponse{}
+	mi := &file_wallet_wallet_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AchievementsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AchievementsResponse) ProtoMessage() {}
+
+func (x *AchievementsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_wallet_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AchievementsResponse.ProtoReflect.Descriptor instead.
+func (*AchievementsResponse) Descriptor() ([]byte, []int) {
+	return file_wallet_wallet_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *AchievementsResponse) GetAchievements() []*Achievement {
+	if x != nil {
+		return x.Achievements
+	}
+	return nil
+}
+
+// --- Демо-режим ---
+type DemoRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DemoRequest) Reset() {
+	*x = DemoRequest{}
+	mi := &file_wallet_wallet_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DemoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DemoRequest) ProtoMessage() {}
+
+func (x *DemoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_wallet_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DemoRequest.ProtoReflect.Descriptor instead.
+func (*DemoRequest) Descriptor() ([]byte, []int) {
+	return file_wallet_wallet_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *DemoRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type DemoResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Balance       *Money                 `protobuf:"bytes,3,opt,name=balance,proto3" json:"balance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DemoResponse) Reset() {
+	*x = DemoResponse{}
+	mi := &file_wallet_wallet_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DemoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DemoResponse) ProtoMessage() {}
+
+func (x *DemoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_wallet_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DemoResponse.ProtoReflect.Descriptor instead.
+func (*DemoResponse) Descriptor() ([]byte, []int) {
+	return file_wallet_wallet_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *DemoResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *DemoResponse) GetBalance() *Money {
+	if x != nil {
+		return x.Balance
+	}
+	return nil
+}
+
+// --- Аудит ---
+// Запись журнала: раунд из RecordResults плюс хеш предыдущей записи.
+// hash = sha256 от полей записи, см. auditHash в wallet_service/audit.go.
+type AuditEntry struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Seq      int64                  `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`
+	PrevHash string                 `protobuf:"bytes,2,opt,name=prev_hash,json=prevHash,proto3" json:"prev_hash,omitempty"`
+	Hash     string                 `protobuf:"bytes,3,opt,name=hash,proto3" json:"hash,omitempty"`
+	UserId   string                 `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Game     string                 `protobuf:"bytes,5,opt,name=game,proto3" json:"game,omitempty"`
+	RoundId  string                 `protobuf:"bytes,6,opt,name=round_id,json=roundId,proto3" json:"round_id,omitempty"`
+	// у записей до перехода на минимальные единицы валюта пустая,
+	// а суммы — в старых целых кредитах
+	Stake  *Money   `protobuf:"bytes,11,opt,name=stake,proto3" json:"stake,omitempty"`
+	Payout *Money   `protobuf:"bytes,12,opt,name=payout,proto3" json:"payout,omitempty"`
+	Tags   []string `protobuf:"bytes,9,rep,name=tags,proto3" json:"tags,omitempty"`
+	// unix-время (мс) записи в журнал
+	SettledAt     int64 `protobuf:"varint,10,opt,name=settled_at,json=settledAt,proto3" json:"settled_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditEntry) Reset() {
+	*x = AuditEntry{}
+	mi := &file_wallet_wallet_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEntry) ProtoMessage() {}
+
+func (x *AuditEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_wallet_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEntry.ProtoReflect.Descriptor instead.
+func (*AuditEntry) Descriptor() ([]byte, []int) {
+	return file_wallet_wallet_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *AuditEntry) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *AuditEntry) GetPrevHash() string {
+	if x != nil {
+		return x.PrevHash
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetGame() string {
+	if x != nil {
+		return x.Game
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetRoundId() string {
+	if x != nil {
+		return x.RoundId
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetStake() *Money {
+	if x != nil {
+		return x.Stake
+	}
+	return nil
+}
+
+func (x *AuditEntry) GetPayout() *Money {
+	if x != nil {
+		return x.Payout
+	}
+	return nil
+}
+
+func (x *AuditEntry) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *AuditEntry) GetSettledAt() int64 {
+	if x != nil {
+		return x.SettledAt
+	}
+	return 0
+}
+
+type AuditExportRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// с какой записи, 0 — с начала
+	FromSeq int64 `protobuf:"varint,1,opt,name=from_seq,json=fromSeq,proto3" json:"from_seq,omitempty"`
+	// по какую включительно, 0 — до конца
+	ToSeq         int64 `protobuf:"varint,2,opt,name=to_seq,json=toSeq,proto3" json:"to_seq,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditExportRequest) Reset() {
+	*x = AuditExportRequest{}
+	mi := &file_wallet_wallet_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditExportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditExportRequest) ProtoMessage() {}
+
+func (x *AuditExportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_wallet_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditExportRequest.ProtoReflect.Descriptor instead.
+func (*AuditExportRequest) Descriptor() ([]byte, []int) {
+	return file_wallet_wallet_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *AuditExportRequest) GetFromSeq() int64 {
+	if x != nil {
+		return x.FromSeq
+	}
+	return 0
+}
+
+func (x *AuditExportRequest) GetToSeq() int64 {
+	if x != nil {
+		return x.ToSeq
+	}
+	return 0
+}
+
+// --- Мониторинг RTP ---
+type RtpStatsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// "15m", "1h", "24h"; пусто — все окна
+	Window string `protobuf:"bytes,1,opt,name=window,proto3" json:"window,omitempty"`
+	// пусто — все игры
+	Game          string `protobuf:"bytes,2,opt,name=game,proto3" json:"game,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RtpStatsRequest) Reset() {
+	*x = RtpStatsRequest{}
+	mi := &file_wallet_wallet_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RtpStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RtpStatsRequest) ProtoMessage() {}
+
+func (x *RtpStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_wallet_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RtpStatsRequest.ProtoReflect.Descriptor instead.
+func (*RtpStatsRequest) Descriptor() ([]byte, []int) {
+	return file_wallet_wallet_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *RtpStatsRequest) GetWindow() string {
+	if x != nil {
+		return x.Window
+	}
+	return ""
+}
+
+func (x *RtpStatsRequest) GetGame() string {
+	if x != nil {
+		return x.Game
+	}
+	return ""
+}
+
+type RtpStat struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Game    string                 `protobuf:"bytes,1,opt,name=game,proto3" json:"game,omitempty"`
+	Table   string                 `protobuf:"bytes,2,opt,name=table,proto3" json:"table,omitempty"`
+	RuleSet string                 `protobuf:"bytes,3,opt,name=rule_set,json=ruleSet,proto3" json:"rule_set,omitempty"`
+	Window  string                 `protobuf:"bytes,4,opt,name=window,proto3" json:"window,omitempty"`
+	Rounds  int64                  `protobuf:"varint,5,opt,name=rounds,proto3" json:"rounds,omitempty"`
+	// суммы — в минимальных единицах валюты кошелька
+	Wagered int64 `protobuf:"varint,6,opt,name=wagered,proto3" json:"wagered,omitempty"`
+	Paid    int64 `protobuf:"varint,7,opt,name=paid,proto3" json:"paid,omitempty"`
+	// paid / wagered
+	Rtp            float64 `protobuf:"fixed64,8,opt,name=rtp,proto3" json:"rtp,omitempty"`
+	TheoreticalRtp float64 `protobuf:"fixed64,9,opt,name=theoretical_rtp,json=theoreticalRtp,proto3" json:"theoretical_rtp,omitempty"`
+	// доверительный интервал фактического RTP
+	CiLow  float64 `protobuf:"fixed64,10,opt,name=ci_low,json=ciLow,proto3" json:"ci_low,omitempty"`
+	CiHigh float64 `protobuf:"fixed64,11,opt,name=ci_high,json=ciHigh,proto3" json:"ci_high,omitempty"`
+	// самая крупная возможная выплата одного раунда в окне
+	MaxLiability int64 `protobuf:"varint,12,opt,name=max_liability,json=maxLiability,proto3" json:"max_liability,omitempty"`
+	// теоретический RTP вне интервала при достаточном числе раундов
+	Alert         bool `protobuf:"varint,13,opt,name=alert,proto3" json:"alert,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RtpStat) Reset() {
+	*x = RtpStat{}
+	mi := &file_wallet_wallet_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RtpStat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RtpStat) ProtoMessage() {}
+
+func (x *RtpStat) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_wallet_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RtpStat.ProtoReflect.Descriptor instead.
+func (*RtpStat) Descriptor() ([]byte, []int) {
+	return file_wallet_wallet_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *RtpStat) GetGame() string {
+	if x != nil {
+		return x.Game
+	}
+	return ""
+}
+
+func (x *RtpStat) GetTable() string {
+	if x != nil {
+		return x.Table
+	}
+	return ""
+}
+
+func (x *RtpStat) GetRuleSet() string {
+	if x != nil {
+		return x.RuleSet
+	}
+	return ""
+}
+
+func (x *RtpStat) GetWindow() string {
+	if x != nil {
+		return x.Window
+	}
+	return ""
+}
+
+func (x *RtpStat) GetRounds() int64 {
+	if x != nil {
+		return x.Rounds
+	}
+	return 0
+}
+
+func (x *RtpStat) GetWagered() int64 {
+	if x != nil {
+		return x.Wagered
+	}
+	return 0
+}
+
+func (x *RtpStat) GetPaid() int64 {
+	if x != nil {
+		return x.Paid
+	}
+	return 0
+}
+
+func (x *RtpStat) GetRtp() float64 {
+	if x != nil {
+		return x.Rtp
+	}
+	return 0
+}
+
+func (x *RtpStat) GetTheoreticalRtp() float64 {
+	if x != nil {
+		return x.TheoreticalRtp
+	}
+	return 0
+}
+
+func (x *RtpStat) GetCiLow() float64 {
+	if x != nil {
+		return x.CiLow
+	}
+	return 0
+}
+
+func (x *RtpStat) GetCiHigh() float64 {
+	if x != nil {
+		return x.CiHigh
+	}
+	return 0
+}
+
+func (x *RtpStat) GetMaxLiability() int64 {
+	if x != nil {
+		return x.MaxLiability
+	}
+	return 0
+}
+
+func (x *RtpStat) GetAlert() bool {
+	if x != nil {
+		return x.Alert
+	}
+	return false
+}
+
+type RtpStatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stats         []*RtpStat             `protobuf:"bytes,1,rep,name=stats,proto3" json:"stats,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RtpStatsResponse) Reset() {
+	*x = RtpStatsResponse{}
+	mi := &file_wallet_wallet_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RtpStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RtpStatsResponse) ProtoMessage() {}
+
+func (x *RtpStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_wallet_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RtpStatsResponse.ProtoReflect.Descriptor instead.
+func (*RtpStatsResponse) Descriptor() ([]byte, []int) {
+	return file_wallet_wallet_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *RtpStatsResponse) GetStats() []*RtpStat {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+// --- Журнал проводок ---
+// Каждая проводка — обе стороны сразу: amount уходит со счёта counter
+// ("house:games", "house:bonus"…) на кошелёк user_id (или обратно при amount < 0).
+type Transaction struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	TxId   string                 `protobuf:"bytes,1,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	UserId string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// порядковый номер проводки в подкошельке, с 1 (0 — входящий остаток)
+	Seq          int64  `protobuf:"varint,3,opt,name=seq,proto3" json:"seq,omitempty"`
+	Type         string `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	Ref          string `protobuf:"bytes,5,opt,name=ref,proto3" json:"ref,omitempty"`
+	Amount       *Money `protobuf:"bytes,10,opt,name=amount,proto3" json:"amount,omitempty"`
+	Counter      string `protobuf:"bytes,7,opt,name=counter,proto3" json:"counter,omitempty"`
+	BalanceAfter *Money `protobuf:"bytes,11,opt,name=balance_after,json=balanceAfter,proto3" json:"balance_after,omitempty"`
+	// unix-время (мс)
+	CreatedAt int64 `protobuf:"varint,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// у conversion: применённый курс (единиц валюты зачисления за единицу
+	// списанной), ref — id обмена, общий у обеих проводок
+	Rate          string `protobuf:"bytes,12,opt,name=rate,proto3" json:"rate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Transaction) Reset() {
+	*x = Transaction{}
+	mi := &file_wallet_wallet_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Transaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transaction) ProtoMessage() {}
+
+func (x *Transaction) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_wallet_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transaction.ProtoReflect.Descriptor instead.
+func (*Transaction) Descriptor() ([]byte, []int) {
+	return file_wallet_wallet_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *Transaction) GetTxId() string {
+	if x != nil {
+		return x.TxId
+	}
+	return ""
+}
+
+func (x *Transaction) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Transaction) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *Transaction) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Transaction) GetRef() string {
+	if x != nil {
+		return x.Ref
+	}
+	return ""
+}
+
+func (x *Transaction) GetAmount() *Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *Transaction) GetCounter() string {
+	if x != nil {
+		return x.Counter
+	}
+	return ""
+}
+
+func (x *Transaction) GetBalanceAfter() *Money {
+	if x != nil {
+		return x.BalanceAfter
+	}
+	return nil
+}
+
+func (x *Transaction) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Transaction) GetRate() string {
+	if x != nil {
+		return x.Rate
+	}
+	return ""
+}
+
+type ListTransactionsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// фильтр по типам, пусто — все
+	Types []string `protobuf:"bytes,2,rep,name=types,proto3" json:"types,omitempty"`
+	Ref   string   `protobuf:"bytes,3,opt,name=ref,proto3" json:"ref,omitempty"`
+	// unix-время (мс), 0 — без границы
+	From int64 `protobuf:"varint,4,opt,name=from,proto3" json:"from,omitempty"`
+	To   int64 `protobuf:"varint,5,opt,name=to,proto3" json:"to,omitempty"`
+	// курсор: проводки с seq меньше этого, 0 — с последней
+	BeforeSeq int64 `protobuf:"varint,6,opt,name=before_seq,json=beforeSeq,proto3" json:"before_seq,omitempty"`
+	Limit     int32 `protobuf:"varint,7,opt,name=limit,proto3" json:"limit,omitempty"`
+	// подкошелёк (у каждого свой seq), пусто — основной
+	Currency      string `protobuf:"bytes,8,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTransactionsRequest) Reset() {
+	*x = ListTransactionsRequest{}
+	mi := &file_wallet_wallet_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransactionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransactionsRequest) ProtoMessage() {}
+
+func (x *ListTransactionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_wallet_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransactionsRequest.ProtoReflect.Descriptor instead.
+func (*ListTransactionsRequest) Descriptor() ([]byte, []int) {
+	return file_wallet_wallet_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListTransactionsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListTransactionsRequest) GetTypes() []string {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+func (x *ListTransactionsRequest) GetRef() string {
+	if x != nil {
+		return x.Ref
+	}
+	return ""
+}
+
+func (x *ListTransactionsRequest) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *ListTransactionsRequest) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *ListTransactionsRequest) GetBeforeSeq() int64 {
+	if x != nil {
+		return x.BeforeSeq
+	}
+	return 0
+}
+
+func (x *ListTransactionsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListTransactionsRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type ListTransactionsResponse struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Transactions []*Transaction         `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	// before_seq следующей страницы, 0 — страниц больше нет
+	NextBeforeSeq int64 `protobuf:"varint,2,opt,name=next_before_seq,json=nextBeforeSeq,proto3" json:"next_before_seq,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTransactionsResponse) Reset() {
+	*x = ListTransactionsResponse{}
+	mi := &file_wallet_wallet_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransactionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransactionsResponse) ProtoMessage() {}
+
+func (x *ListTransactionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_wallet_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransactionsResponse.ProtoReflect.Descriptor instead.
+func (*ListTransactionsResponse) Descriptor() ([]byte, []int) {
+	return file_wallet_wallet_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListTransactionsResponse) GetTransactions() []*Transaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+func (x *ListTransactionsResponse) GetNextBeforeSeq() int64 {
+	if x != nil {
+		return x.NextBeforeSeq
+	}
+	return 0
+}
+
+type ReconcileRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Fix    bool                   `protobuf:"varint,2,opt,name=fix,proto3" json:"fix,omitempty"`
+	// подкошелёк, пусто — основной
+	Currency      string `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReconcileRequest) Reset() {
+	*x = ReconcileRequest{}
+	mi := &file_wallet_wallet_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReconcileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconcileRequest) ProtoMessage() {}
+
+func (x *ReconcileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_wallet_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconcileRequest.ProtoReflect.Descriptor instead.
+func (*ReconcileRequest) Descriptor() ([]byte, []int) {
+	return file_wallet_wallet_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ReconcileRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ReconcileRequest) GetFix() bool {
+	if x != nil {
+		return x.Fix
+	}
+	return false
+}
+
+func (x *ReconcileRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type ReconcileResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Snapshot      *Money                 `protobuf:"bytes,5,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	Ledger        *Money                 `protobuf:"bytes,6,opt,name=ledger,proto3" json:"ledger,omitempty"`
+	Match         bool                   `protobuf:"varint,3,opt,name=match,proto3" json:"match,omitempty"`
+	Fixed         bool                   `protobuf:"varint,4,opt,name=fixed,proto3" json:"fixed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReconcileResponse) Reset() {
+	*x = ReconcileResponse{}
+	mi := &file_wallet_wallet_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReconcileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconcileResponse) ProtoMessage() {}
+
+func (x *ReconcileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_wallet_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconcileResponse.ProtoReflect.Descriptor instead.
+func (*ReconcileResponse) Descriptor() ([]byte, []int) {
+	return file_wallet_wallet_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ReconcileResponse) GetSnapshot() *Money {
+	if x != nil {
+		return x.Snapshot
+	}
+	return nil
+}
+
+func (x *ReconcileResponse) GetLedger() *Money {
+	if x != nil {
+		return x.Ledger
+	}
+	return nil
+}
+
+func (x *ReconcileResponse) GetMatch() bool {
+	if x != nil {
+		return x.Match
+	}
+	return false
+}
+
+func (x *ReconcileResponse) GetFixed() bool {
+	if x != nil {
+		return x.Fixed
+	}
+	return false
+}
+
+type ReserveRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Amount *Money                 `protobuf:"bytes,6,opt,name=amount,proto3" json:"amount,omitempty"`
+	// раунд, под который держим деньги (ref проводки при списании)
+	RoundId string `protobuf:"bytes,3,opt,name=round_id,json=roundId,proto3" json:"round_id,omitempty"`
+	// через сколько секунд брошенное удержание отпустится само (0 — по умолчанию)
+	TtlSeconds     int32  `protobuf:"varint,4,opt,name=ttl_seconds,json=ttlSeconds,proto3" json:"ttl_seconds,omitempty"`
+	IdempotencyKey string `protobuf:"bytes,5,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ReserveRequest) Reset() {
+	*x = ReserveRequest{}
+	mi := &file_wallet_wallet_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveRequest) ProtoMessage() {}
+
+func (x *ReserveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_wallet_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveRequest.ProtoReflect.Descriptor instead.
+func (*ReserveRequest) Descriptor() ([]byte, []int) {
+	return file_wallet_wallet_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ReserveRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ReserveRequest) GetAmount() *Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *ReserveRequest) GetRoundId() string {
+	if x != nil {
+		return x.RoundId
+	}
+	return ""
+}
+
+func (x *ReserveRequest) GetTtlSeconds() int32 {
+	if x != nil {
+		return x.TtlSeconds
+	}
+	return 0
+}
+
+func (x *ReserveRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+type CaptureRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	HoldId string                 `protobuf:"bytes,1,opt,name=hold_id,json=holdId,proto3" json:"hold_id,omitempty"`
+	// сколько списать, не больше удержанного; пусто или 0 — всё
+	Amount        *Money `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CaptureRequest) Reset() {
+	*x = CaptureRequest{}
+	mi := &file_wallet_wallet_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CaptureRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CaptureRequest) ProtoMessage() {}
+
+func (x *CaptureRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_wallet_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CaptureRequest.ProtoReflect.Descriptor instead.
+func (*CaptureRequest) Descriptor() ([]byte, []int) {
+	return file_wallet_wallet_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *CaptureRequest) GetHoldId() string {
+	if x != nil {
+		return x.HoldId
+	}
+	return ""
+}
+
+func (x *CaptureRequest) GetAmount() *Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+type ReleaseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	HoldId        string                 `protobuf:"bytes,1,opt,name=hold_id,json=holdId,proto3" json:"hold_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseRequest) Reset() {
+	*x = ReleaseRequest{}
+	mi := &file_wallet_wallet_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseRequest) ProtoMessage() {}
+
+func (x *ReleaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_wallet_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseRequest) Descriptor() ([]byte, []int) {
+	return file_wallet_wallet_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ReleaseRequest) GetHoldId() string {
+	if x != nil {
+		return x.HoldId
+	}
+	return ""
+}
+
+type Hold struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	HoldId  string                 `protobuf:"bytes,1,opt,name=hold_id,json=holdId,proto3" json:"hold_id,omitempty"`
+	UserId  string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	RoundId string                 `protobuf:"bytes,3,opt,name=round_id,json=roundId,proto3" json:"round_id,omitempty"`
+	Amount  *Money                 `protobuf:"bytes,9,opt,name=amount,proto3" json:"amount,omitempty"`
+	// held, captured, released или expired
+	Status   string `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	Captured *Money `protobuf:"bytes,10,opt,name=captured,proto3" json:"captured,omitempty"`
+	// unix мс
+	ExpiresAt int64 `protobuf:"varint,7,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// проводка списания (после Capture)
+	TxId          string `protobuf:"bytes,8,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Hold) Reset() {
+	*x = Hold{}
+	mi := &file_wallet_wallet_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Hold) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Hold) ProtoMessage() {}
+
+func (x *Hold) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_wallet_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Hold.ProtoReflect.Descriptor instead.
+func (*Hold) Descriptor() ([]byte, []int) {
+	return file_wallet_wallet_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *Hold) GetHoldId() string {
+	if x != nil {
+		return x.HoldId
+	}
+	return ""
+}
+
+func (x *Hold) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Hold) GetRoundId() string {
+	if x != nil {
+		return x.RoundId
+	}
+	return ""
+}
+
+func (x *Hold) GetAmount() *Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *Hold) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Hold) GetCaptured() *Money {
+	if x != nil {
+		return x.Captured
+	}
+	return nil
+}
+
+func (x *Hold) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *Hold) GetTxId() string {
+	if x != nil {
+		return x.TxId
+	}
+	return ""
+}
+
+type HoldResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Hold  *Hold                  `protobuf:"bytes,1,opt,name=hold,proto3" json:"hold,omitempty"`
+	// доступный и удержанный баланс после операции
+	Balance       *Money `protobuf:"bytes,4,opt,name=balance,proto3" json:"balance,omitempty"`
+	Held          *Money `protobuf:"bytes,5,opt,name=held,proto3" json:"held,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HoldResponse) Reset() {
+	*x = HoldResponse{}
+	mi := &file_wallet_wallet_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HoldResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HoldResponse) ProtoMessage() {}
+
+func (x *HoldResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_wallet_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HoldResponse.ProtoReflect.Descriptor instead.
+func (*HoldResponse) Descriptor() ([]byte, []int) {
+	return file_wallet_wallet_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *HoldResponse) GetHold() *Hold {
+	if x != nil {
+		return x.Hold
+	}
+	return nil
+}
+
+func (x *HoldResponse) GetBalance() *Money {
+	if x != nil {
+		return x.Balance
+	}
+	return nil
+}
+
+func (x *HoldResponse) GetHeld() *Money {
+	if x != nil {
+		return x.Held
+	}
+	return nil
+}
+
+// --- Мультивалютность ---
+type SubWallet struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Balance       *Money                 `protobuf:"bytes,1,opt,name=balance,proto3" json:"balance,omitempty"`
+	Held          *Money                 `protobuf:"bytes,2,opt,name=held,proto3" json:"held,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubWallet) Reset() {
+	*x = SubWallet{}
+	mi := &file_wallet_wallet_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubWallet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubWallet) ProtoMessage() {}
+
+func (x *SubWallet) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_wallet_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubWallet.ProtoReflect.Descriptor instead.
+func (*SubWallet) Descriptor() ([]byte, []int) {
+	return file_wallet_wallet_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *SubWallet) GetBalance() *Money {
+	if x != nil {
+		return x.Balance
+	}
+	return nil
+}
+
+func (x *SubWallet) GetHeld() *Money {
+	if x != nil {
+		return x.Held
+	}
+	return nil
+}
+
+type ListWalletsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Wallets       []*SubWallet           `protobuf:"bytes,1,rep,name=wallets,proto3" json:"wallets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWalletsResponse) Reset() {
+	*x = ListWalletsResponse{}
+	mi := &file_wallet_wallet_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWalletsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWalletsResponse) ProtoMessage() {}
+
+func (x *ListWalletsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_wallet_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWalletsResponse.ProtoReflect.Descriptor instead.
+func (*ListWalletsResponse) Descriptor() ([]byte, []int) {
+	return file_wallet_wallet_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ListWalletsResponse) GetWallets() []*SubWallet {
+	if x != nil {
+		return x.Wallets
+	}
+	return nil
+}
+
+// Курс пары: сколько единиц to дают за одну единицу from. Десятичная
+// строка ("472.15"), чтобы не терять точность; обратный курс — отдельная пара.
+type Rate struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	From  string                 `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To    string                 `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	Rate  string                 `protobuf:"bytes,3,opt,name=rate,proto3" json:"rate,omitempty"`
+	// unix-время (мс) и кто поставил
+	UpdatedAt     int64  `protobuf:"varint,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	UpdatedBy     string `protobuf:"bytes,5,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Rate) Reset() {
+	*x = Rate{}
+	mi := &file_wallet_wallet_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Rate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Rate) ProtoMessage() {}
+
+func (x *Rate) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_wallet_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Rate.ProtoReflect.Descriptor instead.
+func (*Rate) Descriptor() ([]byte, []int) {
+	return file_wallet_wallet_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *Rate) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *Rate) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *Rate) GetRate() string {
+	if x != nil {
+		return x.Rate
+	}
+	return ""
+}
+
+func (x *Rate) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+func (x *Rate) GetUpdatedBy() string {
+	if x != nil {
+		return x.UpdatedBy
+	}
+	return ""
+}
+
+type SetRateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          string                 `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To            string                 `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	Rate          string                 `protobuf:"bytes,3,opt,name=rate,proto3" json:"rate,omitempty"`
+	AdminId       string                 `protobuf:"bytes,4,opt,name=admin_id,json=adminId,proto3" json:"admin_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetRateRequest) Reset() {
+	*x = SetRateRequest{}
+	mi := &file_wallet_wallet_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetRateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetRateRequest) ProtoMessage() {}
+
+func (x *SetRateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_wallet_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetRateRequest.ProtoReflect.Descriptor instead.
+func (*SetRateRequest) Descriptor() ([]byte, []int) {
+	return file_wallet_wallet_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *SetRateRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *SetRateRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *SetRateRequest) GetRate() string {
+	if x != nil {
+		return x.Rate
+	}
+	return ""
+}
+
+func (x *SetRateRequest) GetAdminId() string {
+	if x != nil {
+		return x.AdminId
+	}
+	return ""
+}
+
+type ListRatesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRatesRequest) Reset() {
+	*x = ListRatesRequest{}
+	mi := &file_wallet_wallet_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRatesRequest) ProtoMessage() {}
+
+func (x *ListRatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_wallet_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRatesRequest.ProtoReflect.Descriptor instead.
+func (*ListRatesRequest) Descriptor() ([]byte, []int) {
+	return file_wallet_wallet_proto_rawDescGZIP(), []int{38}
+}
+
+type ListRatesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rates         []*Rate                `protobuf:"bytes,1,rep,name=rates,proto3" json:"rates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRatesResponse) Reset() {
+	*x = ListRatesResponse{}
+	mi := &file_wallet_wallet_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRatesResponse) ProtoMessage() {}
+
+func (x *ListRatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_wallet_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRatesResponse.ProtoReflect.Descriptor instead.
+func (*ListRatesResponse) Descriptor() ([]byte, []int) {
+	return file_wallet_wallet_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ListRatesResponse) GetRates() []*Rate {
+	if x != nil {
+		return x.Rates
+	}
+	return nil
+}
+
+type ConvertRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// сколько списать; валюта — подкошелёк, из которого списываем
+	Amount         *Money `protobuf:"bytes,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	ToCurrency     string `protobuf:"bytes,3,opt,name=to_currency,json=toCurrency,proto3" json:"to_currency,omitempty"`
+	IdempotencyKey string `protobuf:"bytes,4,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ConvertRequest) Reset() {
+	*x = ConvertRequest{}
+	mi := &file_wallet_wallet_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConvertRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConvertRequest) ProtoMessage() {}
+
+func (x *ConvertRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_wallet_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConvertRequest.ProtoReflect.Descriptor instead.
+func (*ConvertRequest) Descriptor() ([]byte, []int) {
+	return file_wallet_wallet_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ConvertRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ConvertRequest) GetAmount() *Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *ConvertRequest) GetToCurrency() string {
+	if x != nil {
+		return x.ToCurrency
+	}
+	return ""
+}
+
+func (x *ConvertRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+type ConvertResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ref обеих проводок conversion
+	ConversionId string `protobuf:"bytes,1,opt,name=conversion_id,json=conversionId,proto3" json:"conversion_id,omitempty"`
+	Debited      *Money `protobuf:"bytes,2,opt,name=debited,proto3" json:"debited,omitempty"`
+	// по курсу, с округлением вниз до минимальной единицы
+	Credited *Money `protobuf:"bytes,3,opt,name=credited,proto3" json:"credited,omitempty"`
+	Rate     string `protobuf:"bytes,4,opt,name=rate,proto3" json:"rate,omitempty"`
+	// доступные балансы обоих подкошельков после обмена
+	FromBalance   *Money `protobuf:"bytes,5,opt,name=from_balance,json=fromBalance,proto3" json:"from_balance,omitempty"`
+	ToBalance     *Money `protobuf:"bytes,6,opt,name=to_balance,json=toBalance,proto3" json:"to_balance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConvertResponse) Reset() {
+	*x = ConvertResponse{}
+	mi := &file_wallet_wallet_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConvertResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConvertResponse) ProtoMessage() {}
+
+func (x *ConvertResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_wallet_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConvertResponse.ProtoReflect.Descriptor instead.
+func (*ConvertResponse) Descriptor() ([]byte, []int) {
+	return file_wallet_wallet_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ConvertResponse) GetConversionId() string {
+	if x != nil {
+		return x.ConversionId
+	}
+	return ""
+}
+
+func (x *ConvertResponse) GetDebited() *Money {
+	if x != nil {
+		return x.Debited
+	}
+	return nil
+}
+
+func (x *ConvertResponse) GetCredited() *Money {
+	if x != nil {
+		return x.Credited
+	}
+	return nil
+}
+
+func (x *ConvertResponse) GetRate() string {
+	if x != nil {
+		return x.Rate
+	}
+	return ""
+}
+
+func (x *ConvertResponse) GetFromBalance() *Money {
+	if x != nil {
+		return x.FromBalance
+	}
+	return nil
+}
+
+func (x *ConvertResponse) GetToBalance() *Money {
+	if x != nil {
+		return x.ToBalance
+	}
+	return nil
+}
+
+type PaymentRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// сумма в валюте подкошелька, пустая валюта — основная
+	Amount *Money `protobuf:"bytes,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	// обязателен, как у UpdateBalance
+	IdempotencyKey string `protobuf:"bytes,3,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *PaymentRequest) Reset() {
+	*x = PaymentRequest{}
+	mi := &file_wallet_wallet_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentRequest) ProtoMessage() {}
+
+func (x *PaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_wallet_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentRequest.ProtoReflect.Descriptor instead.
+func (*PaymentRequest) Descriptor() ([]byte, []int) {
+	return file_wallet_wallet_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *PaymentRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *PaymentRequest) GetAmount() *Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *PaymentRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+type Payment struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	PaymentId string                 `protobuf:"bytes,1,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	UserId    string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// deposit | withdrawal
+	Kind   string `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
+	Amount *Money `protobuf:"bytes,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	// pending, approved, rejected, completed, failed
+	Status   string `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	Provider string `protobuf:"bytes,6,opt,name=provider,proto3" json:"provider,omitempty"`
+	// id платежа у провайдера
+	ProviderRef string `protobuf:"bytes,7,opt,name=provider_ref,json=providerRef,proto3" json:"provider_ref,omitempty"`
+	// причина отказа (админ или провайдер)
+	Reason     string `protobuf:"bytes,8,opt,name=reason,proto3" json:"reason,omitempty"`
+	ReviewedBy string `protobuf:"bytes,9,opt,name=reviewed_by,json=reviewedBy,proto3" json:"reviewed_by,omitempty"`
+	// unix мс
+	CreatedAt     int64 `protobuf:"varint,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     int64 `protobuf:"varint,11,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Payment) Reset() {
+	*x = Payment{}
+	mi := &file_wallet_wallet_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Payment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Payment) ProtoMessage() {}
+
+func (x *Payment) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_wallet_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Payment.ProtoReflect.Descriptor instead.
+func (*Payment) Descriptor() ([]byte, []int) {
+	return file_wallet_wallet_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *Payment) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+func (x *Payment) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Payment) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Payment) GetAmount() *Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *Payment) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Payment) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *Payment) GetProviderRef() string {
+	if x != nil {
+		return x.ProviderRef
+	}
+	return ""
+}
+
+func (x *Payment) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Payment) GetReviewedBy() string {
+	if x != nil {
+		return x.ReviewedBy
+	}
+	return ""
+}
+
+func (x *Payment) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Payment) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+type ReviewWithdrawalRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PaymentId     string                 `protobuf:"bytes,1,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	Approve       bool                   `protobuf:"varint,2,opt,name=approve,proto3" json:"approve,omitempty"`
+	AdminId       string                 `protobuf:"bytes,3,opt,name=admin_id,json=adminId,proto3" json:"admin_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewWithdrawalRequest) Reset() {
+	*x = ReviewWithdrawalRequest{}
+	mi := &file_wallet_wallet_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewWithdrawalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewWithdrawalRequest) ProtoMessage() {}
+
+func (x *ReviewWithdrawalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_wallet_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewWithdrawalRequest.ProtoReflect.Descriptor instead.
+func (*ReviewWithdrawalRequest) Descriptor() ([]byte, []int) {
+	return file_wallet_wallet_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ReviewWithdrawalRequest) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+func (x *ReviewWithdrawalRequest) GetApprove() bool {
+	if x != nil {
+		return x.Approve
+	}
+	return false
+}
+
+func (x *ReviewWithdrawalRequest) GetAdminId() string {
+	if x != nil {
+		return x.AdminId
+	}
+	return ""
+}
+
+func (x *ReviewWithdrawalRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ListPaymentsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// пусто — все игроки (для админа)
+	UserId        string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Status        string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Kind          string `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
+	Limit         int32  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPaymentsRequest) Reset() {
+	*x = ListPaymentsRequest{}
+	mi := &file_wallet_wallet_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPaymentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPaymentsRequest) ProtoMessage() {}
+
+func (x *ListPaymentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_wallet_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPaymentsRequest.ProtoReflect.Descriptor instead.
+func (*ListPaymentsRequest) Descriptor() ([]byte, []int) {
+	return file_wallet_wallet_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ListPaymentsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListPaymentsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListPaymentsRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *ListPaymentsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListPaymentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Payments      []*Payment             `protobuf:"bytes,1,rep,name=payments,proto3" json:"payments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPaymentsResponse) Reset() {
+	*x = ListPaymentsResponse{}
+	mi := &file_wallet_wallet_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPaymentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPaymentsResponse) ProtoMessage() {}
+
+func (x *ListPaymentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_wallet_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPaymentsResponse.ProtoReflect.Descriptor instead.
+func (*ListPaymentsResponse) Descriptor() ([]byte, []int) {
+	return file_wallet_wallet_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ListPaymentsResponse) GetPayments() []*Payment {
+	if x != nil {
+		return x.Payments
+	}
+	return nil
+}
+
+type PaymentWebhookRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Provider string                 `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`
+	// тело запроса провайдера как есть — по нему считается подпись
+	Payload       []byte `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+	Signature     string `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PaymentWebhookRequest) Reset() {
+	*x = PaymentWebhookRequest{}
+	mi := &file_wallet_wallet_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PaymentWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentWebhookRequest) ProtoMessage() {}
+
+func (x *PaymentWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_wallet_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentWebhookRequest.ProtoReflect.Descriptor instead.
+func (*PaymentWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_wallet_wallet_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *PaymentWebhookRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *PaymentWebhookRequest) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *PaymentWebhookRequest) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+type TransferRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	FromUserId string                 `protobuf:"bytes,1,opt,name=from_user_id,json=fromUserId,proto3" json:"from_user_id,omitempty"`
+	ToUserId   string                 `protobuf:"bytes,2,opt,name=to_user_id,json=toUserId,proto3" json:"to_user_id,omitempty"`
+	// валюта подкошелька отправителя, получателю приходит в той же
+	Amount         *Money `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	IdempotencyKey string `protobuf:"bytes,4,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *TransferRequest) Reset() {
+	*x = TransferRequest{}
+	mi := &file_wallet_wallet_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferRequest) ProtoMessage() {}
+
+func (x *TransferRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_wallet_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferRequest.ProtoReflect.Descriptor instead.
+func (*TransferRequest) Descriptor() ([]byte, []int) {
+	return file_wallet_wallet_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *TransferRequest) GetFromUserId() string {
+	if x != nil {
+		return x.FromUserId
+	}
+	return ""
+}
+
+func (x *TransferRequest) GetToUserId() string {
+	if x != nil {
+		return x.ToUserId
+	}
+	return ""
+}
+
+func (x *TransferRequest) GetAmount() *Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *TransferRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+type TransferResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// общий ref обеих проводок
+	TransferId string `protobuf:"bytes,1,opt,name=transfer_id,json=transferId,proto3" json:"transfer_id,omitempty"`
+	// проводка отправителя и проводка получателя
+	Debit  *Transaction `protobuf:"bytes,2,opt,name=debit,proto3" json:"debit,omitempty"`
+	Credit *Transaction `protobuf:"bytes,3,opt,name=credit,proto3" json:"credit,omitempty"`
+	// доступный баланс отправителя
+	Balance *Money `protobuf:"bytes,4,opt,name=balance,proto3" json:"balance,omitempty"`
+	// сколько ещё можно отправить сегодня, в основной валюте
+	DailyRemaining *Money `protobuf:"bytes,5,opt,name=daily_remaining,json=dailyRemaining,proto3" json:"daily_remaining,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *TransferResponse) Reset() {
+	*x = TransferResponse{}
+	mi := &file_wallet_wallet_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferResponse) ProtoMessage() {}
+
+func (x *TransferResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_wallet_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferResponse.ProtoReflect.Descriptor instead.
+func (*TransferResponse) Descriptor() ([]byte, []int) {
+	return file_wallet_wallet_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *TransferResponse) GetTransferId() string {
+	if x != nil {
+		return x.TransferId
+	}
+	return ""
+}
+
+func (x *TransferResponse) GetDebit() *Transaction {
+	if x != nil {
+		return x.Debit
+	}
+	return nil
+}
+
+func (x *TransferResponse) GetCredit() *Transaction {
+	if x != nil {
+		return x.Credit
+	}
+	return nil
+}
+
+func (x *TransferResponse) GetBalance() *Money {
+	if x != nil {
+		return x.Balance
+	}
+	return nil
+}
+
+func (x *TransferResponse) GetDailyRemaining() *Money {
+	if x != nil {
+		return x.DailyRemaining
+	}
+	return nil
+}
+
+type GrantBonusRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// welcome | reload
+	Kind string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	// в основной валюте
+	Amount         *Money `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	IdempotencyKey string `protobuf:"bytes,4,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GrantBonusRequest) Reset() {
+	*x = GrantBonusRequest{}
+	mi := &file_wallet_wallet_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GrantBonusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GrantBonusRequest) ProtoMessage() {}
+
+func (x *GrantBonusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_wallet_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GrantBonusRequest.ProtoReflect.Descriptor instead.
+func (*GrantBonusRequest) Descriptor() ([]byte, []int) {
+	return file_wallet_wallet_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *GrantBonusRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GrantBonusRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *GrantBonusRequest) GetAmount() *Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *GrantBonusRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+type Bonus struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	BonusId string                 `protobuf:"bytes,1,opt,name=bonus_id,json=bonusId,proto3" json:"bonus_id,omitempty"`
+	Kind    string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	// начислено и сколько осталось на бонусном балансе
+	Amount  *Money `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Balance *Money `protobuf:"bytes,4,opt,name=balance,proto3" json:"balance,omitempty"`
+	// сколько нужно поставить для разблокировки и сколько уже поставлено
+	WagerRequired *Money `protobuf:"bytes,5,opt,name=wager_required,json=wagerRequired,proto3" json:"wager_required,omitempty"`
+	Wagered       *Money `protobuf:"bytes,6,opt,name=wagered,proto3" json:"wagered,omitempty"`
+	// active, unlocked (остаток ушёл в деньги), expired (сгорел)
+	Status string `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	// ушло в деньги при разблокировке / сгорело по сроку
+	Released  *Money `protobuf:"bytes,8,opt,name=released,proto3" json:"released,omitempty"`
+	Forfeited *Money `protobuf:"bytes,9,opt,name=forfeited,proto3" json:"forfeited,omitempty"`
+	// unix мс
+	ExpiresAt     int64 `protobuf:"varint,10,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	CreatedAt     int64 `protobuf:"varint,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ClosedAt      int64 `protobuf:"varint,12,opt,name=closed_at,json=closedAt,proto3" json:"closed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Bonus) Reset() {
+	*x = Bonus{}
+	mi := &file_wallet_wallet_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Bonus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Bonus) ProtoMessage() {}
+
+func (x *Bonus) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_wallet_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Bonus.ProtoReflect.Descriptor instead.
+func (*Bonus) Descriptor() ([]byte, []int) {
+	return file_wallet_wallet_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *Bonus) GetBonusId() string {
+	if x != nil {
+		return x.BonusId
+	}
+	return ""
+}
+
+func (x *Bonus) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Bonus) GetAmount() *Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *Bonus) GetBalance() *Money {
+	if x != nil {
+		return x.Balance
+	}
+	return nil
+}
+
+func (x *Bonus) GetWagerRequired() *Money {
+	if x != nil {
+		return x.WagerRequired
+	}
+	return nil
+}
+
+func (x *Bonus) GetWagered() *Money {
+	if x != nil {
+		return x.Wagered
+	}
+	return nil
+}
+
+func (x *Bonus) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Bonus) GetReleased() *Money {
+	if x != nil {
+		return x.Released
+	}
+	return nil
+}
+
+func (x *Bonus) GetForfeited() *Money {
+	if x != nil {
+		return x.Forfeited
+	}
+	return nil
+}
+
+func (x *Bonus) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *Bonus) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Bonus) GetClosedAt() int64 {
+	if x != nil {
+		return x.ClosedAt
+	}
+	return 0
+}
+
+type ListBonusesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// новые сверху
+	Bonuses []*Bonus `protobuf:"bytes,1,rep,name=bonuses,proto3" json:"bonuses,omitempty"`
+	// сумма активных бонусов
+	Balance       *Money `protobuf:"bytes,2,opt,name=balance,proto3" json:"balance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBonusesResponse) Reset() {
+	*x = ListBonusesResponse{}
+	mi := &file_wallet_wallet_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBonusesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBonusesResponse) ProtoMessage() {}
+
+func (x *ListBonusesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_wallet_wallet_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBonusesResponse.ProtoReflect.Descriptor instead.
+func (*ListBonusesResponse) Descriptor() ([]byte, []int) {
+	return file_wallet_wallet_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *ListBonusesResponse) GetBonuses() []*Bonus {
+	if x != nil {
+		return x.Bonuses
+	}
+	return nil
+}
+
+func (x *ListBonusesResponse) GetBalance() *Money {
+	if x != nil {
+		return x.Balance
+	}
+	return nil
+}
+
+var File_wallet_wallet_proto protoreflect.FileDescriptor
+
+const file_wallet_wallet_proto_rawDesc = "" +
+	"\n" +
+	"\x13wallet/wallet.proto\x12\x06wallet\";\n" +
+	"\x05Money\x12\x16\n" +
+	"\x06amount\x18\x01 \x01(\x03R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\"D\n" +
+	"\rWalletRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\"h\n" +
+	"\x0eWalletResponse\x12'\n" +
+	"\abalance\x18\x03 \x01(\v2\r.wallet.MoneyR\abalance\x12!\n" +
+	"\x04held\x18\x04 \x01(\v2\r.wallet.MoneyR\x04heldJ\x04\b\x01\x10\x02J\x04\b\x02\x10\x03\"\xc7\x01\n" +
+	"\x13WalletUpdateRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12%\n" +
+	"\x06amount\x18\x06 \x01(\v2\r.wallet.MoneyR\x06amount\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x10\n" +
+	"\x03ref\x18\x04 \x01(\tR\x03ref\x12'\n" +
+	"\x0fidempotency_key\x18\x05 \x01(\tR\x0eidempotencyKey\x12\x1b\n" +
+	"\tcash_only\x18\a \x01(\bR\bcashOnlyJ\x04\b\x02\x10\x03\"a\n" +
+	"\x14WalletUpdateResponse\x12.\n" +
+	"\vnew_balance\x18\x03 \x01(\v2\r.wallet.MoneyR\n" +
+	"newBalance\x12\x13\n" +
+	"\x05tx_id\x18\x02 \x01(\tR\x04txIdJ\x04\b\x01\x10\x02\"z\n" +
+	"\fBalanceDelta\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12%\n" +
+	"\x06amount\x18\x05 \x01(\v2\r.wallet.MoneyR\x06amount\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x10\n" +
+	"\x03ref\x18\x04 \x01(\tR\x03refJ\x04\b\x02\x10\x03\"m\n" +
+	"\x12BatchUpdateRequest\x12.\n" +
+	"\aupdates\x18\x01 \x03(\v2\x14.wallet.BalanceDeltaR\aupdates\x12'\n" +
+	"\x0fidempotency_key\x18\x02 \x01(\tR\x0eidempotencyKey\"K\n" +
+	"\x13BatchUpdateResponse\x12\x18\n" +
+	"\aupdated\x18\x01 \x01(\x05R\aupdated\x12\x1a\n" +
+	"\brejected\x18\x02 \x01(\x05R\brejected\"\xb6\x02\n" +
+	"\n" +
+	"GameResult\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04game\x18\x02 \x01(\tR\x04game\x12\x19\n" +
+	"\bround_id\x18\x03 \x01(\tR\aroundId\x12#\n" +
+	"\x05stake\x18\v \x01(\v2\r.wallet.MoneyR\x05stake\x12%\n" +
+	"\x06payout\x18\f \x01(\v2\r.wallet.MoneyR\x06payout\x12\x12\n" +
+	"\x04tags\x18\x06 \x03(\tR\x04tags\x12\x14\n" +
+	"\x05table\x18\a \x01(\tR\x05table\x12\x19\n" +
+	"\brule_set\x18\b \x01(\tR\aruleSet\x12\x10\n" +
+	"\x03rtp\x18\t \x01(\x01R\x03rtp\x12+\n" +
+	"\tliability\x18\r \x01(\v2\r.wallet.MoneyR\tliabilityJ\x04\b\x04\x10\x05J\x04\b\x05\x10\x06J\x04\b\n" +
+	"\x10\v\"D\n" +
+	"\x14RecordResultsRequest\x12,\n" +
+	"\aresults\x18\x01 \x03(\v2\x12.wallet.GameResultR\aresults\"3\n" +
+	"\x15RecordResultsResponse\x12\x1a\n" +
+	"\brecorded\x18\x01 \x01(\x05R\brecorded\"q\n" +
+	"\x12LeaderboardRequest\x12\x14\n" +
+	"\x05board\x18\x01 \x01(\tR\x05board\x12\x16\n" +
+	"\x06period\x18\x02 \x01(\tR\x06period\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\"U\n" +
+	"\x10LeaderboardEntry\x12\x12\n" +
+	"\x04rank\x18\x01 \x01(\x05R\x04rank\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05score\x18\x03 \x01(\x03R\x05score\"\xb6\x01\n" +
+	"\x13LeaderboardResponse\x12\x14\n" +
+	"\x05board\x18\x01 \x01(\tR\x05board\x12\x16\n" +
+	"\x06period\x18\x02 \x01(\tR\x06period\x12*\n" +
+	"\x03top\x18\x03 \x03(\v2\x18.wallet.LeaderboardEntryR\x03top\x12(\n" +
+	"\x02me\x18\x04 \x01(\v2\x18.wallet.LeaderboardEntryR\x02me\x12\x1b\n" +
+	"\tresets_at\x18\x05 \x01(\x03R\bresetsAt\".\n" +
+	"\x13AchievementsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\xef\x01\n" +
+	"\vAchievement\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x16\n" +
+	"\x06target\x18\x04 \x01(\x03R\x06target\x12\x1a\n" +
+	"\bprogress\x18\x05 \x01(\x03R\bprogress\x12\x1a\n" +
+	"\bunlocked\x18\x06 \x01(\bR\bunlocked\x12\x1f\n" +
+	"\vunlocked_at\x18\a \x01(\x03R\n" +
+	"unlockedAt\x12#\n" +
+	"\x05bonus\x18\t \x01(\v2\r.wallet.MoneyR\x05bonusJ\x04\b\b\x10\t\"O\n" +
+	"\x14AchievementsResponse\x127\n" +
+	"\fachievements\x18\x01 \x03(\v2\x13.wallet.AchievementR\fachievements\"&\n" +
+	"\vDemoRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"V\n" +
+	"\fDemoResponse\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12'\n" +
+	"\abalance\x18\x03 \x01(\v2\r.wallet.MoneyR\abalanceJ\x04\b\x02\x10\x03\"\xa2\x02\n" +
+	"\n" +
+	"AuditEntry\x12\x10\n" +
+	"\x03seq\x18\x01 \x01(\x03R\x03seq\x12\x1b\n" +
+	"\tprev_hash\x18\x02 \x01(\tR\bprevHash\x12\x12\n" +
+	"\x04hash\x18\x03 \x01(\tR\x04hash\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04game\x18\x05 \x01(\tR\x04game\x12\x19\n" +
+	"\bround_id\x18\x06 \x01(\tR\aroundId\x12#\n" +
+	"\x05stake\x18\v \x01(\v2\r.wallet.MoneyR\x05stake\x12%\n" +
+	"\x06payout\x18\f \x01(\v2\r.wallet.MoneyR\x06payout\x12\x12\n" +
+	"\x04tags\x18\t \x03(\tR\x04tags\x12\x1d\n" +
+	"\n" +
+	"settled_at\x18\n" +
+	" \x01(\x03R\tsettledAtJ\x04\b\a\x10\bJ\x04\b\b\x10\t\"F\n" +
+	"\x12AuditExportRequest\x12\x19\n" +
+	"\bfrom_seq\x18\x01 \x01(\x03R\afromSeq\x12\x15\n" +
+	"\x06to_seq\x18\x02 \x01(\x03R\x05toSeq\"=\n" +
+	"\x0fRtpStatsRequest\x12\x16\n" +
+	"\x06window\x18\x01 \x01(\tR\x06window\x12\x12\n" +
+	"\x04game\x18\x02 \x01(\tR\x04game\"\xd2\x02\n" +
+	"\aRtpStat\x12\x12\n" +
+	"\x04game\x18\x01 \x01(\tR\x04game\x12\x14\n" +
+	"\x05table\x18\x02 \x01(\tR\x05table\x12\x19\n" +
+	"\brule_set\x18\x03 \x01(\tR\aruleSet\x12\x16\n" +
+	"\x06window\x18\x04 \x01(\tR\x06window\x12\x16\n" +
+	"\x06rounds\x18\x05 \x01(\x03R\x06rounds\x12\x18\n" +
+	"\awagered\x18\x06 \x01(\x03R\awagered\x12\x12\n" +
+	"\x04paid\x18\a \x01(\x03R\x04paid\x12\x10\n" +
+	"\x03rtp\x18\b \x01(\x01R\x03rtp\x12'\n" +
+	"\x0ftheoretical_rtp\x18\t \x01(\x01R\x0etheoreticalRtp\x12\x15\n" +
+	"\x06ci_low\x18\n" +
+	" \x01(\x01R\x05ciLow\x12\x17\n" +
+	"\aci_high\x18\v \x01(\x01R\x06ciHigh\x12#\n" +
+	"\rmax_liability\x18\f \x01(\x03R\fmaxLiability\x12\x14\n" +
+	"\x05alert\x18\r \x01(\bR\x05alert\"9\n" +
+	"\x10RtpStatsResponse\x12%\n" +
+	"\x05stats\x18\x01 \x03(\v2\x0f.wallet.RtpStatR\x05stats\"\xa7\x02\n" +
+	"\vTransaction\x12\x13\n" +
+	"\x05tx_id\x18\x01 \x01(\tR\x04txId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x10\n" +
+	"\x03seq\x18\x03 \x01(\x03R\x03seq\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\tR\x04type\x12\x10\n" +
+	"\x03ref\x18\x05 \x01(\tR\x03ref\x12%\n" +
+	"\x06amount\x18\n" +
+	" \x01(\v2\r.wallet.MoneyR\x06amount\x12\x18\n" +
+	"\acounter\x18\a \x01(\tR\acounter\x122\n" +
+	"\rbalance_after\x18\v \x01(\v2\r.wallet.MoneyR\fbalanceAfter\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\t \x01(\x03R\tcreatedAt\x12\x12\n" +
+	"\x04rate\x18\f \x01(\tR\x04rateJ\x04\b\x06\x10\aJ\x04\b\b\x10\t\"\xcf\x01\n" +
+	"\x17ListTransactionsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05types\x18\x02 \x03(\tR\x05types\x12\x10\n" +
+	"\x03ref\x18\x03 \x01(\tR\x03ref\x12\x12\n" +
+	"\x04from\x18\x04 \x01(\x03R\x04from\x12\x0e\n" +
+	"\x02to\x18\x05 \x01(\x03R\x02to\x12\x1d\n" +
+	"\n" +
+	"before_seq\x18\x06 \x01(\x03R\tbeforeSeq\x12\x14\n" +
+	"\x05limit\x18\a \x01(\x05R\x05limit\x12\x1a\n" +
+	"\bcurrency\x18\b \x01(\tR\bcurrency\"{\n" +
+	"\x18ListTransactionsResponse\x127\n" +
+	"\ftransactions\x18\x01 \x03(\v2\x13.wallet.TransactionR\ftransactions\x12&\n" +
+	"\x0fnext_before_seq\x18\x02 \x01(\x03R\rnextBeforeSeq\"Y\n" +
+	"\x10ReconcileRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x10\n" +
+	"\x03fix\x18\x02 \x01(\bR\x03fix\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\"\x9d\x01\n" +
+	"\x11ReconcileResponse\x12)\n" +
+	"\bsnapshot\x18\x05 \x01(\v2\r.wallet.MoneyR\bsnapshot\x12%\n" +
+	"\x06ledger\x18\x06 \x01(\v2\r.wallet.MoneyR\x06ledger\x12\x14\n" +
+	"\x05match\x18\x03 \x01(\bR\x05match\x12\x14\n" +
+	"\x05fixed\x18\x04 \x01(\bR\x05fixedJ\x04\b\x01\x10\x02J\x04\b\x02\x10\x03\"\xbb\x01\n" +
+	"\x0eReserveRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12%\n" +
+	"\x06amount\x18\x06 \x01(\v2\r.wallet.MoneyR\x06amount\x12\x19\n" +
+	"\bround_id\x18\x03 \x01(\tR\aroundId\x12\x1f\n" +
+	"\vttl_seconds\x18\x04 \x01(\x05R\n" +
+	"ttlSeconds\x12'\n" +
+	"\x0fidempotency_key\x18\x05 \x01(\tR\x0eidempotencyKeyJ\x04\b\x02\x10\x03\"V\n" +
+	"\x0eCaptureRequest\x12\x17\n" +
+	"\ahold_id\x18\x01 \x01(\tR\x06holdId\x12%\n" +
+	"\x06amount\x18\x03 \x01(\v2\r.wallet.MoneyR\x06amountJ\x04\b\x02\x10\x03\")\n" +
+	"\x0eReleaseRequest\x12\x17\n" +
+	"\ahold_id\x18\x01 \x01(\tR\x06holdId\"\xfd\x01\n" +
+	"\x04Hold\x12\x17\n" +
+	"\ahold_id\x18\x01 \x01(\tR\x06holdId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x19\n" +
+	"\bround_id\x18\x03 \x01(\tR\aroundId\x12%\n" +
+	"\x06amount\x18\t \x01(\v2\r.wallet.MoneyR\x06amount\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12)\n" +
+	"\bcaptured\x18\n" +
+	" \x01(\v2\r.wallet.MoneyR\bcaptured\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\a \x01(\x03R\texpiresAt\x12\x13\n" +
+	"\x05tx_id\x18\b \x01(\tR\x04txIdJ\x04\b\x04\x10\x05J\x04\b\x06\x10\a\"\x88\x01\n" +
+	"\fHoldResponse\x12 \n" +
+	"\x04hold\x18\x01 \x01(\v2\f.wallet.HoldR\x04hold\x12'\n" +
+	"\abalance\x18\x04 \x01(\v2\r.wallet.MoneyR\abalance\x12!\n" +
+	"\x04held\x18\x05 \x01(\v2\r.wallet.MoneyR\x04heldJ\x04\b\x02\x10\x03J\x04\b\x03\x10\x04\"W\n" +
+	"\tSubWallet\x12'\n" +
+	"\abalance\x18\x01 \x01(\v2\r.wallet.MoneyR\abalance\x12!\n" +
+	"\x04held\x18\x02 \x01(\v2\r.wallet.MoneyR\x04held\"B\n" +
+	"\x13ListWalletsResponse\x12+\n" +
+	"\awallets\x18\x01 \x03(\v2\x11.wallet.SubWalletR\awallets\"|\n" +
+	"\x04Rate\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\x12\x12\n" +
+	"\x04rate\x18\x03 \x01(\tR\x04rate\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x04 \x01(\x03R\tupdatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_by\x18\x05 \x01(\tR\tupdatedBy\"c\n" +
+	"\x0eSetRateRequest\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\x12\x12\n" +
+	"\x04rate\x18\x03 \x01(\tR\x04rate\x12\x19\n" +
+	"\badmin_id\x18\x04 \x01(\tR\aadminId\"\x12\n" +
+	"\x10ListRatesRequest\"7\n" +
+	"\x11ListRatesResponse\x12\"\n" +
+	"\x05rates\x18\x01 \x03(\v2\f.wallet.RateR\x05rates\"\x9a\x01\n" +
+	"\x0eConvertRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12%\n" +
+	"\x06amount\x18\x02 \x01(\v2\r.wallet.MoneyR\x06amount\x12\x1f\n" +
+	"\vto_currency\x18\x03 \x01(\tR\n" +
+	"toCurrency\x12'\n" +
+	"\x0fidempotency_key\x18\x04 \x01(\tR\x0eidempotencyKey\"\xfe\x01\n" +
+	"\x0fConvertResponse\x12#\n" +
+	"\rconversion_id\x18\x01 \x01(\tR\fconversionId\x12'\n" +
+	"\adebited\x18\x02 \x01(\v2\r.wallet.MoneyR\adebited\x12)\n" +
+	"\bcredited\x18\x03 \x01(\v2\r.wallet.MoneyR\bcredited\x12\x12\n" +
+	"\x04rate\x18\x04 \x01(\tR\x04rate\x120\n" +
+	"\ffrom_balance\x18\x05 \x01(\v2\r.wallet.MoneyR\vfromBalance\x12,\n" +
+	"\n" +
+	"to_balance\x18\x06 \x01(\v2\r.wallet.MoneyR\ttoBalance\"y\n" +
+	"\x0ePaymentRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12%\n" +
+	"\x06amount\x18\x02 \x01(\v2\r.wallet.MoneyR\x06amount\x12'\n" +
+	"\x0fidempotency_key\x18\x03 \x01(\tR\x0eidempotencyKey\"\xca\x02\n" +
+	"\aPayment\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x01 \x01(\tR\tpaymentId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04kind\x18\x03 \x01(\tR\x04kind\x12%\n" +
+	"\x06amount\x18\x04 \x01(\v2\r.wallet.MoneyR\x06amount\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12\x1a\n" +
+	"\bprovider\x18\x06 \x01(\tR\bprovider\x12!\n" +
+	"\fprovider_ref\x18\a \x01(\tR\vproviderRef\x12\x16\n" +
+	"\x06reason\x18\b \x01(\tR\x06reason\x12\x1f\n" +
+	"\vreviewed_by\x18\t \x01(\tR\n" +
+	"reviewedBy\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\v \x01(\x03R\tupdatedAt\"\x85\x01\n" +
+	"\x17ReviewWithdrawalRequest\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x01 \x01(\tR\tpaymentId\x12\x18\n" +
+	"\aapprove\x18\x02 \x01(\bR\aapprove\x12\x19\n" +
+	"\badmin_id\x18\x03 \x01(\tR\aadminId\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\"p\n" +
+	"\x13ListPaymentsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x12\n" +
+	"\x04kind\x18\x03 \x01(\tR\x04kind\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\"C\n" +
+	"\x14ListPaymentsResponse\x12+\n" +
+	"\bpayments\x18\x01 \x03(\v2\x0f.wallet.PaymentR\bpayments\"k\n" +
+	"\x15PaymentWebhookRequest\x12\x1a\n" +
+	"\bprovider\x18\x01 \x01(\tR\bprovider\x12\x18\n" +
+	"\apayload\x18\x02 \x01(\fR\apayload\x12\x1c\n" +
+	"\tsignature\x18\x03 \x01(\tR\tsignature\"\xa1\x01\n" +
+	"\x0fTransferRequest\x12 \n" +
+	"\ffrom_user_id\x18\x01 \x01(\tR\n" +
+	"fromUserId\x12\x1c\n" +
+	"\n" +
+	"to_user_id\x18\x02 \x01(\tR\btoUserId\x12%\n" +
+	"\x06amount\x18\x03 \x01(\v2\r.wallet.MoneyR\x06amount\x12'\n" +
+	"\x0fidempotency_key\x18\x04 \x01(\tR\x0eidempotencyKey\"\xec\x01\n" +
+	"\x10TransferResponse\x12\x1f\n" +
+	"\vtransfer_id\x18\x01 \x01(\tR\n" +
+	"transferId\x12)\n" +
+	"\x05debit\x18\x02 \x01(\v2\x13.wallet.TransactionR\x05debit\x12+\n" +
+	"\x06credit\x18\x03 \x01(\v2\x13.wallet.TransactionR\x06credit\x12'\n" +
+	"\abalance\x18\x04 \x01(\v2\r.wallet.MoneyR\abalance\x126\n" +
+	"\x0fdaily_remaining\x18\x05 \x01(\v2\r.wallet.MoneyR\x0edailyRemaining\"\x90\x01\n" +
+	"\x11GrantBonusRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12%\n" +
+	"\x06amount\x18\x03 \x01(\v2\r.wallet.MoneyR\x06amount\x12'\n" +
+	"\x0fidempotency_key\x18\x04 \x01(\tR\x0eidempotencyKey\"\xb0\x03\n" +
+	"\x05Bonus\x12\x19\n" +
+	"\bbonus_id\x18\x01 \x01(\tR\abonusId\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12%\n" +
+	"\x06amount\x18\x03 \x01(\v2\r.wallet.MoneyR\x06amount\x12'\n" +
+	"\abalance\x18\x04 \x01(\v2\r.wallet.MoneyR\abalance\x124\n" +
+	"\x0ewager_required\x18\x05 \x01(\v2\r.wallet.MoneyR\rwagerRequired\x12'\n" +
+	"\awagered\x18\x06 \x01(\v2\r.wallet.MoneyR\awagered\x12\x16\n" +
+	"\x06status\x18\a \x01(\tR\x06status\x12)\n" +
+	"\breleased\x18\b \x01(\v2\r.wallet.MoneyR\breleased\x12+\n" +
+	"\tforfeited\x18\t \x01(\v2\r.wallet.MoneyR\tforfeited\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\n" +
+	" \x01(\x03R\texpiresAt\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\v \x01(\x03R\tcreatedAt\x12\x1b\n" +
+	"\tclosed_at\x18\f \x01(\x03R\bclosedAt\"g\n" +
+	"\x13ListBonusesResponse\x12'\n" +
+	"\abonuses\x18\x01 \x03(\v2\r.wallet.BonusR\abonuses\x12'\n" +
+	"\abalance\x18\x02 \x01(\v2\r.wallet.MoneyR\abalance2\x82\x0e\n" +
+	"\rWalletService\x12;\n" +
+	"\n" +
+	"GetBalance\x12\x15.wallet.WalletRequest\x1a\x16.wallet.WalletResponse\x12J\n" +
+	"\rUpdateBalance\x12\x1b.wallet.WalletUpdateRequest\x1a\x1c.wallet.WalletUpdateResponse\x12M\n" +
+	"\x12BatchUpdateBalance\x12\x1a.wallet.BatchUpdateRequest\x1a\x1b.wallet.BatchUpdateResponse\x12L\n" +
+	"\rRecordResults\x12\x1c.wallet.RecordResultsRequest\x1a\x1d.wallet.RecordResultsResponse\x12I\n" +
+	"\x0eGetLeaderboard\x12\x1a.wallet.LeaderboardRequest\x1a\x1b.wallet.LeaderboardResponse\x12L\n" +
+	"\x0fGetAchievements\x12\x1b.wallet.AchievementsRequest\x1a\x1c.wallet.AchievementsResponse\x126\n" +
+	"\tStartDemo\x12\x13.wallet.DemoRequest\x1a\x14.wallet.DemoResponse\x124\n" +
+	"\aEndDemo\x12\x13.wallet.DemoRequest\x1a\x14.wallet.DemoResponse\x12?\n" +
+	"\vExportAudit\x12\x1a.wallet.AuditExportRequest\x1a\x12.wallet.AuditEntry0\x01\x12@\n" +
+	"\vGetRtpStats\x12\x17.wallet.RtpStatsRequest\x1a\x18.wallet.RtpStatsResponse\x12U\n" +
+	"\x10ListTransactions\x12\x1f.wallet.ListTransactionsRequest\x1a .wallet.ListTransactionsResponse\x12G\n" +
+	"\x10ReconcileBalance\x12\x18.wallet.ReconcileRequest\x1a\x19.wallet.ReconcileResponse\x127\n" +
+	"\aReserve\x12\x16.wallet.ReserveRequest\x1a\x14.wallet.HoldResponse\x127\n" +
+	"\aCapture\x12\x16.wallet.CaptureRequest\x1a\x14.wallet.HoldResponse\x127\n" +
+	"\aRelease\x12\x16.wallet.ReleaseRequest\x1a\x14.wallet.HoldResponse\x12A\n" +
+	"\vListWallets\x12\x15.wallet.WalletRequest\x1a\x1b.wallet.ListWalletsResponse\x12:\n" +
+	"\aConvert\x12\x16.wallet.ConvertRequest\x1a\x17.wallet.ConvertResponse\x12/\n" +
+	"\aSetRate\x12\x16.wallet.SetRateRequest\x1a\f.wallet.Rate\x12@\n" +
+	"\tListRates\x12\x18.wallet.ListRatesRequest\x1a\x19.wallet.ListRatesResponse\x129\n" +
+	"\x0eRequestDeposit\x12\x16.wallet.PaymentRequest\x1a\x0f.wallet.Payment\x12<\n" +
+	"\x11RequestWithdrawal\x12\x16.wallet.PaymentRequest\x1a\x0f.wallet.Payment\x12D\n" +
+	"\x10ReviewWithdrawal\x12\x1f.wallet.ReviewWithdrawalRequest\x1a\x0f.wallet.Payment\x12I\n" +
+	"\fListPayments\x12\x1b.wallet.ListPaymentsRequest\x1a\x1c.wallet.ListPaymentsResponse\x12@\n" +
+	"\x0ePaymentWebhook\x12\x1d.wallet.PaymentWebhookRequest\x1a\x0f.wallet.Payment\x12=\n" +
+	"\bTransfer\x12\x17.wallet.TransferRequest\x1a\x18.wallet.TransferResponse\x126\n" +
+	"\n" +
+	"GrantBonus\x12\x19.wallet.GrantBonusRequest\x1a\r.wallet.Bonus\x12A\n" +
+	"\vListBonuses\x12\x15.wallet.WalletRequest\x1a\x1b.wallet.ListBonusesResponseB8Z6github.com/Arsencchikkk/projectt/Handbook/proto/walletb\x06proto3"
 
 var (
 	file_wallet_wallet_proto_rawDescOnce sync.Once
@@ -237,23 +4046,178 @@ func file_wallet_wallet_proto_rawDescGZIP() []byte {
 	return file_wallet_wallet_proto_rawDescData
 }
 
-var file_wallet_wallet_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_wallet_wallet_proto_msgTypes = make([]protoimpl.MessageInfo, 53)
 var file_wallet_wallet_proto_goTypes = []any{
-	(*WalletRequest)(nil),        // 0: wallet.WalletRequest
-	(*WalletResponse)(nil),       // 1: wallet.WalletResponse
-	(*WalletUpdateRequest)(nil),  // 2: wallet.WalletUpdateRequest
-	(*WalletUpdateResponse)(nil), // 3: wallet.WalletUpdateResponse
+	(*Money)(nil),                    // 0: wallet.Money
+	(*WalletRequest)(nil),            // 1: wallet.WalletRequest
+	(*WalletResponse)(nil),           // 2: wallet.WalletResponse
+	(*WalletUpdateRequest)(nil),      // 3: wallet.WalletUpdateRequest
+	(*WalletUpdateResponse)(nil),     // 4: wallet.WalletUpdateResponse
+	(*BalanceDelta)(nil),             // 5: wallet.BalanceDelta
+	(*BatchUpdateRequest)(nil),       // 6: wallet.BatchUpdateRequest
+	(*BatchUpdateResponse)(nil),      // 7: wallet.BatchUpdateResponse
+	(*GameResult)(nil),               // 8: wallet.GameResult
+	(*RecordResultsRequest)(nil),     // 9: wallet.RecordResultsRequest
+	(*RecordResultsResponse)(nil),    // 10: wallet.RecordResultsResponse
+	(*LeaderboardRequest)(nil),       // 11: wallet.LeaderboardRequest
+	(*LeaderboardEntry)(nil),         // 12: wallet.LeaderboardEntry
+	(*LeaderboardResponse)(nil),      // 13: wallet.LeaderboardResponse
+	(*AchievementsRequest)(nil),      // 14: wallet.AchievementsRequest
+	(*Achievement)(nil),              // 15: wallet.Achievement
+	(*AchievementsResponse)(nil),     // 16: wallet.AchievementsResponse
+	(*DemoRequest)(nil),              // 17: wallet.DemoRequest
+	(*DemoResponse)(nil),             // 18: wallet.DemoResponse
+	(*AuditEntry)(nil),               // 19: wallet.AuditEntry
+	(*AuditExportRequest)(nil),       // 20: wallet.AuditExportRequest
+	(*RtpStatsRequest)(nil),          // 21: wallet.RtpStatsRequest
+	(*RtpStat)(nil),                  // 22: wallet.RtpStat
+	(*RtpStatsResponse)(nil),         // 23: wallet.RtpStatsResponse
+	(*Transaction)(nil),              // 24: wallet.Transaction
+	(*ListTransactionsRequest)(nil),  // 25: wallet.ListTransactionsRequest
+	(*ListTransactionsResponse)(nil), // 26: wallet.ListTransactionsResponse
+	(*ReconcileRequest)(nil),         // 27: wallet.ReconcileRequest
+	(*ReconcileResponse)(nil),        // 28: wallet.ReconcileResponse
+	(*ReserveRequest)(nil),           // 29: wallet.ReserveRequest
+	(*CaptureRequest)(nil),           // 30: wallet.CaptureRequest
+	(*ReleaseRequest)(nil),           // 31: wallet.ReleaseRequest
+	(*Hold)(nil),                     // 32: wallet.Hold
+	(*HoldResponse)(nil),             // 33: wallet.HoldResponse
+	(*SubWallet)(nil),                // 34: wallet.SubWallet
+	(*ListWalletsResponse)(nil),      // 35: wallet.ListWalletsResponse
+	(*Rate)(nil),                     // 36: wallet.Rate
+	(*SetRateRequest)(nil),           // 37: wallet.SetRateRequest
+	(*ListRatesRequest)(nil),         // 38: wallet.ListRatesRequest
+	(*ListRatesResponse)(nil),        // 39: wallet.ListRatesResponse
+	(*ConvertRequest)(nil),           // 40: wallet.ConvertRequest
+	(*ConvertResponse)(nil),          // 41: wallet.ConvertResponse
+	(*PaymentRequest)(nil),           // 42: wallet.PaymentRequest
+	(*Payment)(nil),                  // 43: wallet.Payment
+	(*ReviewWithdrawalRequest)(nil),  // 44: wallet.ReviewWithdrawalRequest
+	(*ListPaymentsRequest)(nil),      // 45: wallet.ListPaymentsRequest
+	(*ListPaymentsResponse)(nil),     // 46: wallet.ListPaymentsResponse
+	(*PaymentWebhookRequest)(nil),    // 47: wallet.PaymentWebhookRequest
+	(*TransferRequest)(nil),          // 48: wallet.TransferRequest
+	(*TransferResponse)(nil),         // 49: wallet.TransferResponse
+	(*GrantBonusRequest)(nil),        // 50: wallet.GrantBonusRequest
+	(*Bonus)(nil),                    // 51: wallet.Bonus
+	(*ListBonusesResponse)(nil),      // 52: wallet.ListBonusesResponse
 }
 var file_wallet_wallet_proto_depIdxs = []int32{
-	0, // 0: wallet.WalletService.GetBalance:input_type -> wallet.WalletRequest
-	2, // 1: wallet.WalletService.UpdateBalance:input_type -> wallet.WalletUpdateRequest
-	1, // 2: wallet.WalletService.GetBalance:output_type -> wallet.WalletResponse
-	3, // 3: wallet.WalletService.UpdateBalance:output_type -> wallet.WalletUpdateResponse
-	2, // [2:4] is the sub-list for method output_type
-	0, // [0:2] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	0,  // 0: wallet.WalletResponse.balance:type_name -> wallet.Money
+	0,  // 1: wallet.WalletResponse.held:type_name -> wallet.Money
+	0,  // 2: wallet.WalletUpdateRequest.amount:type_name -> wallet.Money
+	0,  // 3: wallet.WalletUpdateResponse.new_balance:type_name -> wallet.Money
+	0,  // 4: wallet.BalanceDelta.amount:type_name -> wallet.Money
+	5,  // 5: wallet.BatchUpdateRequest.updates:type_name -> wallet.BalanceDelta
+	0,  // 6: wallet.GameResult.stake:type_name -> wallet.Money
+	0,  // 7: wallet.GameResult.payout:type_name -> wallet.Money
+	0,  // 8: wallet.GameResult.liability:type_name -> wallet.Money
+	8,  // 9: wallet.RecordResultsRequest.results:type_name -> wallet.GameResult
+	12, // 10: wallet.LeaderboardResponse.top:type_name -> wallet.LeaderboardEntry
+	12, // 11: wallet.LeaderboardResponse.me:type_name -> wallet.LeaderboardEntry
+	0,  // 12: wallet.Achievement.bonus:type_name -> wallet.Money
+	15, // 13: wallet.AchievementsResponse.achievements:type_name -> wallet.Achievement
+	0,  // 14: wallet.DemoResponse.balance:type_name -> wallet.Money
+	0,  // 15: wallet.AuditEntry.stake:type_name -> wallet.Money
+	0,  // 16: wallet.AuditEntry.payout:type_name -> wallet.Money
+	22, // 17: wallet.RtpStatsResponse.stats:type_name -> wallet.RtpStat
+	0,  // 18: wallet.Transaction.amount:type_name -> wallet.Money
+	0,  // 19: wallet.Transaction.balance_after:type_name -> wallet.Money
+	24, // 20: wallet.ListTransactionsResponse.transactions:type_name -> wallet.Transaction
+	0,  // 21: wallet.ReconcileResponse.snapshot:type_name -> wallet.Money
+	0,  // 22: wallet.ReconcileResponse.ledger:type_name -> wallet.Money
+	0,  // 23: wallet.ReserveRequest.amount:type_name -> wallet.Money
+	0,  // 24: wallet.CaptureRequest.amount:type_name -> wallet.Money
+	0,  // 25: wallet.Hold.amount:type_name -> wallet.Money
+	0,  // 26: wallet.Hold.captured:type_name -> wallet.Money
+	32, // 27: wallet.HoldResponse.hold:type_name -> wallet.Hold
+	0,  // 28: wallet.HoldResponse.balance:type_name -> wallet.Money
+	0,  // 29: wallet.HoldResponse.held:type_name -> wallet.Money
+	0,  // 30: wallet.SubWallet.balance:type_name -> wallet.Money
+	0,  // 31: wallet.SubWallet.held:type_name -> wallet.Money
+	34, // 32: wallet.ListWalletsResponse.wallets:type_name -> wallet.SubWallet
+	36, // 33: wallet.ListRatesResponse.rates:type_name -> wallet.Rate
+	0,  // 34: wallet.ConvertRequest.amount:type_name -> wallet.Money
+	0,  // 35: wallet.ConvertResponse.debited:type_name -> wallet.Money
+	0,  // 36: wallet.ConvertResponse.credited:type_name -> wallet.Money
+	0,  // 37: wallet.ConvertResponse.from_balance:type_name -> wallet.Money
+	0,  // 38: wallet.ConvertResponse.to_balance:type_name -> wallet.Money
+	0,  // 39: wallet.PaymentRequest.amount:type_name -> wallet.Money
+	0,  // 40: wallet.Payment.amount:type_name -> wallet.Money
+	43, // 41: wallet.ListPaymentsResponse.payments:type_name -> wallet.Payment
+	0,  // 42: wallet.TransferRequest.amount:type_name -> wallet.Money
+	24, // 43: wallet.TransferResponse.debit:type_name -> wallet.Transaction
+	24, // 44: wallet.TransferResponse.credit:type_name -> wallet.Transaction
+	0,  // 45: wallet.TransferResponse.balance:type_name -> wallet.Money
+	0,  // 46: wallet.TransferResponse.daily_remaining:type_name -> wallet.Money
+	0,  // 47: wallet.GrantBonusRequest.amount:type_name -> wallet.Money
+	0,  // 48: wallet.Bonus.amount:type_name -> wallet.Money
+	0,  // 49: wallet.Bonus.balance:type_name -> wallet.Money
+	0,  // 50: wallet.Bonus.wager_required:type_name -> wallet.Money
+	0,  // 51: wallet.Bonus.wagered:type_name -> wallet.Money
+	0,  // 52: wallet.Bonus.released:type_name -> wallet.Money
+	0,  // 53: wallet.Bonus.forfeited:type_name -> wallet.Money
+	51, // 54: wallet.ListBonusesResponse.bonuses:type_name -> wallet.Bonus
+	0,  // 55: wallet.ListBonusesResponse.balance:type_name -> wallet.Money
+	1,  // 56: wallet.WalletService.GetBalance:input_type -> wallet.WalletRequest
+	3,  // 57: wallet.WalletService.UpdateBalance:input_type -> wallet.WalletUpdateRequest
+	6,  // 58: wallet.WalletService.BatchUpdateBalance:input_type -> wallet.BatchUpdateRequest
+	9,  // 59: wallet.WalletService.RecordResults:input_type -> wallet.RecordResultsRequest
+	11, // 60: wallet.WalletService.GetLeaderboard:input_type -> wallet.LeaderboardRequest
+	14, // 61: wallet.WalletService.GetAchievements:input_type -> wallet.AchievementsRequest
+	17, // 62: wallet.WalletService.StartDemo:input_type -> wallet.DemoRequest
+	17, // 63: wallet.WalletService.EndDemo:input_type -> wallet.DemoRequest
+	20, // 64: wallet.WalletService.ExportAudit:input_type -> wallet.AuditExportRequest
+	21, // 65: wallet.WalletService.GetRtpStats:input_type -> wallet.RtpStatsRequest
+	25, // 66: wallet.WalletService.ListTransactions:input_type -> wallet.ListTransactionsRequest
+	27, // 67: wallet.WalletService.ReconcileBalance:input_type -> wallet.ReconcileRequest
+	29, // 68: wallet.WalletService.Reserve:input_type -> wallet.ReserveRequest
+	30, // 69: wallet.WalletService.Capture:input_type -> wallet.CaptureRequest
+	31, // 70: wallet.WalletService.Release:input_type -> wallet.ReleaseRequest
+	1,  // 71: wallet.WalletService.ListWallets:input_type -> wallet.WalletRequest
+	40, // 72: wallet.WalletService.Convert:input_type -> wallet.ConvertRequest
+	37, // 73: wallet.WalletService.SetRate:input_type -> wallet.SetRateRequest
+	38, // 74: wallet.WalletService.ListRates:input_type -> wallet.ListRatesRequest
+	42, // 75: wallet.WalletService.RequestDeposit:input_type -> wallet.PaymentRequest
+	42, // 76: wallet.WalletService.RequestWithdrawal:input_type -> wallet.PaymentRequest
+	44, // 77: wallet.WalletService.ReviewWithdrawal:input_type -> wallet.ReviewWithdrawalRequest
+	45, // 78: wallet.WalletService.ListPayments:input_type -> wallet.ListPaymentsRequest
+	47, // 79: wallet.WalletService.PaymentWebhook:input_type -> wallet.PaymentWebhookRequest
+	48, // 80: wallet.WalletService.Transfer:input_type -> wallet.TransferRequest
+	50, // 81: wallet.WalletService.GrantBonus:input_type -> wallet.GrantBonusRequest
+	1,  // 82: wallet.WalletService.ListBonuses:input_type -> wallet.WalletRequest
+	2,  // 83: wallet.WalletService.GetBalance:output_type -> wallet.WalletResponse
+	4,  // 84: wallet.WalletService.UpdateBalance:output_type -> wallet.WalletUpdateResponse
+	7,  // 85: wallet.WalletService.BatchUpdateBalance:output_type -> wallet.BatchUpdateResponse
+	10, // 86: wallet.WalletService.RecordResults:output_type -> wallet.RecordResultsResponse
+	13, // 87: wallet.WalletService.GetLeaderboard:output_type -> wallet.LeaderboardResponse
+	16, // 88: wallet.WalletService.GetAchievements:output_type -> wallet.AchievementsResponse
+	18, // 89: wallet.WalletService.StartDemo:output_type -> wallet.DemoResponse
+	18, // 90: wallet.WalletService.EndDemo:output_type -> wallet.DemoResponse
+	19, // 91: wallet.WalletService.ExportAudit:output_type -> wallet.AuditEntry
+	23, // 92: wallet.WalletService.GetRtpStats:output_type -> wallet.RtpStatsResponse
+	26, // 93: wallet.WalletService.ListTransactions:output_type -> wallet.ListTransactionsResponse
+	28, // 94: wallet.WalletService.ReconcileBalance:output_type -> wallet.ReconcileResponse
+	33, // 95: wallet.WalletService.Reserve:output_type -> wallet.HoldResponse
+	33, // 96: wallet.WalletService.Capture:output_type -> wallet.HoldResponse
+	33, // 97: wallet.WalletService.Release:output_type -> wallet.HoldResponse
+	35, // 98: wallet.WalletService.ListWallets:output_type -> wallet.ListWalletsResponse
+	41, // 99: wallet.WalletService.Convert:output_type -> wallet.ConvertResponse
+	36, // 100: wallet.WalletService.SetRate:output_type -> wallet.Rate
+	39, // 101: wallet.WalletService.ListRates:output_type -> wallet.ListRatesResponse
+	43, // 102: wallet.WalletService.RequestDeposit:output_type -> wallet.Payment
+	43, // 103: wallet.WalletService.RequestWithdrawal:output_type -> wallet.Payment
+	43, // 104: wallet.WalletService.ReviewWithdrawal:output_type -> wallet.Payment
+	46, // 105: wallet.WalletService.ListPayments:output_type -> wallet.ListPaymentsResponse
+	43, // 106: wallet.WalletService.PaymentWebhook:output_type -> wallet.Payment
+	49, // 107: wallet.WalletService.Transfer:output_type -> wallet.TransferResponse
+	51, // 108: wallet.WalletService.GrantBonus:output_type -> wallet.Bonus
+	52, // 109: wallet.WalletService.ListBonuses:output_type -> wallet.ListBonusesResponse
+	83, // [83:110] is the sub-list for method output_type
+	56, // [56:83] is the sub-list for method input_type
+	56, // [56:56] is the sub-list for extension type_name
+	56, // [56:56] is the sub-list for extension extendee
+	0,  // [0:56] is the sub-list for field type_name
 }
 
 func init() { file_wallet_wallet_proto_init() }
@@ -267,7 +4231,7 @@ func file_wallet_wallet_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_wallet_wallet_proto_rawDesc), len(file_wallet_wallet_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   53,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,8 +19,33 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	WalletService_GetBalance_FullMethodName    = "/wallet.WalletService/GetBalance"
-	WalletService_UpdateBalance_FullMethodName = "/wallet.WalletService/UpdateBalance"
+	WalletService_GetBalance_FullMethodName         = "/wallet.WalletService/GetBalance"
+	WalletService_UpdateBalance_FullMethodName      = "/wallet.WalletService/UpdateBalance"
+	WalletService_BatchUpdateBalance_FullMethodName = "/wallet.WalletService/BatchUpdateBalance"
+	WalletService_RecordResults_FullMethodName      = "/wallet.WalletService/RecordResults"
+	WalletService_GetLeaderboard_FullMethodName     = "/wallet.WalletService/GetLeaderboard"
+	WalletService_GetAchievements_FullMethodName    = "/wallet.WalletService/GetAchievements"
+	WalletService_StartDemo_FullMethodName          = "/wallet.WalletService/StartDemo"
+	WalletService_EndDemo_FullMethodName            = "/wallet.WalletService/EndDemo"
+	WalletService_ExportAudit_FullMethodName        = "/wallet.WalletService/ExportAudit"
+	WalletService_GetRtpStats_FullMethodName        = "/wallet.WalletService/GetRtpStats"
+	WalletService_ListTransactions_FullMethodName   = "/wallet.WalletService/ListTransactions"
+	WalletService_ReconcileBalance_FullMethodName   = "/wallet.WalletService/ReconcileBalance"
+	WalletService_Reserve_FullMethodName            = "/wallet.WalletService/Reserve"
+	WalletService_Capture_FullMethodName            = "/wallet.WalletService/Capture"
+	WalletService_Release_FullMethodName            = "/wallet.WalletService/Release"
+	WalletService_ListWallets_FullMethodName        = "/wallet.WalletService/ListWallets"
+	WalletService_Convert_FullMethodName            = "/wallet.WalletService/Convert"
+	WalletService_SetRate_FullMethodName            = "/wallet.WalletService/SetRate"
+	WalletService_ListRates_FullMethodName          = "/wallet.WalletService/ListRates"
+	WalletService_RequestDeposit_FullMethodName     = "/wallet.WalletService/RequestDeposit"
+	WalletService_RequestWithdrawal_FullMethodName  = "/wallet.WalletService/RequestWithdrawal"
+	WalletService_ReviewWithdrawal_FullMethodName   = "/wallet.WalletService/ReviewWithdrawal"
+	WalletService_ListPayments_FullMethodName       = "/wallet.WalletService/ListPayments"
+	WalletService_PaymentWebhook_FullMethodName     = "/wallet.WalletService/PaymentWebhook"
+	WalletService_Transfer_FullMethodName           = "/wallet.WalletService/Transfer"
+	WalletService_GrantBonus_FullMethodName         = "/wallet.WalletService/GrantBonus"
+	WalletService_ListBonuses_FullMethodName        = "/wallet.WalletService/ListBonuses"
 )
 
 // WalletServiceClient is the client API for WalletService service.
@@ -31,6 +56,52 @@ const (
 type WalletServiceClient interface {
 	GetBalance(ctx context.Context, in *WalletRequest, opts ...grpc.CallOption) (*WalletResponse, error)
 	UpdateBalance(ctx context.Context, in *WalletUpdateRequest, opts ...grpc.CallOption) (*WalletUpdateResponse, error)
+	// пакетное начисление/списание (расчёт тиражей и т.п.)
+	BatchUpdateBalance(ctx context.Context, in *BatchUpdateRequest, opts ...grpc.CallOption) (*BatchUpdateResponse, error)
+	// итоги рассчитанных раундов — из них строятся лидерборды
+	RecordResults(ctx context.Context, in *RecordResultsRequest, opts ...grpc.CallOption) (*RecordResultsResponse, error)
+	GetLeaderboard(ctx context.Context, in *LeaderboardRequest, opts ...grpc.CallOption) (*LeaderboardResponse, error)
+	// достижения игрока: прогресс и полученные бейджи
+	GetAchievements(ctx context.Context, in *AchievementsRequest, opts ...grpc.CallOption) (*AchievementsResponse, error)
+	// демо-кошельки (user_id "demo:…"): виртуальные кредиты отдельно от настоящих
+	StartDemo(ctx context.Context, in *DemoRequest, opts ...grpc.CallOption) (*DemoResponse, error)
+	EndDemo(ctx context.Context, in *DemoRequest, opts ...grpc.CallOption) (*DemoResponse, error)
+	// журнал аудита раундов (цепочка хешей) по порядку seq
+	ExportAudit(ctx context.Context, in *AuditExportRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AuditEntry], error)
+	// фактический RTP и риск по играм в скользящих окнах
+	GetRtpStats(ctx context.Context, in *RtpStatsRequest, opts ...grpc.CallOption) (*RtpStatsResponse, error)
+	// журнал проводок кошелька, новые сверху
+	ListTransactions(ctx context.Context, in *ListTransactionsRequest, opts ...grpc.CallOption) (*ListTransactionsResponse, error)
+	// сверка снимка баланса с суммой проводок, fix — выставить баланс по журналу
+	ReconcileBalance(ctx context.Context, in *ReconcileRequest, opts ...grpc.CallOption) (*ReconcileResponse, error)
+	// удержание ставки на время раунда: Reserve откладывает деньги,
+	// Capture списывает удержанное (остаток возвращается), Release отпускает всё
+	Reserve(ctx context.Context, in *ReserveRequest, opts ...grpc.CallOption) (*HoldResponse, error)
+	Capture(ctx context.Context, in *CaptureRequest, opts ...grpc.CallOption) (*HoldResponse, error)
+	Release(ctx context.Context, in *ReleaseRequest, opts ...grpc.CallOption) (*HoldResponse, error)
+	// подкошельки игрока по валютам
+	ListWallets(ctx context.Context, in *WalletRequest, opts ...grpc.CallOption) (*ListWalletsResponse, error)
+	// обмен между подкошельками по курсу из таблицы курсов
+	Convert(ctx context.Context, in *ConvertRequest, opts ...grpc.CallOption) (*ConvertResponse, error)
+	// таблица курсов: админ задаёт курс пары, пустой курс удаляет пару
+	SetRate(ctx context.Context, in *SetRateRequest, opts ...grpc.CallOption) (*Rate, error)
+	ListRates(ctx context.Context, in *ListRatesRequest, opts ...grpc.CallOption) (*ListRatesResponse, error)
+	// пополнение и вывод через платёжного провайдера:
+	// pending → approved/rejected → completed (или failed, если провайдер отказал)
+	RequestDeposit(ctx context.Context, in *PaymentRequest, opts ...grpc.CallOption) (*Payment, error)
+	RequestWithdrawal(ctx context.Context, in *PaymentRequest, opts ...grpc.CallOption) (*Payment, error)
+	// решение админа по выводу выше порога
+	ReviewWithdrawal(ctx context.Context, in *ReviewWithdrawalRequest, opts ...grpc.CallOption) (*Payment, error)
+	ListPayments(ctx context.Context, in *ListPaymentsRequest, opts ...grpc.CallOption) (*ListPaymentsResponse, error)
+	// уведомление провайдера о результате (webhook), подпись проверяет провайдер
+	PaymentWebhook(ctx context.Context, in *PaymentWebhookRequest, opts ...grpc.CallOption) (*Payment, error)
+	// перевод между игроками: списание и зачисление одной транзакцией,
+	// с дневным лимитом отправителя
+	Transfer(ctx context.Context, in *TransferRequest, opts ...grpc.CallOption) (*TransferResponse, error)
+	// бонусы (приветственный, reload) на отдельном бонусном балансе с
+	// условием отыгрыша; ставки тратят сначала деньги, потом бонус
+	GrantBonus(ctx context.Context, in *GrantBonusRequest, opts ...grpc.CallOption) (*Bonus, error)
+	ListBonuses(ctx context.Context, in *WalletRequest, opts ...grpc.CallOption) (*ListBonusesResponse, error)
 }
 
 type walletServiceClient struct {
@@ -61,6 +132,265 @@ func (c *walletServiceClient) UpdateBalance(ctx context.Context, in *WalletUpdat
 	return out, nil
 }
 
+func (c *walletServiceClient) BatchUpdateBalance(ctx context.Context, in *BatchUpdateRequest, opts ...grpc.CallOption) (*BatchUpdateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchUpdateResponse)
+	err := c.cc.Invoke(ctx, WalletService_BatchUpdateBalance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) RecordResults(ctx context.Context, in *RecordResultsRequest, opts ...grpc.CallOption) (*RecordResultsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecordResultsResponse)
+	err := c.cc.Invoke(ctx, WalletService_RecordResults_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) GetLeaderboard(ctx context.Context, in *LeaderboardRequest, opts ...grpc.CallOption) (*LeaderboardResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LeaderboardResponse)
+	err := c.cc.Invoke(ctx, WalletService_GetLeaderboard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) GetAchievements(ctx context.Context, in *AchievementsRequest, opts ...grpc.CallOption) (*AchievementsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AchievementsResponse)
+	err := c.cc.Invoke(ctx, WalletService_GetAchievements_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) StartDemo(ctx context.Context, in *DemoRequest, opts ...grpc.CallOption) (*DemoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DemoResponse)
+	err := c.cc.Invoke(ctx, WalletService_StartDemo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) EndDemo(ctx context.Context, in *DemoRequest, opts ...grpc.CallOption) (*DemoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DemoResponse)
+	err := c.cc.Invoke(ctx, WalletService_EndDemo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) ExportAudit(ctx context.Context, in *AuditExportRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AuditEntry], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &WalletService_ServiceDesc.Streams[0], WalletService_ExportAudit_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[AuditExportRequest, AuditEntry]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type WalletService_ExportAuditClient = grpc.ServerStreamingClient[AuditEntry]
+
+func (c *walletServiceClient) GetRtpStats(ctx context.Context, in *RtpStatsRequest, opts ...grpc.CallOption) (*RtpStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RtpStatsResponse)
+	err := c.cc.Invoke(ctx, WalletService_GetRtpStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) ListTransactions(ctx context.Context, in *ListTransactionsRequest, opts ...grpc.CallOption) (*ListTransactionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTransactionsResponse)
+	err := c.cc.Invoke(ctx, WalletService_ListTransactions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) ReconcileBalance(ctx context.Context, in *ReconcileRequest, opts ...grpc.CallOption) (*ReconcileResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReconcileResponse)
+	err := c.cc.Invoke(ctx, WalletService_ReconcileBalance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) Reserve(ctx context.Context, in *ReserveRequest, opts ...grpc.CallOption) (*HoldResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HoldResponse)
+	err := c.cc.Invoke(ctx, WalletService_Reserve_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) Capture(ctx context.Context, in *CaptureRequest, opts ...grpc.CallOption) (*HoldResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HoldResponse)
+	err := c.cc.Invoke(ctx, WalletService_Capture_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) Release(ctx context.Context, in *ReleaseRequest, opts ...grpc.CallOption) (*HoldResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HoldResponse)
+	err := c.cc.Invoke(ctx, WalletService_Release_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) ListWallets(ctx context.Context, in *WalletRequest, opts ...grpc.CallOption) (*ListWalletsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWalletsResponse)
+	err := c.cc.Invoke(ctx, WalletService_ListWallets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) Convert(ctx context.Context, in *ConvertRequest, opts ...grpc.CallOption) (*ConvertResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConvertResponse)
+	err := c.cc.Invoke(ctx, WalletService_Convert_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) SetRate(ctx context.Context, in *SetRateRequest, opts ...grpc.CallOption) (*Rate, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Rate)
+	err := c.cc.Invoke(ctx, WalletService_SetRate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) ListRates(ctx context.Context, in *ListRatesRequest, opts ...grpc.CallOption) (*ListRatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRatesResponse)
+	err := c.cc.Invoke(ctx, WalletService_ListRates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) RequestDeposit(ctx context.Context, in *PaymentRequest, opts ...grpc.CallOption) (*Payment, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Payment)
+	err := c.cc.Invoke(ctx, WalletService_RequestDeposit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) RequestWithdrawal(ctx context.Context, in *PaymentRequest, opts ...grpc.CallOption) (*Payment, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Payment)
+	err := c.cc.Invoke(ctx, WalletService_RequestWithdrawal_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) ReviewWithdrawal(ctx context.Context, in *ReviewWithdrawalRequest, opts ...grpc.CallOption) (*Payment, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Payment)
+	err := c.cc.Invoke(ctx, WalletService_ReviewWithdrawal_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) ListPayments(ctx context.Context, in *ListPaymentsRequest, opts ...grpc.CallOption) (*ListPaymentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPaymentsResponse)
+	err := c.cc.Invoke(ctx, WalletService_ListPayments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) PaymentWebhook(ctx context.Context, in *PaymentWebhookRequest, opts ...grpc.CallOption) (*Payment, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Payment)
+	err := c.cc.Invoke(ctx, WalletService_PaymentWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) Transfer(ctx context.Context, in *TransferRequest, opts ...grpc.CallOption) (*TransferResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransferResponse)
+	err := c.cc.Invoke(ctx, WalletService_Transfer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) GrantBonus(ctx context.Context, in *GrantBonusRequest, opts ...grpc.CallOption) (*Bonus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Bonus)
+	err := c.cc.Invoke(ctx, WalletService_GrantBonus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *walletServiceClient) ListBonuses(ctx context.Context, in *WalletRequest, opts ...grpc.CallOption) (*ListBonusesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBonusesResponse)
+	err := c.cc.Invoke(ctx, WalletService_ListBonuses_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // WalletServiceServer is the server API for WalletService service.
 // All implementations must embed UnimplementedWalletServiceServer
 // for forward compatibility.
@@ -69,6 +399,52 @@ func (c *walletServiceClient) UpdateBalance(ctx context.Context, in *WalletUpdat
 type WalletServiceServer interface {
 	GetBalance(context.Context, *WalletRequest) (*WalletResponse, error)
 	UpdateBalance(context.Context, *WalletUpdateRequest) (*WalletUpdateResponse, error)
+	// пакетное начисление/списание (расчёт тиражей и т.п.)
+	BatchUpdateBalance(context.Context, *BatchUpdateRequest) (*BatchUpdateResponse, error)
+	// итоги рассчитанных раундов — из них строятся лидерборды
+	RecordResults(context.Context, *RecordResultsRequest) (*RecordResultsResponse, error)
+	GetLeaderboard(context.Context, *LeaderboardRequest) (*LeaderboardResponse, error)
+	// достижения игрока: прогресс и полученные бейджи
+	GetAchievements(context.Context, *AchievementsRequest) (*AchievementsResponse, error)
+	// демо-кошельки (user_id "demo:…"): виртуальные кредиты отдельно от настоящих
+	StartDemo(context.Context, *DemoRequest) (*DemoResponse, error)
+	EndDemo(context.Context, *DemoRequest) (*DemoResponse, error)
+	// журнал аудита раундов (цепочка хешей) по порядку seq
+	ExportAudit(*AuditExportRequest, grpc.ServerStreamingServer[AuditEntry]) error
+	// фактический RTP и риск по играм в скользящих окнах
+	GetRtpStats(context.Context, *RtpStatsRequest) (*RtpStatsResponse, error)
+	// журнал проводок кошелька, новые сверху
+	ListTransactions(context.Context, *ListTransactionsRequest) (*ListTransactionsResponse, error)
+	// сверка снимка баланса с суммой проводок, fix — выставить баланс по журналу
+	ReconcileBalance(context.Context, *ReconcileRequest) (*ReconcileResponse, error)
+	// удержание ставки на время раунда: Reserve откладывает деньги,
+	// Capture списывает удержанное (остаток возвращается), Release отпускает всё
+	Reserve(context.Context, *ReserveRequest) (*HoldResponse, error)
+	Capture(context.Context, *CaptureRequest) (*HoldResponse, error)
+	Release(context.Context, *ReleaseRequest) (*HoldResponse, error)
+	// подкошельки игрока по валютам
+	ListWallets(context.Context, *WalletRequest) (*ListWalletsResponse, error)
+	// обмен между подкошельками по курсу из таблицы курсов
+	Convert(context.Context, *ConvertRequest) (*ConvertResponse, error)
+	// таблица курсов: админ задаёт курс пары, пустой курс удаляет пару
+	SetRate(context.Context, *SetRateRequest) (*Rate, error)
+	ListRates(context.Context, *ListRatesRequest) (*ListRatesResponse, error)
+	// пополнение и вывод через платёжного провайдера:
+	// pending → approved/rejected → completed (или failed, если провайдер отказал)
+	RequestDeposit(context.Context, *PaymentRequest) (*Payment, error)
+	RequestWithdrawal(context.Context, *PaymentRequest) (*Payment, error)
+	// решение админа по выводу выше порога
+	ReviewWithdrawal(context.Context, *ReviewWithdrawalRequest) (*Payment, error)
+	ListPayments(context.Context, *ListPaymentsRequest) (*ListPaymentsResponse, error)
+	// уведомление провайдера о результате (webhook), подпись проверяет провайдер
+	PaymentWebhook(context.Context, *PaymentWebhookRequest) (*Payment, error)
+	// перевод между игроками: списание и зачисление одной транзакцией,
+	// с дневным лимитом отправителя
+	Transfer(context.Context, *TransferRequest) (*TransferResponse, error)
+	// бонусы (приветственный, reload) на отдельном бонусном балансе с
+	// условием отыгрыша; ставки тратят сначала деньги, потом бонус
+	GrantBonus(context.Context, *GrantBonusRequest) (*Bonus, error)
+	ListBonuses(context.Context, *WalletRequest) (*ListBonusesResponse, error)
 	mustEmbedUnimplementedWalletServiceServer()
 }
 
@@ -85,6 +461,81 @@ func (UnimplementedWalletServiceServer) GetBalance(context.Context, *WalletReque
 func (UnimplementedWalletServiceServer) UpdateBalance(context.Context, *WalletUpdateRequest) (*WalletUpdateResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateBalance not implemented")
 }
+func (UnimplementedWalletServiceServer) BatchUpdateBalance(context.Context, *BatchUpdateRequest) (*BatchUpdateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchUpdateBalance not implemented")
+}
+func (UnimplementedWalletServiceServer) RecordResults(context.Context, *RecordResultsRequest) (*RecordResultsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecordResults not implemented")
+}
+func (UnimplementedWalletServiceServer) GetLeaderboard(context.Context, *LeaderboardRequest) (*LeaderboardResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLeaderboard not implemented")
+}
+func (UnimplementedWalletServiceServer) GetAchievements(context.Context, *AchievementsRequest) (*AchievementsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAchievements not implemented")
+}
+func (UnimplementedWalletServiceServer) StartDemo(context.Context, *DemoRequest) (*DemoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartDemo not implemented")
+}
+func (UnimplementedWalletServiceServer) EndDemo(context.Context, *DemoRequest) (*DemoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EndDemo not implemented")
+}
+func (UnimplementedWalletServiceServer) ExportAudit(*AuditExportRequest, grpc.ServerStreamingServer[AuditEntry]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportAudit not implemented")
+}
+func (UnimplementedWalletServiceServer) GetRtpStats(context.Context, *RtpStatsRequest) (*RtpStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRtpStats not implemented")
+}
+func (UnimplementedWalletServiceServer) ListTransactions(context.Context, *ListTransactionsRequest) (*ListTransactionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTransactions not implemented")
+}
+func (UnimplementedWalletServiceServer) ReconcileBalance(context.Context, *ReconcileRequest) (*ReconcileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReconcileBalance not implemented")
+}
+func (UnimplementedWalletServiceServer) Reserve(context.Context, *ReserveRequest) (*HoldResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reserve not implemented")
+}
+func (UnimplementedWalletServiceServer) Capture(context.Context, *CaptureRequest) (*HoldResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Capture not implemented")
+}
+func (UnimplementedWalletServiceServer) Release(context.Context, *ReleaseRequest) (*HoldResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Release not implemented")
+}
+func (UnimplementedWalletServiceServer) ListWallets(context.Context, *WalletRequest) (*ListWalletsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWallets not implemented")
+}
+func (UnimplementedWalletServiceServer) Convert(context.Context, *ConvertRequest) (*ConvertResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Convert not implemented")
+}
+func (UnimplementedWalletServiceServer) SetRate(context.Context, *SetRateRequest) (*Rate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetRate not implemented")
+}
+func (UnimplementedWalletServiceServer) ListRates(context.Context, *ListRatesRequest) (*ListRatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRates not implemented")
+}
+func (UnimplementedWalletServiceServer) RequestDeposit(context.Context, *PaymentRequest) (*Payment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestDeposit not implemented")
+}
+func (UnimplementedWalletServiceServer) RequestWithdrawal(context.Context, *PaymentRequest) (*Payment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestWithdrawal not implemented")
+}
+func (UnimplementedWalletServiceServer) ReviewWithdrawal(context.Context, *ReviewWithdrawalRequest) (*Payment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReviewWithdrawal not implemented")
+}
+func (UnimplementedWalletServiceServer) ListPayments(context.Context, *ListPaymentsRequest) (*ListPaymentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPayments not implemented")
+}
+func (UnimplementedWalletServiceServer) PaymentWebhook(context.Context, *PaymentWebhookRequest) (*Payment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PaymentWebhook not implemented")
+}
+func (UnimplementedWalletServiceServer) Transfer(context.Context, *TransferRequest) (*TransferResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Transfer not implemented")
+}
+func (UnimplementedWalletServiceServer) GrantBonus(context.Context, *GrantBonusRequest) (*Bonus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GrantBonus not implemented")
+}
+func (UnimplementedWalletServiceServer) ListBonuses(context.Context, *WalletRequest) (*ListBonusesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBonuses not implemented")
+}
 func (UnimplementedWalletServiceServer) mustEmbedUnimplementedWalletServiceServer() {}
 func (UnimplementedWalletServiceServer) testEmbeddedByValue()                       {}
 
@@ -142,22 +593,567 @@ func _WalletService_UpdateBalance_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
-// WalletService_ServiceDesc is the grpc.ServiceDesc for WalletService service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var WalletService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "wallet.WalletService",
-	HandlerType: (*WalletServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "GetBalance",
-			Handler:    _WalletService_GetBalance_Handler,
-		},
-		{
-			MethodName: "UpdateBalance",
-			Handler:    _WalletService_UpdateBalance_Handler,
+func _WalletService_BatchUpdateBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchUpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).BatchUpdateBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_BatchUpdateBalance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).BatchUpdateBalance(ctx, req.(*BatchUpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_RecordResults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordResultsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).RecordResults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_RecordResults_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).RecordResults(ctx, req.(*RecordResultsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_GetLeaderboard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaderboardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).GetLeaderboard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_GetLeaderboard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).GetLeaderboard(ctx, req.(*LeaderboardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_GetAchievements_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AchievementsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).GetAchievements(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_GetAchievements_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).GetAchievements(ctx, req.(*AchievementsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_StartDemo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DemoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).StartDemo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_StartDemo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).StartDemo(ctx, req.(*DemoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_EndDemo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DemoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).EndDemo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_EndDemo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).EndDemo(ctx, req.(*DemoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_ExportAudit_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(AuditExportRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(WalletServiceServer).ExportAudit(m, &grpc.GenericServerStream[AuditExportRequest, AuditEntry]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type WalletService_ExportAuditServer = grpc.ServerStreamingServer[AuditEntry]
+
+func _WalletService_GetRtpStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RtpStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).GetRtpStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_GetRtpStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).GetRtpStats(ctx, req.(*RtpStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_ListTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).ListTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_ListTransactions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).ListTransactions(ctx, req.(*ListTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_ReconcileBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReconcileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).ReconcileBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_ReconcileBalance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).ReconcileBalance(ctx, req.(*ReconcileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_Reserve_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReserveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).Reserve(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_Reserve_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).Reserve(ctx, req.(*ReserveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_Capture_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CaptureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).Capture(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_Capture_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).Capture(ctx, req.(*CaptureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_Release_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).Release(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_Release_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).Release(ctx, req.(*ReleaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_ListWallets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WalletRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).ListWallets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_ListWallets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).ListWallets(ctx, req.(*WalletRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_Convert_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConvertRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).Convert(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_Convert_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).Convert(ctx, req.(*ConvertRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_SetRate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetRateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).SetRate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_SetRate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).SetRate(ctx, req.(*SetRateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_ListRates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).ListRates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_ListRates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).ListRates(ctx, req.(*ListRatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_RequestDeposit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PaymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).RequestDeposit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_RequestDeposit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).RequestDeposit(ctx, req.(*PaymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_RequestWithdrawal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PaymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).RequestWithdrawal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_RequestWithdrawal_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).RequestWithdrawal(ctx, req.(*PaymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_ReviewWithdrawal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReviewWithdrawalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).ReviewWithdrawal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_ReviewWithdrawal_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).ReviewWithdrawal(ctx, req.(*ReviewWithdrawalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_ListPayments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPaymentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).ListPayments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_ListPayments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).ListPayments(ctx, req.(*ListPaymentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_PaymentWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PaymentWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).PaymentWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_PaymentWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).PaymentWebhook(ctx, req.(*PaymentWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_Transfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).Transfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_Transfer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).Transfer(ctx, req.(*TransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_GrantBonus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GrantBonusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).GrantBonus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_GrantBonus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).GrantBonus(ctx, req.(*GrantBonusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WalletService_ListBonuses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WalletRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WalletServiceServer).ListBonuses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WalletService_ListBonuses_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WalletServiceServer).ListBonuses(ctx, req.(*WalletRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// WalletService_ServiceDesc is the grpc.ServiceDesc for WalletService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var WalletService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "wallet.WalletService",
+	HandlerType: (*WalletServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetBalance",
+			Handler:    _WalletService_GetBalance_Handler,
+		},
+		{
+			MethodName: "UpdateBalance",
+			Handler:    _WalletService_UpdateBalance_Handler,
+		},
+		{
+			MethodName: "BatchUpdateBalance",
+			Handler:    _WalletService_BatchUpdateBalance_Handler,
+		},
+		{
+			MethodName: "RecordResults",
+			Handler:    _WalletService_RecordResults_Handler,
+		},
+		{
+			MethodName: "GetLeaderboard",
+			Handler:    _WalletService_GetLeaderboard_Handler,
+		},
+		{
+			MethodName: "GetAchievements",
+			Handler:    _WalletService_GetAchievements_Handler,
+		},
+		{
+			MethodName: "StartDemo",
+			Handler:    _WalletService_StartDemo_Handler,
+		},
+		{
+			MethodName: "EndDemo",
+			Handler:    _WalletService_EndDemo_Handler,
+		},
+		{
+			MethodName: "GetRtpStats",
+			Handler:    _WalletService_GetRtpStats_Handler,
+		},
+		{
+			MethodName: "ListTransactions",
+			Handler:    _WalletService_ListTransactions_Handler,
+		},
+		{
+			MethodName: "ReconcileBalance",
+			Handler:    _WalletService_ReconcileBalance_Handler,
+		},
+		{
+			MethodName: "Reserve",
+			Handler:    _WalletService_Reserve_Handler,
+		},
+		{
+			MethodName: "Capture",
+			Handler:    _WalletService_Capture_Handler,
+		},
+		{
+			MethodName: "Release",
+			Handler:    _WalletService_Release_Handler,
+		},
+		{
+			MethodName: "ListWallets",
+			Handler:    _WalletService_ListWallets_Handler,
+		},
+		{
+			MethodName: "Convert",
+			Handler:    _WalletService_Convert_Handler,
+		},
+		{
+			MethodName: "SetRate",
+			Handler:    _WalletService_SetRate_Handler,
+		},
+		{
+			MethodName: "ListRates",
+			Handler:    _WalletService_ListRates_Handler,
+		},
+		{
+			MethodName: "RequestDeposit",
+			Handler:    _WalletService_RequestDeposit_Handler,
+		},
+		{
+			MethodName: "RequestWithdrawal",
+			Handler:    _WalletService_RequestWithdrawal_Handler,
+		},
+		{
+			MethodName: "ReviewWithdrawal",
+			Handler:    _WalletService_ReviewWithdrawal_Handler,
+		},
+		{
+			MethodName: "ListPayments",
+			Handler:    _WalletService_ListPayments_Handler,
+		},
+		{
+			MethodName: "PaymentWebhook",
+			Handler:    _WalletService_PaymentWebhook_Handler,
+		},
+		{
+			MethodName: "Transfer",
+			Handler:    _WalletService_Transfer_Handler,
+		},
+		{
+			MethodName: "GrantBonus",
+			Handler:    _WalletService_GrantBonus_Handler,
+		},
+		{
+			MethodName: "ListBonuses",
+			Handler:    _WalletService_ListBonuses_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportAudit",
+			Handler:       _WalletService_ExportAudit_Handler,
+			ServerStreams: true,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "wallet/wallet.proto",
 }
@@ -183,6 +183,7 @@ func (s *userServer) GetProfile(ctx context.Context, req *userpb.GetProfileReque
 		Email    string `bson:"email"`
 		Name     string `bson:"name"`
 		Surname  string `bson:"surname"`
+		Verified bool   `bson:"verified"`
 	}
 	if err := s.col.FindOne(ctx, bson.M{"_id": oid}).Decode(&doc); err != nil {
 		return nil, err
@@ -193,9 +194,26 @@ func (s *userServer) GetProfile(ctx context.Context, req *userpb.GetProfileReque
 		Email:    doc.Email,
 		Name:     doc.Name,
 		Surname:  doc.Surname,
+		Verified: doc.Verified,
 	}, nil
 }
 
+// FindUser ищет игрока по username; почту и имя не отдаёт — ищут другие игроки.
+func (s *userServer) FindUser(ctx context.Context, req *userpb.FindUserRequest) (*userpb.FindUserResponse, error) {
+	var doc struct {
+		ID       primitive.ObjectID `bson:"_id"`
+		Username string             `bson:"username"`
+		Verified bool               `bson:"verified"`
+	}
+	err := s.col.FindOne(ctx, bson.M{"username": req.Username}).Decode(&doc)
+	if err == mongo.ErrNoDocuments {
+		return nil, fmt.Errorf("user not found")
+	} else if err != nil {
+		return nil, err
+	}
+	return &userpb.FindUserResponse{UserId: doc.ID.Hex(), Username: doc.Username, Verified: doc.Verified}, nil
+}
+
 func (s *userServer) UpdateProfile(ctx context.Context, req *userpb.UpdateProfileRequest) (*userpb.UpdateProfileResponse, error) {
 	oid, err := primitive.ObjectIDFromHex(req.UserId)
 	if err != nil {
@@ -0,0 +1,326 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	walletpb "github.com/Arsencchikkk/final/casino/proto/wallet"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Rule — декларативное правило достижения. Раунд подходит правилу, если
+// совпали game, tag, win и min_stake (пустые поля не проверяются).
+//
+//	count       — набрать target подходящих раундов
+//	win_streak  — target побед подряд среди подходящих раундов (ничья серию не рвёт)
+//	days_in_row — играть target дней подряд (по UTC)
+type Rule struct {
+	Id          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Kind        string `json:"kind"`
+	Game        string `json:"game,omitempty"`
+	Tag         string `json:"tag,omitempty"`
+	Win         bool   `json:"win,omitempty"`
+	// min_stake и bonus — в целых кредитах
+	MinStake int64 `json:"min_stake,omitempty"`
+	Target   int64 `json:"target"`
+	Bonus    int64 `json:"bonus,omitempty"`
+}
+
+// правила по умолчанию; свой набор можно положить в ACHIEVEMENTS_FILE (JSON-массив)
+var defaultRules = []Rule{
+	{Id: "first_win", Name: "First Blood", Description: "Win any round", Kind: "count", Win: true, Target: 1, Bonus: 10},
+	{Id: "five_card_21", Name: "Five Card Charlie", Description: "Win blackjack with a 5-card 21", Kind: "count", Game: "blackjack", Tag: "five_card_21", Win: true, Target: 1, Bonus: 50},
+	{Id: "ten_blackjacks", Name: "Natural Talent", Description: "Get 10 blackjacks", Kind: "count", Game: "blackjack", Tag: "natural", Target: 10, Bonus: 100},
+	{Id: "mines_sweeper", Name: "Minesweeper", Description: "Open every safe tile in mines", Kind: "count", Game: "mines", Tag: "full_clear", Target: 1, Bonus: 50},
+	{Id: "crash_x10", Name: "Rocket Rider", Description: "Cash out at 10x or more in crash", Kind: "count", Game: "crash", Tag: "x10", Target: 1, Bonus: 50},
+	{Id: "holdem_quads", Name: "Quads", Description: "Win a hold'em pot with four of a kind or better", Kind: "count", Game: "holdem", Tag: "quads_plus", Win: true, Target: 1, Bonus: 50},
+	{Id: "keno_8", Name: "Lucky Eight", Description: "Hit 8 or more numbers in keno", Kind: "count", Game: "keno", Tag: "hits_8", Target: 1, Bonus: 50},
+	{Id: "high_roller", Name: "High Roller", Description: "Play 10 rounds with a stake of 1000 or more", Kind: "count", MinStake: 1000, Target: 10},
+	{Id: "hot_streak", Name: "On Fire", Description: "Win 5 rounds in a row", Kind: "win_streak", Target: 5, Bonus: 25},
+	{Id: "week_streak", Name: "Regular", Description: "Play 7 days in a row", Kind: "days_in_row", Target: 7, Bonus: 200},
+	{Id: "hundred_rounds", Name: "Centurion", Description: "Play 100 rounds", Kind: "count", Target: 100},
+}
+
+// ProgressDoc — прогресс игрока по одному правилу.
+type ProgressDoc struct {
+	UserId     string    `bson:"user_id"`
+	RuleId     string    `bson:"rule_id"`
+	Progress   int64     `bson:"progress"` // текущее значение (для серий — текущая серия)
+	Best       int64     `bson:"best"`     // лучшее значение, по нему выдаётся достижение
+	LastDay    string    `bson:"last_day,omitempty"`
+	Unlocked   bool      `bson:"unlocked"`
+	UnlockedAt time.Time `bson:"unlocked_at,omitempty"`
+	// бонус за достижение, ещё не зачисленный (в минорных единицах основной валюты)
+	BonusPending int64 `bson:"bonus_pending,omitempty"`
+}
+
+const (
+	achBonusRetryEvery = time.Minute
+	achBonusRetryBatch = 100
+)
+
+func loadRules() ([]Rule, error) {
+	path := os.Getenv("ACHIEVEMENTS_FILE")
+	if path == "" {
+		return defaultRules, nil
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var rules []Rule
+	if err := json.Unmarshal(b, &rules); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	seen := map[string]bool{}
+	for _, r := range rules {
+		switch {
+		case r.Id == "" || seen[r.Id]:
+			return nil, fmt.Errorf("%s: empty or duplicate rule id %q", path, r.Id)
+		case r.Kind != "count" && r.Kind != "win_streak" && r.Kind != "days_in_row":
+			return nil, fmt.Errorf("%s: rule %s: unknown kind %q", path, r.Id, r.Kind)
+		case r.Target < 1:
+			return nil, fmt.Errorf("%s: rule %s: target must be positive", path, r.Id)
+		}
+		seen[r.Id] = true
+	}
+	return rules, nil
+}
+
+// matches проверяет фильтры правила; win для серий не учитывается — там важны и проигрыши.
+func (r *Rule) matches(res *walletpb.GameResult, checkWin bool) bool {
+	if r.Game != "" && r.Game != res.Game {
+		return false
+	}
+	stake, payout := res.GetStake().GetAmount(), res.GetPayout().GetAmount()
+	if r.MinStake > 0 && stake < units(r.MinStake) {
+		return false
+	}
+	if checkWin && r.Win && payout <= stake {
+		return false
+	}
+	if r.Tag != "" {
+		for _, t := range res.Tags {
+			if t == r.Tag {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// evaluateAchievements двигает прогресс по всем правилам для одного
+// рассчитанного раунда. Дубли раундов сюда не доходят (см. RecordResults).
+func (s *server) evaluateAchievements(ctx context.Context, res *walletpb.GameResult) {
+	now := time.Now().UTC()
+	for i := range s.rules {
+		r := &s.rules[i]
+		var best int64
+		var err error
+		switch r.Kind {
+		case "count":
+			if !r.matches(res, true) {
+				continue
+			}
+			var doc *ProgressDoc
+			if doc, err = s.bumpProgress(ctx, res.UserId, r.Id, bson.M{"$inc": bson.M{"progress": 1, "best": 1}}); err == nil {
+				best = doc.Best
+			}
+		case "win_streak":
+			stake, payout := res.GetStake().GetAmount(), res.GetPayout().GetAmount()
+			if !r.matches(res, false) || payout == stake {
+				continue
+			}
+			if payout < stake {
+				_, err = s.bumpProgress(ctx, res.UserId, r.Id, bson.M{"$set": bson.M{"progress": 0}})
+				break
+			}
+			var doc *ProgressDoc
+			if doc, err = s.bumpProgress(ctx, res.UserId, r.Id, bson.M{"$inc": bson.M{"progress": 1}}); err == nil {
+				if doc, err = s.bumpProgress(ctx, res.UserId, r.Id, bson.M{"$max": bson.M{"best": doc.Progress}}); err == nil {
+					best = doc.Best
+				}
+			}
+		case "days_in_row":
+			if !r.matches(res, true) {
+				continue
+			}
+			best, err = s.bumpDays(ctx, res.UserId, r.Id, now)
+		}
+		if err != nil {
+			log.Printf("[achievements] %s/%s: %v", res.UserId, r.Id, err)
+			continue
+		}
+		if best >= r.Target {
+			s.unlock(ctx, res.UserId, r, now)
+		}
+	}
+}
+
+// bumpProgress применяет update к документу прогресса (создаёт при первом раунде)
+// и возвращает документ после изменения.
+func (s *server) bumpProgress(ctx context.Context, userId, ruleId string, update bson.M) (*ProgressDoc, error) {
+	var doc ProgressDoc
+	err := s.achievements.FindOneAndUpdate(ctx,
+		bson.M{"user_id": userId, "rule_id": ruleId},
+		update,
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)).Decode(&doc)
+	if err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+// bumpDays продлевает серию дней: вчера играл — +1, пропустил — заново с 1.
+func (s *server) bumpDays(ctx context.Context, userId, ruleId string, now time.Time) (int64, error) {
+	today := now.Format("20060102")
+	yesterday := now.AddDate(0, 0, -1).Format("20060102")
+
+	var doc ProgressDoc
+	err := s.achievements.FindOne(ctx, bson.M{"user_id": userId, "rule_id": ruleId}).Decode(&doc)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return 0, err
+	}
+	if doc.LastDay == today {
+		return doc.Best, nil
+	}
+	run := int64(1)
+	if doc.LastDay == yesterday {
+		run = doc.Progress + 1
+	}
+	best := doc.Best
+	if run > best {
+		best = run
+	}
+	// фильтр по last_day: параллельный раунд того же дня не продлит серию дважды
+	_, err = s.achievements.UpdateOne(ctx,
+		bson.M{"user_id": userId, "rule_id": ruleId, "last_day": doc.LastDay},
+		bson.M{"$set": bson.M{"progress": run, "best": best, "last_day": today}},
+		options.Update().SetUpsert(err == mongo.ErrNoDocuments))
+	if mongo.IsDuplicateKeyError(err) {
+		return doc.Best, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return best, nil
+}
+
+// unlock выдаёт достижение один раз: бонус зачисляет только тот, кто перевёл
+// unlocked в true. Бонус записывается в документ вместе с unlocked и снимается
+// после зачисления; не прошедший платёж повторяет retryAchievementBonuses.
+func (s *server) unlock(ctx context.Context, userId string, r *Rule, now time.Time) {
+	set := bson.M{"unlocked": true, "unlocked_at": now}
+	if r.Bonus > 0 {
+		set["bonus_pending"] = units(r.Bonus)
+	}
+	res, err := s.achievements.UpdateOne(ctx,
+		bson.M{"user_id": userId, "rule_id": r.Id, "unlocked": bson.M{"$ne": true}},
+		bson.M{"$set": set})
+	if err != nil {
+		log.Printf("[achievements] unlock %s/%s: %v", userId, r.Id, err)
+		return
+	}
+	if res.ModifiedCount == 0 {
+		return
+	}
+	log.Printf("[achievements] %s unlocked %s", userId, r.Id)
+	if r.Bonus > 0 {
+		s.payAchievementBonus(ctx, userId, r.Id, units(r.Bonus))
+	}
+}
+
+// payAchievementBonus зачисляет бонус под ключом achievement:<user>:<rule> —
+// повтор после сбоя второго бонуса не даст.
+func (s *server) payAchievementBonus(ctx context.Context, userId, ruleId string, amount int64) bool {
+	if _, err := s.UpdateBalance(ctx, &walletpb.WalletUpdateRequest{UserId: userId, Amount: money(amount, s.currency), Type: "bonus", Ref: "achievement:" + ruleId, IdempotencyKey: "achievement:" + userId + ":" + ruleId}); err != nil {
+		log.Printf("[achievements] bonus %d for %s/%s failed, will retry: %v", amount, userId, ruleId, err)
+		return false
+	}
+	if _, err := s.achievements.UpdateOne(ctx,
+		bson.M{"user_id": userId, "rule_id": ruleId},
+		bson.M{"$unset": bson.M{"bonus_pending": ""}}); err != nil {
+		// пометка осталась — следующий повтор получит тот же ответ по ключу
+		log.Printf("[achievements] bonus %s/%s paid, clear pending: %v", userId, ruleId, err)
+	}
+	return true
+}
+
+// retryAchievementBonuses раз в achBonusRetryEvery доплачивает бонусы
+// открытых достижений, которые не удалось зачислить сразу.
+func (s *server) retryAchievementBonuses(ctx context.Context) {
+	t := time.NewTicker(achBonusRetryEvery)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+		cur, err := s.achievements.Find(ctx,
+			bson.M{"bonus_pending": bson.M{"$gt": 0}},
+			options.Find().SetLimit(achBonusRetryBatch))
+		if err != nil {
+			log.Printf("[achievements] bonus retry find error: %v", err)
+			continue
+		}
+		var docs []ProgressDoc
+		if err := cur.All(ctx, &docs); err != nil {
+			log.Printf("[achievements] bonus retry decode error: %v", err)
+			continue
+		}
+		for _, d := range docs {
+			if !s.payAchievementBonus(ctx, d.UserId, d.RuleId, d.BonusPending) {
+				// кошелёк недоступен — ждём следующего такта
+				break
+			}
+		}
+	}
+}
+
+func (s *server) GetAchievements(ctx context.Context, req *walletpb.AchievementsRequest) (*walletpb.AchievementsResponse, error) {
+	cur, err := s.achievements.Find(ctx, bson.M{"user_id": req.UserId})
+	if err != nil {
+		log.Printf("[GetAchievements] mongo FIND error: %v", err)
+		return nil, err
+	}
+	var docs []ProgressDoc
+	if err := cur.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	byRule := make(map[string]ProgressDoc, len(docs))
+	for _, d := range docs {
+		byRule[d.RuleId] = d
+	}
+
+	resp := &walletpb.AchievementsResponse{}
+	for _, r := range s.rules {
+		d := byRule[r.Id]
+		a := &walletpb.Achievement{
+			Id:          r.Id,
+			Name:        r.Name,
+			Description: r.Description,
+			Target:      r.Target,
+			Progress:    d.Best,
+			Unlocked:    d.Unlocked,
+			Bonus:       money(units(r.Bonus), s.currency),
+		}
+		if a.Progress > r.Target {
+			a.Progress = r.Target
+		}
+		if d.Unlocked {
+			a.UnlockedAt = d.UnlockedAt.Unix()
+		}
+		resp.Achievements = append(resp.Achievements, a)
+	}
+	return resp, nil
+}
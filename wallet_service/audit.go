@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	walletpb "github.com/Arsencchikkk/final/casino/proto/wallet"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Журнал аудита раундов. Каждый рассчитанный раунд из RecordResults
+// дописывается в конец цепочки: запись хранит hash предыдущей, а её
+// собственный hash считается от всех полей вместе с prev_hash. Правка или
+// удаление любой записи ломает цепочку начиная с неё — это проверяет
+// cmd/auditverify. Записи только добавляются, seq идёт подряд с 1.
+type AuditDoc struct {
+	Seq      int64  `bson:"seq"`
+	PrevHash string `bson:"prev_hash"`
+	Hash     string `bson:"hash"`
+	UserId   string `bson:"user_id"`
+	Game     string `bson:"game"`
+	RoundId  string `bson:"round_id"`
+	Stake    int64  `bson:"stake"`
+	Payout   int64  `bson:"payout"`
+	// с переходом на минимальные единицы; у старых записей пусто, суммы в кредитах
+	Currency  string    `bson:"currency,omitempty"`
+	Tags      []string  `bson:"tags,omitempty"`
+	SettledAt time.Time `bson:"settled_at"`
+}
+
+const (
+	auditRoundIndex = "audit_round_unique"
+	auditRetries    = 5
+)
+
+// auditHash — sha256 от полей записи. Формат менять нельзя: по нему
+// проверяется уже записанная история (копия в cmd/auditverify). Валюта
+// дописывается в конец только у записей, где она есть, — старые хеши
+// остаются прежними.
+func auditHash(d AuditDoc) string {
+	line := fmt.Sprintf("%d\n%s\n%s\n%s\n%s\n%d\n%d\n%s\n%d",
+		d.Seq, d.PrevHash, d.UserId, d.Game, d.RoundId, d.Stake, d.Payout,
+		strings.Join(d.Tags, ","), d.SettledAt.UnixMilli())
+	if d.Currency != "" {
+		line += "\n" + d.Currency
+	}
+	sum := sha256.Sum256([]byte(line))
+	return hex.EncodeToString(sum[:])
+}
+
+func auditIndexes(ctx context.Context, col *mongo.Collection) error {
+	_, err := col.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "seq", Value: 1}}, Options: options.Index().SetUnique(true)},
+		// один раунд игрока попадает в журнал один раз
+		{
+			Keys:    bson.D{{Key: "game", Value: 1}, {Key: "round_id", Value: 1}, {Key: "user_id", Value: 1}},
+			Options: options.Index().SetUnique(true).SetName(auditRoundIndex),
+		},
+	})
+	return err
+}
+
+// appendAudit дописывает раунд в конец цепочки. Внутри процесса записи идут
+// по очереди; если параллельно пишет другой экземпляр сервиса, уникальный
+// seq не даст вставить две записи с одним номером — тогда перечитываем
+// хвост и пробуем снова. Повтор уже записанного раунда ничего не меняет.
+func (s *server) appendAudit(ctx context.Context, r *walletpb.GameResult) error {
+	s.auditMu.Lock()
+	defer s.auditMu.Unlock()
+
+	currency := r.GetStake().GetCurrency()
+	if currency == "" {
+		currency = s.currency
+	}
+	for i := 0; i < auditRetries; i++ {
+		var last AuditDoc
+		err := s.audit.FindOne(ctx, bson.M{}, options.FindOne().SetSort(bson.D{{Key: "seq", Value: -1}})).Decode(&last)
+		if err != nil && err != mongo.ErrNoDocuments {
+			return err
+		}
+		d := AuditDoc{
+			Seq:       last.Seq + 1,
+			PrevHash:  last.Hash,
+			UserId:    r.UserId,
+			Game:      r.Game,
+			RoundId:   r.RoundId,
+			Stake:     r.GetStake().GetAmount(),
+			Payout:    r.GetPayout().GetAmount(),
+			Currency:  currency,
+			Tags:      r.Tags,
+			SettledAt: time.Now().UTC().Truncate(time.Millisecond), // Mongo хранит миллисекунды
+		}
+		d.Hash = auditHash(d)
+		_, err = s.audit.InsertOne(ctx, d)
+		if err == nil {
+			return nil
+		}
+		if !mongo.IsDuplicateKeyError(err) {
+			return err
+		}
+		if strings.Contains(err.Error(), auditRoundIndex) {
+			return nil
+		}
+	}
+	return fmt.Errorf("audit: cannot append %s/%s after %d attempts", r.Game, r.RoundId, auditRetries)
+}
+
+func (s *server) ExportAudit(req *walletpb.AuditExportRequest, stream walletpb.WalletService_ExportAuditServer) error {
+	filter := bson.M{"seq": bson.M{"$gte": req.FromSeq}}
+	if req.ToSeq > 0 {
+		if req.ToSeq < req.FromSeq {
+			return fmt.Errorf("to_seq %d is before from_seq %d", req.ToSeq, req.FromSeq)
+		}
+		filter["seq"] = bson.M{"$gte": req.FromSeq, "$lte": req.ToSeq}
+	}
+	ctx := stream.Context()
+	cur, err := s.audit.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "seq", Value: 1}}))
+	if err != nil {
+		log.Printf("[ExportAudit] mongo Find error: %v", err)
+		return err
+	}
+	defer cur.Close(ctx)
+	for cur.Next(ctx) {
+		var d AuditDoc
+		if err := cur.Decode(&d); err != nil {
+			return err
+		}
+		if err := stream.Send(&walletpb.AuditEntry{
+			Seq:       d.Seq,
+			PrevHash:  d.PrevHash,
+			Hash:      d.Hash,
+			UserId:    d.UserId,
+			Game:      d.Game,
+			RoundId:   d.RoundId,
+			Stake:     &walletpb.Money{Amount: d.Stake, Currency: d.Currency},
+			Payout:    &walletpb.Money{Amount: d.Payout, Currency: d.Currency},
+			Tags:      d.Tags,
+			SettledAt: d.SettledAt.UnixMilli(),
+		}); err != nil {
+			return err
+		}
+	}
+	return cur.Err()
+}
@@ -0,0 +1,398 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"time"
+
+	walletpb "github.com/Arsencchikkk/final/casino/proto/wallet"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/protobuf/proto"
+)
+
+// Бонусы. Приветственный и reload-бонусы начисляются не в деньги, а на
+// бонусный баланс — документ в коллекции bonuses на каждый бонус, в журнал
+// проводок он не попадает: это ещё не деньги игрока. У бонуса условие
+// отыгрыша: поставить BONUS_WAGER_X его сумм за BONUS_TTL_DAYS.
+//
+// Ставка в основной валюте тратит сначала деньги, нехватку добирает из
+// самого старого активного бонуса; проводка bet в журнале — только денежная
+// часть. Как разделилась ставка, помнит bonus_bets (по игроку и ref раунда):
+// выигрыш и возврат того же раунда делятся в той же пропорции, и бонусная
+// доля возвращается на бонус, а не в деньги. Каждая ставка целиком идёт в
+// отыгрыш бонуса, из которого взята, или самого старого активного. Отыгранный
+// бонус разблокируется: остаток переходит в деньги проводкой bonus. Бонус,
+// не отыгранный к сроку, сгорает (sweepBonuses) вместе с остатком.
+//
+// Бай-ины игр против других игроков (cash_only) бонус не трогают вовсе:
+// иначе фишки, купленные на бонус, можно проиграть сообщнику и получить у
+// него деньгами.
+//
+// Всё это — внутри транзакции проводок (postIn), так что ставка, списание
+// бонуса и разблокировка проходят вместе или никак.
+const (
+	bonusSweepEvery = time.Minute
+	bonusSweepBatch = 100
+	// сколько помнить раздел ставки: дольше любого раунда и турнира
+	bonusBetTTL = 30 * 24 * time.Hour
+)
+
+type BonusDoc struct {
+	Id            string    `bson:"_id"`
+	UserId        string    `bson:"user_id"`
+	Kind          string    `bson:"kind"` // welcome, reload
+	Amount        int64     `bson:"amount"`
+	Balance       int64     `bson:"balance"`
+	Currency      string    `bson:"currency"`
+	WagerRequired int64     `bson:"wager_required"`
+	Wagered       int64     `bson:"wagered"`
+	Status        string    `bson:"status"` // active, unlocked, expired
+	Released      int64     `bson:"released,omitempty"`
+	Forfeited     int64     `bson:"forfeited,omitempty"`
+	IdemKey       string    `bson:"idem_key"`
+	ExpiresAt     time.Time `bson:"expires_at"`
+	CreatedAt     time.Time `bson:"created_at"`
+	ClosedAt      time.Time `bson:"closed_at,omitempty"`
+}
+
+// BonusBetDoc — раздел ставок раунда между деньгами и бонусом.
+type BonusBetDoc struct {
+	Id        string    `bson:"_id"` // user_id + ":" + ref
+	BonusId   string    `bson:"bonus_id"`
+	Cash      int64     `bson:"cash"`
+	Bonus     int64     `bson:"bonus"`
+	CreatedAt time.Time `bson:"created_at"`
+}
+
+func bonusIndexes(ctx context.Context, bonuses, bets *mongo.Collection) error {
+	if _, err := bonuses.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "status", Value: 1}, {Key: "created_at", Value: 1}}},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "expires_at", Value: 1}}},
+		{Keys: bson.D{{Key: "idem_key", Value: 1}}, Options: options.Index().SetUnique(true)},
+	}); err != nil {
+		return err
+	}
+	_, err := bets.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "created_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(int32(bonusBetTTL.Seconds())),
+	})
+	return err
+}
+
+func bonusToPb(b BonusDoc) *walletpb.Bonus {
+	out := &walletpb.Bonus{
+		BonusId:       b.Id,
+		Kind:          b.Kind,
+		Amount:        money(b.Amount, b.Currency),
+		Balance:       money(b.Balance, b.Currency),
+		WagerRequired: money(b.WagerRequired, b.Currency),
+		Wagered:       money(b.Wagered, b.Currency),
+		Status:        b.Status,
+		Released:      money(b.Released, b.Currency),
+		Forfeited:     money(b.Forfeited, b.Currency),
+		ExpiresAt:     b.ExpiresAt.UnixMilli(),
+		CreatedAt:     b.CreatedAt.UnixMilli(),
+	}
+	if !b.ClosedAt.IsZero() {
+		out.ClosedAt = b.ClosedAt.UnixMilli()
+	}
+	return out
+}
+
+func (s *server) GrantBonus(ctx context.Context, req *walletpb.GrantBonusRequest) (*walletpb.Bonus, error) {
+	fp := fingerprint("bonus", req.UserId, req.Kind, req.GetAmount().GetAmount(), req.GetAmount().GetCurrency())
+	resp, err := s.idempotent(ctx, req.IdempotencyKey, fp, &walletpb.Bonus{}, func() (proto.Message, error) {
+		b, err := s.grantBonus(ctx, req.UserId, req.Kind, req.GetAmount(), req.IdempotencyKey)
+		if err != nil {
+			return nil, err
+		}
+		return bonusToPb(b), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*walletpb.Bonus), nil
+}
+
+// grantBonus начисляет бонус; повтор с тем же ключом отдаёт уже начисленный.
+func (s *server) grantBonus(ctx context.Context, userId, kind string, amount *walletpb.Money, key string) (BonusDoc, error) {
+	if userId == "" || key == "" {
+		return BonusDoc{}, fmt.Errorf("user_id and idempotency_key required")
+	}
+	if isDemo(userId) {
+		return BonusDoc{}, fmt.Errorf("demo wallets have no bonuses")
+	}
+	if kind != "welcome" && kind != "reload" {
+		return BonusDoc{}, fmt.Errorf("unknown bonus kind %q", kind)
+	}
+	if c := amount.GetCurrency(); c != "" && c != s.currency {
+		return BonusDoc{}, fmt.Errorf("bonuses are in %s", s.currency)
+	}
+	if amount.GetAmount() <= 0 {
+		return BonusDoc{}, fmt.Errorf("amount must be positive")
+	}
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	b := BonusDoc{
+		Id:            primitive.NewObjectID().Hex(),
+		UserId:        userId,
+		Kind:          kind,
+		Amount:        amount.GetAmount(),
+		Balance:       amount.GetAmount(),
+		Currency:      s.currency,
+		WagerRequired: amount.GetAmount() * s.bonusWagerX,
+		Status:        "active",
+		IdemKey:       key,
+		ExpiresAt:     now.Add(s.bonusTTL),
+		CreatedAt:     now,
+	}
+	if _, err := s.bonuses.InsertOne(ctx, b); mongo.IsDuplicateKeyError(err) {
+		if err := s.bonuses.FindOne(ctx, bson.M{"idem_key": key}).Decode(&b); err != nil {
+			return b, err
+		}
+		return b, nil
+	} else if err != nil {
+		log.Printf("[GrantBonus] mongo InsertOne error: %v", err)
+		return b, err
+	}
+	log.Printf("[GrantBonus] %s bonus %s: %d for %s, wager %d by %s", kind, b.Id, b.Amount, userId, b.WagerRequired, b.ExpiresAt.Format(time.RFC3339))
+	return b, nil
+}
+
+// reloadBonus — бонус к завершённому пополнению: RELOAD_BONUS_PERCENT от
+// суммы, не больше RELOAD_BONUS_MAX. Только для основной валюты.
+func (s *server) reloadBonus(ctx context.Context, p PaymentDoc) {
+	if s.reloadPercent <= 0 || p.Currency != s.currency {
+		return
+	}
+	amount := p.Amount * s.reloadPercent / 100
+	if amount > s.reloadMax {
+		amount = s.reloadMax
+	}
+	if amount <= 0 {
+		return
+	}
+	if _, err := s.grantBonus(ctx, p.UserId, "reload", money(amount, s.currency), "payment:"+p.Id+":reload"); err != nil {
+		log.Printf("[payments] reload bonus for %s: %v", p.Id, err)
+	}
+}
+
+func (s *server) ListBonuses(ctx context.Context, req *walletpb.WalletRequest) (*walletpb.ListBonusesResponse, error) {
+	if req.UserId == "" {
+		return nil, fmt.Errorf("user_id required")
+	}
+	cur, err := s.bonuses.Find(ctx, bson.M{"user_id": req.UserId},
+		options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}).SetLimit(txDefaultLimit))
+	if err != nil {
+		return nil, err
+	}
+	var docs []BonusDoc
+	if err := cur.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	resp := &walletpb.ListBonusesResponse{}
+	var total int64
+	for _, b := range docs {
+		if b.Status == "active" {
+			total += b.Balance
+		}
+		resp.Bonuses = append(resp.Bonuses, bonusToPb(b))
+	}
+	resp.Balance = money(total, s.currency)
+	return resp, nil
+}
+
+// applyBonus — часть postIn: ставка добирает нехватку из бонуса и идёт в
+// отыгрыш, выигрыш и возврат отдают бонусу его долю. Меняет p.amount на
+// денежную часть и возвращает проводки разблокировки.
+func (s *server) applyBonus(sc mongo.SessionContext, p *posting) ([]posting, error) {
+	if p.currency != s.currency || isDemo(p.userId) {
+		return nil, nil
+	}
+	switch p.typ {
+	case "bet":
+		if p.amount >= 0 || p.cashOnly {
+			return nil, nil
+		}
+		return s.bonusBet(sc, p)
+	case "win", "refund":
+		if p.amount <= 0 || p.ref == "" {
+			return nil, nil
+		}
+		return nil, s.bonusReturn(sc, p)
+	}
+	return nil, nil
+}
+
+func (s *server) bonusBet(sc mongo.SessionContext, p *posting) ([]posting, error) {
+	stake := -p.amount
+	bonusId := ""
+	// списание удержанного: деньги уже отложены, бонус не тратится
+	if !p.held {
+		var w WalletDoc
+		if err := s.mongoCol.FindOne(sc, walletFilter(p.userId, p.currency)).Decode(&w); err != nil && err != mongo.ErrNoDocuments {
+			return nil, err
+		}
+		cash := w.Balance
+		if cash < 0 {
+			cash = 0
+		}
+		if cash > stake {
+			cash = stake
+		}
+		if fromBonus := stake - cash; fromBonus > 0 {
+			var b BonusDoc
+			err := s.bonuses.FindOneAndUpdate(sc,
+				bson.M{"user_id": p.userId, "status": "active", "balance": bson.M{"$gte": fromBonus}},
+				bson.M{"$inc": bson.M{"balance": -fromBonus}},
+				options.FindOneAndUpdate().SetSort(bson.D{{Key: "created_at", Value: 1}}),
+			).Decode(&b)
+			if err == mongo.ErrNoDocuments {
+				return nil, errInsufficientFunds
+			}
+			if err != nil {
+				return nil, err
+			}
+			bonusId = b.Id
+			p.amount = -cash
+		}
+		// раздел заводится ставкой, взявшей бонус; денежные ставки того же
+		// раунда (удвоение и т.п.) его только дополняют
+		if p.ref != "" {
+			if _, err := s.bonusBets.UpdateOne(sc,
+				bson.M{"_id": p.userId + ":" + p.ref},
+				bson.M{
+					"$inc":         bson.M{"cash": cash, "bonus": stake - cash},
+					"$setOnInsert": bson.M{"bonus_id": bonusId, "created_at": time.Now().UTC()},
+				},
+				options.Update().SetUpsert(bonusId != "")); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return s.wager(sc, p.userId, bonusId, stake)
+}
+
+// wager засчитывает ставку в отыгрыш и разблокирует отыгранный бонус.
+func (s *server) wager(sc mongo.SessionContext, userId, bonusId string, stake int64) ([]posting, error) {
+	filter := bson.M{"user_id": userId, "status": "active"}
+	if bonusId != "" {
+		filter["_id"] = bonusId
+	} else {
+		// опустевший бонус отыгрывать незачем
+		filter["balance"] = bson.M{"$gt": 0}
+	}
+	var b BonusDoc
+	err := s.bonuses.FindOneAndUpdate(sc, filter,
+		bson.M{"$inc": bson.M{"wagered": stake}},
+		options.FindOneAndUpdate().SetSort(bson.D{{Key: "created_at", Value: 1}}).SetReturnDocument(options.After),
+	).Decode(&b)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if b.Wagered < b.WagerRequired {
+		return nil, nil
+	}
+	if _, err := s.bonuses.UpdateOne(sc, bson.M{"_id": b.Id, "status": "active"}, bson.M{"$set": bson.M{
+		"status":    "unlocked",
+		"balance":   int64(0),
+		"released":  b.Balance,
+		"closed_at": time.Now().UTC(),
+	}}); err != nil {
+		return nil, err
+	}
+	log.Printf("[bonus] %s of %s unlocked, %d to cash", b.Id, b.UserId, b.Balance)
+	if b.Balance <= 0 {
+		return nil, nil
+	}
+	return []posting{{userId: b.UserId, currency: b.Currency, amount: b.Balance, typ: "bonus", ref: b.Id, key: "bonus:" + b.Id + ":unlock"}}, nil
+}
+
+// bonusReturn отдаёт бонусу его долю выигрыша или возврата раунда. Если
+// бонус уже разблокирован, доля остаётся деньгами, если сгорел — сгорает.
+// Возврат ставки ещё и снимает её из отыгрыша.
+func (s *server) bonusReturn(sc mongo.SessionContext, p *posting) error {
+	var split BonusBetDoc
+	if err := s.bonusBets.FindOne(sc, bson.M{"_id": p.userId + ":" + p.ref}).Decode(&split); err == mongo.ErrNoDocuments {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if split.BonusId == "" || split.Bonus <= 0 {
+		return nil
+	}
+	if p.typ == "refund" {
+		if _, err := s.bonuses.UpdateOne(sc, bson.M{"_id": split.BonusId, "status": "active"}, mongo.Pipeline{
+			{{Key: "$set", Value: bson.M{"wagered": bson.M{"$max": bson.A{0, bson.M{"$subtract": bson.A{"$wagered", p.amount}}}}}}},
+		}); err != nil {
+			return err
+		}
+	}
+	share := new(big.Int).Mul(big.NewInt(p.amount), big.NewInt(split.Bonus))
+	share.Quo(share, big.NewInt(split.Cash+split.Bonus))
+	if share.Sign() == 0 {
+		return nil
+	}
+	var b BonusDoc
+	err := s.bonuses.FindOneAndUpdate(sc, bson.M{"_id": split.BonusId, "status": "active"},
+		bson.M{"$inc": bson.M{"balance": share.Int64()}}).Decode(&b)
+	if err == mongo.ErrNoDocuments {
+		if err := s.bonuses.FindOne(sc, bson.M{"_id": split.BonusId}).Decode(&b); err != nil {
+			return err
+		}
+		if b.Status == "expired" {
+			p.amount -= share.Int64()
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	p.amount -= share.Int64()
+	return nil
+}
+
+// sweepBonuses сжигает бонусы, не отыгранные к сроку.
+func (s *server) sweepBonuses(ctx context.Context) {
+	t := time.NewTicker(bonusSweepEvery)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+		cur, err := s.bonuses.Find(ctx,
+			bson.M{"status": "active", "expires_at": bson.M{"$lt": time.Now()}},
+			options.Find().SetLimit(bonusSweepBatch))
+		if err != nil {
+			log.Printf("[bonus] sweep find error: %v", err)
+			continue
+		}
+		var expired []BonusDoc
+		if err := cur.All(ctx, &expired); err != nil {
+			log.Printf("[bonus] sweep decode error: %v", err)
+			continue
+		}
+		for _, b := range expired {
+			// баланс — из документа в момент обновления, ставка могла его поменять
+			res := s.bonuses.FindOneAndUpdate(ctx, bson.M{"_id": b.Id, "status": "active"}, mongo.Pipeline{
+				{{Key: "$set", Value: bson.M{"status": "expired", "forfeited": "$balance", "balance": int64(0), "closed_at": "$$NOW"}}},
+			}, options.FindOneAndUpdate().SetReturnDocument(options.After))
+			if err := res.Decode(&b); err != nil {
+				if err != mongo.ErrNoDocuments {
+					log.Printf("[bonus] expire %s: %v", b.Id, err)
+				}
+				continue
+			}
+			log.Printf("[bonus] %s of %s expired, %d forfeited (wagered %d of %d)", b.Id, b.UserId, b.Forfeited, b.Wagered, b.WagerRequired)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Кеш балансов. Подкошелёк лежит в Redis одним хешем wallet:<user>:<currency>
+// с полями balance, held и version — версией снимка из Mongo: она растёт на
+// каждой записи кошелька (см. touch). Писать в кеш можно только снимок с
+// версией новее закешированной, это проверяет скрипт на стороне Redis. Так
+// запоздавшая запись — после ответа Mongo гонка между запросами никуда не
+// делась — не затирает свежую: какой бы ни пришла последней, в кеше
+// остаётся большая версия.
+//
+// Промах GetBalance перечитывает Mongo одним запросом на ключ (single-flight),
+// сколько бы чтений ни ждало. Если Redis недоступен и снимок записать не
+// удалось, в кеше может остаться старый: ключ помечается устаревшим, и этот
+// процесс читает баланс из Mongo, пока в кеш не ляжет версия не старше
+// несостоявшейся.
+
+const cacheTTL = 5 * time.Minute
+
+// walletKey — ключ кеша подкошелька.
+func walletKey(userId, currency string) string {
+	return "wallet:" + userId + ":" + currency
+}
+
+// casWallet записывает снимок, если в кеше нет версии новее, и возвращает
+// версию, которая в кеше в итоге.
+var casWallet = redis.NewScript(`
+local cur = redis.call('HGET', KEYS[1], 'version')
+if cur and tonumber(cur) >= tonumber(ARGV[3]) then
+	return tonumber(cur)
+end
+redis.call('HSET', KEYS[1], 'balance', ARGV[1], 'held', ARGV[2], 'version', ARGV[3])
+redis.call('PEXPIRE', KEYS[1], ARGV[4])
+return tonumber(ARGV[3])
+`)
+
+// cacheWallet кладёт в кеш снимок подкошелька, если он новее закешированного.
+func (s *server) cacheWallet(ctx context.Context, w WalletDoc) {
+	key := walletKey(w.UserId, w.Currency)
+	cur, err := casWallet.Run(ctx, s.redis, []string{key}, w.Balance, w.Held, w.Version, cacheTTL.Milliseconds()).Int64()
+	if err != nil {
+		log.Printf("[cache] %s v%d: redis error: %v", key, w.Version, err)
+		s.markStale(key, w.Version)
+		return
+	}
+	s.clearStale(key, cur)
+}
+
+// cachePostings обновляет кеш подкошельков после проводок.
+func (s *server) cachePostings(ctx context.Context, txs []TxDoc) {
+	for _, tx := range txs {
+		if tx.Wallet.UserId != "" {
+			s.cacheWallet(ctx, tx.Wallet)
+		}
+	}
+}
+
+// cachedWallet — снимок из кеша; false, если его там нет, он неполный или
+// ключ помечен устаревшим.
+func (s *server) cachedWallet(ctx context.Context, key string) (WalletDoc, bool) {
+	if s.isStale(key) {
+		return WalletDoc{}, false
+	}
+	vals, err := s.redis.HMGet(ctx, key, "balance", "held", "version").Result()
+	if err != nil {
+		log.Printf("[cache] %s: redis HMGET error: %v", key, err)
+		return WalletDoc{}, false
+	}
+	var n [3]int64
+	for i, v := range vals {
+		if v == nil {
+			return WalletDoc{}, false
+		}
+		if n[i], err = strconv.ParseInt(fmt.Sprint(v), 10, 64); err != nil {
+			return WalletDoc{}, false
+		}
+	}
+	return WalletDoc{Balance: n[0], Held: n[1], Version: n[2]}, true
+}
+
+// refillWallet читает снимок через load и кладёт его в кеш; одновременные
+// промахи по одному ключу ждут одно чтение.
+func (s *server) refillWallet(ctx context.Context, key string, load func(ctx context.Context) (WalletDoc, error)) (WalletDoc, error) {
+	v, err, _ := s.refill.Do(key, func() (interface{}, error) {
+		w, err := load(ctx)
+		if err != nil {
+			return nil, err
+		}
+		s.cacheWallet(ctx, w)
+		return w, nil
+	})
+	if err != nil {
+		return WalletDoc{}, err
+	}
+	return v.(WalletDoc), nil
+}
+
+// dropWallet убирает подкошелёк из кеша (кошелька больше нет).
+func (s *server) dropWallet(ctx context.Context, key string) {
+	if err := s.redis.Del(ctx, key).Err(); err != nil {
+		log.Printf("[cache] %s: redis DEL error: %v", key, err)
+	}
+}
+
+// markStale запоминает версию, которую не удалось записать в кеш.
+func (s *server) markStale(key string, version int64) {
+	s.staleMu.Lock()
+	defer s.staleMu.Unlock()
+	if s.stale == nil {
+		s.stale = make(map[string]int64)
+	}
+	if v, ok := s.stale[key]; !ok || v < version {
+		s.stale[key] = version
+	}
+}
+
+// clearStale снимает пометку, если в кеше уже версия не старше помеченной.
+func (s *server) clearStale(key string, cached int64) {
+	s.staleMu.Lock()
+	defer s.staleMu.Unlock()
+	if v, ok := s.stale[key]; ok && v <= cached {
+		delete(s.stale, key)
+	}
+}
+
+func (s *server) isStale(key string) bool {
+	s.staleMu.Lock()
+	defer s.staleMu.Unlock()
+	_, ok := s.stale[key]
+	return ok
+}
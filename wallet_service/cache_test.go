@@ -0,0 +1,208 @@
+package main
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+)
+
+func cacheServer(t *testing.T) (*server, *miniredis.Miniredis) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	return &server{redis: redis.NewClient(&redis.Options{Addr: mr.Addr()})}, mr
+}
+
+func snapshot(version int64) WalletDoc {
+	return WalletDoc{UserId: "u1", Currency: "USD", Balance: version * 100, Held: version, Version: version}
+}
+
+func mustCached(t *testing.T, s *server, want WalletDoc) {
+	t.Helper()
+	got, ok := s.cachedWallet(context.Background(), walletKey(want.UserId, want.Currency))
+	if !ok {
+		t.Fatalf("cache miss, want v%d", want.Version)
+	}
+	if got.Balance != want.Balance || got.Held != want.Held || got.Version != want.Version {
+		t.Fatalf("cached %+v, want balance=%d held=%d v%d", got, want.Balance, want.Held, want.Version)
+	}
+}
+
+// снимки приходят в кеш в любом порядке: остаётся самый новый
+func TestCacheWalletOutOfOrder(t *testing.T) {
+	s, _ := cacheServer(t)
+	const versions = 200
+	order := rand.Perm(versions)
+	var wg sync.WaitGroup
+	for _, v := range order {
+		wg.Add(1)
+		go func(v int64) {
+			defer wg.Done()
+			s.cacheWallet(context.Background(), snapshot(v))
+		}(int64(v) + 1)
+	}
+	wg.Wait()
+	mustCached(t, s, snapshot(versions))
+}
+
+// запоздавшая запись старого снимка не затирает свежий
+func TestCacheWalletLateWriter(t *testing.T) {
+	s, _ := cacheServer(t)
+	ctx := context.Background()
+	s.cacheWallet(ctx, snapshot(5))
+	s.cacheWallet(ctx, snapshot(4))
+	mustCached(t, s, snapshot(5))
+	s.cacheWallet(ctx, snapshot(6))
+	mustCached(t, s, snapshot(6))
+}
+
+// промахи по одному ключу ждут одно чтение
+func TestRefillSingleFlight(t *testing.T) {
+	s, _ := cacheServer(t)
+	var loads int32
+	release := make(chan struct{})
+	load := func(ctx context.Context) (WalletDoc, error) {
+		atomic.AddInt32(&loads, 1)
+		<-release
+		return snapshot(3), nil
+	}
+
+	const callers = 32
+	var wg sync.WaitGroup
+	got := make([]WalletDoc, callers)
+	errs := make([]error, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			got[i], errs[i] = s.refillWallet(context.Background(), walletKey("u1", "USD"), load)
+		}(i)
+	}
+	// даём всем горутинам встать в ожидание
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if loads != 1 {
+		t.Fatalf("loaded %d times, want 1", loads)
+	}
+	for i := range got {
+		if errs[i] != nil {
+			t.Fatalf("caller %d: %v", i, errs[i])
+		}
+		if got[i].Version != 3 {
+			t.Fatalf("caller %d got v%d, want v3", i, got[i].Version)
+		}
+	}
+	mustCached(t, s, snapshot(3))
+}
+
+// перечитывание, начатое до записи, кладёт старый снимок уже после неё —
+// в кеше остаётся записанный
+func TestRefillRacesWriter(t *testing.T) {
+	s, _ := cacheServer(t)
+	ctx := context.Background()
+	read := make(chan struct{})
+	written := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.refillWallet(ctx, walletKey("u1", "USD"), func(ctx context.Context) (WalletDoc, error) {
+			close(read)
+			<-written
+			return snapshot(7), nil
+		})
+	}()
+	<-read
+	s.cacheWallet(ctx, snapshot(8))
+	close(written)
+	<-done
+	mustCached(t, s, snapshot(8))
+}
+
+// Redis не принял свежий снимок: старый в кеше не читается, пока не ляжет
+// версия не старше несостоявшейся
+func TestCacheStaleAfterRedisError(t *testing.T) {
+	s, mr := cacheServer(t)
+	ctx := context.Background()
+	key := walletKey("u1", "USD")
+	s.cacheWallet(ctx, snapshot(4))
+
+	mr.SetError("LOADING Redis is loading the dataset in memory")
+	s.cacheWallet(ctx, snapshot(5))
+	mr.SetError("")
+
+	if w, ok := s.cachedWallet(ctx, key); ok {
+		t.Fatalf("stale v%d served from the cache", w.Version)
+	}
+	// старый снимок, пришедший позже, пометку не снимает
+	s.cacheWallet(ctx, snapshot(4))
+	if _, ok := s.cachedWallet(ctx, key); ok {
+		t.Fatal("stale mark cleared by an older snapshot")
+	}
+	// перечитанный из Mongo снимок — снимает
+	if _, err := s.refillWallet(ctx, key, func(ctx context.Context) (WalletDoc, error) {
+		return snapshot(5), nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	mustCached(t, s, snapshot(5))
+}
+
+// конкурентные записи и чтения: читатель никогда не видит версию меньше
+// уже виденной
+func TestCacheReadersMonotonic(t *testing.T) {
+	s, _ := cacheServer(t)
+	ctx := context.Background()
+	key := walletKey("u1", "USD")
+	const writers, perWriter = 8, 50
+	var next int64
+	var wg sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < perWriter; j++ {
+				s.cacheWallet(ctx, snapshot(atomic.AddInt64(&next, 1)))
+			}
+		}()
+	}
+	stop := make(chan struct{})
+	var readers sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		readers.Add(1)
+		go func() {
+			defer readers.Done()
+			var seen int64
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				w, ok := s.cachedWallet(ctx, key)
+				if !ok {
+					continue
+				}
+				if w.Version < seen {
+					t.Errorf("read v%d after v%d", w.Version, seen)
+					return
+				}
+				if w.Balance != w.Version*100 || w.Held != w.Version {
+					t.Errorf("torn snapshot %+v", w)
+					return
+				}
+				seen = w.Version
+			}
+		}()
+	}
+	wg.Wait()
+	close(stop)
+	readers.Wait()
+	mustCached(t, s, snapshot(writers*perWriter))
+}
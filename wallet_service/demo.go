@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	walletpb "github.com/Arsencchikkk/final/casino/proto/wallet"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Демо-кошельки. user_id с префиксом "demo:" живут в своей коллекции, поэтому
+// любая игра, списывающая и начисляющая по user_id, работает с демо-балансом
+// без изменений, а настоящих кошельков он не касается. Демо-кошелёк удаляется
+// TTL-индексом после DEMO_WALLET_TTL_HOURS без игры и в реальные деньги не
+// переводится никогда.
+const demoPrefix = "demo:"
+
+func isDemo(userId string) bool {
+	return strings.HasPrefix(userId, demoPrefix)
+}
+
+// wallets — коллекция, в которой живёт кошелёк пользователя.
+func (s *server) wallets(userId string) *mongo.Collection {
+	if isDemo(userId) {
+		return s.demoCol
+	}
+	return s.mongoCol
+}
+
+// touch отмечает запись кошелька: растит версию снимка (по ней кеш отличает
+// свежий снимок от запоздавшего), а демо-кошельку ещё и продлевает жизнь.
+func touch(userId string, update bson.M) bson.M {
+	inc, _ := update["$inc"].(bson.M)
+	if inc == nil {
+		inc = bson.M{}
+		update["$inc"] = inc
+	}
+	inc["version"] = 1
+	if isDemo(userId) {
+		set, _ := update["$set"].(bson.M)
+		if set == nil {
+			set = bson.M{}
+			update["$set"] = set
+		}
+		set["updated_at"] = time.Now()
+	}
+	return update
+}
+
+// recacheDemo перечитывает демо-кошельки после пакетной записи и кладёт в кеш.
+func (s *server) recacheDemo(ctx context.Context, userIds []string) {
+	cur, err := s.demoCol.Find(ctx, bson.M{"user_id": bson.M{"$in": userIds}, "currency": s.currency})
+	if err == nil {
+		var docs []WalletDoc
+		if err = cur.All(ctx, &docs); err == nil {
+			for _, w := range docs {
+				s.cacheWallet(ctx, w)
+			}
+			return
+		}
+	}
+	// снимков нет — хотя бы сбрасываем старые
+	log.Printf("[BatchUpdateBalance] demo re-read error: %v", err)
+	for _, uid := range userIds {
+		s.dropWallet(ctx, walletKey(uid, s.currency))
+	}
+}
+
+// StartDemo заводит демо-кошелёк со стартовыми кредитами. Повторный вызов
+// баланс не пополняет.
+func (s *server) StartDemo(ctx context.Context, req *walletpb.DemoRequest) (*walletpb.DemoResponse, error) {
+	if !isDemo(req.UserId) {
+		return nil, fmt.Errorf("demo wallets need a %q user id", demoPrefix)
+	}
+	doc := WalletDoc{UserId: req.UserId, Balance: s.demoBalance, Currency: s.currency, UpdatedAt: time.Now()}
+	if _, err := s.demoCol.InsertOne(ctx, doc); err != nil {
+		if !mongo.IsDuplicateKeyError(err) {
+			log.Printf("[StartDemo] mongo InsertOne error: %v", err)
+			return nil, err
+		}
+		wr, err := s.GetBalance(ctx, &walletpb.WalletRequest{UserId: req.UserId})
+		if err != nil {
+			return nil, err
+		}
+		return &walletpb.DemoResponse{UserId: req.UserId, Balance: wr.Balance}, nil
+	}
+	log.Printf("[StartDemo] %s with %d demo %s", req.UserId, s.demoBalance, s.currency)
+	return &walletpb.DemoResponse{UserId: req.UserId, Balance: money(doc.Balance, s.currency)}, nil
+}
+
+// EndDemo удаляет демо-кошелёк (например, при переходе на настоящий аккаунт):
+// демо-баланс никуда не переносится.
+func (s *server) EndDemo(ctx context.Context, req *walletpb.DemoRequest) (*walletpb.DemoResponse, error) {
+	if !isDemo(req.UserId) {
+		return nil, fmt.Errorf("%s is not a demo wallet", req.UserId)
+	}
+	if _, err := s.demoCol.DeleteOne(ctx, bson.M{"user_id": req.UserId}); err != nil {
+		log.Printf("[EndDemo] mongo DeleteOne error: %v", err)
+		return nil, err
+	}
+	s.dropWallet(ctx, walletKey(req.UserId, s.currency))
+	return &walletpb.DemoResponse{UserId: req.UserId}, nil
+}
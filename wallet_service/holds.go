@@ -0,0 +1,275 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	walletpb "github.com/Arsencchikkk/final/casino/proto/wallet"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/protobuf/proto"
+)
+
+// Удержания ставок. Reserve переносит сумму из balance в held (деньги ещё
+// игрока, но поставить их второй раз нельзя), Capture списывает удержанное
+// проводкой bet и возвращает остаток, Release возвращает всё. В журнал
+// попадает только списание: пока деньги удержаны, они не двигаются.
+//
+// У удержания есть срок: брошенные раунды отпускает sweepHolds.
+const (
+	holdDefaultTTL = 5 * time.Minute
+	holdMaxTTL     = time.Hour
+	holdSweepEvery = 30 * time.Second
+	holdSweepBatch = 100
+)
+
+type HoldDoc struct {
+	Id         string    `bson:"_id"`
+	UserId     string    `bson:"user_id"`
+	RoundId    string    `bson:"round_id,omitempty"`
+	Amount     int64     `bson:"amount"`
+	Currency   string    `bson:"currency"`
+	Status     string    `bson:"status"` // held, captured, released, expired
+	Captured   int64     `bson:"captured,omitempty"`
+	TxId       string    `bson:"tx_id,omitempty"`
+	ExpiresAt  time.Time `bson:"expires_at"`
+	CreatedAt  time.Time `bson:"created_at"`
+	ResolvedAt time.Time `bson:"resolved_at,omitempty"`
+}
+
+func holdIndexes(ctx context.Context, col *mongo.Collection) error {
+	_, err := col.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "expires_at", Value: 1}}},
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "status", Value: 1}}},
+	})
+	return err
+}
+
+func holdToPb(h HoldDoc) *walletpb.Hold {
+	return &walletpb.Hold{
+		HoldId:    h.Id,
+		UserId:    h.UserId,
+		RoundId:   h.RoundId,
+		Amount:    &walletpb.Money{Amount: h.Amount, Currency: h.Currency},
+		Status:    h.Status,
+		Captured:  &walletpb.Money{Amount: h.Captured, Currency: h.Currency},
+		ExpiresAt: h.ExpiresAt.UnixMilli(),
+		TxId:      h.TxId,
+	}
+}
+
+// inTx выполняет fn в транзакции Mongo.
+func (s *server) inTx(ctx context.Context, fn func(sc mongo.SessionContext) (interface{}, error)) (interface{}, error) {
+	sess, err := s.mongoCol.Database().Client().StartSession()
+	if err != nil {
+		return nil, err
+	}
+	defer sess.EndSession(ctx)
+	return sess.WithTransaction(ctx, fn)
+}
+
+func (s *server) Reserve(ctx context.Context, req *walletpb.ReserveRequest) (*walletpb.HoldResponse, error) {
+	fp := fingerprint("reserve", req.UserId, req.GetAmount().GetAmount(), req.GetAmount().GetCurrency(), req.RoundId, req.TtlSeconds)
+	resp, err := s.idempotent(ctx, req.IdempotencyKey, fp, &walletpb.HoldResponse{}, func() (proto.Message, error) {
+		return s.reserve(ctx, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*walletpb.HoldResponse), nil
+}
+
+func (s *server) reserve(ctx context.Context, req *walletpb.ReserveRequest) (*walletpb.HoldResponse, error) {
+	if req.UserId == "" {
+		return nil, fmt.Errorf("user_id required")
+	}
+	amount, currency, err := s.amountOf(req.UserId, req.Amount)
+	if err != nil {
+		return nil, err
+	}
+	if amount <= 0 {
+		return nil, fmt.Errorf("amount must be positive")
+	}
+	ttl := holdDefaultTTL
+	if req.TtlSeconds > 0 {
+		ttl = time.Duration(req.TtlSeconds) * time.Second
+	}
+	if ttl > holdMaxTTL {
+		return nil, fmt.Errorf("hold ttl is limited to %s", holdMaxTTL)
+	}
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	h := HoldDoc{
+		Id:        primitive.NewObjectID().Hex(),
+		UserId:    req.UserId,
+		RoundId:   req.RoundId,
+		Amount:    amount,
+		Currency:  currency,
+		Status:    "held",
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+	}
+	out, err := s.inTx(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		// как и списание: баланс проверяется в том же обновлении
+		filter := walletFilter(req.UserId, currency)
+		filter["balance"] = bson.M{"$gte": amount}
+		var w WalletDoc
+		err := s.wallets(req.UserId).FindOneAndUpdate(sc,
+			filter,
+			touch(req.UserId, bson.M{"$inc": bson.M{"balance": -amount, "held": amount}}),
+			options.FindOneAndUpdate().SetReturnDocument(options.After),
+		).Decode(&w)
+		if err == mongo.ErrNoDocuments {
+			return nil, errInsufficientFunds
+		}
+		if err != nil {
+			return nil, err
+		}
+		if _, err := s.holds.InsertOne(sc, h); err != nil {
+			return nil, err
+		}
+		return w, nil
+	})
+	if err != nil {
+		log.Printf("[Reserve] %s %d %s: %v", req.UserId, amount, currency, err)
+		return nil, err
+	}
+	w := out.(WalletDoc)
+	s.cacheWallet(ctx, w)
+	log.Printf("[Reserve] hold %s: %d for %s round %s until %s", h.Id, h.Amount, h.UserId, h.RoundId, h.ExpiresAt.Format(time.RFC3339))
+	return &walletpb.HoldResponse{Hold: holdToPb(h), Balance: money(w.Balance, currency), Held: money(w.Held, currency)}, nil
+}
+
+func (s *server) Capture(ctx context.Context, req *walletpb.CaptureRequest) (*walletpb.HoldResponse, error) {
+	if req.GetAmount().GetAmount() < 0 {
+		return nil, fmt.Errorf("amount must not be negative")
+	}
+	return s.resolveHold(ctx, req.HoldId, "captured", req.GetAmount())
+}
+
+func (s *server) Release(ctx context.Context, req *walletpb.ReleaseRequest) (*walletpb.HoldResponse, error) {
+	return s.resolveHold(ctx, req.HoldId, "released", nil)
+}
+
+// resolveHold закрывает удержание: amount списывается (пусто при captured —
+// всё), остальное возвращается в доступный баланс. Повторный вызов с тем же
+// итогом отдаёт уже закрытое удержание, так что Capture/Release можно повторять.
+func (s *server) resolveHold(ctx context.Context, holdId, status string, amount *walletpb.Money) (*walletpb.HoldResponse, error) {
+	if holdId == "" {
+		return nil, fmt.Errorf("hold_id required")
+	}
+	var w WalletDoc
+	out, err := s.inTx(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		var h HoldDoc
+		if err := s.holds.FindOne(sc, bson.M{"_id": holdId}).Decode(&h); err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("hold not found")
+		} else if err != nil {
+			return nil, err
+		}
+		if h.Status != "held" {
+			if h.Status == status {
+				if err := s.wallets(h.UserId).FindOne(sc, walletFilter(h.UserId, h.Currency)).Decode(&w); err != nil {
+					return nil, err
+				}
+				return &walletpb.HoldResponse{Hold: holdToPb(h), Balance: money(w.Balance, h.Currency), Held: money(w.Held, h.Currency)}, nil
+			}
+			return nil, fmt.Errorf("hold is already %s", h.Status)
+		}
+		// списывается в валюте удержания
+		if c := amount.GetCurrency(); c != "" && c != h.Currency {
+			return nil, fmt.Errorf("hold is in %s, not %s", h.Currency, c)
+		}
+		capture := amount.GetAmount()
+		if status == "captured" && capture == 0 {
+			capture = h.Amount
+		}
+		if capture > h.Amount {
+			return nil, fmt.Errorf("cannot capture %d, only %d is held", capture, h.Amount)
+		}
+
+		// остаток — обратно в доступное, без проводки: деньги не двигались
+		if rest := h.Amount - capture; rest > 0 {
+			if _, err := s.wallets(h.UserId).UpdateOne(sc, walletFilter(h.UserId, h.Currency),
+				touch(h.UserId, bson.M{"$inc": bson.M{"balance": rest, "held": -rest}})); err != nil {
+				return nil, err
+			}
+		}
+		if capture > 0 {
+			if isDemo(h.UserId) {
+				if _, err := s.demoCol.UpdateOne(sc, walletFilter(h.UserId, h.Currency),
+					touch(h.UserId, bson.M{"$inc": bson.M{"held": -capture}})); err != nil {
+					return nil, err
+				}
+			} else {
+				txs, err := s.postIn(sc, []posting{{
+					userId:   h.UserId,
+					currency: h.Currency,
+					amount:   -capture,
+					typ:      "bet",
+					ref:      h.RoundId,
+					key:      "hold:" + h.Id + ":capture",
+					held:     true,
+				}})
+				if err != nil {
+					return nil, err
+				}
+				h.TxId = txs[0].Id
+			}
+		}
+
+		h.Status, h.Captured, h.ResolvedAt = status, capture, time.Now().UTC()
+		if _, err := s.holds.UpdateOne(sc, bson.M{"_id": h.Id, "status": "held"}, bson.M{"$set": bson.M{
+			"status":      h.Status,
+			"captured":    h.Captured,
+			"tx_id":       h.TxId,
+			"resolved_at": h.ResolvedAt,
+		}}); err != nil {
+			return nil, err
+		}
+		if err := s.wallets(h.UserId).FindOne(sc, walletFilter(h.UserId, h.Currency)).Decode(&w); err != nil {
+			return nil, err
+		}
+		return &walletpb.HoldResponse{Hold: holdToPb(h), Balance: money(w.Balance, h.Currency), Held: money(w.Held, h.Currency)}, nil
+	})
+	if err != nil {
+		log.Printf("[holds] %s -> %s: %v", holdId, status, err)
+		return nil, err
+	}
+	s.cacheWallet(ctx, w)
+	return out.(*walletpb.HoldResponse), nil
+}
+
+// sweepHolds отпускает удержания брошенных раундов, у которых вышел срок.
+func (s *server) sweepHolds(ctx context.Context) {
+	t := time.NewTicker(holdSweepEvery)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+		cur, err := s.holds.Find(ctx,
+			bson.M{"status": "held", "expires_at": bson.M{"$lt": time.Now()}},
+			options.Find().SetLimit(holdSweepBatch))
+		if err != nil {
+			log.Printf("[holds] sweep find error: %v", err)
+			continue
+		}
+		var expired []HoldDoc
+		if err := cur.All(ctx, &expired); err != nil {
+			log.Printf("[holds] sweep decode error: %v", err)
+			continue
+		}
+		for _, h := range expired {
+			if _, err := s.resolveHold(ctx, h.Id, "expired", nil); err != nil {
+				continue
+			}
+			log.Printf("[holds] hold %s of %s (round %s) expired, %d released", h.Id, h.UserId, h.RoundId, h.Amount)
+		}
+	}
+}
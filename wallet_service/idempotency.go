@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"google.golang.org/protobuf/proto"
+)
+
+// Ключи идемпотентности. Каждое изменение баланса приходит с ключом; первый
+// запрос с ключом занимает его в Redis (SETNX "pending"), выполняется и
+// сохраняет ответ, повторы получают сохранённый ответ. Пока первый запрос
+// ещё выполняется, повтор ждёт его результата.
+//
+// Redis — быстрый путь. Настоящий кошелёк защищён ещё и журналом: ключ пишется
+// в проводку под уникальным индексом, так что даже если запись в Redis
+// потерялась, второй раз деньги не проведутся.
+//
+// Значение в Redis: "pending|<отпечаток>" или "done|<отпечаток>|<ответ в hex>".
+// Отпечаток — суть запроса: тот же ключ с другой суммой — ошибка клиента.
+const (
+	idemPrefix     = "idem:"
+	idemPendingTTL = 30 * time.Second
+	idemWait       = 5 * time.Second
+	idemPoll       = 20 * time.Millisecond
+)
+
+var errIdemInProgress = fmt.Errorf("a request with this idempotency key is still in progress, retry later")
+
+// fingerprint — sha256 от частей запроса. Каждая часть кодируется в JSON
+// отдельно, так что ("a", "bc") и ("ab", "c") дают разные отпечатки.
+func fingerprint(parts ...interface{}) string {
+	h := sha256.New()
+	enc := json.NewEncoder(h)
+	for _, p := range parts {
+		if err := enc.Encode(p); err != nil {
+			// сюда попадают только строки и числа — кодируем как есть
+			fmt.Fprintf(h, "%T:%v\n", p, p)
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// idempotent выполняет run один раз на ключ. empty — пустое сообщение того же
+// типа, что возвращает run: в него разбирается сохранённый ответ.
+func (s *server) idempotent(ctx context.Context, key, fp string, empty proto.Message, run func() (proto.Message, error)) (proto.Message, error) {
+	if key == "" {
+		return nil, fmt.Errorf("idempotency_key required")
+	}
+	rkey := idemPrefix + key
+	deadline := time.Now().Add(idemWait)
+	for {
+		ok, err := s.redis.SetNX(ctx, rkey, "pending|"+fp, idemPendingTTL).Result()
+		if err != nil {
+			// без Redis повтор отсечёт только журнал — выполняем как есть
+			log.Printf("[idempotency] redis SETNX %s: %v", key, err)
+			return run()
+		}
+		if ok {
+			return s.idemRun(ctx, rkey, fp, run)
+		}
+
+		val, err := s.redis.Get(ctx, rkey).Result()
+		if err == redis.Nil {
+			// первый запрос не удался и отпустил ключ — пробуем сами
+			continue
+		}
+		if err != nil {
+			log.Printf("[idempotency] redis GET %s: %v", key, err)
+			return run()
+		}
+		parts := strings.SplitN(val, "|", 3)
+		if len(parts) < 2 || parts[1] != fp {
+			return nil, fmt.Errorf("idempotency key %q was already used for a different request", key)
+		}
+		if parts[0] == "done" && len(parts) == 3 {
+			raw, err := hex.DecodeString(parts[2])
+			if err != nil {
+				return nil, err
+			}
+			if err := proto.Unmarshal(raw, empty); err != nil {
+				return nil, err
+			}
+			return empty, nil
+		}
+		if time.Now().After(deadline) {
+			return nil, errIdemInProgress
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(idemPoll):
+		}
+	}
+}
+
+func (s *server) idemRun(ctx context.Context, rkey, fp string, run func() (proto.Message, error)) (proto.Message, error) {
+	resp, err := run()
+	if err != nil {
+		// ничего не сохранилось — ключ свободен для повтора
+		if derr := s.redis.Del(context.Background(), rkey).Err(); derr != nil {
+			log.Printf("[idempotency] redis DEL %s: %v", rkey, derr)
+		}
+		return nil, err
+	}
+	raw, err := proto.Marshal(resp)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.redis.Set(context.Background(), rkey, "done|"+fp+"|"+hex.EncodeToString(raw), s.idemTTL).Err(); err != nil {
+		log.Printf("[idempotency] redis SET %s: %v", rkey, err)
+	}
+	return resp, nil
+}
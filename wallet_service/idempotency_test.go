@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"google.golang.org/protobuf/proto"
+
+	walletpb "github.com/Arsencchikkk/final/casino/proto/wallet"
+)
+
+func idemServer(t *testing.T) *server {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	return &server{
+		redis:   redis.NewClient(&redis.Options{Addr: mr.Addr()}),
+		idemTTL: time.Hour,
+	}
+}
+
+// один ключ из многих горутин: операция выполняется один раз, ответы одинаковые
+func TestIdempotentConcurrent(t *testing.T) {
+	s := idemServer(t)
+	var runs int32
+	run := func() (proto.Message, error) {
+		n := atomic.AddInt32(&runs, 1)
+		time.Sleep(50 * time.Millisecond)
+		return &walletpb.WalletUpdateResponse{NewBalance: &walletpb.Money{Amount: int64(100 * n)}, TxId: fmt.Sprint("tx", n)}, nil
+	}
+	fp := fingerprint("update", "u1", int64(100), "USD", "win", "r1")
+
+	const callers = 32
+	var wg sync.WaitGroup
+	resps := make([]*walletpb.WalletUpdateResponse, callers)
+	errs := make([]error, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			resp, err := s.idempotent(context.Background(), "k1", fp, &walletpb.WalletUpdateResponse{}, run)
+			if err == nil {
+				resps[i] = resp.(*walletpb.WalletUpdateResponse)
+			}
+			errs[i] = err
+		}(i)
+	}
+	wg.Wait()
+
+	if runs != 1 {
+		t.Fatalf("run executed %d times, want 1", runs)
+	}
+	for i := range resps {
+		if errs[i] != nil {
+			t.Fatalf("caller %d: %v", i, errs[i])
+		}
+		if resps[i].NewBalance.GetAmount() != 100 || resps[i].TxId != "tx1" {
+			t.Fatalf("caller %d got %v, want the first response", i, resps[i])
+		}
+	}
+}
+
+// тот же ключ с другим запросом — ошибка, а не чужой ответ
+func TestIdempotentFingerprintMismatch(t *testing.T) {
+	s := idemServer(t)
+	ok := func() (proto.Message, error) {
+		return &walletpb.WalletUpdateResponse{NewBalance: &walletpb.Money{Amount: 1}}, nil
+	}
+	if _, err := s.idempotent(context.Background(), "k2", fingerprint("a"), &walletpb.WalletUpdateResponse{}, ok); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.idempotent(context.Background(), "k2", fingerprint("b"), &walletpb.WalletUpdateResponse{}, ok); err == nil {
+		t.Fatal("expected an error for a reused key with a different request")
+	}
+}
+
+// соседние части не склеиваются: ("a", "bc") и ("ab", "c") — разные запросы
+func TestFingerprintSeparatesParts(t *testing.T) {
+	if fingerprint("update", "a", "bc") == fingerprint("update", "ab", "c") {
+		t.Fatal("adjacent parts collide")
+	}
+	if fingerprint("x", int64(1)) == fingerprint("x", "1") {
+		t.Fatal("a number and a string with the same text collide")
+	}
+}
+
+// неудачный запрос отпускает ключ, повтор выполняется заново
+func TestIdempotentFailureReleasesKey(t *testing.T) {
+	s := idemServer(t)
+	fp := fingerprint("x")
+	fail := func() (proto.Message, error) { return nil, fmt.Errorf("insufficient funds") }
+	if _, err := s.idempotent(context.Background(), "k3", fp, &walletpb.WalletUpdateResponse{}, fail); err == nil {
+		t.Fatal("expected the run error")
+	}
+	var runs int32
+	ok := func() (proto.Message, error) {
+		atomic.AddInt32(&runs, 1)
+		return &walletpb.WalletUpdateResponse{NewBalance: &walletpb.Money{Amount: 7}}, nil
+	}
+	resp, err := s.idempotent(context.Background(), "k3", fp, &walletpb.WalletUpdateResponse{}, ok)
+	if err != nil || runs != 1 || resp.(*walletpb.WalletUpdateResponse).NewBalance.GetAmount() != 7 {
+		t.Fatalf("retry after failure: resp=%v err=%v runs=%d", resp, err, runs)
+	}
+}
+
+func TestIdempotentKeyRequired(t *testing.T) {
+	s := idemServer(t)
+	run := func() (proto.Message, error) { return &walletpb.WalletUpdateResponse{}, nil }
+	if _, err := s.idempotent(context.Background(), "", "fp", &walletpb.WalletUpdateResponse{}, run); err == nil {
+		t.Fatal("expected an error for an empty key")
+	}
+}
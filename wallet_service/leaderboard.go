@@ -0,0 +1,208 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	walletpb "github.com/Arsencchikkk/final/casino/proto/wallet"
+	"github.com/go-redis/redis/v8"
+)
+
+// Лидерборды — sorted set'ы в Redis:
+//
+//	lb:profit:<period>       — чистый выигрыш (payout - stake), ZINCRBY
+//	lb:biggest_win:<period>  — самый крупный выигрыш за раунд, ZADD GT
+//	lb:streak:<period>       — самая длинная серия побед, ZADD GT
+//
+// Для day/week в ключ входит номер периода (lb:profit:day:20261019), так что
+// в полночь UTC / в понедельник таблица начинается с нуля сама, а старые
+// ключи удаляет TTL. Текущие серии побед тоже считаются по периодам
+// (lb:streak:current:day:20261019 и т.д.): серия дня начинается в полночь.
+//
+// Раунд учитывается одним скриптом (lbRecord): пометка lb:seen ставится
+// вместе с обновлением таблиц, так что раунд либо учтён целиком, либо не
+// учтён вовсе и пройдёт при повторной доставке.
+var (
+	lbBoards  = map[string]bool{"profit": true, "biggest_win": true, "streak": true}
+	lbPeriods = []string{"day", "week", "all"}
+)
+
+const (
+	lbStreakKey = "lb:streak:current" // hash user_id -> текущая серия за всё время
+	lbSeenTTL   = 7 * 24 * time.Hour
+	lbMaxLimit  = 100
+
+	// валюта раундов турниров (фишки, см. game_service)
+	tournamentChips = "CHIPS"
+)
+
+// lbKey возвращает ключ таблицы на момент now и время её сброса (ноль для "all").
+func lbKey(board, period string, now time.Time) (string, time.Time) {
+	now = now.UTC()
+	switch period {
+	case "day":
+		start := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+		return fmt.Sprintf("lb:%s:day:%s", board, start.Format("20060102")), start.AddDate(0, 0, 1)
+	case "week":
+		year, week := now.ISOWeek()
+		// понедельник текущей недели
+		start := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+		start = start.AddDate(0, 0, -((int(start.Weekday()) + 6) % 7))
+		return fmt.Sprintf("lb:%s:week:%d-W%02d", board, year, week), start.AddDate(0, 0, 7)
+	}
+	return "lb:" + board + ":all", time.Time{}
+}
+
+// lbStreakRunKey — hash текущих серий за период.
+func lbStreakRunKey(period string, now time.Time) string {
+	if period == "all" {
+		return lbStreakKey
+	}
+	key, _ := lbKey("streak", period, now)
+	return lbStreakKey + key[len("lb:streak"):]
+}
+
+// lbRecord учитывает раунд в таблицах, если его ещё не учитывали.
+// KEYS[1] — пометка lb:seen, дальше по каждому периоду четыре ключа: серии,
+// profit, biggest_win, streak. ARGV: user_id, net, TTL пометки в секундах и
+// по периоду — момент удаления ключей (0 — не удалять). Возвращает 1, если
+// раунд учтён сейчас, 0 — если уже был.
+var lbRecord = redis.NewScript(`
+if not redis.call('SET', KEYS[1], 1, 'NX', 'EX', ARGV[3]) then
+	return 0
+end
+local user, net = ARGV[1], tonumber(ARGV[2])
+local periods = (#KEYS - 1) / 4
+for i = 0, periods - 1 do
+	local run, profit, win, streak = KEYS[2+4*i], KEYS[3+4*i], KEYS[4+4*i], KEYS[5+4*i]
+	redis.call('ZINCRBY', profit, net, user)
+	if net > 0 then
+		redis.call('ZADD', win, 'GT', net, user)
+		local n = redis.call('HINCRBY', run, user, 1)
+		redis.call('ZADD', streak, 'GT', n, user)
+	elseif net < 0 then
+		redis.call('HSET', run, user, 0)
+	end
+	local exp = tonumber(ARGV[4+i])
+	if exp > 0 then
+		for _, k in ipairs({run, profit, win, streak}) do
+			redis.call('EXPIREAT', k, exp)
+		end
+	end
+end
+return 1
+`)
+
+func (s *server) RecordResults(ctx context.Context, req *walletpb.RecordResultsRequest) (*walletpb.RecordResultsResponse, error) {
+	now := time.Now()
+	recorded := int32(0)
+	for _, r := range req.Results {
+		// демо-раунды в лидерборды и достижения не попадают
+		if r.UserId == "" || r.RoundId == "" || isDemo(r.UserId) {
+			continue
+		}
+		// журнал аудита ведётся отдельно от лидербордов и помнит раунды вечно
+		if err := s.appendAudit(ctx, r); err != nil {
+			log.Printf("[RecordResults] audit append error: %v", err)
+			return nil, err
+		}
+		// турнирные фишки — не деньги: такие раунды только в журнале аудита
+		if r.GetStake().GetCurrency() == tournamentChips {
+			continue
+		}
+		// статистика ведётся в основной валюте
+		r, ok := s.inMain(ctx, r)
+		if !ok {
+			continue
+		}
+		net := r.GetPayout().GetAmount() - r.GetStake().GetAmount()
+
+		// один раунд учитываем один раз, даже если расчёт повторили
+		keys := []string{fmt.Sprintf("lb:seen:%s:%s:%s", r.Game, r.RoundId, r.UserId)}
+		args := []interface{}{r.UserId, net, int64(lbSeenTTL / time.Second)}
+		for _, period := range lbPeriods {
+			profit, resets := lbKey("profit", period, now)
+			win, _ := lbKey("biggest_win", period, now)
+			streak, _ := lbKey("streak", period, now)
+			keys = append(keys, lbStreakRunKey(period, now), profit, win, streak)
+			expire := int64(0)
+			if !resets.IsZero() {
+				// прошлый период живёт ещё сутки (неделю), потом ключи удалятся сами
+				keep := 24 * time.Hour
+				if period == "week" {
+					keep = 7 * 24 * time.Hour
+				}
+				expire = resets.Add(keep).Unix()
+			}
+			args = append(args, expire)
+		}
+		fresh, err := lbRecord.Run(ctx, s.redis, keys, args...).Int()
+		if err != nil {
+			log.Printf("[RecordResults] redis script error: %v", err)
+			return nil, err
+		}
+		if fresh == 0 {
+			continue
+		}
+		s.rtp.record(r)
+		recorded++
+		s.evaluateAchievements(ctx, r)
+	}
+	return &walletpb.RecordResultsResponse{Recorded: recorded}, nil
+}
+
+func (s *server) GetLeaderboard(ctx context.Context, req *walletpb.LeaderboardRequest) (*walletpb.LeaderboardResponse, error) {
+	board, period := req.Board, req.Period
+	if board == "" {
+		board = "profit"
+	}
+	if period == "" {
+		period = "all"
+	}
+	if !lbBoards[board] {
+		return nil, fmt.Errorf("unknown board %q", board)
+	}
+	if period != "day" && period != "week" && period != "all" {
+		return nil, fmt.Errorf("unknown period %q", period)
+	}
+	limit := int64(req.Limit)
+	if limit <= 0 || limit > lbMaxLimit {
+		limit = 10
+	}
+
+	key, resets := lbKey(board, period, time.Now())
+	resp := &walletpb.LeaderboardResponse{Board: board, Period: period}
+	if !resets.IsZero() {
+		resp.ResetsAt = resets.Unix()
+	}
+
+	top, err := s.redis.ZRevRangeWithScores(ctx, key, 0, limit-1).Result()
+	if err != nil {
+		log.Printf("[GetLeaderboard] redis ZREVRANGE error: %v", err)
+		return nil, err
+	}
+	for i, z := range top {
+		resp.Top = append(resp.Top, &walletpb.LeaderboardEntry{
+			Rank:   int32(i + 1),
+			UserId: z.Member.(string),
+			Score:  int64(z.Score),
+		})
+	}
+
+	if req.UserId != "" {
+		rank, err := s.redis.ZRevRank(ctx, key, req.UserId).Result()
+		switch {
+		case err == redis.Nil:
+		case err != nil:
+			log.Printf("[GetLeaderboard] redis ZREVRANK error: %v", err)
+		default:
+			score, err := s.redis.ZScore(ctx, key, req.UserId).Result()
+			if err == nil {
+				resp.Me = &walletpb.LeaderboardEntry{Rank: int32(rank + 1), UserId: req.UserId, Score: int64(score)}
+			}
+		}
+	}
+	return resp, nil
+}
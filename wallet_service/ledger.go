@@ -0,0 +1,449 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	walletpb "github.com/Arsencchikkk/final/casino/proto/wallet"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Журнал проводок. Любое движение денег настоящего кошелька — неизменяемая
+// запись в коллекции ledger, и обе стороны проводки в ней есть сразу: amount
+// приходит на кошелёк user_id со счёта counter (при amount < 0 — наоборот).
+// Поэтому сумма по всем счетам всегда ноль, а баланс кошелька — сумма его
+// проводок. Документ в wallets — снимок этой суммы для быстрого чтения
+// (balance — доступное, held — удержанное под раунды, вместе — баланс по
+// журналу); он и проводка меняются в одной транзакции Mongo (нужен replica
+// set, Atlas — да).
+//
+// Проводка меняет один подкошелёк (user_id + currency), и сумма по счетам
+// ноль в каждой валюте отдельно. У подкошелька свой счётчик seq: проводки
+// нумеруются подряд с 1. Если у кошелька был баланс до появления журнала,
+// первой проводкой пишется входящий остаток с seq 0. Демо-кошельки в журнал
+// не пишутся.
+type TxDoc struct {
+	Id           string    `bson:"_id"`
+	UserId       string    `bson:"user_id"`
+	Seq          int64     `bson:"seq"`
+	Type         string    `bson:"type"`
+	Ref          string    `bson:"ref,omitempty"`
+	Amount       int64     `bson:"amount"` // в минимальных единицах валюты
+	Currency     string    `bson:"currency"`
+	Counter      string    `bson:"counter"`
+	BalanceAfter int64     `bson:"balance_after"` // баланс по журналу, вместе с удержанным
+	CreatedAt    time.Time `bson:"created_at"`
+	// ключ идемпотентности запроса: уникальный индекс не даст провести его дважды
+	IdemKey string `bson:"idem_key,omitempty"`
+	// курс обмена у проводок conversion
+	Rate string `bson:"rate,omitempty"`
+	// доступный баланс после проводки — для ответа, в журнал не пишется
+	Available int64 `bson:"-"`
+	// снимок подкошелька после всех проводок вызова — для кеша
+	Wallet WalletDoc `bson:"-"`
+}
+
+// txCounters — тип проводки и счёт казино на другой её стороне.
+var txCounters = map[string]string{
+	"deposit": "house:cash",
+	// вывод через провайдера и его возврат при отказе
+	"withdrawal": "house:cash",
+	"bet":        "house:games",
+	"win":        "house:games",
+	"refund":     "house:games",
+	"bonus":      "house:bonus",
+	"adjustment": "house:adjustments",
+	// обмен валют: казино покупает одну валюту и продаёт другую
+	"conversion": "house:fx",
+	// перевод между игроками: на другой стороне кошелёк второго игрока,
+	// counter проводки — "user:<id>"
+	"transfer": "user",
+}
+
+// errInsufficientFunds — списание больше баланса. Код FailedPrecondition
+// сохраняется через game/keno-сервисы, шлюз отвечает на него 402.
+var errInsufficientFunds = status.Error(codes.FailedPrecondition, "insufficient funds")
+
+// debitFilter — условие списания: баланс не уходит в минус. Корректировки
+// (ручные и счёт казино под гарантии турниров; пустой тип — тоже корректировка)
+// могут увести в минус.
+func debitFilter(userId, currency string, amount int64, typ string) (bson.M, bool) {
+	filter := walletFilter(userId, currency)
+	if amount >= 0 || typ == "" || typ == "adjustment" {
+		return filter, false
+	}
+	filter["balance"] = bson.M{"$gte": -amount}
+	return filter, true
+}
+
+const (
+	txDefaultLimit = 50
+	txMaxLimit     = 500
+)
+
+// posting — одна проводка, которую нужно провести.
+type posting struct {
+	userId   string
+	currency string
+	amount   int64
+	typ      string
+	ref      string
+	key      string
+	// списание из удержанного (Capture), а не из доступного
+	held bool
+	// ставка без бонуса (бай-ин против игроков, см. cash_only)
+	cashOnly bool
+	// курс для conversion
+	rate string
+	// счёт на другой стороне, если это не счёт казино из txCounters
+	counter string
+}
+
+func newPosting(userId, currency string, amount int64, typ, ref, key string) (posting, error) {
+	if typ == "" {
+		typ = "adjustment"
+	}
+	if _, ok := txCounters[typ]; !ok {
+		return posting{}, fmt.Errorf("unknown transaction type %q", typ)
+	}
+	if typ == "conversion" {
+		return posting{}, fmt.Errorf("conversions go through Convert")
+	}
+	if typ == "withdrawal" {
+		return posting{}, fmt.Errorf("withdrawals go through RequestWithdrawal")
+	}
+	if typ == "transfer" {
+		return posting{}, fmt.Errorf("transfers go through Transfer")
+	}
+	if userId == "" {
+		return posting{}, fmt.Errorf("user_id required")
+	}
+	return posting{userId: userId, currency: currency, amount: amount, typ: typ, ref: ref, key: key}, nil
+}
+
+func ledgerIndexes(ctx context.Context, col *mongo.Collection) error {
+	// до подкошельков seq был уникален на пользователя
+	if _, err := col.Indexes().DropOne(ctx, "user_id_1_seq_-1"); err != nil {
+		var ce mongo.CommandError
+		// 26 — коллекции ещё нет, 27 — индекса уже нет
+		if !errors.As(err, &ce) || (ce.Code != 26 && ce.Code != 27) {
+			return err
+		}
+	}
+	_, err := col.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "currency", Value: 1}, {Key: "seq", Value: -1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "ref", Value: 1}}, Options: options.Index().SetSparse(true)},
+		{Keys: bson.D{{Key: "idem_key", Value: 1}}, Options: options.Index().SetUnique(true).SetSparse(true)},
+	})
+	return err
+}
+
+// post проводит все проводки одной транзакцией: либо все, либо ни одной.
+// Возвращает проводки с новыми балансами в том же порядке.
+func (s *server) post(ctx context.Context, ps []posting) ([]TxDoc, error) {
+	sess, err := s.mongoCol.Database().Client().StartSession()
+	if err != nil {
+		return nil, err
+	}
+	defer sess.EndSession(ctx)
+
+	out, err := sess.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		return s.postIn(sc, ps)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out.([]TxDoc), nil
+}
+
+// postIn — post внутри уже открытой транзакции. Ставки и выигрыши сначала
+// проходят через бонусы (applyBonus); проводки разблокировки бонусов
+// проводятся следом, но в ответ не попадают.
+func (s *server) postIn(sc mongo.SessionContext, ps []posting) ([]TxDoc, error) {
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	n := len(ps)
+	ps = append([]posting(nil), ps...)
+	txs := make([]TxDoc, 0, len(ps))
+	var docs []interface{}
+	last := make(map[string]WalletDoc)
+	for i := 0; i < len(ps); i++ {
+		p := ps[i]
+		extra, err := s.applyBonus(sc, &p)
+		if err != nil {
+			return nil, err
+		}
+		ps = append(ps, extra...)
+		// проверка баланса и списание — одна операция, гонки между ними нет
+		field := "balance"
+		filter, debit := debitFilter(p.userId, p.currency, p.amount, p.typ)
+		if p.held {
+			field = "held"
+			filter, debit = walletFilter(p.userId, p.currency), true
+			filter["held"] = bson.M{"$gte": -p.amount}
+		}
+		// новый подкошелёк получает валюту из фильтра
+		var w WalletDoc
+		err = s.mongoCol.FindOneAndUpdate(sc,
+			filter,
+			touch(p.userId, bson.M{"$inc": bson.M{field: p.amount, "seq": 1}}),
+			options.FindOneAndUpdate().SetUpsert(!debit).SetReturnDocument(options.After),
+		).Decode(&w)
+		if debit && err == mongo.ErrNoDocuments {
+			return nil, errInsufficientFunds
+		}
+		if err != nil {
+			return nil, err
+		}
+		last[walletKey(p.userId, p.currency)] = w
+		total := w.Balance + w.Held
+		if opening := total - p.amount; w.Seq == 1 && opening != 0 {
+			// кошелёк старше журнала: фиксируем, с чем он в него пришёл
+			docs = append(docs, TxDoc{
+				Id:           primitive.NewObjectID().Hex(),
+				UserId:       p.userId,
+				Seq:          0,
+				Type:         "adjustment",
+				Ref:          "opening-balance",
+				Amount:       opening,
+				Currency:     p.currency,
+				Counter:      "house:opening",
+				BalanceAfter: opening,
+				CreatedAt:    now,
+			})
+		}
+		counter := txCounters[p.typ]
+		if p.counter != "" {
+			counter = p.counter
+		}
+		tx := TxDoc{
+			Id:           primitive.NewObjectID().Hex(),
+			UserId:       p.userId,
+			Seq:          w.Seq,
+			Type:         p.typ,
+			Ref:          p.ref,
+			Amount:       p.amount,
+			Currency:     p.currency,
+			Counter:      counter,
+			BalanceAfter: total,
+			CreatedAt:    now,
+			IdemKey:      p.key,
+			Rate:         p.rate,
+			Available:    w.Balance,
+		}
+		docs = append(docs, tx)
+		txs = append(txs, tx)
+	}
+	if _, err := s.ledger.InsertMany(sc, docs); err != nil {
+		return nil, err
+	}
+	// кеш получает итог, в том числе проводок, добавленных бонусами
+	for i := range txs {
+		txs[i].Wallet = last[walletKey(txs[i].UserId, txs[i].Currency)]
+	}
+	return txs[:n], nil
+}
+
+// postOnce — post, который при уже проведённых ключах возвращает прежние
+// проводки. Сюда попадают повторы, мимо которых прошёл Redis.
+func (s *server) postOnce(ctx context.Context, ps []posting) ([]TxDoc, error) {
+	txs, err := s.post(ctx, ps)
+	if err == nil || !mongo.IsDuplicateKeyError(err) {
+		return txs, err
+	}
+	return s.replayed(ctx, ps, err)
+}
+
+// replayed — уже проведённые проводки с ключами из ps; если проведены не
+// все, возвращает err, из-за которого их искали.
+func (s *server) replayed(ctx context.Context, ps []posting, err error) ([]TxDoc, error) {
+	keys := make([]string, len(ps))
+	for i, p := range ps {
+		keys[i] = p.key
+	}
+	cur, ferr := s.ledger.Find(ctx, bson.M{"idem_key": bson.M{"$in": keys}})
+	if ferr != nil {
+		return nil, err
+	}
+	var done []TxDoc
+	if ferr := cur.All(ctx, &done); ferr != nil || len(done) != len(ps) {
+		return nil, err
+	}
+	byKey := make(map[string]TxDoc, len(done))
+	for _, tx := range done {
+		byKey[tx.IdemKey] = tx
+	}
+	txs := make([]TxDoc, len(keys))
+	for i, k := range keys {
+		txs[i] = byKey[k]
+		// доступный баланс в журнале не хранится — отдаём нынешний
+		var w WalletDoc
+		if ferr := s.mongoCol.FindOne(ctx, walletFilter(txs[i].UserId, txs[i].Currency)).Decode(&w); ferr != nil {
+			return nil, ferr
+		}
+		txs[i].Available, txs[i].Wallet = w.Balance, w
+	}
+	log.Printf("[ledger] %d postings already applied, returning the originals", len(ps))
+	return txs, nil
+}
+
+// ledgerBalance — баланс подкошелька по журналу.
+func (s *server) ledgerBalance(ctx context.Context, userId, currency string) (int64, error) {
+	cur, err := s.ledger.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: walletFilter(userId, currency)}},
+		{{Key: "$group", Value: bson.M{"_id": nil, "sum": bson.M{"$sum": "$amount"}}}},
+	})
+	if err != nil {
+		return 0, err
+	}
+	defer cur.Close(ctx)
+	var res []struct {
+		Sum int64 `bson:"sum"`
+	}
+	if err := cur.All(ctx, &res); err != nil {
+		return 0, err
+	}
+	if len(res) == 0 {
+		return 0, nil
+	}
+	return res[0].Sum, nil
+}
+
+func txToPb(t TxDoc) *walletpb.Transaction {
+	return &walletpb.Transaction{
+		TxId:         t.Id,
+		UserId:       t.UserId,
+		Seq:          t.Seq,
+		Type:         t.Type,
+		Ref:          t.Ref,
+		Amount:       &walletpb.Money{Amount: t.Amount, Currency: t.Currency},
+		Counter:      t.Counter,
+		BalanceAfter: &walletpb.Money{Amount: t.BalanceAfter, Currency: t.Currency},
+		CreatedAt:    t.CreatedAt.UnixMilli(),
+		Rate:         t.Rate,
+	}
+}
+
+func (s *server) ListTransactions(ctx context.Context, req *walletpb.ListTransactionsRequest) (*walletpb.ListTransactionsResponse, error) {
+	if req.UserId == "" {
+		return nil, fmt.Errorf("user_id required")
+	}
+	currency, err := s.currencyOf(req.UserId, req.Currency)
+	if err != nil {
+		return nil, err
+	}
+	limit := int64(req.Limit)
+	if limit <= 0 {
+		limit = txDefaultLimit
+	}
+	if limit > txMaxLimit {
+		limit = txMaxLimit
+	}
+	filter := walletFilter(req.UserId, currency)
+	if req.BeforeSeq > 0 {
+		filter["seq"] = bson.M{"$lt": req.BeforeSeq}
+	}
+	if len(req.Types) > 0 {
+		filter["type"] = bson.M{"$in": req.Types}
+	}
+	if req.Ref != "" {
+		filter["ref"] = req.Ref
+	}
+	if req.From > 0 || req.To > 0 {
+		created := bson.M{}
+		if req.From > 0 {
+			created["$gte"] = time.UnixMilli(req.From)
+		}
+		if req.To > 0 {
+			created["$lt"] = time.UnixMilli(req.To)
+		}
+		filter["created_at"] = created
+	}
+
+	// на одну больше, чтобы понять, есть ли следующая страница
+	cur, err := s.ledger.Find(ctx, filter, options.Find().
+		SetSort(bson.D{{Key: "seq", Value: -1}}).
+		SetLimit(limit+1))
+	if err != nil {
+		log.Printf("[ListTransactions] mongo Find error: %v", err)
+		return nil, err
+	}
+	var docs []TxDoc
+	if err := cur.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	resp := &walletpb.ListTransactionsResponse{}
+	if int64(len(docs)) > limit {
+		docs = docs[:limit]
+		// seq 0 — входящий остаток, за ним страниц нет
+		resp.NextBeforeSeq = docs[len(docs)-1].Seq
+	}
+	for _, d := range docs {
+		resp.Transactions = append(resp.Transactions, txToPb(d))
+	}
+	return resp, nil
+}
+
+func (s *server) ReconcileBalance(ctx context.Context, req *walletpb.ReconcileRequest) (*walletpb.ReconcileResponse, error) {
+	if isDemo(req.UserId) {
+		return nil, fmt.Errorf("demo wallets have no ledger")
+	}
+	currency, err := s.currencyOf(req.UserId, req.Currency)
+	if err != nil {
+		return nil, err
+	}
+	sess, err := s.mongoCol.Database().Client().StartSession()
+	if err != nil {
+		return nil, err
+	}
+	defer sess.EndSession(ctx)
+
+	// снимок и журнал читаем в одной транзакции, чтобы не попасть между ними
+	var fixed WalletDoc
+	out, err := sess.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		var w WalletDoc
+		if err := s.mongoCol.FindOne(sc, walletFilter(req.UserId, currency)).Decode(&w); err != nil && err != mongo.ErrNoDocuments {
+			return nil, err
+		}
+		sum, err := s.ledgerBalance(sc, req.UserId, currency)
+		if err != nil {
+			return nil, err
+		}
+		// удержанное — тоже деньги игрока, журнал его ещё не списал
+		snapshot := w.Balance + w.Held
+		resp := &walletpb.ReconcileResponse{Snapshot: money(snapshot, currency), Ledger: money(sum, currency), Match: snapshot == sum}
+		if w.Seq == 0 && snapshot != 0 {
+			// журнала у кошелька ещё нет, первая проводка запишет входящий остаток
+			resp.Ledger, resp.Match = money(snapshot, currency), true
+		}
+		if !resp.Match && req.Fix {
+			if err := s.mongoCol.FindOneAndUpdate(sc, walletFilter(req.UserId, currency),
+				touch(req.UserId, bson.M{"$set": bson.M{"balance": sum - w.Held}}),
+				options.FindOneAndUpdate().SetReturnDocument(options.After),
+			).Decode(&fixed); err != nil {
+				return nil, err
+			}
+			resp.Fixed = true
+		}
+		return resp, nil
+	})
+	if err != nil {
+		log.Printf("[ReconcileBalance] %s: %v", req.UserId, err)
+		return nil, err
+	}
+	resp := out.(*walletpb.ReconcileResponse)
+	if !resp.Match {
+		log.Printf("[ReconcileBalance] %s %s: snapshot %d, ledger %d, fixed=%v", req.UserId, currency, resp.Snapshot.Amount, resp.Ledger.Amount, resp.Fixed)
+	}
+	if resp.Fixed {
+		s.cacheWallet(ctx, fixed)
+	}
+	return resp, nil
+}
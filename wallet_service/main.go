@@ -2,31 +2,92 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"net"
+	"net/http"
 	"os"
 	"strconv"
+	"sync"
 	"time"
 
 	walletpb "github.com/Arsencchikkk/final/casino/proto/wallet"
 	"github.com/go-redis/redis/v8"
 	"github.com/joho/godotenv"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"golang.org/x/sync/singleflight"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
 )
 
-// WalletDoc представляет документ в Mongo
+// WalletDoc представляет документ в Mongo: подкошелёк игрока в одной валюте
 type WalletDoc struct {
 	UserId  string `bson:"user_id"`
-	Balance int32  `bson:"balance"`
+	Balance int64  `bson:"balance"` // доступно, в минимальных единицах валюты
+	// удержано под незавершённые раунды (Reserve), в balance не входит
+	Held     int64  `bson:"held,omitempty"`
+	Currency string `bson:"currency,omitempty"`
+	// номер последней проводки в журнале
+	Seq int64 `bson:"seq,omitempty"`
+	// версия снимка: растёт с каждой записью, по ней сверяется кеш
+	Version int64 `bson:"version,omitempty"`
+	// только у демо-кошельков: по нему TTL-индекс удаляет брошенные
+	UpdatedAt time.Time `bson:"updated_at,omitempty"`
 }
 
 type server struct {
 	walletpb.UnimplementedWalletServiceServer
 	mongoCol *mongo.Collection
 	redis    *redis.Client
+
+	// кеш балансов: перечитывание по ключу и ключи, которые не удалось обновить
+	refill  singleflight.Group
+	staleMu sync.Mutex
+	stale   map[string]int64
+
+	achievements *mongo.Collection
+	rules        []Rule
+
+	demoCol     *mongo.Collection
+	demoBalance int64
+
+	audit   *mongo.Collection
+	auditMu sync.Mutex
+
+	rtp *rtpMonitor
+
+	ledger  *mongo.Collection
+	idemTTL time.Duration
+
+	holds *mongo.Collection
+
+	// основная валюта и все, в которых бывают подкошельки
+	currency   string
+	currencies map[string]bool
+	// таблица курсов обмена
+	rates *mongo.Collection
+
+	// пополнения и выводы; выводы больше reviewAbove (в основной валюте) ждут админа
+	payments    *mongo.Collection
+	provider    PaymentProvider
+	reviewAbove int64
+
+	// переводы между игроками: дневной лимит отправителя в основной валюте
+	transferLimits *mongo.Collection
+	transferLimit  int64
+
+	// бонусы: отыгрыш в bonusWagerX сумм бонуса за bonusTTL; reload — процент
+	// от пополнения, не больше reloadMax
+	bonuses       *mongo.Collection
+	bonusBets     *mongo.Collection
+	bonusWagerX   int64
+	bonusTTL      time.Duration
+	reloadPercent int64
+	reloadMax     int64
 }
 
 func NewServer(ctx context.Context) *server {
@@ -52,6 +113,29 @@ func NewServer(ctx context.Context) *server {
 	col := mClient.Database(mongoDB).Collection(mongoCol)
 	log.Printf("[init] MongoDB connected: DB=%s, COLLECTION=%s", mongoDB, mongoCol)
 
+	// основная валюта (для запросов без валюты) и остальные подкошельки
+	currency := os.Getenv("WALLET_CURRENCY")
+	if currency == "" {
+		currency = "USD"
+	}
+	currencies, err := parseCurrencies(currency+","+os.Getenv("WALLET_CURRENCIES"), currency)
+	if err != nil {
+		log.Fatalf("WALLET_CURRENCY / WALLET_CURRENCIES: %v", err)
+	}
+	// кошельки из времён int32-кредитов сначала переводит cmd/walletmigrate
+	if err := col.FindOne(ctx, bson.M{"currency": bson.M{"$exists": false}}).Err(); err == nil {
+		log.Fatal("[init] wallets still hold int32 credits, run cmd/walletmigrate first")
+	} else if err != mongo.ErrNoDocuments {
+		log.Fatalf("[init][mongo] migration check error: %v", err)
+	}
+	// по подкошельку на пользователя и валюту
+	if _, err := col.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "currency", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}); err != nil {
+		log.Fatalf("[init][mongo] wallets index error: %v", err)
+	}
+
 	// Redis
 	opt, err := redis.ParseURL(redisURL)
 	if err != nil {
@@ -63,71 +147,501 @@ func NewServer(ctx context.Context) *server {
 	}
 	log.Printf("[init] Redis connected at %s", redisURL)
 
-	return &server{mongoCol: col, redis: rdb}
+	// достижения: прогресс по правилам, уникальный на пару user_id + rule_id
+	achCol := os.Getenv("MONGO_ACHIEVEMENTS_COL")
+	if achCol == "" {
+		achCol = "achievements"
+	}
+	ach := mClient.Database(mongoDB).Collection(achCol)
+	if _, err := ach.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "rule_id", Value: 1}}, Options: options.Index().SetUnique(true)},
+		// незачисленные бонусы ищет retryAchievementBonuses
+		{Keys: bson.D{{Key: "bonus_pending", Value: 1}}, Options: options.Index().SetSparse(true)},
+	}); err != nil {
+		log.Fatalf("[init][mongo] achievements index error: %v", err)
+	}
+	rules, err := loadRules()
+	if err != nil {
+		log.Fatalf("[init] achievements rules: %v", err)
+	}
+	log.Printf("[init] %d achievement rules loaded", len(rules))
+
+	// демо-кошельки: отдельная коллекция, брошенные удаляются сами
+	demoColName := os.Getenv("MONGO_DEMO_COL")
+	if demoColName == "" {
+		demoColName = "demo_wallets"
+	}
+	demoTTL, demoBalance := 24, 1000
+	if v := os.Getenv("DEMO_WALLET_TTL_HOURS"); v != "" {
+		if demoTTL, err = strconv.Atoi(v); err != nil || demoTTL < 1 {
+			log.Fatalf("DEMO_WALLET_TTL_HOURS: bad value %q", v)
+		}
+	}
+	if v := os.Getenv("DEMO_BALANCE"); v != "" {
+		if demoBalance, err = strconv.Atoi(v); err != nil || demoBalance < 1 {
+			log.Fatalf("DEMO_BALANCE: bad value %q", v)
+		}
+	}
+	demo := mClient.Database(mongoDB).Collection(demoColName)
+	if _, err := demo.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "user_id", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "updated_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(int32(demoTTL * 3600))},
+	}); err != nil {
+		log.Fatalf("[init][mongo] demo wallets index error: %v", err)
+	}
+
+	// журнал аудита раундов: цепочка хешей, только дописывается
+	auditColName := os.Getenv("MONGO_AUDIT_COL")
+	if auditColName == "" {
+		auditColName = "audit_log"
+	}
+	audit := mClient.Database(mongoDB).Collection(auditColName)
+	if err := auditIndexes(ctx, audit); err != nil {
+		log.Fatalf("[init][mongo] audit index error: %v", err)
+	}
+
+	// журнал проводок: баланс в wallets — снимок суммы проводок
+	ledgerColName := os.Getenv("MONGO_LEDGER_COL")
+	if ledgerColName == "" {
+		ledgerColName = "ledger"
+	}
+	ledger := mClient.Database(mongoDB).Collection(ledgerColName)
+	if err := ledgerIndexes(ctx, ledger); err != nil {
+		log.Fatalf("[init][mongo] ledger index error: %v", err)
+	}
+
+	// удержания ставок под незавершённые раунды
+	holdsColName := os.Getenv("MONGO_HOLDS_COL")
+	if holdsColName == "" {
+		holdsColName = "holds"
+	}
+	holds := mClient.Database(mongoDB).Collection(holdsColName)
+	if err := holdIndexes(ctx, holds); err != nil {
+		log.Fatalf("[init][mongo] holds index error: %v", err)
+	}
+
+	// курсы обмена между подкошельками, их ставит админ
+	ratesColName := os.Getenv("MONGO_RATES_COL")
+	if ratesColName == "" {
+		ratesColName = "rates"
+	}
+	rates := mClient.Database(mongoDB).Collection(ratesColName)
+
+	// пополнения и выводы через платёжного провайдера
+	paymentsColName := os.Getenv("MONGO_PAYMENTS_COL")
+	if paymentsColName == "" {
+		paymentsColName = "payments"
+	}
+	payments := mClient.Database(mongoDB).Collection(paymentsColName)
+	if err := paymentIndexes(ctx, payments); err != nil {
+		log.Fatalf("[init][mongo] payments index error: %v", err)
+	}
+	reviewAbove := units(500)
+	if v := os.Getenv("WITHDRAWAL_REVIEW_ABOVE"); v != "" {
+		if reviewAbove, err = parseAmount(v, currency); err != nil {
+			log.Fatalf("WITHDRAWAL_REVIEW_ABOVE: %v", err)
+		}
+	}
+	// без провайдера пополнения и выводы выключены. fake зачисляет деньги
+	// сам, поэтому включается только явно, вместе с PAYMENT_FAKE_DEV=true
+	providerName := os.Getenv("PAYMENT_PROVIDER")
+	switch providerName {
+	case "":
+		log.Printf("[payments] PAYMENT_PROVIDER not set, deposits and withdrawals are disabled")
+	case "fake":
+		if os.Getenv("PAYMENT_FAKE_DEV") != "true" {
+			log.Fatalf("PAYMENT_PROVIDER=fake credits deposits by itself; set PAYMENT_FAKE_DEV=true to use it in development")
+		}
+		log.Printf("[payments] using the fake provider, deposits are credited without real money")
+	default:
+		log.Fatalf("PAYMENT_PROVIDER: unknown provider %q", providerName)
+	}
+	// переводы: счётчики дневного лимита, удаляются через день
+	transferLimitsColName := os.Getenv("MONGO_TRANSFER_LIMITS_COL")
+	if transferLimitsColName == "" {
+		transferLimitsColName = "transfer_limits"
+	}
+	transferLimits := mClient.Database(mongoDB).Collection(transferLimitsColName)
+	if err := transferLimitIndexes(ctx, transferLimits); err != nil {
+		log.Fatalf("[init][mongo] transfer limits index error: %v", err)
+	}
+	transferLimit := units(1000)
+	if v := os.Getenv("TRANSFER_DAILY_LIMIT"); v != "" {
+		if transferLimit, err = parseAmount(v, currency); err != nil {
+			log.Fatalf("TRANSFER_DAILY_LIMIT: %v", err)
+		}
+	}
+
+	// бонусы и раздел ставок между деньгами и бонусом
+	bonusesColName := os.Getenv("MONGO_BONUSES_COL")
+	if bonusesColName == "" {
+		bonusesColName = "bonuses"
+	}
+	bonuses := mClient.Database(mongoDB).Collection(bonusesColName)
+	bonusBets := mClient.Database(mongoDB).Collection(bonusesColName + "_bets")
+	if err := bonusIndexes(ctx, bonuses, bonusBets); err != nil {
+		log.Fatalf("[init][mongo] bonuses index error: %v", err)
+	}
+	wagerX, bonusDays, reloadPercent := 30, 30, 0
+	if v := os.Getenv("BONUS_WAGER_X"); v != "" {
+		if wagerX, err = strconv.Atoi(v); err != nil || wagerX < 0 {
+			log.Fatalf("BONUS_WAGER_X: bad value %q", v)
+		}
+	}
+	if v := os.Getenv("BONUS_TTL_DAYS"); v != "" {
+		if bonusDays, err = strconv.Atoi(v); err != nil || bonusDays < 1 {
+			log.Fatalf("BONUS_TTL_DAYS: bad value %q", v)
+		}
+	}
+	if v := os.Getenv("RELOAD_BONUS_PERCENT"); v != "" {
+		if reloadPercent, err = strconv.Atoi(v); err != nil || reloadPercent < 0 {
+			log.Fatalf("RELOAD_BONUS_PERCENT: bad value %q", v)
+		}
+	}
+	reloadMax := units(100)
+	if v := os.Getenv("RELOAD_BONUS_MAX"); v != "" {
+		if reloadMax, err = parseAmount(v, currency); err != nil {
+			log.Fatalf("RELOAD_BONUS_MAX: %v", err)
+		}
+	}
+
+	fakeDelay := 3
+	if v := os.Getenv("PAYMENT_FAKE_DELAY_SEC"); v != "" {
+		if fakeDelay, err = strconv.Atoi(v); err != nil || fakeDelay < 0 {
+			log.Fatalf("PAYMENT_FAKE_DELAY_SEC: bad value %q", v)
+		}
+	}
+
+	// ключи идемпотентности помним IDEMPOTENCY_TTL_HOURS (по умолчанию сутки)
+	idemTTL := 24
+	if v := os.Getenv("IDEMPOTENCY_TTL_HOURS"); v != "" {
+		if idemTTL, err = strconv.Atoi(v); err != nil || idemTTL < 1 {
+			log.Fatalf("IDEMPOTENCY_TTL_HOURS: bad value %q", v)
+		}
+	}
+
+	// мониторинг RTP: ширина доверительного интервала и минимум раундов для тревоги
+	rtpZ, rtpMinRounds := 3.0, 1000
+	if v := os.Getenv("RTP_ALERT_Z"); v != "" {
+		if rtpZ, err = strconv.ParseFloat(v, 64); err != nil || rtpZ <= 0 {
+			log.Fatalf("RTP_ALERT_Z: bad value %q", v)
+		}
+	}
+	if v := os.Getenv("RTP_ALERT_MIN_ROUNDS"); v != "" {
+		if rtpMinRounds, err = strconv.Atoi(v); err != nil || rtpMinRounds < 2 {
+			log.Fatalf("RTP_ALERT_MIN_ROUNDS: bad value %q", v)
+		}
+	}
+
+	srv := &server{
+		mongoCol:       col,
+		redis:          rdb,
+		achievements:   ach,
+		rules:          rules,
+		demoCol:        demo,
+		demoBalance:    units(int64(demoBalance)),
+		currency:       currency,
+		currencies:     currencies,
+		rates:          rates,
+		audit:          audit,
+		rtp:            newRtpMonitor(rtpZ, int64(rtpMinRounds)),
+		ledger:         ledger,
+		idemTTL:        time.Duration(idemTTL) * time.Hour,
+		holds:          holds,
+		payments:       payments,
+		reviewAbove:    reviewAbove,
+		transferLimits: transferLimits,
+		transferLimit:  transferLimit,
+		bonuses:        bonuses,
+		bonusBets:      bonusBets,
+		bonusWagerX:    int64(wagerX),
+		bonusTTL:       time.Duration(bonusDays) * 24 * time.Hour,
+		reloadPercent:  int64(reloadPercent),
+		reloadMax:      reloadMax,
+	}
+	if providerName == "fake" {
+		srv.provider = newFakeProvider(time.Duration(fakeDelay)*time.Second, os.Getenv("PAYMENT_FAKE_WEBHOOK_URL"), func(payload []byte, sig string) {
+			if _, err := srv.PaymentWebhook(context.Background(), &walletpb.PaymentWebhookRequest{Provider: "fake", Payload: payload, Signature: sig}); err != nil {
+				log.Printf("[payments] fake webhook: %v", err)
+			}
+		})
+	}
+	return srv
 }
 
 func (s *server) GetBalance(ctx context.Context, req *walletpb.WalletRequest) (*walletpb.WalletResponse, error) {
-	key := "balance:" + req.UserId
-	log.Printf("[GetBalance] user=%s", req.UserId)
+	currency, err := s.currencyOf(req.UserId, req.Currency)
+	if err != nil {
+		return nil, err
+	}
+	key := walletKey(req.UserId, currency)
+	log.Printf("[GetBalance] user=%s currency=%s", req.UserId, currency)
 
 	// 1) пробуем кеш
-	if val, err := s.redis.Get(ctx, key).Result(); err == nil {
-		log.Printf("[GetBalance] cache hit: %s=%s", key, val)
-		if b, err := strconv.Atoi(val); err == nil {
-			return &walletpb.WalletResponse{Balance: int32(b)}, nil
-		}
-	} else if err != redis.Nil {
-		log.Printf("[GetBalance] redis GET error: %v", err)
+	if w, ok := s.cachedWallet(ctx, key); ok {
+		log.Printf("[GetBalance] cache hit: %s=%d held=%d v%d", key, w.Balance, w.Held, w.Version)
+		return &walletpb.WalletResponse{Balance: money(w.Balance, currency), Held: money(w.Held, currency)}, nil
 	}
 
-	// 2) кеш-промах — читаем из Mongo
+	// 2) кеш-промах — читаем из Mongo и кладём в кеш
 	log.Printf("[GetBalance] cache miss, query MongoDB user=%s", req.UserId)
-	filter := bson.M{"user_id": req.UserId}
+	doc, err := s.refillWallet(ctx, key, func(ctx context.Context) (WalletDoc, error) {
+		return s.loadWallet(ctx, req.UserId, currency)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &walletpb.WalletResponse{Balance: money(doc.Balance, currency), Held: money(doc.Held, currency)}, nil
+}
+
+// loadWallet читает подкошелёк из Mongo, заводя пустой, если его нет.
+func (s *server) loadWallet(ctx context.Context, userId, currency string) (WalletDoc, error) {
+	filter := walletFilter(userId, currency)
 	var doc WalletDoc
-	err := s.mongoCol.FindOne(ctx, filter).Decode(&doc)
+	err := s.wallets(userId).FindOne(ctx, filter).Decode(&doc)
 	if err == mongo.ErrNoDocuments {
-		log.Printf("[GetBalance] no wallet, create default for %s", req.UserId)
-		doc = WalletDoc{UserId: req.UserId, Balance: 0}
-		if _, err := s.mongoCol.InsertOne(ctx, doc); err != nil {
+		log.Printf("[GetBalance] no %s wallet, create default for %s", currency, userId)
+		doc = WalletDoc{UserId: userId, Balance: 0, Currency: currency}
+		if isDemo(userId) {
+			doc.UpdatedAt = time.Now()
+		}
+		_, err = s.wallets(userId).InsertOne(ctx, doc)
+		if mongo.IsDuplicateKeyError(err) {
+			// кошелёк завела параллельная запись
+			err = s.wallets(userId).FindOne(ctx, filter).Decode(&doc)
+		}
+		if err != nil {
 			log.Printf("[GetBalance] insert default error: %v", err)
-			return nil, err
+			return WalletDoc{}, err
 		}
 	} else if err != nil {
 		log.Printf("[GetBalance] mongo FIND error: %v", err)
-		return nil, err
+		return WalletDoc{}, err
 	}
+	return doc, nil
+}
 
-	// 3) записываем в кеш
-	log.Printf("[GetBalance] caching %s=%d", key, doc.Balance)
-	if err := s.redis.Set(ctx, key, doc.Balance, 5*time.Minute).Err(); err != nil {
-		log.Printf("[GetBalance] redis SET error: %v", err)
+// ListWallets — все подкошельки игрока; основной идёт первым, даже пустой.
+func (s *server) ListWallets(ctx context.Context, req *walletpb.WalletRequest) (*walletpb.ListWalletsResponse, error) {
+	if req.UserId == "" {
+		return nil, fmt.Errorf("user_id required")
 	}
-
-	return &walletpb.WalletResponse{Balance: doc.Balance}, nil
+	cur, err := s.wallets(req.UserId).Find(ctx, bson.M{"user_id": req.UserId}, options.Find().SetSort(bson.D{{Key: "currency", Value: 1}}))
+	if err != nil {
+		log.Printf("[ListWallets] mongo Find error: %v", err)
+		return nil, err
+	}
+	var docs []WalletDoc
+	if err := cur.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	resp := &walletpb.ListWalletsResponse{Wallets: []*walletpb.SubWallet{{Balance: money(0, s.currency), Held: money(0, s.currency)}}}
+	for _, d := range docs {
+		w := &walletpb.SubWallet{Balance: money(d.Balance, d.Currency), Held: money(d.Held, d.Currency)}
+		if d.Currency == s.currency {
+			resp.Wallets[0] = w
+			continue
+		}
+		resp.Wallets = append(resp.Wallets, w)
+	}
+	return resp, nil
 }
 
 func (s *server) UpdateBalance(ctx context.Context, req *walletpb.WalletUpdateRequest) (*walletpb.WalletUpdateResponse, error) {
-	key := "balance:" + req.UserId
-	log.Printf("[UpdateBalance] user=%s delta=%d", req.UserId, req.Amount)
+	fp := fingerprint("update", req.UserId, req.GetAmount().GetAmount(), req.GetAmount().GetCurrency(), req.Type, req.Ref, req.CashOnly)
+	resp, err := s.idempotent(ctx, req.IdempotencyKey, fp, &walletpb.WalletUpdateResponse{}, func() (proto.Message, error) {
+		return s.updateBalance(ctx, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*walletpb.WalletUpdateResponse), nil
+}
+
+func (s *server) updateBalance(ctx context.Context, req *walletpb.WalletUpdateRequest) (*walletpb.WalletUpdateResponse, error) {
+	amount, currency, err := s.amountOf(req.UserId, req.Amount)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("[UpdateBalance] user=%s delta=%d %s type=%s ref=%s key=%s", req.UserId, amount, currency, req.Type, req.Ref, req.IdempotencyKey)
 
-	// атомарное обновление в Mongo
-	filter := bson.M{"user_id": req.UserId}
-	update := bson.M{"$inc": bson.M{"balance": req.Amount}}
-	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	if !isDemo(req.UserId) {
+		p, err := newPosting(req.UserId, currency, amount, req.Type, req.Ref, req.IdempotencyKey)
+		if err != nil {
+			return nil, err
+		}
+		p.cashOnly = req.CashOnly
+		if amount == 0 {
+			// проводить нечего
+			wr, err := s.GetBalance(ctx, &walletpb.WalletRequest{UserId: req.UserId, Currency: currency})
+			if err != nil {
+				return nil, err
+			}
+			return &walletpb.WalletUpdateResponse{NewBalance: wr.Balance}, nil
+		}
+		txs, err := s.postOnce(ctx, []posting{p})
+		if err != nil {
+			log.Printf("[UpdateBalance] ledger post error: %v", err)
+			return nil, err
+		}
+		tx := txs[0]
+		log.Printf("[UpdateBalance] tx %s: new balance for %s = %d", tx.Id, req.UserId, tx.Available)
+		s.cachePostings(ctx, txs)
+		return &walletpb.WalletUpdateResponse{NewBalance: money(tx.Available, currency), TxId: tx.Id}, nil
+	}
+
+	// демо-кошелёк: без журнала, атомарное обновление в Mongo
+	filter, debit := debitFilter(req.UserId, currency, amount, req.Type)
+	update := touch(req.UserId, bson.M{"$inc": bson.M{"balance": amount}})
+	opts := options.FindOneAndUpdate().SetUpsert(!debit).SetReturnDocument(options.After)
 	var updated WalletDoc
-	if err := s.mongoCol.FindOneAndUpdate(ctx, filter, update, opts).Decode(&updated); err != nil {
+	err = s.wallets(req.UserId).FindOneAndUpdate(ctx, filter, update, opts).Decode(&updated)
+	if debit && err == mongo.ErrNoDocuments {
+		log.Printf("[UpdateBalance] %s: insufficient funds for %d", req.UserId, amount)
+		return nil, errInsufficientFunds
+	}
+	if err != nil {
 		log.Printf("[UpdateBalance] mongo FindOneAndUpdate error: %v", err)
 		return nil, err
 	}
 	log.Printf("[UpdateBalance] new Mongo balance for %s = %d", req.UserId, updated.Balance)
 
 	// обновляем кеш
-	log.Printf("[UpdateBalance] setting cache %s=%d", key, updated.Balance)
-	if err := s.redis.Set(ctx, key, updated.Balance, 5*time.Minute).Err(); err != nil {
-		log.Printf("[UpdateBalance] redis SET error: %v", err)
+	s.cacheWallet(ctx, updated)
+
+	return &walletpb.WalletUpdateResponse{NewBalance: money(updated.Balance, currency)}, nil
+}
+
+func (s *server) BatchUpdateBalance(ctx context.Context, req *walletpb.BatchUpdateRequest) (*walletpb.BatchUpdateResponse, error) {
+	parts := []interface{}{"batch"}
+	for _, u := range req.Updates {
+		parts = append(parts, u.UserId, u.GetAmount().GetAmount(), u.GetAmount().GetCurrency(), u.Type, u.Ref)
+	}
+	resp, err := s.idempotent(ctx, req.IdempotencyKey, fingerprint(parts...), &walletpb.BatchUpdateResponse{}, func() (proto.Message, error) {
+		return s.batchUpdateBalance(ctx, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*walletpb.BatchUpdateResponse), nil
+}
+
+func (s *server) batchUpdateBalance(ctx context.Context, req *walletpb.BatchUpdateRequest) (*walletpb.BatchUpdateResponse, error) {
+	log.Printf("[BatchUpdateBalance] %d updates, key=%s", len(req.Updates), req.IdempotencyKey)
+
+	// настоящие кошельки — по проводке на каждую дельту, все одной транзакцией;
+	// демо складываем по пользователю, чтобы была одна операция на кошелёк
+	var postings []posting
+	demoDeltas := make(map[string]int64)
+	demoTypes := make(map[string]string)
+	var demoOrder []string
+	for i, u := range req.Updates {
+		amount, currency, err := s.amountOf(u.UserId, u.Amount)
+		if err != nil {
+			return nil, err
+		}
+		if isDemo(u.UserId) {
+			if _, ok := demoDeltas[u.UserId]; !ok {
+				demoOrder = append(demoOrder, u.UserId)
+			}
+			demoDeltas[u.UserId] += amount
+			if demoTypes[u.UserId] == "" || u.Type != "adjustment" {
+				demoTypes[u.UserId] = u.Type
+			}
+			continue
+		}
+		if amount == 0 {
+			continue
+		}
+		// у каждой проводки пакета свой ключ в журнале
+		p, err := newPosting(u.UserId, currency, amount, u.Type, u.Ref, fmt.Sprintf("%s#%d", req.IdempotencyKey, i))
+		if err != nil {
+			return nil, err
+		}
+		postings = append(postings, p)
+	}
+
+	// демо-кошельки транзакцией не связаны и частично применённый пакет не
+	// откатить: непокрытое списание отклоняем до любой записи
+	if err := s.checkDemoDebits(ctx, demoOrder, demoDeltas, demoTypes); err != nil {
+		return nil, err
+	}
+
+	updated := make(map[string]bool)
+	if len(postings) > 0 {
+		txs, err := s.postOnce(ctx, postings)
+		if err != nil {
+			log.Printf("[BatchUpdateBalance] ledger post error: %v", err)
+			return nil, err
+		}
+		for _, tx := range txs {
+			updated[tx.UserId] = true
+		}
+		s.cachePostings(ctx, txs)
 	}
 
-	return &walletpb.WalletUpdateResponse{NewBalance: updated.Balance}, nil
+	if len(demoOrder) > 0 {
+		models := make([]mongo.WriteModel, 0, len(demoOrder))
+		for _, uid := range demoOrder {
+			filter, debit := debitFilter(uid, s.currency, demoDeltas[uid], demoTypes[uid])
+			models = append(models, mongo.NewUpdateOneModel().
+				SetFilter(filter).
+				SetUpdate(touch(uid, bson.M{"$inc": bson.M{"balance": demoDeltas[uid]}})).
+				SetUpsert(!debit))
+			updated[uid] = true
+		}
+		res, err := s.demoCol.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+		if err != nil {
+			log.Printf("[BatchUpdateBalance] mongo BulkWrite error: %v", err)
+			return nil, err
+		}
+		// новых снимков BulkWrite не отдаёт — перечитываем; версия прочитанного
+		// не старше нашей записи
+		s.recacheDemo(ctx, demoOrder)
+		if n := res.MatchedCount + res.UpsertedCount; n < int64(len(models)) {
+			// баланс уменьшился между проверкой и записью. Остальные дельты уже
+			// применены — ошибкой не отвечаем: ключ отпустился бы, и повтор
+			// применил бы их ещё раз. Частичный итог сохраняется под ключом.
+			rejected := int64(len(models)) - n
+			log.Printf("[BatchUpdateBalance] %d demo debits rejected: insufficient funds", rejected)
+			return &walletpb.BatchUpdateResponse{Updated: int32(int64(len(updated)) - rejected), Rejected: int32(rejected)}, nil
+		}
+	}
+
+	return &walletpb.BatchUpdateResponse{Updated: int32(len(updated))}, nil
+}
+
+// checkDemoDebits проверяет, что каждое демо-списание пакета покрыто балансом.
+func (s *server) checkDemoDebits(ctx context.Context, userIds []string, deltas map[string]int64, types map[string]string) error {
+	var debits []string
+	for _, uid := range userIds {
+		if _, debit := debitFilter(uid, s.currency, deltas[uid], types[uid]); debit {
+			debits = append(debits, uid)
+		}
+	}
+	if len(debits) == 0 {
+		return nil
+	}
+	cur, err := s.demoCol.Find(ctx, bson.M{"user_id": bson.M{"$in": debits}, "currency": s.currency})
+	if err != nil {
+		return err
+	}
+	var docs []WalletDoc
+	if err := cur.All(ctx, &docs); err != nil {
+		return err
+	}
+	balance := make(map[string]int64, len(docs))
+	for _, w := range docs {
+		balance[w.UserId] = w.Balance
+	}
+	for _, uid := range debits {
+		// кошелька нет — баланс нулевой
+		if balance[uid] < -deltas[uid] {
+			log.Printf("[BatchUpdateBalance] %s: insufficient funds for %d", uid, deltas[uid])
+			return errInsufficientFunds
+		}
+	}
+	return nil
 }
 
 func main() {
@@ -135,6 +649,25 @@ func main() {
 	defer cancel()
 
 	srv := NewServer(ctx)
+	go srv.rtp.watch(context.Background())
+	go srv.sweepHolds(context.Background())
+	go srv.resumePayments(context.Background())
+	go srv.sweepBonuses(context.Background())
+	go srv.retryAchievementBonuses(context.Background())
+
+	// метрики Prometheus (RTP и риск по играм) на отдельном порту
+	metricsAddr := os.Getenv("METRICS_ADDR")
+	if metricsAddr == "" {
+		metricsAddr = ":9102"
+	}
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(srv.rtp)
+	go func() {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", promhttp.HandlerFor(reg, promhttp.HandlerOpts{}))
+		log.Printf("metrics on %s/metrics", metricsAddr)
+		log.Fatal(http.ListenAndServe(metricsAddr, mux))
+	}()
 
 	lis, err := net.Listen("tcp", ":50052")
 	if err != nil {
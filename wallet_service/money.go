@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	walletpb "github.com/Arsencchikkk/final/casino/proto/wallet"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Деньги в кошельке — int64 в минимальных единицах валюты (центах, тийынах…),
+// так что дробные суммы и балансы больше 2^31 не проблема. У игрока по
+// подкошельку на каждую валюту из WALLET_CURRENCIES; основной —
+// WALLET_CURRENCY, он же для запросов без валюты. Игры считают в целых
+// кредитах, кредит — одна единица валюты ставки, поэтому у всех валют
+// кошелька должно быть два знака после запятой.
+//
+// currencyExponent — сколько знаков после запятой у валюты (ISO 4217;
+// CRD — внутренние игровые кредиты).
+var currencyExponent = map[string]int{
+	"USD": 2,
+	"EUR": 2,
+	"GBP": 2,
+	"KZT": 2,
+	"RUB": 2,
+	"JPY": 0,
+	"CRD": 2,
+}
+
+// creditMinor — минимальных единиц в одном кредите игр.
+const creditMinor = 100
+
+// money — сумма для ответа.
+func money(amount int64, currency string) *walletpb.Money {
+	return &walletpb.Money{Amount: amount, Currency: currency}
+}
+
+// currencyOf — подкошелёк игрока по коду валюты из запроса, пусто — основной.
+// У демо-кошелька подкошелёк один, основной.
+func (s *server) currencyOf(userId, c string) (string, error) {
+	if c == "" || c == s.currency {
+		return s.currency, nil
+	}
+	if !s.currencies[c] {
+		return "", fmt.Errorf("currency %s is not supported", c)
+	}
+	if isDemo(userId) {
+		return "", fmt.Errorf("demo wallets only hold %s", s.currency)
+	}
+	return c, nil
+}
+
+// amountOf — сумма из запроса в минимальных единицах и её подкошелёк.
+func (s *server) amountOf(userId string, m *walletpb.Money) (int64, string, error) {
+	cur, err := s.currencyOf(userId, m.GetCurrency())
+	if err != nil {
+		return 0, "", err
+	}
+	return m.GetAmount(), cur, nil
+}
+
+// parseCurrencies разбирает WALLET_CURRENCIES; основная валюта входит всегда.
+func parseCurrencies(list, main string) (map[string]bool, error) {
+	out := map[string]bool{main: true}
+	for _, c := range strings.Split(list, ",") {
+		c = strings.ToUpper(strings.TrimSpace(c))
+		if c == "" {
+			continue
+		}
+		if exp, ok := currencyExponent[c]; !ok || exp != 2 {
+			return nil, fmt.Errorf("%q: need a known currency with 2 decimal places (a game credit is 100 minor units)", c)
+		}
+		out[c] = true
+	}
+	return out, nil
+}
+
+// parseAmount читает десятичную сумму ("500.00") в минимальные единицы валюты.
+func parseAmount(v, currency string) (int64, error) {
+	r, ok := new(big.Rat).SetString(v)
+	if !ok || r.Sign() < 0 || strings.ContainsAny(v, "/eE") {
+		return 0, fmt.Errorf("bad amount %q", v)
+	}
+	r.Mul(r, new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(currencyExponent[currency])), nil)))
+	if !r.IsInt() || !r.Num().IsInt64() {
+		return 0, fmt.Errorf("bad amount %q for %s", v, currency)
+	}
+	return r.Num().Int64(), nil
+}
+
+// units — целые кредиты (правила достижений, DEMO_BALANCE) в минимальных единицах.
+func units(credits int64) int64 {
+	return credits * creditMinor
+}
+
+// walletFilter — подкошелёк игрока в одной валюте.
+func walletFilter(userId, currency string) bson.M {
+	return bson.M{"user_id": userId, "currency": currency}
+}
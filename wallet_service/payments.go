@@ -0,0 +1,437 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	walletpb "github.com/Arsencchikkk/final/casino/proto/wallet"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/protobuf/proto"
+)
+
+// Пополнения и выводы. Платёж проходит статусы
+//
+//	pending → approved | rejected
+//	approved → completed | failed
+//
+// Пополнение одобряется сразу и уходит провайдеру; деньги приходят на
+// кошелёк проводкой deposit, только когда провайдер подтвердил оплату.
+// Вывод списывается проводкой withdrawal сразу при заявке, чтобы деньги не
+// ушли в ставки, пока заявка ждёт. Выводы больше порога (в основной валюте)
+// ждут решения админа, остальные одобряются сами. Отказ админа или
+// провайдера возвращает списанное встречной проводкой withdrawal.
+//
+// Смена статуса и проводка — одна транзакция Mongo, а статус меняется только
+// из ожидаемого, поэтому повторное уведомление провайдера или двойное
+// нажатие админа деньги второй раз не двигают.
+type PaymentDoc struct {
+	Id          string    `bson:"_id"`
+	UserId      string    `bson:"user_id"`
+	Kind        string    `bson:"kind"` // deposit, withdrawal
+	Amount      int64     `bson:"amount"`
+	Currency    string    `bson:"currency"`
+	Status      string    `bson:"status"`
+	Provider    string    `bson:"provider"`
+	ProviderRef string    `bson:"provider_ref,omitempty"`
+	Reason      string    `bson:"reason,omitempty"`
+	ReviewedBy  string    `bson:"reviewed_by,omitempty"`
+	CreatedAt   time.Time `bson:"created_at"`
+	UpdatedAt   time.Time `bson:"updated_at"`
+}
+
+// paymentNext — куда можно перейти из статуса.
+var paymentNext = map[string][]string{
+	"pending":  {"approved", "rejected"},
+	"approved": {"completed", "failed"},
+}
+
+// errPaymentMoved — статус платежа поменяли раньше нас.
+var errPaymentMoved = errors.New("payment status changed concurrently")
+
+// errPaymentsDisabled — платёжный провайдер не настроен.
+var errPaymentsDisabled = errors.New("payments are disabled: no payment provider configured")
+
+func paymentIndexes(ctx context.Context, col *mongo.Collection) error {
+	_, err := col.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}}},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "kind", Value: 1}}},
+	})
+	return err
+}
+
+func paymentToPb(p PaymentDoc) *walletpb.Payment {
+	return &walletpb.Payment{
+		PaymentId:   p.Id,
+		UserId:      p.UserId,
+		Kind:        p.Kind,
+		Amount:      money(p.Amount, p.Currency),
+		Status:      p.Status,
+		Provider:    p.Provider,
+		ProviderRef: p.ProviderRef,
+		Reason:      p.Reason,
+		ReviewedBy:  p.ReviewedBy,
+		CreatedAt:   p.CreatedAt.UnixMilli(),
+		UpdatedAt:   p.UpdatedAt.UnixMilli(),
+	}
+}
+
+func (s *server) RequestDeposit(ctx context.Context, req *walletpb.PaymentRequest) (*walletpb.Payment, error) {
+	fp := fingerprint("deposit", req.UserId, req.GetAmount().GetAmount(), req.GetAmount().GetCurrency())
+	resp, err := s.idempotent(ctx, req.IdempotencyKey, fp, &walletpb.Payment{}, func() (proto.Message, error) {
+		return s.requestPayment(ctx, "deposit", req)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*walletpb.Payment), nil
+}
+
+func (s *server) RequestWithdrawal(ctx context.Context, req *walletpb.PaymentRequest) (*walletpb.Payment, error) {
+	fp := fingerprint("withdrawal", req.UserId, req.GetAmount().GetAmount(), req.GetAmount().GetCurrency())
+	resp, err := s.idempotent(ctx, req.IdempotencyKey, fp, &walletpb.Payment{}, func() (proto.Message, error) {
+		return s.requestPayment(ctx, "withdrawal", req)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*walletpb.Payment), nil
+}
+
+func (s *server) requestPayment(ctx context.Context, kind string, req *walletpb.PaymentRequest) (*walletpb.Payment, error) {
+	if req.UserId == "" {
+		return nil, fmt.Errorf("user_id required")
+	}
+	if isDemo(req.UserId) {
+		return nil, fmt.Errorf("demo wallets cannot deposit or withdraw")
+	}
+	if s.provider == nil {
+		return nil, errPaymentsDisabled
+	}
+	amount, currency, err := s.amountOf(req.UserId, req.Amount)
+	if err != nil {
+		return nil, err
+	}
+	if amount <= 0 {
+		return nil, fmt.Errorf("amount must be positive")
+	}
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	p := PaymentDoc{
+		Id:        primitive.NewObjectID().Hex(),
+		UserId:    req.UserId,
+		Kind:      kind,
+		Amount:    amount,
+		Currency:  currency,
+		Status:    "pending",
+		Provider:  s.provider.Name(),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if kind == "deposit" {
+		if _, err := s.payments.InsertOne(ctx, p); err != nil {
+			return nil, err
+		}
+		log.Printf("[payments] deposit %s: %d %s for %s", p.Id, amount, currency, p.UserId)
+		if p, err = s.approvePayment(ctx, p, ""); err != nil {
+			return nil, err
+		}
+		return paymentToPb(p), nil
+	}
+
+	// вывод: заявка и списание вместе
+	out, err := s.inTx(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		if _, err := s.payments.InsertOne(sc, p); err != nil {
+			return nil, err
+		}
+		return s.postIn(sc, []posting{{userId: p.UserId, currency: currency, amount: -amount, typ: "withdrawal", ref: p.Id, key: "payment:" + p.Id + ":debit"}})
+	})
+	if err != nil {
+		log.Printf("[payments] withdrawal %d %s for %s: %v", amount, currency, p.UserId, err)
+		return nil, err
+	}
+	s.cachePostings(ctx, out.([]TxDoc))
+	review, err := s.needsReview(ctx, amount, currency)
+	if err != nil {
+		log.Printf("[payments] withdrawal %s goes to review: %v", p.Id, err)
+	}
+	log.Printf("[payments] withdrawal %s: %d %s for %s (review: %v)", p.Id, amount, currency, p.UserId, review)
+	if !review {
+		if p, err = s.approvePayment(ctx, p, ""); err != nil {
+			return nil, err
+		}
+	}
+	return paymentToPb(p), nil
+}
+
+// needsReview — вывод больше порога WITHDRAWAL_REVIEW_ABOVE. Другие валюты
+// сравниваются по курсу в основную; нет курса — решает админ.
+func (s *server) needsReview(ctx context.Context, amount int64, currency string) (bool, error) {
+	if currency != s.currency {
+		_, rate, err := s.rate(ctx, currency, s.currency)
+		if err != nil {
+			return true, err
+		}
+		if amount, err = convertAmount(amount, currency, s.currency, rate); err != nil {
+			return true, err
+		}
+	}
+	return amount > s.reviewAbove, nil
+}
+
+func (s *server) ReviewWithdrawal(ctx context.Context, req *walletpb.ReviewWithdrawalRequest) (*walletpb.Payment, error) {
+	if req.PaymentId == "" || req.AdminId == "" {
+		return nil, fmt.Errorf("payment_id and admin_id required")
+	}
+	var p PaymentDoc
+	if err := s.payments.FindOne(ctx, bson.M{"_id": req.PaymentId}).Decode(&p); err == mongo.ErrNoDocuments {
+		return nil, fmt.Errorf("payment not found")
+	} else if err != nil {
+		return nil, err
+	}
+	if p.Kind != "withdrawal" {
+		return nil, fmt.Errorf("only withdrawals are reviewed")
+	}
+	var err error
+	if req.Approve {
+		p, err = s.approvePayment(ctx, p, req.AdminId)
+	} else {
+		reason := req.Reason
+		if reason == "" {
+			reason = "rejected by admin"
+		}
+		p, err = s.movePayment(ctx, p, "rejected", bson.M{"reviewed_by": req.AdminId, "reason": reason}, s.refundPosting(p))
+	}
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("[payments] withdrawal %s %s by %s", p.Id, p.Status, req.AdminId)
+	return paymentToPb(p), nil
+}
+
+// approvePayment одобряет платёж и отдаёт его провайдеру.
+func (s *server) approvePayment(ctx context.Context, p PaymentDoc, adminId string) (PaymentDoc, error) {
+	set := bson.M{}
+	if adminId != "" {
+		set["reviewed_by"] = adminId
+	}
+	p, err := s.movePayment(ctx, p, "approved", set, nil)
+	if err != nil {
+		return p, err
+	}
+	return s.dispatch(ctx, p), nil
+}
+
+// dispatch отдаёт одобренный платёж провайдеру. Если провайдер его не
+// принял, платёж закрывается как failed.
+func (s *server) dispatch(ctx context.Context, p PaymentDoc) PaymentDoc {
+	if s.provider == nil {
+		// остаётся одобренным: его отправит resumePayments, когда провайдер появится
+		log.Printf("[payments] %s %s: %v", p.Kind, p.Id, errPaymentsDisabled)
+		return p
+	}
+	start := s.provider.Deposit
+	if p.Kind == "withdrawal" {
+		start = s.provider.Payout
+	}
+	ref, err := start(ctx, p)
+	if err != nil {
+		log.Printf("[payments] %s %s: provider error: %v", p.Kind, p.Id, err)
+		if failed, ferr := s.settlePayment(ctx, p, PaymentEvent{PaymentId: p.Id, Reason: err.Error()}); ferr == nil {
+			return failed
+		}
+		return p
+	}
+	// уведомление могло прийти раньше и само записать ref
+	if _, err := s.payments.UpdateOne(ctx, bson.M{"_id": p.Id, "provider_ref": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"provider_ref": ref}}); err != nil {
+		log.Printf("[payments] %s provider_ref: %v", p.Id, err)
+	}
+	p.ProviderRef = ref
+	return p
+}
+
+// settlePayment закрывает одобренный платёж по итогу от провайдера.
+func (s *server) settlePayment(ctx context.Context, p PaymentDoc, ev PaymentEvent) (PaymentDoc, error) {
+	set := bson.M{}
+	if ev.ProviderRef != "" {
+		set["provider_ref"] = ev.ProviderRef
+	}
+	if !ev.Success {
+		set["reason"] = ev.Reason
+		return s.movePayment(ctx, p, "failed", set, s.refundPosting(p))
+	}
+	if p.Kind == "withdrawal" {
+		return s.movePayment(ctx, p, "completed", set, nil)
+	}
+	p, err := s.movePayment(ctx, p, "completed", set, []posting{{userId: p.UserId, currency: p.Currency, amount: p.Amount, typ: "deposit", ref: p.Id, key: "payment:" + p.Id + ":credit"}})
+	if err == nil {
+		s.reloadBonus(ctx, p)
+	}
+	return p, err
+}
+
+// refundPosting — возврат списанного под вывод; у пополнения возвращать нечего.
+func (s *server) refundPosting(p PaymentDoc) []posting {
+	if p.Kind != "withdrawal" {
+		return nil
+	}
+	return []posting{{userId: p.UserId, currency: p.Currency, amount: p.Amount, typ: "withdrawal", ref: p.Id, key: "payment:" + p.Id + ":reversal"}}
+}
+
+// movePayment переводит платёж в статус to вместе с проводками ps. Если
+// платёж уже в to (повтор), отдаёт его как есть.
+func (s *server) movePayment(ctx context.Context, p PaymentDoc, to string, set bson.M, ps []posting) (PaymentDoc, error) {
+	allowed := false
+	for _, next := range paymentNext[p.Status] {
+		allowed = allowed || next == to
+	}
+	if !allowed {
+		if p.Status == to {
+			return p, nil
+		}
+		return p, fmt.Errorf("payment is %s, cannot become %s", p.Status, to)
+	}
+	set["status"] = to
+	set["updated_at"] = time.Now().UTC().Truncate(time.Millisecond)
+	var txs []TxDoc
+	out, err := s.inTx(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		var d PaymentDoc
+		err := s.payments.FindOneAndUpdate(sc,
+			bson.M{"_id": p.Id, "status": p.Status},
+			bson.M{"$set": set},
+			options.FindOneAndUpdate().SetReturnDocument(options.After),
+		).Decode(&d)
+		if err == mongo.ErrNoDocuments {
+			return nil, errPaymentMoved
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(ps) > 0 {
+			if txs, err = s.postIn(sc, ps); err != nil {
+				return nil, err
+			}
+		}
+		return d, nil
+	})
+	if err == errPaymentMoved {
+		// перечитываем: если нас опередили тем же переходом — это повтор
+		var d PaymentDoc
+		if ferr := s.payments.FindOne(ctx, bson.M{"_id": p.Id}).Decode(&d); ferr != nil {
+			return p, ferr
+		}
+		if d.Status == to {
+			return d, nil
+		}
+		return d, fmt.Errorf("payment is %s, cannot become %s", d.Status, to)
+	}
+	if err != nil {
+		log.Printf("[payments] %s %s -> %s: %v", p.Kind, p.Id, to, err)
+		return p, err
+	}
+	s.cachePostings(ctx, txs)
+	return out.(PaymentDoc), nil
+}
+
+func (s *server) PaymentWebhook(ctx context.Context, req *walletpb.PaymentWebhookRequest) (*walletpb.Payment, error) {
+	if s.provider == nil || req.Provider != s.provider.Name() {
+		return nil, fmt.Errorf("unknown payment provider %q", req.Provider)
+	}
+	ev, err := s.provider.ParseWebhook(req.Payload, req.Signature)
+	if err != nil {
+		log.Printf("[payments] webhook from %s rejected: %v", req.Provider, err)
+		return nil, err
+	}
+	var p PaymentDoc
+	if err := s.payments.FindOne(ctx, bson.M{"_id": ev.PaymentId, "provider": req.Provider}).Decode(&p); err == mongo.ErrNoDocuments {
+		return nil, fmt.Errorf("payment not found")
+	} else if err != nil {
+		return nil, err
+	}
+	if p.ProviderRef != "" && ev.ProviderRef != p.ProviderRef {
+		return nil, fmt.Errorf("provider_ref does not match the payment")
+	}
+	if p, err = s.settlePayment(ctx, p, ev); err != nil {
+		return nil, err
+	}
+	log.Printf("[payments] %s %s %s (%s)", p.Kind, p.Id, p.Status, p.Reason)
+	return paymentToPb(p), nil
+}
+
+func (s *server) ListPayments(ctx context.Context, req *walletpb.ListPaymentsRequest) (*walletpb.ListPaymentsResponse, error) {
+	filter := bson.M{}
+	if req.UserId != "" {
+		filter["user_id"] = req.UserId
+	}
+	if req.Status != "" {
+		filter["status"] = req.Status
+	}
+	if req.Kind != "" {
+		filter["kind"] = req.Kind
+	}
+	limit := int64(req.Limit)
+	if limit <= 0 {
+		limit = txDefaultLimit
+	}
+	if limit > txMaxLimit {
+		limit = txMaxLimit
+	}
+	cur, err := s.payments.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}).SetLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	var docs []PaymentDoc
+	if err := cur.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	resp := &walletpb.ListPaymentsResponse{}
+	for _, d := range docs {
+		resp.Payments = append(resp.Payments, paymentToPb(d))
+	}
+	return resp, nil
+}
+
+// resumePayments после рестарта доводит платежи, оборванные на полпути:
+// пополнения, не успевшие получить одобрение, и одобренные платежи без
+// итога — их ещё раз отдаём провайдеру (повтор у провайдера безопасен).
+func (s *server) resumePayments(ctx context.Context) {
+	if s.provider == nil {
+		return
+	}
+	cur, err := s.payments.Find(ctx, bson.M{"$or": bson.A{
+		bson.M{"status": "pending", "kind": "deposit"},
+		bson.M{"status": "approved"},
+	}})
+	if err != nil {
+		log.Printf("[payments] resume: %v", err)
+		return
+	}
+	var docs []PaymentDoc
+	if err := cur.All(ctx, &docs); err != nil {
+		log.Printf("[payments] resume: %v", err)
+		return
+	}
+	for _, p := range docs {
+		if p.Provider != s.provider.Name() {
+			log.Printf("[payments] %s is with provider %s, not configured", p.Id, p.Provider)
+			continue
+		}
+		if p.Status == "pending" {
+			_, err = s.approvePayment(ctx, p, "")
+		} else {
+			s.dispatch(ctx, p)
+		}
+		if err != nil {
+			log.Printf("[payments] resume %s: %v", p.Id, err)
+		}
+	}
+	if len(docs) > 0 {
+		log.Printf("[payments] resumed %d payments", len(docs))
+	}
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+)
+
+// PaymentProvider — платёжный провайдер. Платёж он принимает к исполнению
+// сразу, а итог сообщает позже уведомлением (webhook): шлюз принимает его на
+// /api/payments/webhook/:provider и передаёт в PaymentWebhook как есть,
+// подпись проверяет сам провайдер.
+type PaymentProvider interface {
+	Name() string
+	// Deposit и Payout отдают платёж провайдеру и возвращают его id у
+	// провайдера. Повтор с тем же платежом (после рестарта) второго не создаёт.
+	Deposit(ctx context.Context, p PaymentDoc) (string, error)
+	Payout(ctx context.Context, p PaymentDoc) (string, error)
+	// ParseWebhook проверяет подпись уведомления и разбирает его.
+	ParseWebhook(payload []byte, signature string) (PaymentEvent, error)
+}
+
+// PaymentEvent — итог платежа от провайдера.
+type PaymentEvent struct {
+	PaymentId   string `json:"payment_id"`
+	ProviderRef string `json:"provider_ref"`
+	Success     bool   `json:"success"`
+	Reason      string `json:"reason,omitempty"`
+}
+
+// fakeProvider — провайдер для разработки: денег не двигает, а через delay
+// сам присылает подписанное уведомление об итоге. Без webhookURL оно уходит
+// прямо в сервис, с ним — POST на шлюз, как от настоящего провайдера.
+// Суммы, оканчивающиеся на .99, он отклоняет — так проверяется путь отказа.
+type fakeProvider struct {
+	secret     []byte
+	delay      time.Duration
+	webhookURL string
+	// доставка уведомления в сервис, когда webhookURL не задан
+	deliver func(payload []byte, signature string)
+}
+
+// newFakeProvider — секрет подписи случайный: подписывает и проверяет один
+// и тот же процесс.
+func newFakeProvider(delay time.Duration, webhookURL string, deliver func([]byte, string)) *fakeProvider {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		log.Fatalf("[payments] fake provider secret: %v", err)
+	}
+	return &fakeProvider{secret: secret, delay: delay, webhookURL: webhookURL, deliver: deliver}
+}
+
+func (f *fakeProvider) Name() string { return "fake" }
+
+func (f *fakeProvider) Deposit(ctx context.Context, p PaymentDoc) (string, error) {
+	return f.start(p), nil
+}
+
+func (f *fakeProvider) Payout(ctx context.Context, p PaymentDoc) (string, error) {
+	return f.start(p), nil
+}
+
+func (f *fakeProvider) start(p PaymentDoc) string {
+	ref := "fake_" + p.Id
+	ev := PaymentEvent{PaymentId: p.Id, ProviderRef: ref, Success: true}
+	if p.Amount%100 == 99 {
+		ev.Success, ev.Reason = false, "declined by the fake provider"
+	}
+	go func() {
+		time.Sleep(f.delay)
+		payload, _ := json.Marshal(ev)
+		sig := f.sign(payload)
+		if f.webhookURL == "" {
+			f.deliver(payload, sig)
+			return
+		}
+		req, err := http.NewRequest(http.MethodPost, f.webhookURL, bytes.NewReader(payload))
+		if err != nil {
+			log.Printf("[payments] fake webhook: %v", err)
+			return
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Signature", sig)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			log.Printf("[payments] fake webhook: %v", err)
+			return
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			log.Printf("[payments] fake webhook for %s: HTTP %d", p.Id, resp.StatusCode)
+		}
+	}()
+	return ref
+}
+
+func (f *fakeProvider) sign(payload []byte) string {
+	m := hmac.New(sha256.New, f.secret)
+	m.Write(payload)
+	return hex.EncodeToString(m.Sum(nil))
+}
+
+func (f *fakeProvider) ParseWebhook(payload []byte, signature string) (PaymentEvent, error) {
+	var ev PaymentEvent
+	if !hmac.Equal([]byte(signature), []byte(f.sign(payload))) {
+		return ev, fmt.Errorf("bad webhook signature")
+	}
+	if err := json.Unmarshal(payload, &ev); err != nil {
+		return ev, fmt.Errorf("bad webhook payload: %v", err)
+	}
+	return ev, nil
+}
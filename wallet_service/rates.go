@@ -0,0 +1,230 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+	"time"
+
+	walletpb "github.com/Arsencchikkk/final/casino/proto/wallet"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/protobuf/proto"
+)
+
+// Обмен между подкошельками. Курс пары ставит админ (SetRate), обратное
+// направление — отдельная пара, так что спред задаётся явно. Обмен — две
+// проводки conversion с общим ref (id обмена) и курсом: списание из одного
+// подкошелька и зачисление в другой, на другой стороне — счёт house:fx.
+// Зачисление округляется вниз до минимальной единицы.
+
+// RateDoc — курс пары в коллекции rates, _id — "FROM/TO".
+type RateDoc struct {
+	Id        string    `bson:"_id"`
+	From      string    `bson:"from"`
+	To        string    `bson:"to"`
+	Rate      string    `bson:"rate"` // десятичная строка, см. parseRate
+	UpdatedAt time.Time `bson:"updated_at"`
+	UpdatedBy string    `bson:"updated_by,omitempty"`
+}
+
+func rateToPb(d RateDoc) *walletpb.Rate {
+	return &walletpb.Rate{From: d.From, To: d.To, Rate: d.Rate, UpdatedAt: d.UpdatedAt.UnixMilli(), UpdatedBy: d.UpdatedBy}
+}
+
+// parseRate принимает только положительную десятичную дробь ("472.15"):
+// big.Rat понял бы и "1/3", и "1e3", но в журнале курс должен читаться как есть.
+func parseRate(v string) (*big.Rat, error) {
+	if v == "" || strings.ContainsAny(v, "/eE+-") {
+		return nil, fmt.Errorf("rate must be a positive decimal like 472.15")
+	}
+	r, ok := new(big.Rat).SetString(v)
+	if !ok || r.Sign() <= 0 {
+		return nil, fmt.Errorf("rate must be a positive decimal like 472.15")
+	}
+	return r, nil
+}
+
+// convertAmount переводит сумму from в валюту to по курсу, с округлением к
+// нулю до минимальной единицы.
+func convertAmount(amount int64, from, to string, rate *big.Rat) (int64, error) {
+	v := new(big.Rat).Mul(new(big.Rat).SetInt64(amount), rate)
+	// у валют может быть разное число знаков после запятой
+	shift := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(currencyExponent[to]-currencyExponent[from]))), nil))
+	if currencyExponent[to] >= currencyExponent[from] {
+		v.Mul(v, shift)
+	} else {
+		v.Quo(v, shift)
+	}
+	q := new(big.Int).Quo(v.Num(), v.Denom())
+	if !q.IsInt64() {
+		return 0, fmt.Errorf("converted amount is out of range")
+	}
+	return q.Int64(), nil
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// rate — курс пары из таблицы.
+func (s *server) rate(ctx context.Context, from, to string) (RateDoc, *big.Rat, error) {
+	var d RateDoc
+	if err := s.rates.FindOne(ctx, bson.M{"_id": from + "/" + to}).Decode(&d); err == mongo.ErrNoDocuments {
+		return d, nil, fmt.Errorf("no rate for %s to %s", from, to)
+	} else if err != nil {
+		return d, nil, err
+	}
+	r, err := parseRate(d.Rate)
+	if err != nil {
+		return d, nil, fmt.Errorf("rate %s: %v", d.Id, err)
+	}
+	return d, r, nil
+}
+
+func (s *server) SetRate(ctx context.Context, req *walletpb.SetRateRequest) (*walletpb.Rate, error) {
+	from, to := strings.ToUpper(req.From), strings.ToUpper(req.To)
+	if !s.currencies[from] || !s.currencies[to] {
+		return nil, fmt.Errorf("both currencies must be wallet currencies")
+	}
+	if from == to {
+		return nil, fmt.Errorf("from and to must differ")
+	}
+	id := from + "/" + to
+	if req.Rate == "" {
+		if _, err := s.rates.DeleteOne(ctx, bson.M{"_id": id}); err != nil {
+			return nil, err
+		}
+		log.Printf("[SetRate] %s removed by %s", id, req.AdminId)
+		return &walletpb.Rate{From: from, To: to}, nil
+	}
+	if _, err := parseRate(req.Rate); err != nil {
+		return nil, err
+	}
+	d := RateDoc{Id: id, From: from, To: to, Rate: req.Rate, UpdatedAt: time.Now().UTC().Truncate(time.Millisecond), UpdatedBy: req.AdminId}
+	if _, err := s.rates.ReplaceOne(ctx, bson.M{"_id": id}, d, options.Replace().SetUpsert(true)); err != nil {
+		log.Printf("[SetRate] mongo ReplaceOne error: %v", err)
+		return nil, err
+	}
+	log.Printf("[SetRate] %s = %s by %s", id, d.Rate, req.AdminId)
+	return rateToPb(d), nil
+}
+
+func (s *server) ListRates(ctx context.Context, req *walletpb.ListRatesRequest) (*walletpb.ListRatesResponse, error) {
+	cur, err := s.rates.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	var docs []RateDoc
+	if err := cur.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	resp := &walletpb.ListRatesResponse{}
+	for _, d := range docs {
+		resp.Rates = append(resp.Rates, rateToPb(d))
+	}
+	return resp, nil
+}
+
+func (s *server) Convert(ctx context.Context, req *walletpb.ConvertRequest) (*walletpb.ConvertResponse, error) {
+	fp := fingerprint("convert", req.UserId, req.GetAmount().GetAmount(), req.GetAmount().GetCurrency(), req.ToCurrency)
+	resp, err := s.idempotent(ctx, req.IdempotencyKey, fp, &walletpb.ConvertResponse{}, func() (proto.Message, error) {
+		return s.convert(ctx, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*walletpb.ConvertResponse), nil
+}
+
+func (s *server) convert(ctx context.Context, req *walletpb.ConvertRequest) (*walletpb.ConvertResponse, error) {
+	if req.UserId == "" {
+		return nil, fmt.Errorf("user_id required")
+	}
+	if isDemo(req.UserId) {
+		return nil, fmt.Errorf("demo wallets have a single currency")
+	}
+	amount, from, err := s.amountOf(req.UserId, req.Amount)
+	if err != nil {
+		return nil, err
+	}
+	if req.ToCurrency == "" {
+		return nil, fmt.Errorf("to_currency required")
+	}
+	to, err := s.currencyOf(req.UserId, req.ToCurrency)
+	if err != nil {
+		return nil, err
+	}
+	if from == to {
+		return nil, fmt.Errorf("cannot convert %s to itself", from)
+	}
+	if amount <= 0 {
+		return nil, fmt.Errorf("amount must be positive")
+	}
+	rd, rate, err := s.rate(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	credited, err := convertAmount(amount, from, to, rate)
+	if err != nil {
+		return nil, err
+	}
+	if credited <= 0 {
+		return nil, fmt.Errorf("amount is too small to convert")
+	}
+
+	id := primitive.NewObjectID().Hex()
+	txs, err := s.postOnce(ctx, []posting{
+		{userId: req.UserId, currency: from, amount: -amount, typ: "conversion", ref: id, key: req.IdempotencyKey + ":from", rate: rd.Rate},
+		{userId: req.UserId, currency: to, amount: credited, typ: "conversion", ref: id, key: req.IdempotencyKey + ":to", rate: rd.Rate},
+	})
+	if err != nil {
+		log.Printf("[Convert] %s %d %s -> %s: %v", req.UserId, amount, from, to, err)
+		return nil, err
+	}
+	s.cachePostings(ctx, txs)
+	// при повторе txs — первые проводки, с их id обмена и курсом
+	debit, credit := txs[0], txs[1]
+	log.Printf("[Convert] %s: %d %s -> %d %s at %s (%s)", req.UserId, -debit.Amount, from, credit.Amount, to, debit.Rate, debit.Ref)
+	return &walletpb.ConvertResponse{
+		ConversionId: debit.Ref,
+		Debited:      money(-debit.Amount, from),
+		Credited:     money(credit.Amount, to),
+		Rate:         debit.Rate,
+		FromBalance:  money(debit.Available, from),
+		ToBalance:    money(credit.Available, to),
+	}, nil
+}
+
+// inMain — итог раунда в основной валюте для RTP, лидербордов и достижений:
+// суммы других подкошельков пересчитываются по нынешнему курсу. false —
+// курса нет, такой раунд в статистику не идёт (в аудит он уже записан).
+func (s *server) inMain(ctx context.Context, r *walletpb.GameResult) (*walletpb.GameResult, bool) {
+	cur := r.GetStake().GetCurrency()
+	if cur == "" || cur == s.currency {
+		return r, true
+	}
+	_, rate, err := s.rate(ctx, cur, s.currency)
+	if err != nil {
+		log.Printf("[RecordResults] %s/%s left out of stats: %v", r.Game, r.RoundId, err)
+		return nil, false
+	}
+	conv := func(m *walletpb.Money) *walletpb.Money {
+		if m == nil {
+			return nil
+		}
+		// суммы раундов далеки от границ int64, ошибки тут не бывает
+		v, _ := convertAmount(m.Amount, cur, s.currency, rate)
+		return money(v, s.currency)
+	}
+	n := proto.Clone(r).(*walletpb.GameResult)
+	n.Stake, n.Payout, n.Liability = conv(r.Stake), conv(r.Payout), conv(r.Liability)
+	return n, true
+}
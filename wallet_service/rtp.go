@@ -0,0 +1,293 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	walletpb "github.com/Arsencchikkk/final/casino/proto/wallet"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Мониторинг RTP. Каждый раунд из RecordResults попадает в минутную корзину
+// своей группы (игра + стол + вариант правил); окна 15m/1h/24h — сумма
+// последних корзин. Статистика живёт в памяти экземпляра и после рестарта
+// набирается заново — для истории есть журнал аудита.
+//
+// Доверительный интервал строится по выплате на единицу ставки x = payout/stake:
+// RTP ± z·s/√n, где s — выборочное отклонение x. Если теоретический RTP игры
+// вне интервала и раундов в окне не меньше rtpMinRounds, группа в тревоге.
+var rtpWindows = []struct {
+	name string
+	d    time.Duration
+}{
+	{"15m", 15 * time.Minute},
+	{"1h", time.Hour},
+	{"24h", 24 * time.Hour},
+}
+
+const rtpBucket = time.Minute
+
+type rtpKey struct {
+	Game, Table, RuleSet string
+}
+
+type rtpBucketStats struct {
+	rounds, wagered, paid int64
+	sumX, sumX2           float64
+	maxLiability          int64
+}
+
+type rtpGroup struct {
+	theoretical float64
+	buckets     map[int64]*rtpBucketStats // unix-минута -> корзина
+	alerting    map[string]bool           // окно -> была ли тревога на прошлой проверке
+}
+
+type rtpMonitor struct {
+	mu        sync.Mutex
+	groups    map[rtpKey]*rtpGroup
+	z         float64
+	minRounds int64
+	now       func() time.Time
+}
+
+func newRtpMonitor(z float64, minRounds int64) *rtpMonitor {
+	return &rtpMonitor{groups: make(map[rtpKey]*rtpGroup), z: z, minRounds: minRounds, now: time.Now}
+}
+
+// record учитывает рассчитанный раунд.
+func (m *rtpMonitor) record(r *walletpb.GameResult) {
+	stake, payout := r.GetStake().GetAmount(), r.GetPayout().GetAmount()
+	if stake <= 0 {
+		return
+	}
+	key := rtpKey{r.Game, r.Table, r.RuleSet}
+	minute := m.now().Truncate(rtpBucket).Unix()
+	liability := r.GetLiability().GetAmount()
+	if payout > liability {
+		liability = payout
+	}
+	x := float64(payout) / float64(stake)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	g := m.groups[key]
+	if g == nil {
+		g = &rtpGroup{buckets: make(map[int64]*rtpBucketStats), alerting: make(map[string]bool)}
+		m.groups[key] = g
+	}
+	if r.Rtp > 0 {
+		g.theoretical = r.Rtp
+	}
+	b := g.buckets[minute]
+	if b == nil {
+		b = &rtpBucketStats{}
+		g.buckets[minute] = b
+	}
+	b.rounds++
+	b.wagered += stake
+	b.paid += payout
+	b.sumX += x
+	b.sumX2 += x * x
+	if liability > b.maxLiability {
+		b.maxLiability = liability
+	}
+}
+
+// stats считает окна по всем группам; пустые окна пропускаются. Заодно
+// выкидывает корзины старше самого длинного окна.
+func (m *rtpMonitor) stats(window, game string) []*walletpb.RtpStat {
+	now := m.now()
+	oldest := now.Add(-rtpWindows[len(rtpWindows)-1].d).Truncate(rtpBucket).Unix()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []*walletpb.RtpStat
+	for key, g := range m.groups {
+		for minute := range g.buckets {
+			if minute < oldest {
+				delete(g.buckets, minute)
+			}
+		}
+		if len(g.buckets) == 0 {
+			delete(m.groups, key)
+			continue
+		}
+		if game != "" && key.Game != game {
+			continue
+		}
+		for _, w := range rtpWindows {
+			if window != "" && w.name != window {
+				continue
+			}
+			from := now.Add(-w.d).Truncate(rtpBucket).Unix()
+			var sum rtpBucketStats
+			for minute, b := range g.buckets {
+				if minute < from {
+					continue
+				}
+				sum.rounds += b.rounds
+				sum.wagered += b.wagered
+				sum.paid += b.paid
+				sum.sumX += b.sumX
+				sum.sumX2 += b.sumX2
+				if b.maxLiability > sum.maxLiability {
+					sum.maxLiability = b.maxLiability
+				}
+			}
+			if sum.rounds == 0 {
+				continue
+			}
+			out = append(out, m.stat(key, g, w.name, &sum))
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if a.Game != b.Game {
+			return a.Game < b.Game
+		}
+		if a.Table != b.Table {
+			return a.Table < b.Table
+		}
+		if a.RuleSet != b.RuleSet {
+			return a.RuleSet < b.RuleSet
+		}
+		return a.Window < b.Window
+	})
+	return out
+}
+
+func (m *rtpMonitor) stat(key rtpKey, g *rtpGroup, window string, s *rtpBucketStats) *walletpb.RtpStat {
+	st := &walletpb.RtpStat{
+		Game:           key.Game,
+		Table:          key.Table,
+		RuleSet:        key.RuleSet,
+		Window:         window,
+		Rounds:         s.rounds,
+		Wagered:        s.wagered,
+		Paid:           s.paid,
+		Rtp:            float64(s.paid) / float64(s.wagered),
+		TheoreticalRtp: g.theoretical,
+		MaxLiability:   s.maxLiability,
+	}
+	st.CiLow, st.CiHigh = st.Rtp, st.Rtp
+	if s.rounds > 1 {
+		n := float64(s.rounds)
+		mean := s.sumX / n
+		variance := (s.sumX2 - n*mean*mean) / (n - 1)
+		if variance > 0 {
+			half := m.z * math.Sqrt(variance/n)
+			st.CiLow, st.CiHigh = st.Rtp-half, st.Rtp+half
+		}
+	}
+	st.Alert = g.theoretical > 0 && s.rounds >= m.minRounds &&
+		(g.theoretical < st.CiLow || g.theoretical > st.CiHigh)
+	return st
+}
+
+// watch раз в минуту проверяет окна и пишет в лог, когда группа входит в
+// тревогу и выходит из неё. Сами алерты — по метрике casino_rtp_alert.
+func (m *rtpMonitor) watch(ctx context.Context) {
+	t := time.NewTicker(rtpBucket)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+		for _, st := range m.stats("", "") {
+			key := rtpKey{st.Game, st.Table, st.RuleSet}
+			m.mu.Lock()
+			g := m.groups[key]
+			was := g != nil && g.alerting[st.Window]
+			if g != nil {
+				g.alerting[st.Window] = st.Alert
+			}
+			m.mu.Unlock()
+			switch {
+			case st.Alert && !was:
+				log.Printf("[rtp] ALERT %s: RTP %.4f over %s (%d rounds), expected %.4f outside [%.4f, %.4f]",
+					groupName(key), st.Rtp, st.Window, st.Rounds, st.TheoreticalRtp, st.CiLow, st.CiHigh)
+			case !st.Alert && was:
+				log.Printf("[rtp] recovered %s: RTP %.4f over %s", groupName(key), st.Rtp, st.Window)
+			}
+		}
+	}
+}
+
+func groupName(k rtpKey) string {
+	name := k.Game
+	if k.Table != "" {
+		name += " table=" + k.Table
+	}
+	if k.RuleSet != "" {
+		name += " rules=" + k.RuleSet
+	}
+	return name
+}
+
+func (s *server) GetRtpStats(ctx context.Context, req *walletpb.RtpStatsRequest) (*walletpb.RtpStatsResponse, error) {
+	if req.Window != "" {
+		known := false
+		for _, w := range rtpWindows {
+			known = known || w.name == req.Window
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown window %q (15m, 1h, 24h)", req.Window)
+		}
+	}
+	return &walletpb.RtpStatsResponse{Stats: s.rtp.stats(req.Window, req.Game)}, nil
+}
+
+// --- Prometheus ---
+
+var (
+	rtpLabels       = []string{"game", "table", "rule_set", "window"}
+	rtpRoundsDesc   = prometheus.NewDesc("casino_rounds", "Settled rounds in the window.", rtpLabels, nil)
+	rtpWageredDesc  = prometheus.NewDesc("casino_wagered", "Total staked in the window, in minor currency units.", rtpLabels, nil)
+	rtpPaidDesc     = prometheus.NewDesc("casino_paid", "Total paid out in the window, in minor currency units.", rtpLabels, nil)
+	rtpObservedDesc = prometheus.NewDesc("casino_rtp_observed", "Observed RTP (paid / wagered) in the window.", rtpLabels, nil)
+	rtpCiLowDesc    = prometheus.NewDesc("casino_rtp_ci_low", "Lower bound of the observed RTP confidence interval.", rtpLabels, nil)
+	rtpCiHighDesc   = prometheus.NewDesc("casino_rtp_ci_high", "Upper bound of the observed RTP confidence interval.", rtpLabels, nil)
+	rtpTheoryDesc   = prometheus.NewDesc("casino_rtp_theoretical", "Theoretical RTP of the game.", rtpLabels, nil)
+	rtpLiabDesc     = prometheus.NewDesc("casino_max_liability", "Largest payout a single round could have required in the window, in minor currency units.", rtpLabels, nil)
+	rtpAlertDesc    = prometheus.NewDesc("casino_rtp_alert", "1 when the theoretical RTP is outside the observed confidence interval.", rtpLabels, nil)
+)
+
+// rtpMonitor — prometheus.Collector: значения считаются в момент scrape.
+func (m *rtpMonitor) Describe(ch chan<- *prometheus.Desc) {
+	for _, d := range []*prometheus.Desc{rtpRoundsDesc, rtpWageredDesc, rtpPaidDesc, rtpObservedDesc,
+		rtpCiLowDesc, rtpCiHighDesc, rtpTheoryDesc, rtpLiabDesc, rtpAlertDesc} {
+		ch <- d
+	}
+}
+
+func (m *rtpMonitor) Collect(ch chan<- prometheus.Metric) {
+	for _, st := range m.stats("", "") {
+		labels := []string{st.Game, st.Table, st.RuleSet, st.Window}
+		gauge := func(d *prometheus.Desc, v float64) {
+			ch <- prometheus.MustNewConstMetric(d, prometheus.GaugeValue, v, labels...)
+		}
+		gauge(rtpRoundsDesc, float64(st.Rounds))
+		gauge(rtpWageredDesc, float64(st.Wagered))
+		gauge(rtpPaidDesc, float64(st.Paid))
+		gauge(rtpObservedDesc, st.Rtp)
+		gauge(rtpCiLowDesc, st.CiLow)
+		gauge(rtpCiHighDesc, st.CiHigh)
+		gauge(rtpLiabDesc, float64(st.MaxLiability))
+		alert := 0.0
+		if st.Alert {
+			alert = 1
+		}
+		gauge(rtpAlertDesc, alert)
+		if st.TheoreticalRtp > 0 {
+			gauge(rtpTheoryDesc, st.TheoreticalRtp)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	walletpb "github.com/Arsencchikkk/final/casino/proto/wallet"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/protobuf/proto"
+)
+
+// Переводы между игроками. Перевод — две проводки transfer с общим ref (id
+// перевода): списание у отправителя и зачисление получателю в той же
+// валюте, counter каждой — кошелёк второго ("user:<id>"). Казино в переводе
+// не участвует, сумма по счетам остаётся нулём.
+//
+// Дневной лимит отправителя (TRANSFER_DAILY_LIMIT, в основной валюте)
+// считается в коллекции transfer_limits: документ на игрока и день UTC,
+// used растёт в той же транзакции, что и проводки, и только пока не
+// превышает лимит. Имя получателя и подтверждение почты проверяет шлюз —
+// в кошельке пользователей нет.
+
+// errTransferLimit — перевод не помещается в дневной лимит.
+var errTransferLimit = errors.New("daily transfer limit reached")
+
+func transferLimitIndexes(ctx context.Context, col *mongo.Collection) error {
+	_, err := col.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	})
+	return err
+}
+
+func (s *server) Transfer(ctx context.Context, req *walletpb.TransferRequest) (*walletpb.TransferResponse, error) {
+	fp := fingerprint("transfer", req.FromUserId, req.ToUserId, req.GetAmount().GetAmount(), req.GetAmount().GetCurrency())
+	resp, err := s.idempotent(ctx, req.IdempotencyKey, fp, &walletpb.TransferResponse{}, func() (proto.Message, error) {
+		return s.transfer(ctx, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*walletpb.TransferResponse), nil
+}
+
+func (s *server) transfer(ctx context.Context, req *walletpb.TransferRequest) (*walletpb.TransferResponse, error) {
+	from, to := req.FromUserId, req.ToUserId
+	if from == "" || to == "" {
+		return nil, fmt.Errorf("from_user_id and to_user_id required")
+	}
+	if from == to {
+		return nil, fmt.Errorf("cannot transfer to yourself")
+	}
+	if isDemo(from) || isDemo(to) {
+		return nil, fmt.Errorf("demo wallets cannot transfer")
+	}
+	amount, currency, err := s.amountOf(from, req.Amount)
+	if err != nil {
+		return nil, err
+	}
+	if amount <= 0 {
+		return nil, fmt.Errorf("amount must be positive")
+	}
+	// лимит — в основной валюте
+	inMain := amount
+	if currency != s.currency {
+		_, rate, err := s.rate(ctx, currency, s.currency)
+		if err != nil {
+			return nil, fmt.Errorf("cannot check the daily limit: %v", err)
+		}
+		if inMain, err = convertAmount(amount, currency, s.currency, rate); err != nil {
+			return nil, err
+		}
+	}
+	if inMain > s.transferLimit {
+		return nil, errTransferLimit
+	}
+
+	now := time.Now().UTC()
+	day := now.Truncate(24 * time.Hour)
+	id := primitive.NewObjectID().Hex()
+	ps := []posting{
+		{userId: from, currency: currency, amount: -amount, typ: "transfer", ref: id, key: req.IdempotencyKey + ":from", counter: "user:" + to},
+		{userId: to, currency: currency, amount: amount, typ: "transfer", ref: id, key: req.IdempotencyKey + ":to", counter: "user:" + from},
+	}
+	var used int64
+	out, err := s.inTx(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		// документ дня уже упёрся в лимит — фильтр не совпадёт, а upsert
+		// наткнётся на тот же _id
+		var lim struct {
+			Used int64 `bson:"used"`
+		}
+		err := s.transferLimits.FindOneAndUpdate(sc,
+			bson.M{"_id": from + ":" + day.Format("2006-01-02"), "used": bson.M{"$lte": s.transferLimit - inMain}},
+			bson.M{"$inc": bson.M{"used": inMain}, "$setOnInsert": bson.M{"user_id": from, "expires_at": day.Add(48 * time.Hour)}},
+			options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+		).Decode(&lim)
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, errTransferLimit
+		}
+		if err != nil {
+			return nil, err
+		}
+		used = lim.Used
+		return s.postIn(sc, ps)
+	})
+	var txs []TxDoc
+	switch {
+	case err == nil:
+		txs = out.([]TxDoc)
+	case mongo.IsDuplicateKeyError(err):
+		// повтор, мимо которого прошёл Redis: перевод уже проведён
+		if txs, err = s.replayed(ctx, ps, err); err != nil {
+			return nil, err
+		}
+		used = s.transferredToday(ctx, from, day)
+	default:
+		log.Printf("[Transfer] %s -> %s %d %s: %v", from, to, amount, currency, err)
+		return nil, err
+	}
+	s.cachePostings(ctx, txs)
+	debit, credit := txs[0], txs[1]
+	log.Printf("[Transfer] %s: %s -> %s %d %s", debit.Ref, from, to, credit.Amount, currency)
+	return &walletpb.TransferResponse{
+		TransferId:     debit.Ref,
+		Debit:          txToPb(debit),
+		Credit:         txToPb(credit),
+		Balance:        money(debit.Available, currency),
+		DailyRemaining: money(s.transferLimit-used, s.currency),
+	}, nil
+}
+
+// transferredToday — сколько отправитель перевёл за день, в основной валюте.
+func (s *server) transferredToday(ctx context.Context, userId string, day time.Time) int64 {
+	var lim struct {
+		Used int64 `bson:"used"`
+	}
+	if err := s.transferLimits.FindOne(ctx, bson.M{"_id": userId + ":" + day.Format("2006-01-02")}).Decode(&lim); err != nil && err != mongo.ErrNoDocuments {
+		log.Printf("[Transfer] limit lookup: %v", err)
+	}
+	return lim.Used
+}